@@ -26,12 +26,16 @@ func main() {
 
 	server := httpserver.NewServer(dependencies)
 	server.Middlewares(httpserver.WithGzip(), httpserver.WithAPM(), httpserver.WithRecover(),
-		httpserver.WithLogger(dependencies.Config), httpserver.WithRequestID(), httpserver.WithCORS(),
-		httpserver.WithRequestHeaderValidator(dependencies.Config), httpserver.WithLoggerBody(dependencies.Logs))
+		httpserver.WithLogger(dependencies.Config), httpserver.WithRequestID(), httpserver.WithAuditContext(),
+		httpserver.WithCORS(), httpserver.WithRequestHeaderValidator(dependencies.Config),
+		httpserver.WithLoggerBody(dependencies.Logs))
 	server.Validator()
 	server.Routes()
 
 	go dependencies.ChargebacksHandler.ListenChargebacks()
+	go dependencies.RulesSnapshot.Watch(context.Background())
+	go dependencies.BinTable.Watch(context.Background())
+	go dependencies.IPIntelligence.Watch(context.Background())
 
 	server.SetErrorHandler(httpserver.HTTPErrorHandler)
 	server.Start()
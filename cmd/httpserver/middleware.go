@@ -11,7 +11,9 @@ import (
 	"net/http"
 
 	"github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/risk-rules/internal/apps/audit"
 	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -29,7 +31,8 @@ var (
 			return uuid.New().String()
 		},
 	}
-	XRequestRisk = "X-Request-Risk"
+	XRequestRisk   = "X-Request-Risk"
+	XApplicationID = "X-Application-ID"
 )
 
 type Middleware func(*Server)
@@ -95,6 +98,28 @@ func WithRequestID() Middleware {
 	}
 }
 
+// WithAuditContext keeps the request id and origin of each request so the audit can trace the mutations,
+// it must be registered after WithRequestID.
+func WithAuditContext() Middleware {
+	return func(s *Server) {
+		s.Server.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+				if customString.IsEmpty(requestID) {
+					requestID = c.Request().Header.Get(echo.HeaderXRequestID)
+				}
+				origin := entities.AuditOrigin{
+					RequestID: requestID,
+					Origin:    c.Request().Header.Get(XApplicationID),
+					Actor:     c.QueryParam("author"),
+				}
+				c.SetRequest(c.Request().WithContext(audit.NewContext(c.Request().Context(), origin)))
+				return next(c)
+			}
+		})
+	}
+}
+
 func WithRequestHeaderValidator(cfg config.Config) Middleware {
 	return func(s *Server) {
 		s.Server.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -158,9 +183,18 @@ func HTTPErrorHandler(err error, ctx echo.Context) {
 	case exceptions.InvalidRequestException:
 		apiError = resterror.NewRestError(err.Error(), http.StatusBadRequest, http.StatusText(http.StatusBadRequest),
 			[]interface{}{value.Causes()})
+	case exceptions.InvalidRuleException:
+		causes := make([]interface{}, 0, len(value.Clauses()))
+		for _, clause := range value.Clauses() {
+			causes = append(causes, clause)
+		}
+		apiError = resterror.NewRestError(err.Error(), http.StatusBadRequest, http.StatusText(http.StatusBadRequest), causes)
 	case exceptions.AssociatedException:
 		apiError = resterror.NewRestError(err.Error(), http.StatusBadRequest, "Bad Request",
 			[]interface{}{value.Causes()})
+	case exceptions.ServiceUnavailableException:
+		apiError = resterror.NewRestError(err.Error(), http.StatusServiceUnavailable,
+			http.StatusText(http.StatusServiceUnavailable), []interface{}{value.Causes()})
 	default:
 		apiError = resterror.NewInternalServerError(err.Error(), err)
 	}
@@ -2,6 +2,8 @@ package httpserver
 
 import (
 	"github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/risk-rules/internal/apps/audit"
+	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/labstack/echo/v4"
 	"net/http"
@@ -87,6 +89,19 @@ func TestHTTPErrorHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
 	})
+	t.Run("exception InvalidRuleException", func(t *testing.T) {
+		err := exceptions.NewInvalidRuleException([]exceptions.ClauseError{
+			{Clause: 1, Field: "email", Operator: ">", Code: exceptions.RuleFieldNotFound, Message: "field 'email' not found"},
+		})
+		resp := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, resp)
+
+		HTTPErrorHandler(err, ctx)
+
+		restError, _ := resterror.NewRestErrorFromBytes(resp.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, ctx.Response().Status)
+		assert.Len(t, restError.Causes(), 1)
+	})
 	t.Run("exception AssociatedException", func(t *testing.T) {
 		err := exceptions.NewAssociatedExceptionWithCause("reason", exceptions.Causes{
 			Code:    "002",
@@ -115,6 +130,14 @@ func TestHTTPErrorHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, ctx.Response().Status)
 	})
+	t.Run("exception ServiceUnavailableException", func(t *testing.T) {
+		err := exceptions.NewServiceUnavailableExceptionWithCause("reason", exceptions.Causes{Code: "Blacklist"})
+		ctx := echo.New().NewContext(req, resp)
+
+		HTTPErrorHandler(err, ctx)
+
+		assert.Equal(t, http.StatusServiceUnavailable, ctx.Response().Status)
+	})
 
 	t.Run("exception RestErr", func(t *testing.T) {
 		err := resterror.NewUnauthorizedError("unauthorized")
@@ -126,3 +149,25 @@ func TestHTTPErrorHandler(t *testing.T) {
 	})
 
 }
+
+func TestWithAuditContext_ShouldKeepTheRequestOrigin(t *testing.T) {
+	dependencies := container.Dependencies{}
+	server := NewServer(dependencies)
+	server.Middlewares(WithRequestID(), WithAuditContext())
+
+	var origin entities.AuditOrigin
+	server.Server.GET("/risk-rules/v1/rules", func(c echo.Context) error {
+		origin = audit.FromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})
+	req := httptest.NewRequest("GET", "/risk-rules/v1/rules?author=santiago.ceron@conekta.com", nil)
+	req.Header.Set(XApplicationID, "risk-console")
+	resp := httptest.NewRecorder()
+	server.ServerHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, resp.Header().Get(echo.HeaderXRequestID), origin.RequestID)
+	assert.NotEmpty(t, origin.RequestID)
+	assert.Equal(t, "risk-console", origin.Origin)
+	assert.Equal(t, "santiago.ceron@conekta.com", origin.Actor)
+}
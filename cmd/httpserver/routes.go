@@ -9,11 +9,23 @@ func (s *Server) Routes() {
 	rulesGroup := root.Group("/rules")
 	rulesGroup.POST("", s.dependencies.RulesHandler.AddRule)
 	rulesGroup.GET("", s.dependencies.RulesHandler.GetPaged)
+	rulesGroup.POST("/backtest", s.dependencies.BacktestHandler.Backtest)
+	rulesGroup.GET("/export", s.dependencies.RuleTransferHandler.Export)
+	rulesGroup.POST("/import", s.dependencies.RuleTransferHandler.Import)
+	rulesGroup.POST("/lint", s.dependencies.RulesHandler.LintRule)
 	rulesGroup.PUT("/:id", s.dependencies.RulesHandler.UpdateRule)
 	rulesGroup.DELETE("/:id", s.dependencies.RulesHandler.RemoveRule)
+	rulesGroup.GET("/:id/versions", s.dependencies.RulesHandler.GetVersions)
+	rulesGroup.GET("/:id/versions/:n", s.dependencies.RulesHandler.GetVersion)
+	rulesGroup.POST("/:id/rollback/:n", s.dependencies.RulesHandler.RollbackRule)
+	rulesGroup.POST("/:id/submit", s.dependencies.RulesHandler.SubmitRule)
+	rulesGroup.POST("/:id/approve", s.dependencies.RulesHandler.ApproveRule)
+	rulesGroup.POST("/:id/reject", s.dependencies.RulesHandler.RejectRule)
+	rulesGroup.POST("/:id/retire", s.dependencies.RulesHandler.RetireRule)
 
 	chargesGroup := root.Group("/charges")
 	chargesGroup.POST("/evaluate", s.dependencies.ChargeHandler.Evaluate)
+	chargesGroup.POST("/evaluate/batch", s.dependencies.ChargeHandler.EvaluateBatch)
 	chargesGroup.POST("/evaluate_rules", s.dependencies.ChargeHandler.EvaluateOnlyRules)
 	chargesGroup.GET("/evaluations/:id", s.dependencies.ChargeHandler.GetEvaluation)
 	chargesGroup.GET("/evaluations_rules/:id", s.dependencies.ChargeHandler.GetEvaluationOnlyRules)
@@ -54,6 +66,20 @@ func (s *Server) Routes() {
 	familyCompaniesGroup.DELETE("/:id", s.dependencies.FamilyCompaniesHandler.Delete)
 	familyCompaniesGroup.GET("", s.dependencies.FamilyCompaniesHandler.Get)
 
+	scoreThresholdsGroup := root.Group("/score_thresholds")
+	scoreThresholdsGroup.POST("", s.dependencies.ScoreThresholdHandler.Create)
+	scoreThresholdsGroup.GET("", s.dependencies.ScoreThresholdHandler.Get)
+	scoreThresholdsGroup.PUT("/:id", s.dependencies.ScoreThresholdHandler.Update)
+	scoreThresholdsGroup.DELETE("/:id", s.dependencies.ScoreThresholdHandler.Delete)
+
+	listsGroup := root.Group("/lists")
+	listsGroup.POST("", s.dependencies.ListsHandler.Create)
+	listsGroup.GET("", s.dependencies.ListsHandler.Get)
+	listsGroup.PUT("/:id", s.dependencies.ListsHandler.Update)
+	listsGroup.DELETE("/:id", s.dependencies.ListsHandler.Delete)
+
+	root.GET("/audit", s.dependencies.AuditHandler.GetPaged)
+
 	merchantsGroup := root.Group("/merchants_score")
 	merchantsGroup.POST("", s.dependencies.MerchantsScoreHandler.MerchantScoreProcessing)
 }
@@ -184,7 +184,7 @@ func Evaluate(rule string, charge map[string]interface{}) (bool, error) {
 func InsertRules() {
 	configs := config.NewConfig()
 	mongoDB := mongodb.NewMongoDB(configs)
-	ruleService := rules.NewRulesService(config.Config{}, nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.Config{}, nil, nil, nil, nil, nil, nil, nil)
 	now := time.Now().Truncate(time.Millisecond)
 
 	companyID := "60ad5c44926c8400016cbfdc"
@@ -16,6 +16,7 @@ require (
 	github.com/stretchr/testify v1.8.0
 	go.mongodb.org/mongo-driver v1.10.1
 	gopkg.in/DataDog/dd-trace-go.v1 v1.40.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -90,5 +91,4 @@ require (
 	google.golang.org/protobuf v1.28.0 // indirect
 	gopkg.in/ini.v1 v1.66.6 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
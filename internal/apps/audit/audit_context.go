@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+)
+
+type originKey struct{}
+
+func NewContext(ctx context.Context, origin entities.AuditOrigin) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+func FromContext(ctx context.Context) entities.AuditOrigin {
+	origin, _ := ctx.Value(originKey{}).(entities.AuditOrigin)
+	return origin
+}
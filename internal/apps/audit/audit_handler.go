@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/text"
+	"github.com/labstack/echo/v4"
+)
+
+const handlerName = "audit.handler.%s"
+
+type AuditHandler interface {
+	GetPaged(ctx echo.Context) error
+}
+
+type auditHandler struct {
+	logs    logs.Logger
+	service AuditService
+}
+
+func NewAuditHandler(service AuditService, logger logs.Logger) AuditHandler {
+	return &auditHandler{
+		logs:    logger,
+		service: service,
+	}
+}
+
+func (handler *auditHandler) GetPaged(ctx echo.Context) error {
+	var filter entities.AuditFilter
+	pagination := entities.NewDefaultPagination()
+	ctx.Bind(&pagination)
+	ctx.Bind(&filter)
+
+	if err := filter.Validate(); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "GetPaged"))
+		ctx.Error(err)
+		return nil
+	}
+
+	entries, err := handler.service.Search(ctx.Request().Context(), filter, pagination)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, entries)
+}
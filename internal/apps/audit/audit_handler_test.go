@@ -0,0 +1,60 @@
+package audit_test
+
+import (
+	"net/http"
+	"testing"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/echo"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const auditUri = "/risk-rules/v1/audit"
+
+func Test_auditHandler_GetPaged(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("when entity_type is not valid then return bad request", func(t *testing.T) {
+		ctx, rec := echo.SetupAsRecorder(http.MethodGet, auditUri+"?entity_type=charges", "", "")
+		handler := audit.NewAuditHandler(nil, logger)
+
+		handler.GetPaged(ctx)
+
+		restError, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "entity_type [charges] is not a valid value", restError.Message())
+	})
+
+	t.Run("when the date range is not valid then return bad request", func(t *testing.T) {
+		ctx, rec := echo.SetupAsRecorder(http.MethodGet,
+			auditUri+"?from=2022-06-02T00:00:00Z&until=2022-06-01T00:00:00Z", "", "")
+		handler := audit.NewAuditHandler(nil, logger)
+
+		handler.GetPaged(ctx)
+
+		restError, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "until must be after from", restError.Message())
+	})
+
+	t.Run("when the filter is valid then return the entries", func(t *testing.T) {
+		ctx, rec := echo.SetupAsRecorder(http.MethodGet,
+			auditUri+"?entity_type=rules&actor=santiago.ceron@conekta.com", "", "")
+		service := new(mocks.AuditServiceMock)
+		filter := entities.AuditFilter{EntityType: "rules", Actor: "santiago.ceron@conekta.com"}
+
+		service.On("Search", mock.Anything, filter, mock.AnythingOfType("entities.Pagination")).
+			Return(entities.PagedResponse{Data: []entities.AuditEntry{}}, nil).Once()
+		handler := audit.NewAuditHandler(service, logger)
+
+		handler.GetPaged(ctx)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+}
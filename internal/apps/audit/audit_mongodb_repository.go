@@ -0,0 +1,140 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const repositoryName = "audit.repository.mongo.%s"
+
+type AuditRepository interface {
+	Add(ctx context.Context, entry *entities.AuditEntry) error
+	FindPaged(ctx context.Context, filter entities.AuditFilter,
+		pagination entities.Pagination) (entities.PagedResponse, error)
+	FindEntity(ctx context.Context, entityType entities.AuditEntityType, entityID string) (bson.M, error)
+}
+
+type auditMongoDBRepository struct {
+	config      config.Config
+	mongodb     mongodb.MongoDBier
+	log         logs.Logger
+	collections map[entities.AuditEntityType]string
+}
+
+func NewAuditMongoDBRepository(cfg config.Config, mongoDBier mongodb.MongoDBier, logger logs.Logger) AuditRepository {
+	collections := cfg.MongoDB.Collections
+	return &auditMongoDBRepository{
+		config:  cfg,
+		mongodb: mongoDBier,
+		log:     logger,
+		collections: map[entities.AuditEntityType]string{
+			entities.AuditRules:           collections.Rules,
+			entities.AuditFamilies:        collections.Families,
+			entities.AuditFamilyCompanies: collections.FamilyCompanies,
+			entities.AuditModules:         collections.Modules,
+			entities.AuditFields:          collections.Fields,
+			entities.AuditOperators:       collections.Operators,
+			entities.AuditConditions:      collections.Conditions,
+		},
+	}
+}
+
+func (r *auditMongoDBRepository) Add(ctx context.Context, entry *entities.AuditEntry) error {
+	result, err := r.mongodb.Collection(r.config.MongoDB.Collections.AuditLogs).InsertOne(ctx, entry)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Add"))
+		return err
+	}
+
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *auditMongoDBRepository) FindPaged(ctx context.Context, filter entities.AuditFilter,
+	pagination entities.Pagination) (entities.PagedResponse, error) {
+	collection := r.mongodb.Collection(r.config.MongoDB.Collections.AuditLogs)
+	query := buildAuditFilter(filter)
+
+	total, _ := collection.CountDocuments(ctx, query)
+	hasMore := pagination.HasMorePages(total)
+
+	opts := options.FindOptions{}
+	opts.SetLimit(pagination.PageSize)
+	opts.SetSkip(pagination.GetPageStartIndex())
+	opts.SetSort(bson.D{primitive.E{Key: "created_at", Value: -1}})
+
+	entries := make([]entities.AuditEntry, 0)
+	cur, err := collection.Find(ctx, query, &opts)
+	if err == nil {
+		err = cur.All(ctx, &entries)
+	}
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "FindPaged"))
+		return entities.PagedResponse{}, err
+	}
+
+	return entities.NewPagedResponse(entries, hasMore, total), nil
+}
+
+// FindEntity returns the stored document of the entity, nil when it does not exist.
+func (r *auditMongoDBRepository) FindEntity(ctx context.Context, entityType entities.AuditEntityType,
+	entityID string) (bson.M, error) {
+	ID, err := primitive.ObjectIDFromHex(entityID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var document bson.M
+	err = r.mongodb.Collection(r.collections[entityType]).FindOne(ctx, bson.M{"_id": ID}).Decode(&document)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "FindEntity"))
+		return nil, err
+	}
+
+	return document, nil
+}
+
+func buildAuditFilter(filter entities.AuditFilter) bson.D {
+	query := bson.D{}
+	fields := []bson.E{
+		{Key: "entity_type", Value: filter.EntityType},
+		{Key: "entity_id", Value: filter.EntityID},
+		{Key: "actor", Value: filter.Actor},
+		{Key: "action", Value: filter.Action},
+		{Key: "request_id", Value: filter.RequestID},
+		{Key: "origin", Value: filter.Origin},
+	}
+	for _, field := range fields {
+		if !strings.IsEmpty(field.Value.(string)) {
+			query = append(query, field)
+		}
+	}
+
+	from, until, _ := filter.GetDateRange()
+	createdAt := bson.M{}
+	if from != nil {
+		createdAt["$gte"] = *from
+	}
+	if until != nil {
+		createdAt["$lt"] = *until
+	}
+	if len(createdAt) > 0 {
+		query = append(query, bson.E{Key: "created_at", Value: createdAt})
+	}
+
+	return query
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const serviceName = "audit.service.%s"
+
+type AuditService interface {
+	Snapshot(ctx context.Context, entityType entities.AuditEntityType, entityID string) bson.M
+	Record(ctx context.Context, entry entities.AuditEntry)
+	Search(ctx context.Context, filter entities.AuditFilter,
+		pagination entities.Pagination) (entities.PagedResponse, error)
+}
+
+type auditService struct {
+	config     config.Config
+	repository AuditRepository
+	logs       logs.Logger
+	datadog    datadog.Metricer
+}
+
+func NewAuditService(cfg config.Config, repository AuditRepository, logger logs.Logger,
+	metric datadog.Metricer) AuditService {
+	return &auditService{
+		config:     cfg,
+		repository: repository,
+		logs:       logger,
+		datadog:    metric,
+	}
+}
+
+// Snapshot returns the stored state of the entity, the audit never blocks a mutation so failures only get logged.
+func (service *auditService) Snapshot(ctx context.Context, entityType entities.AuditEntityType,
+	entityID string) bson.M {
+	document, err := service.repository.FindEntity(ctx, entityType, entityID)
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(serviceName, "Snapshot"),
+			text.AuditEntityType, entityType)
+		return nil
+	}
+
+	return document
+}
+
+func (service *auditService) Record(ctx context.Context, entry entities.AuditEntry) {
+	metricData := metrics.NewMetricData(ctx, "Record", serviceName, service.config.Env)
+	metricData.AddCustomTags([]string{fmt.Sprintf(text.MetricTagEntityType, entry.EntityType)})
+
+	origin := FromContext(ctx)
+	entry.RequestID = origin.RequestID
+	entry.Origin = origin.Origin
+	if strings.IsEmpty(entry.Actor) {
+		entry.Actor = origin.Actor
+	}
+	if entry.Action != entities.AuditDeleted {
+		entry.After = service.Snapshot(ctx, entry.EntityType, entry.EntityID)
+	}
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	if err := service.repository.Add(ctx, &entry); err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(serviceName, "Record"),
+			text.AuditEntityType, entry.EntityType, text.AuditEntityID, entry.EntityID)
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveAuditMetricName)
+		return
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveAuditMetricName)
+}
+
+func (service *auditService) Search(ctx context.Context, filter entities.AuditFilter,
+	pagination entities.Pagination) (entities.PagedResponse, error) {
+	return service.repository.FindPaged(ctx, filter, pagination)
+}
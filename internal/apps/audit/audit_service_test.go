@@ -0,0 +1,101 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_auditService_Record(t *testing.T) {
+	logger, _ := logs.New()
+	entityID := "6283e2f8b3d06e4b2e6a8f10"
+	origin := entities.AuditOrigin{
+		RequestID: "0b2e1c63-8e5e-4b39-9f39-4a4c7f1cbd2e",
+		Origin:    "risk-console",
+		Actor:     "santiago.ceron@conekta.com",
+	}
+
+	t.Run("when the mutation has no author the actor and origin come from the context", func(t *testing.T) {
+		ctx := audit.NewContext(context.TODO(), origin)
+		before := bson.M{"name": "policy_compliance"}
+		after := bson.M{"name": "policy_compliance_v2"}
+		repository := new(mocks.AuditRepositoryMock)
+
+		repository.On("FindEntity", ctx, entities.AuditModules, entityID).Return(after, nil).Once()
+		repository.On("Add", ctx, mock.MatchedBy(func(entry *entities.AuditEntry) bool {
+			return entry.Actor == origin.Actor &&
+				entry.RequestID == origin.RequestID &&
+				entry.Origin == origin.Origin &&
+				entry.Action == entities.AuditUpdated &&
+				assert.ObjectsAreEqual(before, entry.Before) &&
+				assert.ObjectsAreEqual(after, entry.After) &&
+				!entry.CreatedAt.IsZero()
+		})).Return(nil).Once()
+
+		service := audit.NewAuditService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+		service.Record(ctx, entities.NewAuditEntry(entities.AuditModules, entityID, entities.AuditUpdated, "", before))
+
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("when the entity is deleted there is no state after the mutation", func(t *testing.T) {
+		ctx := audit.NewContext(context.TODO(), origin)
+		before := bson.M{"name": "policy_compliance"}
+		repository := new(mocks.AuditRepositoryMock)
+
+		repository.On("Add", ctx, mock.MatchedBy(func(entry *entities.AuditEntry) bool {
+			return entry.Actor == "carlos.maldonado@conekta.com" && entry.After == nil
+		})).Return(nil).Once()
+
+		service := audit.NewAuditService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+		service.Record(ctx, entities.NewAuditEntry(entities.AuditModules, entityID, entities.AuditDeleted,
+			"carlos.maldonado@conekta.com", before))
+
+		repository.AssertExpectations(t)
+		repository.AssertNotCalled(t, "FindEntity", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when the repository fails the error is not propagated", func(t *testing.T) {
+		repository := new(mocks.AuditRepositoryMock)
+
+		repository.On("FindEntity", context.TODO(), entities.AuditRules, entityID).
+			Return(nil, errors.New("connection lost")).Once()
+		repository.On("Add", context.TODO(), mock.AnythingOfType("*entities.AuditEntry")).
+			Return(errors.New("connection lost")).Once()
+
+		service := audit.NewAuditService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+
+		assert.NotPanics(t, func() {
+			service.Record(context.TODO(), entities.NewAuditEntry(entities.AuditRules, entityID,
+				entities.AuditCreated, "carlos.maldonado@conekta.com", nil))
+		})
+		repository.AssertExpectations(t)
+	})
+}
+
+func Test_auditService_Search(t *testing.T) {
+	logger, _ := logs.New()
+	filter := entities.AuditFilter{EntityType: string(entities.AuditRules)}
+	pagination := entities.NewDefaultPagination()
+	expected := entities.PagedResponse{Data: []entities.AuditEntry{{EntityType: entities.AuditRules}}}
+	repository := new(mocks.AuditRepositoryMock)
+
+	repository.On("FindPaged", context.TODO(), filter, pagination).Return(expected, nil).Once()
+
+	service := audit.NewAuditService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+	got, err := service.Search(context.TODO(), filter, pagination)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, got)
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,62 @@
+package backtests
+
+import (
+	"fmt"
+	"net/http"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/text"
+	"github.com/labstack/echo/v4"
+)
+
+const handlerName = "backtests.handler.%s"
+
+type BacktestHandler interface {
+	Backtest(c echo.Context) error
+}
+
+type backtestHandler struct {
+	service BacktestService
+	logs    logs.Logger
+}
+
+func NewBacktestHandler(service BacktestService, logger logs.Logger) BacktestHandler {
+	return &backtestHandler{
+		service: service,
+		logs:    logger,
+	}
+}
+
+func (handler *backtestHandler) Backtest(ctx echo.Context) error {
+	backtestReq := new(entities.BacktestRequest)
+	if err := ctx.Bind(backtestReq); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Backtest"))
+		ctx.Error(err)
+		return nil
+	}
+
+	if err := ctx.Validate(backtestReq); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Backtest"))
+		ctx.Error(err)
+		return nil
+	}
+
+	if err := backtestReq.Validate(); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Backtest"))
+		ctx.Error(err)
+		return nil
+	}
+
+	result, err := handler.service.Backtest(ctx.Request().Context(), *backtestReq)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
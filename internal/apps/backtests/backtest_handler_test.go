@@ -0,0 +1,91 @@
+package backtests_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/backtests"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/echo"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBacktestHandler_Backtest(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("when the backtest is ok", func(t *testing.T) {
+		request := testdata.GetDefaultBacktestRequest()
+		body, _ := json.Marshal(request)
+		response := entities.NewBacktestResponse()
+		response.Evaluated = 4
+		response.Hits = 1
+
+		service := new(mocks.BacktestServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/rules/backtest", "", string(body))
+		service.On("Backtest", context.Request().Context(), mock.AnythingOfType("entities.BacktestRequest")).
+			Return(response, nil)
+
+		handler := backtests.NewBacktestHandler(service, logger)
+
+		err := handler.Backtest(context)
+
+		var got entities.BacktestResponse
+		json.Unmarshal(rec.Body.Bytes(), &got)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, response.Hits, got.Hits)
+	})
+
+	t.Run("when the request is malformed, then return StatusBadRequest", func(t *testing.T) {
+		service := new(mocks.BacktestServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/rules/backtest", "", testdata.GetJsonMalformed())
+
+		handler := backtests.NewBacktestHandler(service, logger)
+
+		err := handler.Backtest(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when the window is not valid, then return StatusBadRequest", func(t *testing.T) {
+		request := testdata.GetDefaultBacktestRequest()
+		request.From, request.To = request.To, request.From
+		body, _ := json.Marshal(request)
+
+		service := new(mocks.BacktestServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/rules/backtest", "", string(body))
+
+		handler := backtests.NewBacktestHandler(service, logger)
+
+		err := handler.Backtest(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		service.AssertNotCalled(t, "Backtest", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when service fails, then return error", func(t *testing.T) {
+		request := testdata.GetDefaultBacktestRequest()
+		body, _ := json.Marshal(request)
+
+		service := new(mocks.BacktestServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/rules/backtest", "", string(body))
+		service.On("Backtest", context.Request().Context(), mock.AnythingOfType("entities.BacktestRequest")).
+			Return(entities.BacktestResponse{}, errors.New("database connection lost"))
+
+		handler := backtests.NewBacktestHandler(service, logger)
+
+		err := handler.Backtest(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
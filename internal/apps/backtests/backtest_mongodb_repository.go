@@ -0,0 +1,116 @@
+package backtests
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const repositoryName = "backtests.repository.mongo.%s"
+
+type BacktestRepository interface {
+	GetEvaluations(ctx context.Context, filter entities.BacktestFilter) ([]entities.StoredEvaluation, error)
+}
+
+type backtestMongoDBRepository struct {
+	config  config.Config
+	mongodb mongodb.MongoDBier
+	log     logs.Logger
+}
+
+func NewBacktestMongoDBRepository(cfg config.Config, mongoDBier mongodb.MongoDBier, logger logs.Logger) BacktestRepository {
+	return &backtestMongoDBRepository{
+		config:  cfg,
+		mongodb: mongoDBier,
+		log:     logger,
+	}
+}
+
+func (r *backtestMongoDBRepository) GetEvaluations(ctx context.Context,
+	filter entities.BacktestFilter) ([]entities.StoredEvaluation, error) {
+	sources := map[string]string{
+		entities.EvaluationsSource:          r.config.MongoDB.Collections.ChargeEvaluations,
+		entities.EvaluationsOnlyRulesSource: r.config.MongoDB.Collections.ChargeEvaluationsOnlyRules,
+	}
+
+	evaluations := make([]entities.StoredEvaluation, 0)
+	for _, source := range []string{entities.EvaluationsSource, entities.EvaluationsOnlyRulesSource} {
+		found, err := r.find(ctx, sources[source], filter)
+		if err != nil {
+			r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "GetEvaluations"))
+			return nil, err
+		}
+
+		for _, evaluation := range found {
+			evaluation.Source = source
+			evaluations = append(evaluations, evaluation)
+		}
+	}
+
+	return entities.LatestEvaluationsByCharge(evaluations, filter.Limit), nil
+}
+
+// find returns the latest evaluation of every charge of the collection, in the order they were evaluated, both
+// sources are read up to the limit so neither of them is left out of the sample.
+func (r *backtestMongoDBRepository) find(ctx context.Context, collectionName string,
+	filter entities.BacktestFilter) ([]entities.StoredEvaluation, error) {
+	pipeline := []bson.M{
+		{"$match": buildBacktestFilter(filter)},
+		{"$sort": bson.M{"_id": -1}},
+		{"$group": bson.M{
+			"_id":           bson.M{"$ifNull": bson.A{"$charge._id", "$_id"}},
+			"evaluation_id": bson.M{"$first": "$_id"},
+			"decision":      bson.M{"$first": "$decision"},
+			"charge":        bson.M{"$first": "$charge"},
+		}},
+		{"$sort": bson.M{"evaluation_id": 1}},
+	}
+	if filter.Limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": filter.Limit})
+	}
+
+	cur, err := r.mongodb.Collection(collectionName).Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations := make([]entities.StoredEvaluation, 0)
+	if err = cur.All(ctx, &evaluations); err != nil {
+		return nil, err
+	}
+
+	return evaluations, nil
+}
+
+func buildBacktestFilter(filter entities.BacktestFilter) bson.M {
+	query := bson.M{
+		"_id": bson.M{
+			"$gte": filter.GetFromObjectID(),
+			"$lt":  filter.GetToObjectID(),
+		},
+	}
+
+	companyFilter := bson.M{}
+	if len(filter.CompanyIDs) > 0 {
+		companyFilter["$in"] = filter.CompanyIDs
+	}
+	if len(filter.ExcludedCompanyIDs) > 0 {
+		companyFilter["$nin"] = filter.ExcludedCompanyIDs
+	}
+	if len(companyFilter) > 0 {
+		query["charge.company_id"] = companyFilter
+	}
+
+	if len(filter.CompanyMccs) > 0 {
+		query["charge.company_mcc"] = bson.M{"$in": filter.CompanyMccs}
+	}
+
+	return query
+}
@@ -0,0 +1,152 @@
+package backtests
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/families"
+	familycom "github.com/conekta/risk-rules/internal/apps/family_companies"
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+const serviceMethodName = "backtests.service.%s"
+
+type BacktestService interface {
+	Backtest(ctx context.Context, request entities.BacktestRequest) (entities.BacktestResponse, error)
+}
+
+type backtestService struct {
+	config                 config.Config
+	rules                  rules.RuleValidator
+	ruleService            rules.RuleService
+	backtestRepository     BacktestRepository
+	familyService          families.FamilyService
+	familyCompaniesService familycom.FamilyCompaniesService
+	logs                   logs.Logger
+	datadog                datadog.Metricer
+}
+
+func NewBacktestService(cfg config.Config,
+	rulesValidator rules.RuleValidator,
+	ruleService rules.RuleService,
+	backtestRepository BacktestRepository,
+	familyService families.FamilyService,
+	familyCompaniesService familycom.FamilyCompaniesService,
+	logger logs.Logger,
+	metric datadog.Metricer) BacktestService {
+	return &backtestService{
+		config:                 cfg,
+		rules:                  rulesValidator,
+		ruleService:            ruleService,
+		backtestRepository:     backtestRepository,
+		familyService:          familyService,
+		familyCompaniesService: familyCompaniesService,
+		logs:                   logger,
+		datadog:                metric,
+	}
+}
+
+func (service *backtestService) Backtest(ctx context.Context,
+	request entities.BacktestRequest) (entities.BacktestResponse, error) {
+	metricData := metrics.NewMetricData(ctx, "Backtest", serviceMethodName, service.config.Env)
+	response, err := service.backtest(ctx, request)
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(serviceMethodName, "Backtest"))
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.BacktestRuleMetricName)
+		return entities.BacktestResponse{}, err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.BacktestRuleMetricName)
+	return response, nil
+}
+
+func (service *backtestService) backtest(ctx context.Context,
+	request entities.BacktestRequest) (entities.BacktestResponse, error) {
+	filter, err := service.getBacktestFilter(ctx, request)
+	if err != nil {
+		return entities.BacktestResponse{}, err
+	}
+
+	evaluations, err := service.backtestRepository.GetEvaluations(ctx, filter)
+	if err != nil {
+		return entities.BacktestResponse{}, err
+	}
+
+	rule := request.Rule.NewRuleFromPostRequest()
+	rule.Rule = service.ruleService.BuildExpression(rule)
+	defer service.rules.Invalidate(rule.ID.Hex())
+
+	sampleSize := request.SampleSize
+	if sampleSize == 0 {
+		sampleSize = service.config.Backtest.DefaultSampleSize
+	}
+
+	response := entities.NewBacktestResponse()
+	response.Truncated = filter.Limit > 0 && int64(len(evaluations)) >= filter.Limit
+	for _, evaluation := range evaluations {
+		data, _ := evaluation.Charge.ToMap()
+		response.Evaluated++
+		response.Sources[evaluation.Source]++
+
+		isHit, err := service.rules.Evaluate(ctx, rule, data)
+		if err != nil {
+			response.Errors++
+			continue
+		}
+		if isHit {
+			response.AddHit(evaluation, rule.Decision, sampleSize)
+		}
+	}
+	response.SetHitRate()
+
+	return response, nil
+}
+
+func (service *backtestService) getBacktestFilter(ctx context.Context,
+	request entities.BacktestRequest) (entities.BacktestFilter, error) {
+	filter := entities.BacktestFilter{
+		From:  request.From,
+		To:    request.To,
+		Limit: service.config.Backtest.MaxEvaluations,
+	}
+
+	companyID, familyID, familyCompanyID := request.GetScope()
+	switch {
+	case !strings.IsEmpty(companyID):
+		filter.CompanyIDs = []string{companyID}
+	case !strings.IsEmpty(familyID):
+		family, err := service.familyService.GetFamilyFromFilter(ctx, entities.FamilyFilter{ID: familyID})
+		if err != nil {
+			return entities.BacktestFilter{}, err
+		}
+		if family.IsEmpty() {
+			return entities.BacktestFilter{}, exceptions.NewNotFoundException(
+				fmt.Sprintf("error, family %s not found", familyID))
+		}
+		filter.CompanyMccs = family.Mccs
+		filter.ExcludedCompanyIDs = family.ExcludedCompanies
+	case !strings.IsEmpty(familyCompanyID):
+		familiesCompanies, err := service.familyCompaniesService.GetFamiliesCompaniesFromFilter(ctx,
+			entities.FamilyCompaniesFilter{ID: familyCompanyID})
+		if err != nil {
+			return entities.BacktestFilter{}, err
+		}
+		if len(familiesCompanies) == 0 {
+			return entities.BacktestFilter{}, exceptions.NewNotFoundException(
+				fmt.Sprintf("error, family companies %s not found", familyCompanyID))
+		}
+		filter.CompanyIDs = familiesCompanies[0].CompanyIDs
+	}
+
+	return filter, nil
+}
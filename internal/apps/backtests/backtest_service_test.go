@@ -0,0 +1,117 @@
+package backtests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBacktestService_Backtest(t *testing.T) {
+	logger, _ := logs.New()
+	cfg := config.Config{}
+	cfg.Backtest.MaxEvaluations = 100
+
+	newService := func(repository BacktestRepository, familyService *mocks.FamilyServiceMock,
+		familyCompaniesService *mocks.FamilyCompaniesServiceMock) BacktestService {
+		validator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(cfg, logger, new(datadog.MetricsDogMock)))
+		ruleService := rules.NewRulesService(cfg, validator, mocks.NewRuleCatalogServiceMock(),
+			nil, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
+		return NewBacktestService(cfg, validator, ruleService, repository, familyService, familyCompaniesService,
+			logger, new(datadog.MetricsDogMock))
+	}
+
+	t.Run("when the rule is replayed on the company evaluations, then return hits and decision changes",
+		func(t *testing.T) {
+			request := testdata.GetDefaultBacktestRequest()
+			repositoryMock := new(mocks.BacktestRepositoryMock)
+			repositoryMock.On("GetEvaluations", context.Background(), entities.BacktestFilter{
+				From:       request.From,
+				To:         request.To,
+				CompanyIDs: []string{request.Rule.CompanyID},
+				Limit:      cfg.Backtest.MaxEvaluations,
+			}).Return(testdata.GetStoredEvaluations(), nil)
+
+			got, err := newService(repositoryMock, nil, nil).Backtest(context.Background(), request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(4), got.Evaluated)
+			assert.Equal(t, int64(3), got.Hits)
+			assert.Equal(t, 0.75, got.HitRate)
+			assert.Equal(t, map[string]int64{"A->D": 1, "UN->D": 1}, got.DecisionChanges)
+			assert.Equal(t, []string{"615324eb5bc1dea9ce660690"}, got.SampleChargeIDs)
+			assert.Equal(t, map[string]int64{entities.EvaluationsSource: 3, entities.EvaluationsOnlyRulesSource: 1},
+				got.Sources)
+			assert.False(t, got.Truncated)
+			repositoryMock.AssertExpectations(t)
+		})
+
+	t.Run("when the scope is a family, then filter the evaluations by the family mccs", func(t *testing.T) {
+		request := testdata.GetDefaultBacktestRequest()
+		family := testdata.GetDefaultFamily()
+		request.FamilyID = family.ID.Hex()
+
+		familyServiceMock := new(mocks.FamilyServiceMock)
+		familyServiceMock.On("GetFamilyFromFilter", context.Background(), entities.FamilyFilter{ID: request.FamilyID}).
+			Return(family, nil)
+
+		repositoryMock := new(mocks.BacktestRepositoryMock)
+		repositoryMock.On("GetEvaluations", context.Background(), entities.BacktestFilter{
+			From:               request.From,
+			To:                 request.To,
+			CompanyMccs:        family.Mccs,
+			ExcludedCompanyIDs: family.ExcludedCompanies,
+			Limit:              cfg.Backtest.MaxEvaluations,
+		}).Return([]entities.StoredEvaluation{}, nil)
+
+		got, err := newService(repositoryMock, familyServiceMock, nil).Backtest(context.Background(), request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), got.Evaluated)
+		assert.Equal(t, float64(0), got.HitRate)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("when the family companies scope does not exist, then return not found", func(t *testing.T) {
+		request := testdata.GetDefaultBacktestRequest()
+		request.FamilyCompanyID = "617ae8a4649e59500b7cd54d"
+
+		familyCompaniesServiceMock := new(mocks.FamilyCompaniesServiceMock)
+		familyCompaniesServiceMock.On("GetFamiliesCompaniesFromFilter", context.Background(),
+			entities.FamilyCompaniesFilter{ID: request.FamilyCompanyID}).
+			Return([]entities.FamilyCompanies{}, nil)
+
+		repositoryMock := new(mocks.BacktestRepositoryMock)
+
+		_, err := newService(repositoryMock, nil, familyCompaniesServiceMock).Backtest(context.Background(), request)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+		repositoryMock.AssertNotCalled(t, "GetEvaluations")
+	})
+
+	t.Run("when the repository fails, then return error", func(t *testing.T) {
+		request := testdata.GetDefaultBacktestRequest()
+		expectedErr := errors.New("database connection lost")
+
+		repositoryMock := new(mocks.BacktestRepositoryMock)
+		repositoryMock.On("GetEvaluations", context.Background(), entities.BacktestFilter{
+			From:       request.From,
+			To:         request.To,
+			CompanyIDs: []string{request.Rule.CompanyID},
+			Limit:      cfg.Backtest.MaxEvaluations,
+		}).Return([]entities.StoredEvaluation{}, expectedErr)
+
+		_, err := newService(repositoryMock, nil, nil).Backtest(context.Background(), request)
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
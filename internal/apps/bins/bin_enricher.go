@@ -0,0 +1,95 @@
+package bins
+
+import (
+	"context"
+	"strings"
+
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+)
+
+const (
+	enricherName               = "bin"
+	binField                   = "bin"
+	paymentMethodBrandField    = "payment_method.brand"
+	paymentMethodIssuerField   = "payment_method.issuer"
+	paymentMethodCountryField  = "payment_method.country"
+	paymentMethodCardTypeField = "payment_method.card_type"
+)
+
+type binEnricher struct {
+	config     config.Config
+	repository BinTableRepository
+}
+
+func NewBinEnricher(cfg config.Config, repository BinTableRepository) entities.Enricher {
+	return &binEnricher{
+		config:     cfg,
+		repository: repository,
+	}
+}
+
+func (enricher *binEnricher) Name() string {
+	return enricherName
+}
+
+func (enricher *binEnricher) Fields() []string {
+	return []string{binField, paymentMethodBrandField, paymentMethodIssuerField, paymentMethodCountryField,
+		paymentMethodCardTypeField}
+}
+
+func (enricher *binEnricher) TimeoutMilliseconds() int {
+	return enricher.config.Enrichment.BinTimeoutMilliseconds
+}
+
+func (enricher *binEnricher) FailurePolicy() entities.EnrichmentFailurePolicy {
+	return entities.EnrichmentFailurePolicy{OnFailure: entities.EnrichmentOmitFields}
+}
+
+func (enricher *binEnricher) IsEnabled() bool {
+	return enricher.config.BinTable.IsEnabled
+}
+
+// Enrich exposes the BIN data as bin.* and fills the payment method fields the caller left empty, the fields the
+// caller sent are kept and compared in bin.mismatches.
+func (enricher *binEnricher) Enrich(ctx context.Context,
+	charge entities.ChargeRequest) (map[string]interface{}, error) {
+	info, found := enricher.repository.FindByBin(ctx, charge.PaymentMethod.BinNumber)
+	values := map[string]interface{}{binField: binValues(info, found, info.Mismatches(charge.PaymentMethod))}
+	setIfEmpty(values, paymentMethodBrandField, charge.PaymentMethod.Brand, info.Brand)
+	setIfEmpty(values, paymentMethodIssuerField, charge.PaymentMethod.Issuer, info.Issuer)
+	setIfEmpty(values, paymentMethodCountryField, charge.PaymentMethod.Country, info.Country)
+	setIfEmpty(values, paymentMethodCardTypeField, charge.PaymentMethod.CardType, info.CardType)
+
+	return values, nil
+}
+
+func binValues(info entities.BinInfo, found bool, mismatches map[string]bool) map[string]interface{} {
+	mismatch := false
+	fieldMismatches := make(map[string]interface{}, len(mismatches))
+	for field, isMismatch := range mismatches {
+		fieldMismatches[field] = isMismatch
+		mismatch = mismatch || isMismatch
+	}
+
+	productLevel := strings.ToLower(strings.TrimSpace(info.ProductLevel))
+	return map[string]interface{}{
+		"found":         found,
+		"brand":         info.Brand,
+		"issuer":        info.Issuer,
+		"bank_name":     info.BankName,
+		"country":       info.Country,
+		"card_type":     info.CardType,
+		"product_level": productLevel,
+		"is_prepaid":    productLevel == entities.BinProductPrepaid,
+		"is_corporate":  productLevel == entities.BinProductCorporate,
+		"mismatch":      mismatch,
+		"mismatches":    fieldMismatches,
+	}
+}
+
+func setIfEmpty(values map[string]interface{}, field, value, binValue string) {
+	if strings.TrimSpace(value) == "" && binValue != "" {
+		values[field] = binValue
+	}
+}
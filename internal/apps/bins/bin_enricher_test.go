@@ -0,0 +1,78 @@
+package bins_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/conekta/risk-rules/internal/apps/bins"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBinEnricher_Enrich(t *testing.T) {
+	t.Run("empty payment method fields are filled and the sent ones are compared", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		charge.PaymentMethod = entities.PaymentMethodRequest{BinNumber: "41111199", Brand: "visa", Country: "US"}
+		repository := new(mocks.BinTableRepositoryMock)
+		repository.On("FindByBin", mock.Anything, "41111199").Return(testdata.GetDefaultBinInfo(), true)
+		enricher := bins.NewBinEnricher(config.Config{}, repository)
+
+		got, err := enricher.Enrich(context.TODO(), charge)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BBVA", got["payment_method.issuer"])
+		assert.Equal(t, "credit", got["payment_method.card_type"])
+		assert.NotContains(t, got, "payment_method.brand")
+		assert.NotContains(t, got, "payment_method.country")
+		assert.Equal(t, map[string]interface{}{
+			"found":         true,
+			"brand":         "visa",
+			"issuer":        "BBVA",
+			"bank_name":     "BBVA Mexico",
+			"country":       "MX",
+			"card_type":     "credit",
+			"product_level": "classic",
+			"is_prepaid":    false,
+			"is_corporate":  false,
+			"mismatch":      true,
+			"mismatches": map[string]interface{}{
+				"brand": false, "issuer": false, "country": true, "card_type": false,
+			},
+		}, got["bin"])
+	})
+
+	t.Run("unknown bins keep the payment method and are not found", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		repository := new(mocks.BinTableRepositoryMock)
+		repository.On("FindByBin", mock.Anything, charge.PaymentMethod.BinNumber).Return(entities.BinInfo{}, false)
+		enricher := bins.NewBinEnricher(config.Config{}, repository)
+
+		got, err := enricher.Enrich(context.TODO(), charge)
+
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		values := got["bin"].(map[string]interface{})
+		assert.Equal(t, false, values["found"])
+		assert.Equal(t, false, values["mismatch"])
+	})
+
+	t.Run("prepaid and corporate product levels are flagged", func(t *testing.T) {
+		info := testdata.GetDefaultBinInfo()
+		info.ProductLevel = " Prepaid "
+		repository := new(mocks.BinTableRepositoryMock)
+		repository.On("FindByBin", mock.Anything, mock.Anything).Return(info, true)
+		enricher := bins.NewBinEnricher(config.Config{}, repository)
+
+		got, err := enricher.Enrich(context.TODO(), testdata.GetDefaultCharge())
+
+		assert.NoError(t, err)
+		values := got["bin"].(map[string]interface{})
+		assert.Equal(t, entities.BinProductPrepaid, values["product_level"])
+		assert.Equal(t, true, values["is_prepaid"])
+		assert.Equal(t, false, values["is_corporate"])
+	})
+}
@@ -0,0 +1,176 @@
+package bins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/csv"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/text"
+	"github.com/gocarina/gocsv"
+)
+
+const binTableRepositoryName = "bins.repository.table.%s"
+
+var errEmptyBinTable = errors.New("bin table has no valid rows")
+
+type BinTableRepository interface {
+	FindByBin(ctx context.Context, binNumber string) (entities.BinInfo, bool)
+	Load(ctx context.Context) error
+	Watch(ctx context.Context)
+}
+
+type binTable struct {
+	byPrefix  map[string]entities.BinInfo
+	minLength int
+	maxLength int
+}
+
+type binTableRepository struct {
+	config   config.Config
+	s3Reader csv.S3Reader
+	log      logs.Logger
+	datadog  datadog.Metricer
+	mutex    sync.RWMutex
+	table    *binTable
+}
+
+func NewBinTableRepository(cfg config.Config, s3Reader csv.S3Reader, logger logs.Logger,
+	metric datadog.Metricer) BinTableRepository {
+	return &binTableRepository{
+		config:   cfg,
+		s3Reader: s3Reader,
+		log:      logger,
+		datadog:  metric,
+	}
+}
+
+// FindByBin returns the row of the longest prefix of the bin number found in the table.
+func (r *binTableRepository) FindByBin(_ context.Context, binNumber string) (entities.BinInfo, bool) {
+	r.mutex.RLock()
+	table := r.table
+	r.mutex.RUnlock()
+
+	binNumber = strings.TrimSpace(binNumber)
+	if table == nil || !isDigits(binNumber) {
+		return entities.BinInfo{}, false
+	}
+
+	length := len(binNumber)
+	if length > table.maxLength {
+		length = table.maxLength
+	}
+	for ; length >= table.minLength; length-- {
+		if info, ok := table.byPrefix[binNumber[:length]]; ok {
+			return info, true
+		}
+	}
+
+	return entities.BinInfo{}, false
+}
+
+// Load reads the table from the local file when it is configured or from S3 otherwise, the loaded table is
+// kept when the new one can not be read.
+func (r *binTableRepository) Load(ctx context.Context) error {
+	if !r.config.BinTable.IsEnabled {
+		return nil
+	}
+
+	metricData := metrics.NewMetricData(ctx, "Load", binTableRepositoryName, r.config.Env)
+	content, err := r.readFile(ctx)
+	rows := make([]entities.BinInfo, 0)
+	if err == nil {
+		err = gocsv.UnmarshalBytes(content, &rows)
+	}
+
+	var table *binTable
+	if err == nil {
+		table, err = newBinTable(rows)
+	}
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(binTableRepositoryName, "Load"))
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(r.datadog, r.log, metricData, text.BinTableSyncMetricName)
+		return err
+	}
+
+	r.mutex.Lock()
+	r.table = table
+	r.mutex.Unlock()
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(r.datadog, r.log, metricData, text.BinTableSyncMetricName)
+	return nil
+}
+
+func (r *binTableRepository) Watch(ctx context.Context) {
+	if !r.config.BinTable.IsEnabled || r.config.BinTable.ReloadSeconds <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(r.config.BinTable.ReloadSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = r.Load(ctx)
+		}
+	}
+}
+
+func (r *binTableRepository) readFile(ctx context.Context) ([]byte, error) {
+	if r.config.BinTable.FilePath != "" {
+		return os.ReadFile(r.config.BinTable.FilePath)
+	}
+
+	return r.s3Reader.ReadS3File(ctx, r.config.BinTable.S3Bucket, r.config.BinTable.S3File)
+}
+
+func newBinTable(rows []entities.BinInfo) (*binTable, error) {
+	table := &binTable{byPrefix: make(map[string]entities.BinInfo, len(rows))}
+	for _, row := range rows {
+		row.Bin = strings.TrimSpace(row.Bin)
+		if !isDigits(row.Bin) {
+			continue
+		}
+
+		table.byPrefix[row.Bin] = row
+		if table.minLength == 0 || len(row.Bin) < table.minLength {
+			table.minLength = len(row.Bin)
+		}
+		if len(row.Bin) > table.maxLength {
+			table.maxLength = len(row.Bin)
+		}
+	}
+
+	if len(table.byPrefix) == 0 {
+		return nil, errEmptyBinTable
+	}
+
+	return table, nil
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
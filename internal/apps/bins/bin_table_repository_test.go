@@ -0,0 +1,111 @@
+package bins_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/bins"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinTableRepository_FindByBin(t *testing.T) {
+	logger, _ := logs.New()
+	cfg := config.Config{}
+	cfg.BinTable.IsEnabled = true
+	cfg.BinTable.S3Bucket = "bucket"
+	cfg.BinTable.S3File = "bins.csv"
+
+	s3Reader := new(mocks.S3ReaderMock)
+	s3Reader.On("ReadS3File", context.TODO(), "bucket", "bins.csv").Return(testdata.GetRawBinTable(), nil)
+	repository := bins.NewBinTableRepository(cfg, s3Reader, logger, new(datadog.MetricsDogMock))
+	assert.NoError(t, repository.Load(context.TODO()))
+
+	t.Run("the card number is matched by its prefix", func(t *testing.T) {
+		info, found := repository.FindByBin(context.TODO(), "4111119999")
+
+		assert.True(t, found)
+		assert.Equal(t, testdata.GetDefaultBinInfo(), info)
+	})
+
+	t.Run("the longest prefix wins", func(t *testing.T) {
+		info, found := repository.FindByBin(context.TODO(), "41111122")
+
+		assert.True(t, found)
+		assert.Equal(t, "Banorte", info.Issuer)
+		assert.Equal(t, entities.BinProductPrepaid, info.ProductLevel)
+	})
+
+	t.Run("unknown and invalid bins are not found", func(t *testing.T) {
+		for _, binNumber := range []string{"999999", "4111", "invalid", ""} {
+			_, found := repository.FindByBin(context.TODO(), binNumber)
+			assert.False(t, found, binNumber)
+		}
+	})
+}
+
+func TestBinTableRepository_Load(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("when the table is disabled nothing is read", func(t *testing.T) {
+		s3Reader := new(mocks.S3ReaderMock)
+		repository := bins.NewBinTableRepository(config.Config{}, s3Reader, logger, new(datadog.MetricsDogMock))
+
+		assert.NoError(t, repository.Load(context.TODO()))
+		s3Reader.AssertNotCalled(t, "ReadS3File")
+	})
+
+	t.Run("the local file is read instead of S3 when it is configured", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bins.csv")
+		assert.NoError(t, os.WriteFile(path, testdata.GetRawBinTable(), 0o600))
+		cfg := config.Config{}
+		cfg.BinTable.IsEnabled = true
+		cfg.BinTable.FilePath = path
+		s3Reader := new(mocks.S3ReaderMock)
+		repository := bins.NewBinTableRepository(cfg, s3Reader, logger, new(datadog.MetricsDogMock))
+
+		assert.NoError(t, repository.Load(context.TODO()))
+
+		info, found := repository.FindByBin(context.TODO(), "520000")
+		assert.True(t, found)
+		assert.Equal(t, "Santander", info.Issuer)
+		s3Reader.AssertNotCalled(t, "ReadS3File")
+	})
+
+	t.Run("a failed reload keeps the loaded table", func(t *testing.T) {
+		cfg := config.Config{}
+		cfg.BinTable.IsEnabled = true
+		s3Reader := new(mocks.S3ReaderMock)
+		s3Reader.On("ReadS3File", context.TODO(), "", "").Return(testdata.GetRawBinTable(), nil).Once()
+		s3Reader.On("ReadS3File", context.TODO(), "", "").Return([]byte(nil), errors.New("access denied")).Once()
+		repository := bins.NewBinTableRepository(cfg, s3Reader, logger, new(datadog.MetricsDogMock))
+		assert.NoError(t, repository.Load(context.TODO()))
+
+		err := repository.Load(context.TODO())
+
+		assert.EqualError(t, err, "access denied")
+		_, found := repository.FindByBin(context.TODO(), "411111")
+		assert.True(t, found)
+	})
+
+	t.Run("a table without valid rows is rejected", func(t *testing.T) {
+		cfg := config.Config{}
+		cfg.BinTable.IsEnabled = true
+		s3Reader := new(mocks.S3ReaderMock)
+		s3Reader.On("ReadS3File", context.TODO(), "", "").
+			Return([]byte("bin,brand,issuer\ninvalid,visa,BBVA\n"), nil)
+		repository := bins.NewBinTableRepository(cfg, s3Reader, logger, new(datadog.MetricsDogMock))
+
+		err := repository.Load(context.TODO())
+
+		assert.EqualError(t, err, "bin table has no valid rows")
+	})
+}
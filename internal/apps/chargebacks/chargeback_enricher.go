@@ -0,0 +1,58 @@
+package chargebacks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+)
+
+const (
+	enricherName     = "chargebacks"
+	chargebacksField = "payer.chargebacks"
+)
+
+type chargebacksEnricher struct {
+	config     config.Config
+	repository ChargebackRepository
+}
+
+func NewChargebacksEnricher(cfg config.Config, repository ChargebackRepository) entities.Enricher {
+	return &chargebacksEnricher{
+		config:     cfg,
+		repository: repository,
+	}
+}
+
+func (enricher *chargebacksEnricher) Name() string {
+	return enricherName
+}
+
+func (enricher *chargebacksEnricher) Fields() []string {
+	return []string{chargebacksField}
+}
+
+func (enricher *chargebacksEnricher) TimeoutMilliseconds() int {
+	return enricher.config.Enrichment.ChargebacksTimeoutMilliseconds
+}
+
+func (enricher *chargebacksEnricher) FailurePolicy() entities.EnrichmentFailurePolicy {
+	return entities.EnrichmentFailurePolicy{
+		OnFailure: entities.EnrichmentUseDefaults,
+		Defaults:  map[string]interface{}{chargebacksField: int64(0)},
+	}
+}
+
+func (enricher *chargebacksEnricher) IsEnabled() bool {
+	return true
+}
+
+func (enricher *chargebacksEnricher) Enrich(ctx context.Context,
+	charge entities.ChargeRequest) (map[string]interface{}, error) {
+	foundPayer, err := enricher.repository.Find(ctx, entities.Payer{Email: charge.Details.Email})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{chargebacksField: int64(len(foundPayer.Chargebacks))}, nil
+}
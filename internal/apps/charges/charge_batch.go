@@ -0,0 +1,101 @@
+package charges
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/entities"
+)
+
+type sharedRulesRepository struct {
+	rules.RuleRepository
+	mutex   sync.Mutex
+	lookups map[string]*rulesLookup
+}
+
+type rulesLookup struct {
+	once  sync.Once
+	rules []entities.Rule
+	err   error
+}
+
+func newSharedRulesRepository(ruleRepository rules.RuleRepository) rules.RuleRepository {
+	return &sharedRulesRepository{
+		RuleRepository: ruleRepository,
+		lookups:        make(map[string]*rulesLookup),
+	}
+}
+
+func (r *sharedRulesRepository) GetRulesByFilters(ctx context.Context, filter entities.RuleFilter,
+	component entities.ConsoleComponent) ([]entities.Rule, error) {
+	key := fmt.Sprintf("%s|%s|%s|%s", component, filter.CompanyID, filter.FamilyID,
+		strings.Join(filter.FamilyCompaniesIDs, ","))
+
+	r.mutex.Lock()
+	lookup, ok := r.lookups[key]
+	if !ok {
+		lookup = &rulesLookup{}
+		r.lookups[key] = lookup
+	}
+	r.mutex.Unlock()
+
+	lookup.once.Do(func() {
+		lookup.rules, lookup.err = r.RuleRepository.GetRulesByFilters(ctx, filter, component)
+	})
+
+	return lookup.rules, lookup.err
+}
+
+func (service *chargeService) EvaluateChargesBatch(ctx context.Context,
+	charges []entities.ChargeRequest) []entities.BatchEvaluationItem {
+	batchService := *service
+	batchService.rulesRepository = newSharedRulesRepository(service.rulesRepository)
+
+	concurrency := service.config.BatchEvaluation.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	items := make([]entities.BatchEvaluationItem, len(charges))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for index, charge := range charges {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(index int, charge entities.ChargeRequest) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			items[index] = batchService.evaluateBatchItem(ctx, index, charge)
+		}(index, charge)
+	}
+	wg.Wait()
+
+	return items
+}
+
+func (service *chargeService) evaluateBatchItem(ctx context.Context, index int,
+	charge entities.ChargeRequest) (item entities.BatchEvaluationItem) {
+	item.Index = index
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			item.Evaluation = nil
+			item.Error = fmt.Sprintf("%v", recovered)
+		}
+	}()
+
+	evaluation, err := service.EvaluateCharge(ctx, charge)
+	if err != nil {
+		item.Error = err.Error()
+		return item
+	}
+	item.Evaluation = &evaluation
+
+	return item
+}
@@ -0,0 +1,65 @@
+package charges
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+const companyDegradationPolicyKey = "%s.%s"
+
+// degrade applies the degradation policy of the company to a component that could not be evaluated, the fail policy
+// returns a service unavailable error so the charge is not approved by an outage.
+func (service *chargeService) degrade(ctx context.Context, charge entities.ChargeRequest, component entities.Component,
+	cause error, componentTrace *entities.ComponentTrace) (entities.Degradation, error) {
+	degradation := entities.NewDegradation(component.Name, service.getDegradationPolicy(charge.CompanyID, component.Name),
+		cause)
+	componentTrace.AddReason("%s", degradation.Reason())
+	service.logs.Error(ctx, fmt.Sprintf("charge %s: %s", charge.ID, degradation.Reason()),
+		text.LogTagMethod, fmt.Sprintf(serviceMethodName, "degrade"))
+	service.sendDegradationMetrics(ctx, charge, degradation)
+
+	if degradation.Policy == entities.DegradationFail {
+		return degradation, exceptions.NewServiceUnavailableExceptionWithCause(
+			fmt.Sprintf("charge %s could not be evaluated: %s", charge.ID, degradation.Reason()),
+			exceptions.Causes{Code: string(component.Name), Message: degradation.Error})
+	}
+
+	return degradation, nil
+}
+
+// getDegradationPolicy returns the policy of the company for the component, then the one of the component and then
+// the default one, an invalid policy continues the evaluation.
+func (service *chargeService) getDegradationPolicy(companyID string,
+	component entities.ConsoleComponent) entities.DegradationPolicy {
+	policies := service.config.Degradation
+	policy, ok := policies.CompanyPolicies[fmt.Sprintf(companyDegradationPolicyKey, companyID, component)]
+	if !ok {
+		policy, ok = policies.ComponentPolicies[string(component)]
+	}
+	if !ok {
+		policy = policies.DefaultPolicy
+	}
+
+	if !entities.DegradationPolicy(policy).IsValid() {
+		return entities.DegradationContinue
+	}
+
+	return entities.DegradationPolicy(policy)
+}
+
+func (service *chargeService) sendDegradationMetrics(ctx context.Context, charge entities.ChargeRequest,
+	degradation entities.Degradation) {
+	metricData := metrics.NewMetricData(ctx, "degrade", serviceMethodName, service.config.Env)
+	metricData.AddCustomTags([]string{
+		fmt.Sprintf(text.MetricTagCompany, charge.CompanyID),
+		fmt.Sprintf(text.MetricTagComponent, degradation.Component),
+		fmt.Sprintf(text.MetricTagDegradationPolicy, degradation.Policy),
+	})
+	metricData.SetResult(false)
+	metrics.SendAsyncMetrics(service.metrics, service.logs, metricData, text.DegradedEvaluationMetricName)
+}
@@ -0,0 +1,188 @@
+package charges
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+const listsEnrichment = "lists"
+
+type enrichment struct {
+	name                string
+	timeoutMilliseconds int
+	run                 func(ctx context.Context) interface{}
+}
+
+type enrichmentResults struct {
+	values   map[string]interface{}
+	timedOut []string
+}
+
+type enricherOutput struct {
+	values map[string]interface{}
+	err    error
+}
+
+type listsEnrichmentResult struct {
+	lists []entities.List
+	err   error
+}
+
+func (service *chargeService) getChargeEnrichments(charge entities.ChargeRequest, withLists bool) []enrichment {
+	enrichments := make([]enrichment, 0, len(service.enrichers)+1)
+	for _, enricher := range service.enrichers {
+		if !enricher.IsEnabled() {
+			continue
+		}
+
+		enricher := enricher
+		enrichments = append(enrichments, enrichment{
+			name:                enricher.Name(),
+			timeoutMilliseconds: enricher.TimeoutMilliseconds(),
+			run: func(ctx context.Context) interface{} {
+				values, err := enricher.Enrich(ctx, charge)
+				return enricherOutput{values: values, err: err}
+			},
+		})
+	}
+
+	if withLists {
+		enrichments = append(enrichments, enrichment{
+			name:                listsEnrichment,
+			timeoutMilliseconds: service.config.Enrichment.ListsTimeoutMilliseconds,
+			run: func(ctx context.Context) interface{} {
+				foundLists, err := service.listsService.GetLists(ctx, charge.NewListsSearch())
+				return listsEnrichmentResult{lists: foundLists, err: err}
+			},
+		})
+	}
+
+	return enrichments
+}
+
+// applyEnrichments writes the enricher outputs in the charge, the failure policy decides what a skipped, failed
+// or timed out enricher leaves, and returns how every enricher ended.
+func (service *chargeService) applyEnrichments(ctx context.Context, charge *entities.ChargeRequest,
+	results enrichmentResults) []entities.EnrichmentResult {
+	values := make(map[string]interface{})
+	enrichmentResults := make([]entities.EnrichmentResult, 0, len(service.enrichers))
+
+	for _, enricher := range service.enrichers {
+		result := entities.EnrichmentResult{Name: enricher.Name(), Status: entities.EnrichmentRan}
+		output, err := results.enricher(enricher.Name())
+		if !enricher.IsEnabled() {
+			result.Status = entities.EnrichmentSkipped
+		} else if err != nil {
+			result.Status = entities.EnrichmentFailed
+			result.Error = err.Error()
+			service.logs.Error(ctx, fmt.Sprintf("enrichment %s failed: %v", enricher.Name(), err),
+				text.LogTagMethod, fmt.Sprintf(serviceMethodName, "applyEnrichments"))
+		}
+
+		if result.Status != entities.EnrichmentRan {
+			output = enricher.FailurePolicy().Fallback()
+		}
+
+		for _, field := range enricher.Fields() {
+			if value, ok := output[field]; ok {
+				values[field] = value
+			}
+		}
+		enrichmentResults = append(enrichmentResults, result)
+	}
+
+	if err := charge.SetEnrichments(values); err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(serviceMethodName, "applyEnrichments"))
+	}
+
+	return enrichmentResults
+}
+
+func (service *chargeService) enrich(ctx context.Context, enrichments []enrichment) enrichmentResults {
+	budgetCtx, cancel := withTimeoutMilliseconds(ctx, service.config.Enrichment.BudgetMilliseconds)
+	defer cancel()
+
+	results := enrichmentResults{values: make(map[string]interface{}, len(enrichments))}
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, item := range enrichments {
+		wg.Add(1)
+		go func(item enrichment) {
+			defer wg.Done()
+			value, ok := service.runEnrichment(budgetCtx, item)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if !ok {
+				results.timedOut = append(results.timedOut, item.name)
+				return
+			}
+			results.values[item.name] = value
+		}(item)
+	}
+	wg.Wait()
+
+	sort.Strings(results.timedOut)
+	return results
+}
+
+func (service *chargeService) runEnrichment(ctx context.Context, item enrichment) (interface{}, bool) {
+	enrichmentCtx, cancel := withTimeoutMilliseconds(ctx, item.timeoutMilliseconds)
+	defer cancel()
+
+	done := make(chan interface{}, 1)
+	go func() {
+		done <- item.run(enrichmentCtx)
+	}()
+
+	select {
+	case value := <-done:
+		return value, true
+	case <-enrichmentCtx.Done():
+		service.logs.Error(ctx, fmt.Sprintf("enrichment %s timed out: %v", item.name, enrichmentCtx.Err()),
+			text.LogTagMethod, fmt.Sprintf(serviceMethodName, "enrich"))
+		service.sendEnrichmentTimeoutMetrics(ctx, item.name)
+		return nil, false
+	}
+}
+
+func (service *chargeService) sendEnrichmentTimeoutMetrics(ctx context.Context, name string) {
+	metricData := metrics.NewMetricData(ctx, "enrich", serviceMethodName, service.config.Env)
+	metricData.AddCustomTags([]string{fmt.Sprintf(text.MetricTagEnrichment, name)})
+	metricData.SetResult(false)
+	metrics.SendAsyncMetrics(service.metrics, service.logs, metricData, text.EnrichmentTimeoutMetricName)
+}
+
+func (results enrichmentResults) enricher(name string) (map[string]interface{}, error) {
+	if value, ok := results.values[name]; ok {
+		output := value.(enricherOutput)
+		return output.values, output.err
+	}
+
+	return nil, fmt.Errorf("enrichment %s timed out", name)
+}
+
+func (results enrichmentResults) lists() ([]entities.List, error) {
+	if value, ok := results.values[listsEnrichment]; ok {
+		listsResult := value.(listsEnrichmentResult)
+		return listsResult.lists, listsResult.err
+	}
+
+	return nil, fmt.Errorf("enrichment %s timed out", listsEnrichment)
+}
+
+func withTimeoutMilliseconds(ctx context.Context, milliseconds int) (context.Context, context.CancelFunc) {
+	if milliseconds <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Duration(milliseconds)*time.Millisecond)
+}
@@ -3,6 +3,7 @@ package charges
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/config"
@@ -12,6 +13,7 @@ import (
 	"github.com/conekta/risk-rules/pkg/text"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const repositoryMethodName = "charge_evaluation.repository.mongo.%s"
@@ -95,3 +97,49 @@ func (repository *chargeEvaluationMongoDBRepository) GetOnlyRules(ctx context.Co
 
 	return result, nil
 }
+
+// FindEvaluation returns the latest evaluation of the charge made since the given time.
+func (repository *chargeEvaluationMongoDBRepository) FindEvaluation(ctx context.Context, id string,
+	since time.Time) (entities.EvaluationResponse, error) {
+	var result entities.EvaluationResponse
+
+	err := repository.findLatest(ctx, repository.config.MongoDB.Collections.ChargeEvaluations, id, since, &result)
+	if err != nil {
+		repository.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryMethodName, "FindEvaluation"))
+		return entities.EvaluationResponse{}, err
+	}
+
+	return result, nil
+}
+
+// FindEvaluationOnlyRules returns the latest rules evaluation of the charge made since the given time.
+func (repository *chargeEvaluationMongoDBRepository) FindEvaluationOnlyRules(ctx context.Context, id string,
+	since time.Time) (entities.RulesEvaluationResponse, error) {
+	var result entities.RulesEvaluationResponse
+
+	err := repository.findLatest(ctx, repository.config.MongoDB.Collections.ChargeEvaluationsOnlyRules, id, since,
+		&result)
+	if err != nil {
+		repository.logs.Error(ctx, err.Error(), text.LogTagMethod,
+			fmt.Sprintf(repositoryMethodName, "FindEvaluationOnlyRules"))
+		return entities.RulesEvaluationResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (repository *chargeEvaluationMongoDBRepository) findLatest(ctx context.Context, collection string, id string,
+	since time.Time, result interface{}) error {
+	filter := bson.D{
+		primitive.E{Key: "charge._id", Value: id},
+		primitive.E{Key: "evaluated_at", Value: bson.M{"$gte": since}},
+	}
+	opts := options.FindOne().SetSort(bson.D{primitive.E{Key: "evaluated_at", Value: -1}})
+
+	err := repository.mongodb.Collection(collection).FindOne(ctx, filter, opts).Decode(result)
+	if err != nil && err.Error() == mongodb.NoResultsOnFind {
+		return exceptions.NewNotFoundException(fmt.Sprintf("error, charge_evaluation %s not found", id))
+	}
+
+	return err
+}
@@ -1,10 +1,16 @@
 package charges
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"unicode"
 
 	"github.com/conekta/risk-rules/pkg/metrics"
 
@@ -19,12 +25,19 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-const handlerName = "charge.handler.%s"
+const (
+	handlerName               = "charge.handler.%s"
+	explainQueryParam         = "explain"
+	forceReevaluateQueryParam = "force_reevaluate"
+	idempotencyHeader         = "X-Idempotent-Evaluation"
+	maxBatchLineLength        = 1024 * 1024
+)
 
 type ChargeHandler interface {
 	Evaluate(c echo.Context) error
 	GetEvaluation(c echo.Context) error
 	EvaluateOnlyRules(c echo.Context) error
+	EvaluateBatch(c echo.Context) error
 	GetEvaluationOnlyRules(c echo.Context) error
 }
 
@@ -51,6 +64,8 @@ func (handler *chargeHandler) Evaluate(ctx echo.Context) error {
 	}
 
 	request.ValidateConsole()
+	request.Explain = isExplainRequested(ctx)
+	request.Idempotent, request.ForceReevaluate = getIdempotencyOptions(ctx)
 
 	resp, err := handler.service.EvaluateCharge(ctx.Request().Context(), *request)
 	if err != nil {
@@ -69,6 +84,8 @@ func (handler *chargeHandler) EvaluateOnlyRules(ctx echo.Context) error {
 	}
 
 	request.ValidateConsoleOnlyRules()
+	request.Explain = isExplainRequested(ctx)
+	request.Idempotent, request.ForceReevaluate = getIdempotencyOptions(ctx)
 
 	resp, err := handler.service.EvaluateChargeOnlyRules(ctx.Request().Context(), *request)
 	if err != nil {
@@ -80,6 +97,61 @@ func (handler *chargeHandler) EvaluateOnlyRules(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, resp)
 }
 
+func (handler *chargeHandler) EvaluateBatch(ctx echo.Context) error {
+	body := ctx.Request().Body
+	if handler.config.BatchEvaluation.MaxBytes > 0 {
+		body = http.MaxBytesReader(ctx.Response(), body, handler.config.BatchEvaluation.MaxBytes)
+	}
+
+	rawCharges, err := readBatchChargeRequests(body, handler.config.BatchEvaluation.MaxSize)
+	if err == nil && len(rawCharges) == 0 {
+		err = errors.New("empty batch")
+	}
+	if err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "EvaluateBatch"))
+		handler.sendMetricsFail(ctx.Request().Context())
+		ctx.Error(err)
+		return nil
+	}
+
+	items := make([]entities.BatchEvaluationItem, len(rawCharges))
+	charges := make([]entities.ChargeRequest, 0, len(rawCharges))
+	positions := make([]int, 0, len(rawCharges))
+	explain := isExplainRequested(ctx)
+	idempotent, forceReevaluate := getIdempotencyOptions(ctx)
+
+	for index, rawCharge := range rawCharges {
+		items[index].Index = index
+
+		var request entities.ChargeRequest
+		if err = json.Unmarshal(rawCharge, &request); err != nil {
+			items[index].Error = err.Error()
+			continue
+		}
+		if err = ctx.Validate(&request); err != nil {
+			items[index].Error = err.Error()
+			continue
+		}
+
+		request.ValidateConsole()
+		request.Explain = explain
+		request.Idempotent, request.ForceReevaluate = idempotent, forceReevaluate
+		charges = append(charges, request)
+		positions = append(positions, index)
+	}
+
+	if len(charges) > 0 {
+		results := handler.service.EvaluateChargesBatch(ctx.Request().Context(), charges)
+		for resultIndex, result := range results {
+			result.Index = positions[resultIndex]
+			items[result.Index] = result
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, entities.NewBatchEvaluationResponse(items))
+}
+
 func (handler *chargeHandler) GetEvaluation(ctx echo.Context) error {
 	id := ctx.Param("id")
 	if strings.IsEmpty(id) {
@@ -146,3 +218,88 @@ func (handler *chargeHandler) sendMetricsFail(ctx context.Context) {
 	metricData.SetResult(false)
 	metrics.SendAsyncMetrics(handler.metrics, handler.logs, metricData, text.EvaluateChargeMetricName)
 }
+
+func isExplainRequested(ctx echo.Context) bool {
+	explain, _ := strconv.ParseBool(ctx.QueryParam(explainQueryParam))
+	return explain
+}
+
+// getIdempotencyOptions tells if the request asks for an idempotent evaluation and if ops forces a new one.
+func getIdempotencyOptions(ctx echo.Context) (idempotent bool, forceReevaluate bool) {
+	idempotent, _ = strconv.ParseBool(ctx.Request().Header.Get(idempotencyHeader))
+	forceReevaluate, _ = strconv.ParseBool(ctx.QueryParam(forceReevaluateQueryParam))
+	return idempotent, forceReevaluate
+}
+
+// readBatchChargeRequests reads the charges of a json array or of ndjson, one per line, and stops reading once the
+// batch has more than maxSize charges.
+func readBatchChargeRequests(body io.Reader, maxSize int) ([]json.RawMessage, error) {
+	reader := bufio.NewReader(body)
+	isArray, err := startsWithArray(reader)
+	if err != nil {
+		return nil, err
+	}
+	if isArray {
+		return readBatchArray(reader, maxSize)
+	}
+
+	rawCharges := make([]json.RawMessage, 0)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxBatchLineLength)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if maxSize > 0 && len(rawCharges) == maxSize {
+			return nil, fmt.Errorf("batch size exceeds the maximum of %d", maxSize)
+		}
+		rawCharges = append(rawCharges, append(json.RawMessage{}, line...))
+	}
+
+	return rawCharges, scanner.Err()
+}
+
+func readBatchArray(reader io.Reader, maxSize int) ([]json.RawMessage, error) {
+	decoder := json.NewDecoder(reader)
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	rawCharges := make([]json.RawMessage, 0)
+	for decoder.More() {
+		if maxSize > 0 && len(rawCharges) == maxSize {
+			return nil, fmt.Errorf("batch size exceeds the maximum of %d", maxSize)
+		}
+		var rawCharge json.RawMessage
+		if err := decoder.Decode(&rawCharge); err != nil {
+			return nil, err
+		}
+		rawCharges = append(rawCharges, rawCharge)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid content after the batch")
+	}
+
+	return rawCharges, nil
+}
+
+// startsWithArray skips the leading white space and tells whether the body is a json array.
+func startsWithArray(reader *bufio.Reader) (bool, error) {
+	for {
+		char, err := reader.ReadByte()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !unicode.IsSpace(rune(char)) {
+			return char == '[', reader.UnreadByte()
+		}
+	}
+}
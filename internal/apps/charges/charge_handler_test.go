@@ -108,6 +108,49 @@ func TestChargeHandler_Evaluate(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 
+	t.Run("when the evaluation is requested with explain", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		request, _ := json.Marshal(charge)
+
+		service := new(mocks.ChargeServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/charges/evaluate?explain=true", "", string(request))
+		response := testdata.GetEvaluationResponseSuccessful()
+		explainedCharge := charge
+		explainedCharge.Explain = true
+		service.On("EvaluateCharge", context.Request().Context(), explainedCharge).Return(response, nil)
+
+		handler := charges.NewChargeHandler(config.Config{}, service, logger, metrics)
+
+		err := handler.Evaluate(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("when an idempotent evaluation is forced to reevaluate", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		request, _ := json.Marshal(charge)
+
+		service := new(mocks.ChargeServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/charges/evaluate?force_reevaluate=true", "",
+			string(request))
+		context.Request().Header.Set("X-Idempotent-Evaluation", "true")
+		response := testdata.GetEvaluationResponseSuccessful()
+		idempotentCharge := charge
+		idempotentCharge.Idempotent = true
+		idempotentCharge.ForceReevaluate = true
+		service.On("EvaluateCharge", context.Request().Context(), idempotentCharge).Return(response, nil)
+
+		handler := charges.NewChargeHandler(config.Config{}, service, logger, metrics)
+
+		err := handler.Evaluate(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+
 	t.Run("charge has aggregation fields and evaluation is ok", func(t *testing.T) {
 		request := testdata.GetDefaultCharge()
 		body, _ := json.Marshal(request)
@@ -259,6 +302,135 @@ func TestChargeHandler_Evaluate(t *testing.T) {
 
 }
 
+func TestChargeHandler_EvaluateBatch(t *testing.T) {
+	logger, _ := logs.New()
+	response := testdata.GetEvaluationResponseSuccessful()
+
+	t.Run("when the batch is a json array, then results keep the request order", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		request, _ := json.Marshal([]entities.ChargeRequest{charge, charge})
+
+		service := new(mocks.ChargeServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/charges/evaluate/batch", "", string(request))
+		service.On("EvaluateChargesBatch", context.Request().Context(), mock.AnythingOfType("[]entities.ChargeRequest")).
+			Return([]entities.BatchEvaluationItem{
+				{Index: 0, Evaluation: &response},
+				{Index: 1, Error: "evaluation failed"},
+			})
+
+		handler := charges.NewChargeHandler(config.Config{}, service, logger, metrics)
+
+		err := handler.EvaluateBatch(context)
+
+		var batchResponse entities.BatchEvaluationResponse
+		json.Unmarshal(rec.Body.Bytes(), &batchResponse)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 2, batchResponse.Total)
+		assert.Equal(t, 1, batchResponse.Failed)
+		assert.Equal(t, response.Decision, batchResponse.Data[0].Evaluation.Decision)
+		assert.Equal(t, "evaluation failed", batchResponse.Data[1].Error)
+	})
+
+	t.Run("when the batch is ndjson with an invalid item, then the item fails alone", func(t *testing.T) {
+		charge, _ := json.Marshal(testdata.GetDefaultCharge())
+		invalidCharge, _ := json.Marshal(testdata.GetChargeInvalidRequestCompanyIDRequired())
+		request := strings.Join([]string{string(charge), string(invalidCharge), "", string(charge)}, "\n")
+
+		service := new(mocks.ChargeServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/charges/evaluate/batch", "", request)
+		service.On("EvaluateChargesBatch", context.Request().Context(),
+			mock.MatchedBy(func(charges []entities.ChargeRequest) bool { return len(charges) == 2 })).
+			Return([]entities.BatchEvaluationItem{
+				{Index: 0, Evaluation: &response},
+				{Index: 1, Evaluation: &response},
+			})
+
+		handler := charges.NewChargeHandler(config.Config{}, service, logger, metrics)
+
+		err := handler.EvaluateBatch(context)
+
+		var batchResponse entities.BatchEvaluationResponse
+		json.Unmarshal(rec.Body.Bytes(), &batchResponse)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 3, batchResponse.Total)
+		assert.Equal(t, 1, batchResponse.Failed)
+		assert.NotNil(t, batchResponse.Data[0].Evaluation)
+		assert.NotEmpty(t, batchResponse.Data[1].Error)
+		assert.Equal(t, 2, batchResponse.Data[2].Index)
+		assert.NotNil(t, batchResponse.Data[2].Evaluation)
+	})
+
+	t.Run("when the batch exceeds the maximum size, then return StatusBadRequest", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		request, _ := json.Marshal([]entities.ChargeRequest{charge, charge})
+		cfg := config.Config{}
+		cfg.BatchEvaluation.MaxSize = 1
+
+		service := new(mocks.ChargeServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/charges/evaluate/batch", "", string(request))
+
+		handler := charges.NewChargeHandler(cfg, service, logger, metrics)
+
+		err := handler.EvaluateBatch(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		service.AssertNotCalled(t, "EvaluateChargesBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when the ndjson batch exceeds the maximum size, then return StatusBadRequest", func(t *testing.T) {
+		charge, _ := json.Marshal(testdata.GetDefaultCharge())
+		request := strings.Join([]string{string(charge), string(charge), string(charge)}, "\n")
+		cfg := config.Config{}
+		cfg.BatchEvaluation.MaxSize = 2
+
+		service := new(mocks.ChargeServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/charges/evaluate/batch", "", request)
+
+		handler := charges.NewChargeHandler(cfg, service, logger, metrics)
+
+		err := handler.EvaluateBatch(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		service.AssertNotCalled(t, "EvaluateChargesBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when the batch exceeds the maximum bytes, then return StatusBadRequest", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		request, _ := json.Marshal([]entities.ChargeRequest{charge, charge})
+		cfg := config.Config{}
+		cfg.BatchEvaluation.MaxBytes = int64(len(request) / 2)
+
+		service := new(mocks.ChargeServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/charges/evaluate/batch", "", string(request))
+
+		handler := charges.NewChargeHandler(cfg, service, logger, metrics)
+
+		err := handler.EvaluateBatch(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		service.AssertNotCalled(t, "EvaluateChargesBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when the batch is malformed, then return StatusBadRequest", func(t *testing.T) {
+		service := new(mocks.ChargeServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, "/charges/evaluate/batch", "", "[{")
+
+		handler := charges.NewChargeHandler(config.Config{}, service, logger, metrics)
+
+		err := handler.EvaluateBatch(context)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
 func TestChargeHandler_EvaluateOnlyRules(t *testing.T) {
 	logger, _ := logs.New()
 
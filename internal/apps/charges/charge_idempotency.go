@@ -0,0 +1,113 @@
+package charges
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+const (
+	idempotencyKey = "%s:%s"
+
+	idempotencyStored    = "stored"
+	idempotencyInFlight  = "in_flight"
+	idempotencyEvaluated = "evaluated"
+)
+
+type idempotentEvaluations struct {
+	mutex    sync.Mutex
+	inFlight map[string]*idempotentEvaluation
+}
+
+type idempotentEvaluation struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func newIdempotentEvaluations() *idempotentEvaluations {
+	return &idempotentEvaluations{inFlight: make(map[string]*idempotentEvaluation)}
+}
+
+func (service *chargeService) isIdempotent(charge entities.ChargeRequest) bool {
+	return service.config.Idempotency.IsEnabled || charge.Idempotent
+}
+
+// evaluateOnce returns the evaluation of the key stored inside the idempotency window or the one in flight of a
+// concurrent duplicate, otherwise it evaluates and keeps the evaluation in flight until it is saved. A forced
+// evaluation skips the stored one but still shares the one in flight, which is fresh.
+func (service *chargeService) evaluateOnce(ctx context.Context, key string, force bool,
+	find func(since time.Time) (interface{}, error),
+	evaluate func(saved func(interface{})) (interface{}, error)) (interface{}, bool, error) {
+	evaluations := service.idempotency
+	evaluations.mutex.Lock()
+	if inFlight, ok := evaluations.inFlight[key]; ok {
+		evaluations.mutex.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-inFlight.done:
+			service.sendIdempotencyMetrics(ctx, idempotencyInFlight)
+			return inFlight.value, inFlight.err == nil, inFlight.err
+		}
+	}
+	evaluation := &idempotentEvaluation{done: make(chan struct{})}
+	evaluations.inFlight[key] = evaluation
+	evaluations.mutex.Unlock()
+
+	windowSeconds := service.config.Idempotency.WindowSeconds
+	if !force && windowSeconds > 0 {
+		stored, err := find(time.Now().UTC().Add(-time.Duration(windowSeconds) * time.Second))
+		if err == nil {
+			evaluations.release(key, evaluation, stored, nil)
+			service.sendIdempotencyMetrics(ctx, idempotencyStored)
+			return stored, true, nil
+		}
+		if _, isNotFound := err.(exceptions.NotFoundException); !isNotFound {
+			service.logs.Error(ctx, fmt.Sprintf("stored evaluation of %s not available: %v", key, err),
+				text.LogTagMethod, fmt.Sprintf(serviceMethodName, "evaluateOnce"))
+		}
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			evaluations.release(key, evaluation, nil, fmt.Errorf("evaluation of %s panicked: %v", key, recovered))
+			panic(recovered)
+		}
+	}()
+
+	value, err := evaluate(func(saved interface{}) {
+		evaluations.release(key, evaluation, saved, nil)
+	})
+	if err != nil {
+		evaluations.release(key, evaluation, nil, err)
+	}
+	service.sendIdempotencyMetrics(ctx, idempotencyEvaluated)
+
+	return value, false, err
+}
+
+// release removes the evaluation from the ones in flight and hands its value to the duplicates waiting for it.
+func (evaluations *idempotentEvaluations) release(key string, evaluation *idempotentEvaluation, value interface{},
+	err error) {
+	evaluations.mutex.Lock()
+	delete(evaluations.inFlight, key)
+	evaluations.mutex.Unlock()
+
+	evaluation.value = value
+	evaluation.err = err
+	close(evaluation.done)
+}
+
+func (service *chargeService) sendIdempotencyMetrics(ctx context.Context, result string) {
+	metricData := metrics.NewMetricData(ctx, "evaluateOnce", serviceMethodName, service.config.Env)
+	metricData.AddCustomTags([]string{fmt.Sprintf(text.MetricTagIdempotencyResult, result)})
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.metrics, service.logs, metricData, text.IdempotencyMetricName)
+}
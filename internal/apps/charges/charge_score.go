@@ -0,0 +1,38 @@
+package charges
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+func (service *chargeService) applyRiskScore(ctx context.Context, charge entities.ChargeRequest, familyID string,
+	response *entities.RulesResponse, componentTrace *entities.ComponentTrace) {
+	riskScore := entities.NewRiskScore()
+	for _, rule := range response.DecisionRules {
+		riskScore.AddContribution(rule)
+	}
+	for _, rule := range response.TestRules {
+		riskScore.AddContribution(rule)
+	}
+
+	threshold, err := service.scoreThresholdService.GetThreshold(ctx, charge.CompanyID, familyID)
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(serviceMethodName, "applyRiskScore"))
+		response.Errors = append(response.Errors, err.Error())
+	}
+
+	riskScore.ApplyThreshold(threshold)
+	response.Decision = riskScore.Decision
+	response.TestDecision = riskScore.TestDecision
+	response.RiskScore = riskScore
+
+	if threshold.IsEmpty() {
+		componentTrace.AddReason("risk score %.2f without threshold configured", riskScore.Score)
+		return
+	}
+	componentTrace.AddReason("risk score %.2f mapped to %s by %s threshold", riskScore.Score, riskScore.Decision,
+		riskScore.ThresholdScope)
+}
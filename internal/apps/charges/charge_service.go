@@ -3,17 +3,17 @@ package charges
 import (
 	"context"
 	"fmt"
+	"time"
 
 	familycom "github.com/conekta/risk-rules/internal/apps/family_companies"
-	merchantsscore "github.com/conekta/risk-rules/internal/apps/merchants_score"
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
-	"github.com/conekta/risk-rules/internal/apps/chargebacks"
 	"github.com/conekta/risk-rules/internal/apps/families"
 	"github.com/conekta/risk-rules/internal/apps/lists"
-	"github.com/conekta/risk-rules/internal/apps/omniscores"
 	"github.com/conekta/risk-rules/internal/apps/rules"
+	scorethresholds "github.com/conekta/risk-rules/internal/apps/score_thresholds"
+	"github.com/conekta/risk-rules/internal/apps/velocity"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/pkg/metrics"
@@ -36,6 +36,7 @@ type ChargeService interface {
 	Get(ctx context.Context, id string) (entities.EvaluationResponse, error)
 	EvaluateChargeOnlyRules(ctx context.Context, charge entities.ChargeRequest) (entities.RulesEvaluationResponse, error)
 	GetOnlyRules(ctx context.Context, id string) (entities.RulesEvaluationResponse, error)
+	EvaluateChargesBatch(ctx context.Context, charges []entities.ChargeRequest) []entities.BatchEvaluationItem
 }
 
 type ChargeRepository interface {
@@ -43,53 +44,87 @@ type ChargeRepository interface {
 	SaveOnlyRules(ctx context.Context, evaluation entities.RulesEvaluationResponse) error
 	Get(ctx context.Context, id string) (entities.EvaluationResponse, error)
 	GetOnlyRules(ctx context.Context, id string) (entities.RulesEvaluationResponse, error)
+	FindEvaluation(ctx context.Context, id string, since time.Time) (entities.EvaluationResponse, error)
+	FindEvaluationOnlyRules(ctx context.Context, id string, since time.Time) (entities.RulesEvaluationResponse, error)
 }
 
 type chargeService struct {
-	config                  config.Config
-	rulesRepository         rules.RuleRepository
-	listsService            lists.ListsService
-	chargesRepository       ChargeRepository
-	familyService           families.FamilyService
-	familyCompaniesService  familycom.FamilyCompaniesService
-	rulesValidatorService   rules.RuleValidator
-	payerRepository         chargebacks.ChargebackRepository
-	omniscoreService        omniscores.OmniscoreService
-	merchantScoreRepository merchantsscore.MerchantsScoreRepository
-	logs                    logs.Logger
-	metrics                 datadog.Metricer
+	config                 config.Config
+	rulesRepository        rules.RuleRepository
+	listsService           lists.ListsService
+	chargesRepository      ChargeRepository
+	familyService          families.FamilyService
+	familyCompaniesService familycom.FamilyCompaniesService
+	rulesValidatorService  rules.RuleValidator
+	scoreThresholdService  scorethresholds.ScoreThresholdService
+	velocityService        velocity.VelocityService
+	enrichers              []entities.Enricher
+	idempotency            *idempotentEvaluations
+	logs                   logs.Logger
+	metrics                datadog.Metricer
 }
 
 func NewChargeService(cfg config.Config, ruleValidator rules.RuleValidator, ruleRepository rules.RuleRepository,
 	listsService lists.ListsService, chargeRepository ChargeRepository, familyService families.FamilyService,
-	familyCompaniesService familycom.FamilyCompaniesService, payerRepository chargebacks.ChargebackRepository,
-	omniscoreService omniscores.OmniscoreService, merchantScoreRepository merchantsscore.MerchantsScoreRepository,
-	logger logs.Logger, metric datadog.Metricer) ChargeService {
+	familyCompaniesService familycom.FamilyCompaniesService, scoreThresholdService scorethresholds.ScoreThresholdService,
+	velocityService velocity.VelocityService, enrichers []entities.Enricher, logger logs.Logger,
+	metric datadog.Metricer) ChargeService {
 	return &chargeService{
-		config:                  cfg,
-		rulesRepository:         ruleRepository,
-		listsService:            listsService,
-		rulesValidatorService:   ruleValidator,
-		chargesRepository:       chargeRepository,
-		familyService:           familyService,
-		familyCompaniesService:  familyCompaniesService,
-		payerRepository:         payerRepository,
-		omniscoreService:        omniscoreService,
-		merchantScoreRepository: merchantScoreRepository,
-		logs:                    logger,
-		metrics:                 metric,
+		config:                 cfg,
+		rulesRepository:        ruleRepository,
+		listsService:           listsService,
+		rulesValidatorService:  ruleValidator,
+		chargesRepository:      chargeRepository,
+		familyService:          familyService,
+		familyCompaniesService: familyCompaniesService,
+		scoreThresholdService:  scoreThresholdService,
+		velocityService:        velocityService,
+		enrichers:              enrichers,
+		idempotency:            newIdempotentEvaluations(),
+		logs:                   logger,
+		metrics:                metric,
 	}
 }
 
 func (service *chargeService) EvaluateCharge(ctx context.Context,
 	charge entities.ChargeRequest) (entities.EvaluationResponse, error) {
+	if !service.isIdempotent(charge) {
+		return service.evaluateCharge(ctx, charge, func(entities.EvaluationResponse) {})
+	}
+
+	value, replayed, err := service.evaluateOnce(ctx, fmt.Sprintf(idempotencyKey, "charge", charge.ID),
+		charge.ForceReevaluate,
+		func(since time.Time) (interface{}, error) {
+			return service.chargesRepository.FindEvaluation(ctx, charge.ID, since)
+		},
+		func(saved func(interface{})) (interface{}, error) {
+			return service.evaluateCharge(ctx, charge, func(evaluation entities.EvaluationResponse) { saved(evaluation) })
+		})
+	if err != nil {
+		return entities.EvaluationResponse{}, err
+	}
+
+	result := value.(entities.EvaluationResponse)
+	result.Replayed = replayed
+	return result, nil
+}
+
+// evaluateCharge evaluates the charge and saves the evaluation in background, saved is called once it is stored.
+func (service *chargeService) evaluateCharge(ctx context.Context, charge entities.ChargeRequest,
+	saved func(entities.EvaluationResponse)) (entities.EvaluationResponse, error) {
 	result := entities.NewUndecidedEvaluationResponse(charge, evaluationOrder)
 
-	charge.Payer.Chargebacks = service.FindChargebacks(ctx, charge.Details.Email)
-	charge.Omniscore = service.omniscoreService.GetScore(ctx, charge)
-	charge.MerchantScore = service.getScore(ctx, charge)
+	enrichments := service.enrich(ctx, service.getChargeEnrichments(charge, true))
+	enrichmentResults := service.applyEnrichments(ctx, &charge, enrichments)
+	foundLists, listsErr := enrichments.lists()
+	trace := entities.NewEvaluationTrace(charge.Explain)
+	var degradations []entities.Degradation
 
-	definitiveDecision, testDecision, definitiveRulesResult, listResult := service.getDecisionByConsole(ctx, charge)
+	definitiveDecision, testDecision, definitiveRulesResult, listResult, riskScore, err := service.getDecisionByConsole(ctx,
+		charge, foundLists, listsErr, trace, &degradations)
+	if err != nil {
+		return entities.EvaluationResponse{}, err
+	}
 
 	result.Decision = definitiveDecision.ValidateDecision().String()
 	result.Modules.WhiteList = listResult.GetResponses(entities.White, entities.Accepted)
@@ -101,16 +136,29 @@ func (service *chargeService) EvaluateCharge(ctx context.Context,
 	result.Charge.Omniscore = charge.Omniscore
 	result.Charge.MerchantScore = charge.MerchantScore
 	result.Charge.MarketSegment = charge.MarketSegment
+	result.Charge.Velocity = charge.Velocity
+	result.Charge.Enrichments = charge.Enrichments
+	result.TimedOutEnrichments = enrichments.timedOut
+	result.Enrichments = enrichmentResults
+	result.RiskScore = riskScore
+	result.Degradations = degradations
+	result.Trace = trace
+	evaluatedAt := time.Now().UTC()
 
 	go func() {
 		ctxBg := context.Background()
 		service.sendChargeMetrics(ctxBg, charge, definitiveDecision.ValidateDecision().String(),
 			testDecision.ValidateDecision().String())
 
-		err := service.chargesRepository.Save(ctxBg, result)
+		service.trackVelocity(ctxBg, charge)
+
+		evaluation := result
+		evaluation.EvaluatedAt = &evaluatedAt
+		err := service.chargesRepository.Save(ctxBg, evaluation)
 		if err != nil {
 			service.logs.Error(ctxBg, err.Error())
 		}
+		saved(evaluation)
 	}()
 
 	return result, nil
@@ -118,47 +166,124 @@ func (service *chargeService) EvaluateCharge(ctx context.Context,
 
 func (service *chargeService) EvaluateChargeOnlyRules(ctx context.Context,
 	charge entities.ChargeRequest) (entities.RulesEvaluationResponse, error) {
-	result := entities.NewUndecidedEvaluationResponseOnlyRules(charge)
+	if !service.isIdempotent(charge) {
+		return service.evaluateChargeOnlyRules(ctx, charge, func(entities.RulesEvaluationResponse) {})
+	}
 
-	charge.Payer.Chargebacks = service.FindChargebacks(ctx, charge.Details.Email)
-	result.Omniscore = service.omniscoreService.GetScore(ctx, charge)
-	result.MerchantScore = service.getScore(ctx, charge)
+	value, replayed, err := service.evaluateOnce(ctx, fmt.Sprintf(idempotencyKey, "rules", charge.ID),
+		charge.ForceReevaluate,
+		func(since time.Time) (interface{}, error) {
+			return service.chargesRepository.FindEvaluationOnlyRules(ctx, charge.ID, since)
+		},
+		func(saved func(interface{})) (interface{}, error) {
+			return service.evaluateChargeOnlyRules(ctx, charge,
+				func(evaluation entities.RulesEvaluationResponse) { saved(evaluation) })
+		})
+	if err != nil {
+		return entities.RulesEvaluationResponse{}, err
+	}
+
+	result := value.(entities.RulesEvaluationResponse)
+	result.Replayed = replayed
+	return result, nil
+}
+
+// evaluateChargeOnlyRules evaluates the rules of the charge and saves the evaluation in background, saved is called
+// once it is stored.
+func (service *chargeService) evaluateChargeOnlyRules(ctx context.Context, charge entities.ChargeRequest,
+	saved func(entities.RulesEvaluationResponse)) (entities.RulesEvaluationResponse, error) {
+	result := entities.NewUndecidedEvaluationResponseOnlyRules(charge)
 
-	definitiveDecision, testDecision, rulesModulesResponse := service.getDecisionByConsoleOnlyRules(ctx, charge)
+	enrichments := service.enrich(ctx, service.getChargeEnrichments(charge, false))
+	result.Enrichments = service.applyEnrichments(ctx, &charge, enrichments)
+	result.Omniscore = charge.Omniscore
+	result.MerchantScore = charge.MerchantScore
+	result.Charge.Velocity = charge.Velocity
+	result.Charge.Enrichments = charge.Enrichments
+	result.TimedOutEnrichments = enrichments.timedOut
+	trace := entities.NewEvaluationTrace(charge.Explain)
+	var degradations []entities.Degradation
+
+	definitiveDecision, testDecision, rulesModulesResponse, riskScore, err := service.getDecisionByConsoleOnlyRules(ctx,
+		charge, trace, &degradations)
+	if err != nil {
+		return entities.RulesEvaluationResponse{}, err
+	}
 
 	result.Decision = definitiveDecision.ValidateDecision().String()
 	result.RulesModules = rulesModulesResponse
+	result.RiskScore = riskScore
+	result.Degradations = degradations
+	result.Trace = trace
+	evaluatedAt := time.Now().UTC()
 	go func() {
 		ctxBg := context.Background()
 		service.sendChargeMetrics(ctxBg, charge, definitiveDecision.ValidateDecision().String(),
 			testDecision.ValidateDecision().String())
 
-		err := service.chargesRepository.SaveOnlyRules(ctxBg, result)
+		service.trackVelocity(ctxBg, charge)
+
+		evaluation := result
+		evaluation.EvaluatedAt = &evaluatedAt
+		err := service.chargesRepository.SaveOnlyRules(ctxBg, evaluation)
 		if err != nil {
 			service.logs.Error(ctxBg, err.Error())
 		}
+		saved(evaluation)
 	}()
 
 	return result, nil
 }
 
 func (service *chargeService) getDecisionByConsole(ctx context.Context, charge entities.ChargeRequest,
-) (definitiveDecision entities.Decision, testDecision entities.Decision,
-	definitiveRulesResult entities.RulesResponse, listResult entities.ListResponse) {
+	foundLists []entities.List, listsErr error, trace *entities.EvaluationTrace,
+	degradations *[]entities.Degradation) (definitiveDecision entities.Decision, testDecision entities.Decision,
+	definitiveRulesResult entities.RulesResponse, listResult entities.ListResponse, riskScore *entities.RiskScore,
+	err error) {
 	var decisionTaken, listDecisionTaken bool
 	var rulesResult entities.RulesResponse
 	var decision entities.Decision
-
-	foundLists, err := service.listsService.GetLists(ctx, charge.NewListsSearch())
-	if err != nil {
-		listResult.Errors = append(listResult.Errors, err.Error())
-	}
+	var degradation entities.Degradation
+	var listsDegradation entities.Degradation
+	var listsDegraded bool
 
 	for _, component := range charge.Console {
+		componentTrace := trace.AddComponent(component)
+		var componentErr error
 		if component.Name.IsList() {
 			listResult, listDecisionTaken = service.getDecisionByList(ctx, charge, component, foundLists)
+			componentTrace.SetListResult(listResult)
+			if listsErr != nil {
+				listResult.Errors = append(listResult.Errors, listsErr.Error())
+			}
+			// the lists of every component are searched at once, so their failure is degraded on the first list
+			// component only and the others keep its outcome, which can only be to continue
+			if listsErr != nil && !listsDegraded {
+				componentErr = listsErr
+			} else if listsDegraded {
+				componentTrace.AddReason("%s", listsDegradation.Reason())
+			}
 		} else {
-			rulesResult = service.getDecisionByRule(ctx, charge, component)
+			rulesResult, componentErr = service.getDecisionByRule(ctx, charge, component, componentTrace)
+			if rulesResult.RiskScore != nil {
+				riskScore = rulesResult.RiskScore
+			}
+		}
+
+		if componentErr != nil {
+			degradation, err = service.degrade(ctx, charge, component, componentErr, componentTrace)
+			*degradations = append(*degradations, degradation)
+			if component.Name.IsList() {
+				listsDegradation, listsDegraded = degradation, true
+			}
+			if err != nil {
+				return definitiveDecision, testDecision, definitiveRulesResult, listResult, riskScore, err
+			}
+			if forcedDecision, ok := degradation.Policy.ForcedDecision(); ok {
+				componentTrace.SetDecision(forcedDecision, forcedDecision, true)
+				trace.SetShortCircuit(component, forcedDecision, degradation.Reason())
+				return forcedDecision, forcedDecision, rulesResult, listResult, riskScore, nil
+			}
 		}
 
 		if listResult.Type == entities.Gray && !listResult.IsListResponseEmpty() {
@@ -168,11 +293,13 @@ func (service *chargeService) getDecisionByConsole(ctx context.Context, charge e
 		evaluations := entities.EvaluationResults{&listResult, &rulesResult}
 		decision, decisionTaken = calculateDecisionByEvaluation(evaluations, component, false)
 		testDecision, _ = calculateDecisionByEvaluation(evaluations, component, true)
+		componentTrace.SetDecision(decision, testDecision, decisionTaken)
 
 		if (listDecisionTaken || decisionTaken) && component.Name != entities.GraylistType {
+			trace.SetShortCircuit(component, decision, getShortCircuitReason(component, decision, listDecisionTaken))
 			definitiveDecision = decision
 			definitiveRulesResult = rulesResult
-			return definitiveDecision, testDecision, definitiveRulesResult, listResult
+			return definitiveDecision, testDecision, definitiveRulesResult, listResult, riskScore, nil
 		}
 
 		if decision.ValidateDecision() != entities.Undecided {
@@ -180,27 +307,49 @@ func (service *chargeService) getDecisionByConsole(ctx context.Context, charge e
 			definitiveRulesResult = rulesResult
 		}
 	}
-	return definitiveDecision, testDecision, definitiveRulesResult, listResult
+	return definitiveDecision, testDecision, definitiveRulesResult, listResult, riskScore, nil
 }
 
 func (service *chargeService) getDecisionByConsoleOnlyRules(ctx context.Context, charge entities.ChargeRequest,
-) (definitiveDecision entities.Decision, testDecision entities.Decision,
-	rulesModulesResponse entities.RulesModulesResponse) {
+	trace *entities.EvaluationTrace, degradations *[]entities.Degradation) (definitiveDecision entities.Decision,
+	testDecision entities.Decision, rulesModulesResponse entities.RulesModulesResponse, riskScore *entities.RiskScore,
+	err error) {
 	var decisionTaken bool
 	var rulesResult entities.RulesResponse
 	var decision entities.Decision
+	var degradation entities.Degradation
+	var componentErr error
 
 	for _, component := range charge.Console {
-		rulesResult = service.getDecisionByRule(ctx, charge, component)
+		componentTrace := trace.AddComponent(component)
+		rulesResult, componentErr = service.getDecisionByRule(ctx, charge, component, componentTrace)
 		rulesModulesResponse.SetRuleResponse(component, rulesResult)
+		if rulesResult.RiskScore != nil {
+			riskScore = rulesResult.RiskScore
+		}
+
+		if componentErr != nil {
+			degradation, err = service.degrade(ctx, charge, component, componentErr, componentTrace)
+			*degradations = append(*degradations, degradation)
+			if err != nil {
+				return definitiveDecision, testDecision, rulesModulesResponse, riskScore, err
+			}
+			if forcedDecision, ok := degradation.Policy.ForcedDecision(); ok {
+				componentTrace.SetDecision(forcedDecision, forcedDecision, true)
+				trace.SetShortCircuit(component, forcedDecision, degradation.Reason())
+				return forcedDecision, forcedDecision, rulesModulesResponse, riskScore, nil
+			}
+		}
 
 		evaluations := entities.EvaluationResults{&rulesResult}
 		decision, decisionTaken = calculateDecisionByEvaluation(evaluations, component, false)
 		testDecision, _ = calculateDecisionByEvaluation(evaluations, component, true)
+		componentTrace.SetDecision(decision, testDecision, decisionTaken)
 
 		if decisionTaken {
+			trace.SetShortCircuit(component, decision, getShortCircuitReason(component, decision, false))
 			definitiveDecision = decision
-			return definitiveDecision, testDecision, rulesModulesResponse
+			return definitiveDecision, testDecision, rulesModulesResponse, riskScore, nil
 		}
 
 		if decision.ValidateDecision() != entities.Undecided {
@@ -208,7 +357,7 @@ func (service *chargeService) getDecisionByConsoleOnlyRules(ctx context.Context,
 		}
 	}
 
-	return definitiveDecision, testDecision, rulesModulesResponse
+	return definitiveDecision, testDecision, rulesModulesResponse, riskScore, nil
 }
 
 func (service *chargeService) getDecisionByList(ctx context.Context, charge entities.ChargeRequest,
@@ -223,8 +372,16 @@ func (service *chargeService) getDecisionByList(ctx context.Context, charge enti
 }
 
 func (service *chargeService) getDecisionByRule(ctx context.Context, charge entities.ChargeRequest,
-	component entities.Component) entities.RulesResponse {
-	return service.EvaluateRules(ctx, charge, component)
+	component entities.Component, componentTrace *entities.ComponentTrace) (entities.RulesResponse, error) {
+	return service.evaluateRules(ctx, charge, component, componentTrace)
+}
+
+func getShortCircuitReason(component entities.Component, decision entities.Decision, listDecisionTaken bool) string {
+	if listDecisionTaken {
+		return fmt.Sprintf("list %s matched the charge with decision %s", component.Name, decision)
+	}
+
+	return fmt.Sprintf("shouldTakeDecision: decision %s is the first priority of %s", decision, component.Name)
 }
 
 func (service *chargeService) sendChargeMetrics(ctx context.Context, charge entities.ChargeRequest,
@@ -246,6 +403,14 @@ func (service *chargeService) sendChargeMetrics(ctx context.Context, charge enti
 
 func (service *chargeService) EvaluateRules(ctx context.Context, charge entities.ChargeRequest,
 	component entities.Component) entities.RulesResponse {
+	response, _ := service.evaluateRules(ctx, charge, component, nil)
+	return response
+}
+
+// evaluateRules evaluates the rules of the component, the error is the one of the rules search so the console
+// can apply the degradation policy of the component.
+func (service *chargeService) evaluateRules(ctx context.Context, charge entities.ChargeRequest,
+	component entities.Component, componentTrace *entities.ComponentTrace) (entities.RulesResponse, error) {
 	response := entities.NewRulesResponse()
 	var familyID string
 	var familyCompaniesIDs []string
@@ -254,7 +419,7 @@ func (service *chargeService) EvaluateRules(ctx context.Context, charge entities
 	mapCharge, err := charge.ToMap()
 	if err != nil {
 		response.Errors = append(response.Errors, err.Error())
-		return response
+		return response, nil
 	}
 
 	decisionRules := make([]entities.Rule, 0)
@@ -264,14 +429,25 @@ func (service *chargeService) EvaluateRules(ctx context.Context, charge entities
 		familyID = service.getFamilyIDFromCharge(ctx, charge)
 	} else if component.Name == entities.FamilyMccRulesType {
 		familyCompaniesIDs = service.getFamilyCompaniesIDsFromCharge(ctx, charge)
+	} else if component.Name == entities.ScoreRulesType {
+		familyID = service.getFamilyIDFromCharge(ctx, charge)
+		familyCompaniesIDs = service.getFamilyCompaniesIDsFromCharge(ctx, charge)
 	}
 
-	rulesFound, _ := service.rulesRepository.GetRulesByFilters(ctx,
+	rulesFound, rulesErr := service.rulesRepository.GetRulesByFilters(ctx,
 		entities.RuleFilter{CompanyID: charge.CompanyID, FamilyID: familyID, FamilyCompaniesIDs: familyCompaniesIDs}, component.Name)
+	if rulesErr != nil {
+		response.Errors = append(response.Errors, rulesErr.Error())
+	}
+
+	rulesFound = activeRules(rulesFound, time.Now().UTC())
 
 	totalApplied = 0
 	for _, rule := range rulesFound {
 		isApplied, err := service.rulesValidatorService.Evaluate(ctx, rule, mapCharge)
+		if componentTrace != nil {
+			componentTrace.AddRule(service.getRuleTrace(ctx, rule, mapCharge, isApplied, err))
+		}
 		if err != nil {
 			response.Errors = append(response.Errors, err.Error())
 			continue
@@ -299,16 +475,56 @@ func (service *chargeService) EvaluateRules(ctx context.Context, charge entities
 		}
 	}
 
+	response.DecisionRules = decisionRules
+	response.TestRules = testRules
+
+	if component.Name == entities.ScoreRulesType {
+		service.applyRiskScore(ctx, charge, familyID, &response, componentTrace)
+		return response, rulesErr
+	}
+
 	if component.HaveSecondaryDecision() {
 		if shouldAssignSecondaryDecision(totalApplied, rulesFound, component, response) {
 			response.Decision = component.Priority[1]
+			componentTrace.SetSecondaryDecision(response.Decision)
 		}
 	}
 
-	response.DecisionRules = decisionRules
-	response.TestRules = testRules
+	return response, rulesErr
+}
 
-	return response
+func (service *chargeService) getRuleTrace(ctx context.Context, rule entities.Rule, mapCharge map[string]interface{},
+	isApplied bool, err error) entities.RuleTrace {
+	ruleTrace := entities.RuleTrace{
+		RuleID:   rule.ID.Hex(),
+		Revision: rule.Revision,
+		Rule:     rule.Rule,
+		IsTest:   rule.IsTest,
+		Decision: rule.Decision,
+		Result:   isApplied,
+		Clauses:  service.rulesValidatorService.ExplainClauses(ctx, rule, mapCharge),
+	}
+	if err != nil {
+		ruleTrace.Error = err.Error()
+	}
+
+	return ruleTrace
+}
+
+// activeRules keeps the rules in effect at now, drafts run as test rules until they are approved.
+func activeRules(rulesFound []entities.Rule, now time.Time) []entities.Rule {
+	active := make([]entities.Rule, 0, len(rulesFound))
+	for _, rule := range rulesFound {
+		if !rule.IsEvaluable() || !rule.IsActiveAt(now) {
+			continue
+		}
+		if rule.GetStatus() == entities.RuleStatusDraft {
+			rule.IsTest = true
+		}
+		active = append(active, rule)
+	}
+
+	return active
 }
 
 func shouldAssignSecondaryDecision(totalApplied int64, companyRules []entities.Rule,
@@ -372,6 +588,10 @@ func (service *chargeService) EvaluateList(ctx context.Context, charge entities.
 			continue
 		}
 
+		if list.IsIPList() && !list.MatchesIP(charge.Details.IPAddress) {
+			continue
+		}
+
 		if component == entities.GraylistType && list.IsGraylist() {
 			if list.IsTest {
 				listResponse.TestDecision = entities.Undecided
@@ -404,24 +624,14 @@ func (service *chargeService) EvaluateList(ctx context.Context, charge entities.
 	return false
 }
 
-func (service *chargeService) FindChargebacks(ctx context.Context, email string) int64 {
-	foundPayer, err := service.payerRepository.Find(ctx, entities.Payer{Email: email})
-	if err != nil {
-		service.logs.Error(ctx, err.Error(), text.LogTagMethod, "HasChargebacks", text.Email, email)
-		return 0
+func (service *chargeService) trackVelocity(ctx context.Context, charge entities.ChargeRequest) {
+	if !service.config.Velocity.IsEnabled {
+		return
 	}
 
-	return int64(len(foundPayer.Chargebacks))
-}
-
-func (service *chargeService) FindMerchantScore(ctx context.Context, companyID string) float64 {
-	foundMerchantScore, err := service.merchantScoreRepository.FindByMerchantID(ctx, companyID)
-	if err != nil {
-		service.logs.Error(ctx, err.Error(), text.LogTagMethod, "HasMerchantScore", text.CompanyID, companyID)
-		return 0
+	if err := service.velocityService.Track(ctx, charge); err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(serviceMethodName, "trackVelocity"))
 	}
-
-	return foundMerchantScore.Score
 }
 
 func calculateDecisionByEvaluation(results entities.EvaluationResults,
@@ -455,14 +665,6 @@ func shouldTakeDecision(priorities entities.Component,
 		!isTest && priorities.HaveSecondaryDecision()
 }
 
-func (service *chargeService) getScore(ctx context.Context, charge entities.ChargeRequest) float64 {
-	if service.config.MerchantScore.IsEnabled {
-		return service.FindMerchantScore(ctx, charge.CompanyID)
-	}
-
-	return -1
-}
-
 func (service *chargeService) Get(ctx context.Context, id string) (entities.EvaluationResponse, error) {
 	return service.chargesRepository.Get(ctx, id)
 }
@@ -3,7 +3,9 @@ package charges
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/apps/chargebacks"
@@ -13,8 +15,10 @@ import (
 	merchantsscore "github.com/conekta/risk-rules/internal/apps/merchants_score"
 	"github.com/conekta/risk-rules/internal/apps/omniscores"
 	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/apps/velocity"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/conekta/risk-rules/pkg/rest"
 	"github.com/conekta/risk-rules/test/mocks"
 	"github.com/conekta/risk-rules/test/mocks/datadog"
@@ -79,6 +83,9 @@ func TestChargeService_EvaluateChargeOnlyRulesMerchantScoreEnabled(t *testing.T)
 	chargeRepositoryErrMock.On("SaveOnlyRules", mock.Anything, mock.AnythingOfType("entities.RulesEvaluationResponse")).
 		Return(errors.New("connection to database lost"))
 
+	want := testdata.GetRulesEvaluationResponseUndecidedCauseConsoleIsEmptyOnlyRules()
+	want.Enrichments[2].Status = entities.EnrichmentRan
+
 	testsCases := []testItemOnlyRules{
 		{
 			"the charge evaluation is undecided cause console is empty and merchant score is enabled",
@@ -86,7 +93,7 @@ func TestChargeService_EvaluateChargeOnlyRulesMerchantScoreEnabled(t *testing.T)
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFirstCase,
 				listsService:            listServiceMockFirstCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyServiceMockFirstCase,
 				familyCompaniesService:  familyCompaniesServiceMockFirstCase,
@@ -95,17 +102,18 @@ func TestChargeService_EvaluateChargeOnlyRulesMerchantScoreEnabled(t *testing.T)
 				merchantScoreRepository: merchantScoreRepositoryMockFirstCase,
 			},
 			args{charge: testdata.GetChargeConsoleIsEmptyOnlyRules()},
-			testdata.GetRulesEvaluationResponseUndecidedCauseConsoleIsEmptyOnlyRules(),
+			want,
 			false,
 		},
 	}
 
 	for _, ttCase := range testsCases {
 		t.Run(ttCase.name, func(t *testing.T) {
+			enrichers := newEnrichers(ttCase.fields.config, ttCase.fields.chargebackRepository, ttCase.fields.omniscoreService,
+				ttCase.fields.merchantScoreRepository)
 			r := NewChargeService(ttCase.fields.config, ttCase.fields.rulesValidatorService, ttCase.fields.rulesRepository,
 				ttCase.fields.listsService, ttCase.fields.chargeRepository, ttCase.fields.familyService,
-				ttCase.fields.familyCompaniesService, ttCase.fields.chargebackRepository, ttCase.fields.omniscoreService,
-				ttCase.fields.merchantScoreRepository, log, new(datadog.MetricsDogMock),
+				ttCase.fields.familyCompaniesService, nil, nil, enrichers, log, new(datadog.MetricsDogMock),
 			)
 			got, err := r.EvaluateChargeOnlyRules(context.Background(), ttCase.args.charge)
 			if (err != nil) != ttCase.wantErr {
@@ -155,7 +163,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFirstCase,
 				listsService:            listServiceMockFirstCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyServiceMockFirstCase,
 				familyCompaniesService:  familyCompaniesServiceMockFirstCase,
@@ -173,7 +181,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockSecondCase,
 				listsService:            listServiceMockSecondCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockSecondCase,
 				familyCompaniesService:  familyCompaniesServiceMockSecondCase,
@@ -191,7 +199,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockThirdCase,
 				listsService:            listServiceMockThirdCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockThirdCase,
 				familyCompaniesService:  familyCompaniesServiceMockThirdCase,
@@ -209,7 +217,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFourthCase,
 				listsService:            listServiceMockFourthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockFourthCase,
 				familyCompaniesService:  familyCompaniesServiceMockFourthCase,
@@ -227,7 +235,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFifthCase,
 				listsService:            listServiceMockFifthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockFifthCase,
 				familyCompaniesService:  familyCompaniesServiceMockFifthCase,
@@ -245,7 +253,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockSixthCase,
 				listsService:            listServiceMockSixthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockSixthCase,
 				familyCompaniesService:  familyCompaniesServiceMockSixthCase,
@@ -263,7 +271,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockSevenCase,
 				listsService:            listServiceMockSevenCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockSevenCase,
 				familyCompaniesService:  familyCompaniesServiceMockSevenCase,
@@ -281,7 +289,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockEighthCase,
 				listsService:            listServiceMockEighthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockEighthCase,
 				familyCompaniesService:  familyCompaniesServiceMockEighthCase,
@@ -299,7 +307,7 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockNinthCase,
 				listsService:            listServiceMockNinthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockNinthCase,
 				familyCompaniesService:  familyCompaniesServiceMockNinthCase,
@@ -315,10 +323,11 @@ func TestChargeService_EvaluateChargeOnlyRules(t *testing.T) {
 
 	for _, ttCase := range testsCases {
 		t.Run(ttCase.name, func(t *testing.T) {
+			enrichers := newEnrichers(ttCase.fields.config, ttCase.fields.chargebackRepository, ttCase.fields.omniscoreService,
+				ttCase.fields.merchantScoreRepository)
 			r := NewChargeService(ttCase.fields.config, ttCase.fields.rulesValidatorService, ttCase.fields.rulesRepository,
 				ttCase.fields.listsService, ttCase.fields.chargeRepository, ttCase.fields.familyService,
-				ttCase.fields.familyCompaniesService, ttCase.fields.chargebackRepository, ttCase.fields.omniscoreService,
-				ttCase.fields.merchantScoreRepository, log, new(datadog.MetricsDogMock),
+				ttCase.fields.familyCompaniesService, nil, nil, enrichers, log, new(datadog.MetricsDogMock),
 			)
 			got, err := r.EvaluateChargeOnlyRules(context.Background(), ttCase.args.charge)
 			if (err != nil) != ttCase.wantErr {
@@ -373,7 +382,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFirstCase,
 				listsService:            listServiceMockFirstCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyServiceMockFirstCase,
 				familyCompaniesService:  familyCompaniesServiceMockFirstCase,
@@ -391,7 +400,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockSecondCase,
 				listsService:            listServiceMockSecondCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockSecondCase,
 				familyCompaniesService:  familyCompaniesServiceMockSecondCase,
@@ -409,7 +418,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockThirdCase,
 				listsService:            listServiceMockThirdCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockThirdCase,
 				familyCompaniesService:  familyCompaniesServiceMockThirdCase,
@@ -427,7 +436,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFourthCase,
 				listsService:            listServiceMockFourthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockFourthCase,
 				familyCompaniesService:  familyCompaniesServiceMockFourthCase,
@@ -445,7 +454,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFifthCase,
 				listsService:            listServiceMockFifthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockFifthCase,
 				familyCompaniesService:  familyCompaniesServiceMockFifthCase,
@@ -463,7 +472,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockSixthCase,
 				listsService:            listServiceMockSixthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockSixthCase,
 				familyCompaniesService:  familyCompaniesServiceMockSixthCase,
@@ -481,7 +490,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockNinthCase,
 				listsService:            listServiceMockNinthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockNinthCase,
 				familyCompaniesService:  familyCompaniesServiceMockNinthCase,
@@ -499,7 +508,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockEleventhCase,
 				listsService:            listServiceMockEleventhCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockEleventhCase,
 				familyCompaniesService:  familyCompaniesServiceMockEleventhCase,
@@ -517,7 +526,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockThirteenthCase,
 				listsService:            listServiceMockThirteenthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockThirteenthCase,
 				familyCompaniesService:  familyCompaniesServiceMockThirteenthCase,
@@ -535,7 +544,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFourteenthCase,
 				listsService:            listServiceMockFourteenthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockFourteenthCase,
 				familyCompaniesService:  familyCompaniesServiceMockFourteenthCase,
@@ -553,7 +562,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockFifteenthCase,
 				listsService:            listServiceMockFifteenthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockFifteenthCase,
 				familyCompaniesService:  familyCompaniesServiceMockFifteenthCase,
@@ -571,7 +580,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockSixteenthCase,
 				listsService:            listServiceMockSixteenthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockSixteenthCase,
 				familyCompaniesService:  familyCompaniesServiceMockSixteenthCase,
@@ -589,7 +598,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockSeventeenthCase,
 				listsService:            listServiceMockSeventeenthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockSeventeenthCase,
 				familyCompaniesService:  familyCompaniesServiceMockSeventeenthCase,
@@ -607,7 +616,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockEighteenthCase,
 				listsService:            listServiceMockEighteenthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockEighteenthCase,
 				familyCompaniesService:  familyCompaniesServiceMockEighteenthCase,
@@ -625,7 +634,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockNineteenthCase,
 				listsService:            listServiceMockNineteenthCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockNineteenthCase,
 				familyCompaniesService:  familyCompaniesServiceMockNineteenthCase,
@@ -643,7 +652,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockTwentiethCase,
 				listsService:            listServiceMockTwentiethCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockTwentiethCase,
 				familyCompaniesService:  familyCompaniesServiceMockTwentiethCase,
@@ -661,7 +670,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockTwentyfirstCase,
 				listsService:            listServiceMockTwentyfirstCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockTwentyfirstCase,
 				familyCompaniesService:  familyCompaniesServiceMockTwentyfirstCase,
@@ -679,7 +688,7 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 				config:                  cfg,
 				rulesRepository:         rulesRepositoryMockTwentySecondCase,
 				listsService:            listServiceMockTwentySecondCase,
-				rulesValidatorService:   rules.NewRulesValidator(log),
+				rulesValidatorService:   rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(config.Config{}, log, new(datadog.MetricsDogMock))),
 				chargeRepository:        chargeRepositoryOkMock,
 				familyService:           familyRepositoryMockTwentySecondCase,
 				familyCompaniesService:  familyCompaniesServiceMockTwentySecondCase,
@@ -695,10 +704,11 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			enrichers := newEnrichers(tt.fields.config, tt.fields.chargebackRepository, tt.fields.omniscoreService,
+				tt.fields.merchantScoreRepository)
 			r := NewChargeService(tt.fields.config, tt.fields.rulesValidatorService, tt.fields.rulesRepository,
 				tt.fields.listsService, tt.fields.chargeRepository, tt.fields.familyService,
-				tt.fields.familyCompaniesService, tt.fields.chargebackRepository, tt.fields.omniscoreService,
-				tt.fields.merchantScoreRepository, log, new(datadog.MetricsDogMock),
+				tt.fields.familyCompaniesService, nil, nil, enrichers, log, new(datadog.MetricsDogMock),
 			)
 			got, err := r.EvaluateCharge(context.Background(), tt.args.charge)
 			if (err != nil) != tt.wantErr {
@@ -713,6 +723,702 @@ func TestChargeService_EvaluateCharge(t *testing.T) {
 	}
 }
 
+func TestChargeService_EvaluateChargeEnrichmentTimeout(t *testing.T) {
+	log, _ := logs.New()
+	cfg := config.Config{}
+	cfg.Enrichment.OmniscoreTimeoutMilliseconds = 20
+	cfg.Enrichment.BudgetMilliseconds = 50
+
+	charge := testdata.GetDefaultCharge()
+	charge.Console = []entities.Component{}
+
+	chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+	chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+		Return(entities.Payer{Chargebacks: []entities.Chargebacks{{}}}, nil)
+
+	omniscoreMock := new(mocks.OmniscoreServiceMock)
+	omniscoreMock.On("GetScore", mock.Anything, mock.AnythingOfType("ChargeRequest")).
+		After(100 * time.Millisecond).
+		Return(0.9)
+
+	chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+	chargeRepositoryMock.On("SaveOnlyRules", mock.Anything, mock.AnythingOfType("entities.RulesEvaluationResponse")).
+		Return(nil)
+
+	service := NewChargeService(cfg, nil, nil, nil, chargeRepositoryMock, nil, nil, nil, nil,
+		newEnrichers(cfg, chargebackRepositoryMock, omniscoreMock, nil), log, new(datadog.MetricsDogMock))
+
+	start := time.Now()
+	got, err := service.EvaluateChargeOnlyRules(context.Background(), charge)
+
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, []string{"omniscore"}, got.TimedOutEnrichments)
+	assert.Equal(t, rest.DefaultScore, got.Omniscore)
+	assert.Equal(t, float64(-1), got.MerchantScore)
+}
+
+func TestChargeService_EvaluateChargeOnlyRulesExplain(t *testing.T) {
+	log, _ := logs.New()
+	cfg := config.Config{}
+
+	charge := testdata.GetDefaultCharge()
+	charge.Console = testdata.SetDefaultConsoleCompany()
+	charge.Explain = true
+
+	formulaFields := []string{"aggregation.payer.charge.h1.count", "aggregation.payer.charge.h2.count"}
+	mathOperation := entities.SUM
+	rule := testdata.GetDefaultRuleWithID(false)
+	rule.Rules = []entities.RuleContent{
+		{
+			Field:          "SUM (aggregation.payer.charge.h1.count,aggregation.payer.charge.h2.count)",
+			Operator:       ">",
+			Value:          "500",
+			Condition:      "and",
+			FormulaContent: entities.FormulaContent{Fields: &formulaFields, MathOperation: &mathOperation},
+		},
+		{Field: "amount", Operator: ">", Value: "100", Condition: "and"},
+	}
+	rule.Rule = "SUM (aggregation.payer.charge.h1.count,aggregation.payer.charge.h2.count) > 500 and amount > 100"
+
+	rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+	rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+		entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+		Return([]entities.Rule{rule}, nil)
+
+	chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+	chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+		Return(entities.Payer{}, nil)
+
+	_, omniscoreIsOff := getOmniscoreTestCases()
+
+	chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+	chargeRepositoryMock.On("SaveOnlyRules", mock.Anything, mock.AnythingOfType("entities.RulesEvaluationResponse")).
+		Return(nil)
+
+	validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+	service := NewChargeService(cfg, validator, rulesRepositoryMock, nil, chargeRepositoryMock, nil, nil, nil, nil,
+		newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil), log, new(datadog.MetricsDogMock))
+
+	got, err := service.EvaluateChargeOnlyRules(context.Background(), charge)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Declined.String(), got.Decision)
+	assert.NotNil(t, got.Trace)
+	assert.Len(t, got.Trace.Components, 1)
+
+	componentTrace := got.Trace.Components[0]
+	assert.Equal(t, entities.CompanyRulesType, componentTrace.Component)
+	assert.True(t, componentTrace.DecisionTaken)
+	assert.Len(t, componentTrace.Rules, 1)
+	assert.True(t, componentTrace.Rules[0].Result)
+	assert.Len(t, componentTrace.Rules[0].Clauses, 2)
+	assert.True(t, componentTrace.Rules[0].Clauses[0].Result)
+	assert.Equal(t, float64(600), *componentTrace.Rules[0].Clauses[0].FormulaValue)
+	assert.True(t, componentTrace.Rules[0].Clauses[1].Result)
+	assert.Nil(t, componentTrace.Rules[0].Clauses[1].FormulaValue)
+
+	assert.Equal(t, entities.CompanyRulesType, got.Trace.ShortCircuit.Component)
+	assert.Equal(t, entities.Declined, got.Trace.ShortCircuit.Decision)
+	assert.Contains(t, got.Trace.ShortCircuit.Reason, "shouldTakeDecision")
+}
+
+func TestChargeService_EvaluateChargeOnlyRulesVelocity(t *testing.T) {
+	log, _ := logs.New()
+	cfg := config.Config{}
+	cfg.Velocity.IsEnabled = true
+
+	charge := testdata.GetDefaultCharge()
+	charge.Console = testdata.SetDefaultConsoleCompany()
+
+	rule := testdata.GetDefaultRuleWithID(false)
+	rule.Rule = "velocity.card_hash.d1.count > 2 and velocity.email.h1.count == 200"
+
+	rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+	rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+		entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+		Return([]entities.Rule{rule}, nil)
+
+	chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+	chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+		Return(entities.Payer{}, nil)
+
+	_, omniscoreIsOff := getOmniscoreTestCases()
+
+	chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+	chargeRepositoryMock.On("SaveOnlyRules", mock.Anything, mock.AnythingOfType("entities.RulesEvaluationResponse")).
+		Return(nil)
+
+	counters := entities.Velocity{}
+	counters.CardHash.D1 = entities.AggregationEventProperties{Sum: 9000, Count: 3}
+	counters.Email.H1 = entities.AggregationEventProperties{Sum: 9000, Count: 3}
+	tracked := make(chan entities.ChargeRequest, 1)
+	velocityServiceMock := new(mocks.VelocityServiceMock)
+	velocityServiceMock.On("GetVelocity", mock.Anything, charge).Return(counters, nil)
+	velocityServiceMock.On("Track", mock.Anything, mock.AnythingOfType("entities.ChargeRequest")).
+		Run(func(args mock.Arguments) { tracked <- args.Get(1).(entities.ChargeRequest) }).
+		Return(nil)
+
+	enrichers := append(newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil),
+		velocity.NewVelocityEnricher(cfg, velocityServiceMock))
+	validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+	service := NewChargeService(cfg, validator, rulesRepositoryMock, nil, chargeRepositoryMock, nil, nil, nil,
+		velocityServiceMock, enrichers, log, new(datadog.MetricsDogMock))
+
+	got, err := service.EvaluateChargeOnlyRules(context.Background(), charge)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Declined.String(), got.Decision)
+	assert.Equal(t, counters.CardHash.D1, got.Charge.Velocity.CardHash.D1)
+	assert.Equal(t, charge.Aggregation.PayerAggregation.Charge.H1, got.Charge.Velocity.Email.H1)
+
+	select {
+	case trackedCharge := <-tracked:
+		assert.Equal(t, charge.ID, trackedCharge.ID)
+	case <-time.After(time.Second):
+		t.Error("the charge was not counted in the velocity")
+	}
+}
+
+func TestChargeService_EvaluateChargeVelocityOnBothEndpoints(t *testing.T) {
+	log, _ := logs.New()
+	cfg := config.Config{}
+	cfg.Velocity.IsEnabled = true
+
+	charge := testdata.GetDefaultCharge()
+	charge.Console = testdata.SetDefaultConsoleCompany()
+
+	rule := testdata.GetDefaultRuleWithID(false)
+	rule.Rule = "amount > 5000"
+
+	rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+	rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+		entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+		Return([]entities.Rule{rule}, nil)
+
+	listServiceMock := new(mocks.ListsServiceMock)
+	listServiceMock.On("GetLists", mock.Anything, mock.Anything).Return([]entities.List{}, nil)
+
+	chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+	chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+		Return(entities.Payer{}, nil)
+
+	_, omniscoreIsOff := getOmniscoreTestCases()
+
+	chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+	chargeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("entities.EvaluationResponse")).Return(nil)
+	chargeRepositoryMock.On("SaveOnlyRules", mock.Anything, mock.AnythingOfType("entities.RulesEvaluationResponse")).
+		Return(nil)
+
+	tracked := make(chan bool, 2)
+	incremented := make(chan struct{}, 2)
+	velocityRepositoryMock := new(mocks.VelocityRepositoryMock)
+	velocityRepositoryMock.On("FindBuckets", mock.Anything, mock.Anything, mock.Anything).
+		Return([]entities.VelocityBucket{}, nil)
+	velocityRepositoryMock.On("MarkTracked", mock.Anything, charge.ID, mock.Anything).
+		Run(func(mock.Arguments) { tracked <- true }).Return(true, nil).Once()
+	velocityRepositoryMock.On("MarkTracked", mock.Anything, charge.ID, mock.Anything).
+		Run(func(mock.Arguments) { tracked <- false }).Return(false, nil).Once()
+	velocityRepositoryMock.On("Increment", mock.Anything, charge.VelocityKeys(), charge.Amount, mock.Anything).
+		Run(func(mock.Arguments) { incremented <- struct{}{} }).Return(nil).Once()
+	velocityService := velocity.NewVelocityService(cfg, velocityRepositoryMock, log, new(datadog.MetricsDogMock))
+
+	enrichers := append(newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil),
+		velocity.NewVelocityEnricher(cfg, velocityService))
+	validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+	service := NewChargeService(cfg, validator, rulesRepositoryMock, listServiceMock, chargeRepositoryMock, nil, nil,
+		nil, velocityService, enrichers, log, new(datadog.MetricsDogMock))
+
+	_, err := service.EvaluateCharge(context.Background(), charge)
+	assert.NoError(t, err)
+	_, err = service.EvaluateChargeOnlyRules(context.Background(), charge)
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-tracked:
+		case <-time.After(time.Second):
+			t.Fatal("the charge was not tracked by both endpoints")
+		}
+	}
+	select {
+	case <-incremented:
+	case <-time.After(time.Second):
+		t.Fatal("the charge was not counted in the velocity")
+	}
+	velocityRepositoryMock.AssertNumberOfCalls(t, "MarkTracked", 2)
+	velocityRepositoryMock.AssertNumberOfCalls(t, "Increment", 1)
+}
+
+type enricherStub struct {
+	name     string
+	fields   []string
+	policy   entities.EnrichmentFailurePolicy
+	disabled bool
+	values   map[string]interface{}
+	err      error
+}
+
+func (stub enricherStub) Name() string                                    { return stub.name }
+func (stub enricherStub) Fields() []string                                { return stub.fields }
+func (stub enricherStub) TimeoutMilliseconds() int                        { return 0 }
+func (stub enricherStub) FailurePolicy() entities.EnrichmentFailurePolicy { return stub.policy }
+func (stub enricherStub) IsEnabled() bool                                 { return !stub.disabled }
+func (stub enricherStub) Enrich(context.Context, entities.ChargeRequest) (map[string]interface{}, error) {
+	return stub.values, stub.err
+}
+
+func TestChargeService_EvaluateChargeOnlyRulesEnrichers(t *testing.T) {
+	log, _ := logs.New()
+	cfg := config.Config{}
+
+	charge := testdata.GetDefaultCharge()
+	charge.Console = testdata.SetDefaultConsoleCompany()
+
+	rule := testdata.GetDefaultRuleWithID(false)
+	rule.Rule = `bin.country == "MX" and omniscore == 0.5 and payer.chargebacks == 0`
+
+	rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+	rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+		entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+		Return([]entities.Rule{rule}, nil)
+
+	chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+	chargeRepositoryMock.On("SaveOnlyRules", mock.Anything, mock.AnythingOfType("entities.RulesEvaluationResponse")).
+		Return(nil)
+
+	enrichers := []entities.Enricher{
+		enricherStub{name: "bin", fields: []string{"bin.country"},
+			values: map[string]interface{}{"bin.country": "MX", "bin.bank": "not declared"}},
+		enricherStub{name: "omniscore", fields: []string{"omniscore"},
+			values: map[string]interface{}{"omniscore": 0.5}},
+		enricherStub{name: "chargebacks", fields: []string{"payer.chargebacks"}, err: errors.New("connection lost"),
+			policy: entities.EnrichmentFailurePolicy{OnFailure: entities.EnrichmentUseDefaults,
+				Defaults: map[string]interface{}{"payer.chargebacks": int64(0)}}},
+		enricherStub{name: "ip", fields: []string{"ip.country"}, disabled: true,
+			policy: entities.EnrichmentFailurePolicy{OnFailure: entities.EnrichmentOmitFields}},
+	}
+
+	validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+	service := NewChargeService(cfg, validator, rulesRepositoryMock, nil, chargeRepositoryMock, nil, nil, nil, nil,
+		enrichers, log, new(datadog.MetricsDogMock))
+
+	got, err := service.EvaluateChargeOnlyRules(context.Background(), charge)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Declined.String(), got.Decision)
+	assert.Equal(t, 0.5, got.Omniscore)
+	assert.Equal(t, map[string]interface{}{"bin.country": "MX"}, got.Charge.Enrichments)
+	assert.Equal(t, []entities.EnrichmentResult{
+		{Name: "bin", Status: entities.EnrichmentRan},
+		{Name: "omniscore", Status: entities.EnrichmentRan},
+		{Name: "chargebacks", Status: entities.EnrichmentFailed, Error: "connection lost"},
+		{Name: "ip", Status: entities.EnrichmentSkipped},
+	}, got.Enrichments)
+}
+
+func TestChargeService_EvaluateListIPRanges(t *testing.T) {
+	log, _ := logs.New()
+	charge := testdata.GetDefaultCharge()
+	charge.Details.IPAddress = "187.190.38.10"
+
+	outOfRange := testdata.GetDefaultBlackList(false)
+	outOfRange.Field = entities.IPField
+	outOfRange.Value = "10.0.0.0/8"
+	inRange := testdata.GetDefaultBlackList(false)
+	inRange.Field = entities.IPField
+	inRange.Value = "187.190.0.0/16"
+
+	service := NewChargeService(config.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, log,
+		new(datadog.MetricsDogMock)).(*chargeService)
+
+	t.Run("ip lists only match the IPs in their range", func(t *testing.T) {
+		var listResponse entities.ListResponse
+		decisionTaken := service.EvaluateList(context.Background(), charge, entities.BlacklistType, &listResponse,
+			[]entities.List{outOfRange, inRange})
+
+		assert.True(t, decisionTaken)
+		assert.Equal(t, entities.Declined, listResponse.Decision)
+		assert.Equal(t, []entities.List{inRange}, listResponse.DecisionRules)
+	})
+
+	t.Run("no decision is taken when the IP is out of every range", func(t *testing.T) {
+		var listResponse entities.ListResponse
+		decisionTaken := service.EvaluateList(context.Background(), charge, entities.BlacklistType, &listResponse,
+			[]entities.List{outOfRange})
+
+		assert.False(t, decisionTaken)
+		assert.Empty(t, listResponse.DecisionRules)
+	})
+}
+
+func TestChargeService_EvaluateChargesBatch(t *testing.T) {
+	log, _ := logs.New()
+	cfg := config.Config{}
+	cfg.BatchEvaluation.Concurrency = 2
+
+	undecidedCharge := testdata.GetDefaultCharge()
+	undecidedCharge.Console = testdata.SetDefaultConsoleCompany()
+	declinedCharge := testdata.GetDefaultCharge()
+	declinedCharge.ID = "615324eb5bc1dea9ce660690"
+	declinedCharge.Amount = 6000
+	declinedCharge.Console = testdata.SetDefaultConsoleCompany()
+
+	rule := testdata.GetDefaultRuleWithID(false)
+	rule.Rule = "amount > 5000"
+
+	rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+	rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+		entities.RuleFilter{CompanyID: undecidedCharge.CompanyID}, entities.CompanyRulesType).
+		Once().
+		Return([]entities.Rule{rule}, nil)
+
+	listServiceMock := new(mocks.ListsServiceMock)
+	listServiceMock.On("GetLists", mock.Anything, mock.Anything).Return([]entities.List{}, nil)
+
+	chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+	chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: undecidedCharge.Details.Email}).
+		Return(entities.Payer{}, nil)
+
+	_, omniscoreIsOff := getOmniscoreTestCases()
+
+	chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+	chargeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("entities.EvaluationResponse")).
+		Return(nil)
+
+	validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+	service := NewChargeService(cfg, validator, rulesRepositoryMock, listServiceMock, chargeRepositoryMock, nil, nil,
+		nil, nil, newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil), log, new(datadog.MetricsDogMock))
+
+	got := service.EvaluateChargesBatch(context.Background(),
+		[]entities.ChargeRequest{undecidedCharge, declinedCharge, undecidedCharge})
+
+	assert.Len(t, got, 3)
+	for index, item := range got {
+		assert.Equal(t, index, item.Index)
+		assert.Empty(t, item.Error)
+		assert.NotNil(t, item.Evaluation)
+	}
+	assert.Equal(t, undecidedCharge.ID, got[0].Evaluation.Charge.ID)
+	assert.Equal(t, entities.Undecided.String(), got[0].Evaluation.Decision)
+	assert.Equal(t, declinedCharge.ID, got[1].Evaluation.Charge.ID)
+	assert.Equal(t, entities.Declined.String(), got[1].Evaluation.Decision)
+	assert.Equal(t, entities.Undecided.String(), got[2].Evaluation.Decision)
+	rulesRepositoryMock.AssertNumberOfCalls(t, "GetRulesByFilters", 1)
+}
+
+func TestChargeService_EvaluateChargeDegradation(t *testing.T) {
+	log, _ := logs.New()
+	charge := testdata.GetDefaultCharge()
+	charge.Console = append([]entities.Component{{
+		Name:     entities.BlacklistType,
+		Priority: []entities.Decision{entities.Declined},
+	}}, testdata.SetDefaultConsoleCompany()...)
+	blacklistPolicyKey := charge.CompanyID + "." + string(entities.BlacklistType)
+
+	rule := testdata.GetDefaultRuleWithID(false)
+	rule.Rule = "amount > 5000"
+
+	tests := []struct {
+		name              string
+		componentPolicies map[string]string
+		companyPolicies   map[string]string
+		wantDecision      string
+		wantPolicy        entities.DegradationPolicy
+		wantErr           bool
+	}{
+		{
+			name:         "without policies the evaluation continues as if no list matched",
+			wantDecision: entities.Undecided.String(),
+			wantPolicy:   entities.DegradationContinue,
+		},
+		{
+			name:            "the company policy forces the decision of the component",
+			companyPolicies: map[string]string{blacklistPolicyKey: "D"},
+			wantDecision:    entities.Declined.String(),
+			wantPolicy:      entities.DegradationPolicy(entities.Declined),
+		},
+		{
+			name:              "the component policy fails the evaluation",
+			componentPolicies: map[string]string{string(entities.BlacklistType): "fail"},
+			wantPolicy:        entities.DegradationFail,
+			wantErr:           true,
+		},
+		{
+			name:              "the company policy overrides the component policy",
+			componentPolicies: map[string]string{string(entities.BlacklistType): "fail"},
+			companyPolicies:   map[string]string{blacklistPolicyKey: "continue"},
+			wantDecision:      entities.Undecided.String(),
+			wantPolicy:        entities.DegradationContinue,
+		},
+		{
+			name:              "an invalid policy continues the evaluation",
+			componentPolicies: map[string]string{string(entities.BlacklistType): "approve"},
+			wantDecision:      entities.Undecided.String(),
+			wantPolicy:        entities.DegradationContinue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{}
+			cfg.Degradation.ComponentPolicies = tt.componentPolicies
+			cfg.Degradation.CompanyPolicies = tt.companyPolicies
+
+			rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+			rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+				entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+				Return([]entities.Rule{rule}, nil)
+
+			listServiceMock := new(mocks.ListsServiceMock)
+			listServiceMock.On("GetLists", mock.Anything, mock.Anything).
+				Return([]entities.List(nil), errors.New("rk-lists is unavailable"))
+
+			chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+			chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+				Return(entities.Payer{}, nil)
+
+			_, omniscoreIsOff := getOmniscoreTestCases()
+
+			chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+			chargeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("entities.EvaluationResponse")).
+				Return(nil)
+
+			validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+			service := NewChargeService(cfg, validator, rulesRepositoryMock, listServiceMock, chargeRepositoryMock, nil,
+				nil, nil, nil, newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil), log,
+				new(datadog.MetricsDogMock))
+
+			got, err := service.EvaluateCharge(context.Background(), charge)
+
+			if tt.wantErr {
+				_, isUnavailable := err.(exceptions.ServiceUnavailableException)
+				assert.True(t, isUnavailable)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDecision, got.Decision)
+			assert.Len(t, got.Degradations, 1)
+			assert.Equal(t, entities.BlacklistType, got.Degradations[0].Component)
+			assert.Equal(t, tt.wantPolicy, got.Degradations[0].Policy)
+			assert.Equal(t, "rk-lists is unavailable", got.Degradations[0].Error)
+		})
+	}
+}
+
+func TestChargeService_EvaluateChargeListsDegradationOnce(t *testing.T) {
+	log, _ := logs.New()
+	charge := testdata.GetDefaultCharge()
+	charge.Console = append([]entities.Component{
+		{Name: entities.WhitelistType, Priority: []entities.Decision{entities.Accepted}},
+		{Name: entities.BlacklistType, Priority: []entities.Decision{entities.Declined}},
+		{Name: entities.GraylistType, Priority: []entities.Decision{entities.Undecided}},
+	}, testdata.SetDefaultConsoleCompany()...)
+
+	rule := testdata.GetDefaultRuleWithID(false)
+	rule.Rule = "amount > 5000"
+
+	rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+	rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+		entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+		Return([]entities.Rule{rule}, nil)
+
+	listServiceMock := new(mocks.ListsServiceMock)
+	listServiceMock.On("GetLists", mock.Anything, mock.Anything).
+		Return([]entities.List(nil), errors.New("rk-lists is unavailable"))
+
+	chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+	chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+		Return(entities.Payer{}, nil)
+
+	_, omniscoreIsOff := getOmniscoreTestCases()
+
+	chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+	chargeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("entities.EvaluationResponse")).
+		Return(nil)
+
+	cfg := config.Config{}
+	validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+	service := NewChargeService(cfg, validator, rulesRepositoryMock, listServiceMock, chargeRepositoryMock, nil,
+		nil, nil, nil, newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil), log,
+		new(datadog.MetricsDogMock))
+
+	got, err := service.EvaluateCharge(context.Background(), charge)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Undecided.String(), got.Decision)
+	assert.Len(t, got.Degradations, 1)
+	assert.Equal(t, entities.WhitelistType, got.Degradations[0].Component)
+	assert.Equal(t, entities.DegradationContinue, got.Degradations[0].Policy)
+	listServiceMock.AssertNumberOfCalls(t, "GetLists", 1)
+}
+
+func TestChargeService_EvaluateChargeOnlyRulesDegradation(t *testing.T) {
+	log, _ := logs.New()
+	charge := testdata.GetDefaultCharge()
+	charge.Console = testdata.SetDefaultConsoleCompany()
+	charge.Explain = true
+
+	tests := []struct {
+		name              string
+		componentPolicies map[string]string
+		wantDecision      string
+		wantShortCircuit  bool
+	}{
+		{
+			name:         "without policies the evaluation continues as if no rule matched",
+			wantDecision: entities.Undecided.String(),
+		},
+		{
+			name:              "the component policy forces a declined decision",
+			componentPolicies: map[string]string{string(entities.CompanyRulesType): "D"},
+			wantDecision:      entities.Declined.String(),
+			wantShortCircuit:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{}
+			cfg.Degradation.ComponentPolicies = tt.componentPolicies
+
+			rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+			rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+				entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+				Return([]entities.Rule(nil), errors.New("connection to database lost"))
+
+			chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+			chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+				Return(entities.Payer{}, nil)
+
+			_, omniscoreIsOff := getOmniscoreTestCases()
+
+			chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+			chargeRepositoryMock.On("SaveOnlyRules", mock.Anything,
+				mock.AnythingOfType("entities.RulesEvaluationResponse")).Return(nil)
+
+			validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+			service := NewChargeService(cfg, validator, rulesRepositoryMock, nil, chargeRepositoryMock, nil, nil, nil,
+				nil, newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil), log, new(datadog.MetricsDogMock))
+
+			got, err := service.EvaluateChargeOnlyRules(context.Background(), charge)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDecision, got.Decision)
+			assert.Len(t, got.Degradations, 1)
+			assert.Equal(t, entities.CompanyRulesType, got.Degradations[0].Component)
+			assert.Equal(t, "connection to database lost", got.Degradations[0].Error)
+			assert.Contains(t, got.Trace.Components[0].Reasons, got.Degradations[0].Reason())
+			assert.Equal(t, tt.wantShortCircuit, got.Trace.ShortCircuit != nil)
+		})
+	}
+}
+
+func TestChargeService_EvaluateChargeIdempotency(t *testing.T) {
+	log, _ := logs.New()
+	cfg := config.Config{}
+	cfg.Idempotency.IsEnabled = true
+	cfg.Idempotency.WindowSeconds = 60
+
+	charge := testdata.GetDefaultCharge()
+	charge.Console = testdata.SetDefaultConsoleCompany()
+	rule := testdata.GetDefaultRuleWithID(false)
+	rule.Rule = "amount > 5000"
+
+	newService := func(rulesRepository rules.RuleRepository,
+		chargeRepository ChargeRepository) ChargeService {
+		listServiceMock := new(mocks.ListsServiceMock)
+		listServiceMock.On("GetLists", mock.Anything, mock.Anything).Return([]entities.List{}, nil)
+
+		chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+		chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+			Return(entities.Payer{}, nil)
+
+		_, omniscoreIsOff := getOmniscoreTestCases()
+		validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+		return NewChargeService(cfg, validator, rulesRepository, listServiceMock, chargeRepository, nil, nil, nil, nil,
+			newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil), log, new(datadog.MetricsDogMock))
+	}
+
+	t.Run("a repeated charge inside the window returns the stored evaluation", func(t *testing.T) {
+		stored := entities.NewUndecidedEvaluationResponse(charge, evaluationOrder)
+		stored.Decision = entities.Declined.String()
+
+		chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+		chargeRepositoryMock.On("FindEvaluation", mock.Anything, charge.ID, mock.AnythingOfType("time.Time")).
+			Return(stored, nil)
+		rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+
+		got, err := newService(rulesRepositoryMock, chargeRepositoryMock).EvaluateCharge(context.Background(), charge)
+
+		assert.NoError(t, err)
+		assert.True(t, got.Replayed)
+		assert.Equal(t, entities.Declined.String(), got.Decision)
+		rulesRepositoryMock.AssertNotCalled(t, "GetRulesByFilters", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("a forced reevaluation skips the stored evaluation", func(t *testing.T) {
+		forcedCharge := charge
+		forcedCharge.ForceReevaluate = true
+
+		chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+		chargeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("entities.EvaluationResponse")).
+			Return(nil)
+		rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+		rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+			entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+			Return([]entities.Rule{rule}, nil)
+
+		got, err := newService(rulesRepositoryMock, chargeRepositoryMock).
+			EvaluateCharge(context.Background(), forcedCharge)
+
+		assert.NoError(t, err)
+		assert.False(t, got.Replayed)
+		assert.Equal(t, entities.Undecided.String(), got.Decision)
+		chargeRepositoryMock.AssertNotCalled(t, "FindEvaluation", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("concurrent duplicates share a single evaluation", func(t *testing.T) {
+		chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+		chargeRepositoryMock.On("FindEvaluation", mock.Anything, charge.ID, mock.AnythingOfType("time.Time")).
+			Return(entities.EvaluationResponse{}, exceptions.NewNotFoundException("not found"))
+		chargeRepositoryMock.On("Save", mock.Anything, mock.AnythingOfType("entities.EvaluationResponse")).
+			Return(nil)
+		rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+		rulesRepositoryMock.On("GetRulesByFilters", context.Background(),
+			entities.RuleFilter{CompanyID: charge.CompanyID}, entities.CompanyRulesType).
+			After(50*time.Millisecond).
+			Return([]entities.Rule{rule}, nil)
+		service := newService(rulesRepositoryMock, chargeRepositoryMock)
+
+		results := make(chan entities.EvaluationResponse, 3)
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got, err := service.EvaluateCharge(context.Background(), charge)
+				assert.NoError(t, err)
+				results <- got
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		replayed := 0
+		for got := range results {
+			assert.Equal(t, entities.Undecided.String(), got.Decision)
+			if got.Replayed {
+				replayed++
+				assert.NotNil(t, got.EvaluatedAt)
+			}
+		}
+		assert.Equal(t, 2, replayed)
+		rulesRepositoryMock.AssertNumberOfCalls(t, "GetRulesByFilters", 1)
+		chargeRepositoryMock.AssertNumberOfCalls(t, "Save", 1)
+	})
+}
+
 func TestChargeService_Get(t *testing.T) {
 	logger, _ := logs.New()
 	t.Run("service returns repository response", func(t *testing.T) {
@@ -748,6 +1454,16 @@ func TestChargeService_GetOnlyRules(t *testing.T) {
 	})
 }
 
+func newEnrichers(cfg config.Config, chargebackRepository chargebacks.ChargebackRepository,
+	omniscoreService omniscores.OmniscoreService,
+	merchantScoreRepository merchantsscore.MerchantsScoreRepository) []entities.Enricher {
+	return []entities.Enricher{
+		chargebacks.NewChargebacksEnricher(cfg, chargebackRepository),
+		omniscores.NewOmniscoreEnricher(cfg, omniscoreService),
+		merchantsscore.NewMerchantScoreEnricher(cfg, merchantScoreRepository),
+	}
+}
+
 func getMockServiceFirstCase() (rules.RuleRepository, lists.ListsService, families.FamilyService, familycom.FamilyCompaniesService, chargebacks.ChargebackRepository, merchantsscore.MerchantsScoreRepository) {
 	rulesRepositoryMock := new(mocks.RulesRepositoryMock)
 	listServiceMock := new(mocks.ListsServiceMock)
@@ -761,15 +1477,15 @@ func getMockServiceFirstCase() (rules.RuleRepository, lists.ListsService, famili
 		NotExcludedCompanies: []string{testdata.GetDefaultCharge().CompanyID},
 	}
 	charge := testdata.GetDefaultCharge()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().Times(3).
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{CompanyID: charge.CompanyID, Score: defaultScore}, nil)
 
@@ -802,15 +1518,15 @@ func getMockServiceSecondCase() (rules.RuleRepository, lists.ListsService, famil
 	}
 
 	charge := testdata.GetDefaultCharge()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{white}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{CompanyID: charge.CompanyID, Score: defaultScore}, nil)
 
@@ -846,15 +1562,15 @@ func getMockServiceThirdCase() (rules.RuleRepository, lists.ListsService, famili
 
 	charge := testdata.GetDefaultCharge()
 	charge.SetDefaultConsole()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{white}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: charge.Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{CompanyID: charge.CompanyID, Score: defaultScore}, nil)
 
@@ -886,16 +1602,16 @@ func getMockServiceFourthCase() (rules.RuleRepository, lists.ListsService, famil
 		NotExcludedCompanies: []string{testdata.GetDefaultCharge().CompanyID},
 	}
 
-	charge := testdata.GetDefaultCharge()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	charge := testdata.GetDefaultChargeBlacklist()
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{testdata.GetDefaultBlackList(false)}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultChargeBlacklist().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultChargeBlacklist().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -931,15 +1647,15 @@ func getMockServiceFifthCase() (rules.RuleRepository, lists.ListsService, famili
 	}
 
 	charge := testdata.GetChargeWithDeviceFingerprintBlocked()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -978,15 +1694,15 @@ func getMockServiceSixthCase() (rules.RuleRepository, lists.ListsService, famili
 	}
 
 	charge := testdata.GetChargeWithEmailBlockedGlobal()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1023,16 +1739,16 @@ func getMockServiceNinthCase() (rules.RuleRepository, lists.ListsService, famili
 		NotExcludedCompanies: []string{testdata.GetDefaultCharge().CompanyID},
 	}
 
-	charge := testdata.GetDefaultCharge()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	charge := testdata.GetDefaultChargeFamily()
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1071,16 +1787,16 @@ func getMockServiceEleventhCase() (rules.RuleRepository, lists.ListsService, fam
 		CompanyIDs: companyIDs,
 	}
 
-	charge := testdata.GetDefaultFamilyCompaniesIDCharge()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	charge := testdata.GetDefaultChargeFamilyMcc()
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultFamilyCompaniesIDCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultFamilyCompaniesIDCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1123,15 +1839,15 @@ func getMockServiceThirteenthCase() (rules.RuleRepository, lists.ListsService, f
 	}
 
 	charge := testdata.GetDefaultChargeFamilyMcc()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultFamilyCompaniesIDCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultFamilyCompaniesIDCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1179,15 +1895,15 @@ func getMockServiceFourteenthCase() (rules.RuleRepository, lists.ListsService, f
 	}
 
 	charge := testdata.GetDefaultChargeInGraylist()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{gray}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultChargeInGraylist().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultChargeInGraylist().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1232,16 +1948,16 @@ func getMockServiceFifteenthCase() (rules.RuleRepository, lists.ListsService, fa
 		Paged:      false,
 	}
 
-	charge := testdata.GetDefaultChargeInGraylist()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	charge := testdata.GetDefaultChargeInGraylistAndRule()
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{gray}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultChargeInGraylist().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultChargeInGraylist().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1285,15 +2001,15 @@ func getMockServiceSixteenthCase() (rules.RuleRepository, lists.ListsService, fa
 	}
 
 	charge := testdata.GetDefaultChargeWithChargebacks()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultChargeWithChargebacks().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultChargeWithChargebacks().Details.Email}).
 		Once().
 		Return(testdata.GetPayerDefeult(), nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1337,15 +2053,15 @@ func getMockServiceSeventeenthCase() (rules.RuleRepository, lists.ListsService,
 	}
 
 	charge := testdata.GetChargeForOmniscoreRule()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetChargeForOmniscoreRule().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetChargeForOmniscoreRule().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1378,18 +2094,18 @@ func getMockServiceNineteenthCase() (rules.RuleRepository, lists.ListsService, f
 		NotExcludedCompanies: []string{testdata.GetDefaultCharge().CompanyID},
 	}
 
-	charge := testdata.GetDefaultCharge()
+	charge := testdata.GetChargeRequestForBlacklist()
 	blacklist := testdata.GetDefaultBlackList(false)
 	blacklist.Type = "Blaklist"
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{blacklist}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultChargeBlacklist().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultChargeBlacklist().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1426,15 +2142,15 @@ func getMockServiceTwentiethCase() (rules.RuleRepository, lists.ListsService, fa
 
 	charge := testdata.GetDefaultCharge()
 
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultChargeBlacklist().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultChargeBlacklist().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{CompanyID: charge.CompanyID, Score: defaultScore}, nil)
 
@@ -1471,15 +2187,15 @@ func getMockServiceTwentyfirstCase() (rules.RuleRepository, lists.ListsService,
 
 	charge := testdata.GetDefaultCharge()
 
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultChargeBlacklist().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultChargeBlacklist().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{CompanyID: charge.CompanyID, Score: defaultScore}, nil)
 
@@ -1516,15 +2232,15 @@ func getMockServiceTwentySecondCase() (rules.RuleRepository, lists.ListsService,
 
 	charge := testdata.GetDefaultCharge()
 
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{CompanyID: charge.CompanyID, Score: 0.1}, nil)
 
@@ -1548,8 +2264,8 @@ func getOmniscoreTestCases() (omniscores.OmniscoreService, omniscores.OmniscoreS
 	OmniscoreOnMock := new(mocks.OmniscoreServiceMock)
 	OmniscoreOffMock := new(mocks.OmniscoreServiceMock)
 
-	OmniscoreOnMock.On("GetScore", context.Background(), mock.AnythingOfType("ChargeRequest")).Return(0.4)
-	OmniscoreOffMock.On("GetScore", context.Background(), mock.AnythingOfType("ChargeRequest")).Return(rest.DefaultScore)
+	OmniscoreOnMock.On("GetScore", mock.Anything, mock.AnythingOfType("ChargeRequest")).Return(0.4)
+	OmniscoreOffMock.On("GetScore", mock.Anything, mock.AnythingOfType("ChargeRequest")).Return(rest.DefaultScore)
 
 	return OmniscoreOnMock, OmniscoreOffMock
 }
@@ -1577,11 +2293,11 @@ func getMockServiceFirstCaseOnlyRules() (rules.RuleRepository, lists.ListsServic
 	}
 	charge := testdata.GetDefaultCharge()
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{CompanyID: charge.CompanyID, Score: defaultScore}, nil)
 
@@ -1613,11 +2329,11 @@ func getMockServiceSecondCaseOnlyRules() (rules.RuleRepository, lists.ListsServi
 	}
 	charge := testdata.GetDefaultCharge()
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{CompanyID: charge.CompanyID, Score: defaultScore}, nil)
 
@@ -1653,15 +2369,15 @@ func getMockServiceThirdCaseOnlyRules() (rules.RuleRepository, lists.ListsServic
 	}
 
 	charge := testdata.GetDefaultCharge()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1701,11 +2417,11 @@ func getMockServiceFourthCaseOnlyRules() (rules.RuleRepository, lists.ListsServi
 	}
 
 	charge := testdata.GetDefaultFamilyCompaniesIDCharge()
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetDefaultFamilyCompaniesIDCharge().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetDefaultFamilyCompaniesIDCharge().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1746,15 +2462,15 @@ func getMockServiceFifthCaseOnlyRules() (rules.RuleRepository, lists.ListsServic
 	}
 
 	charge := testdata.GetChargeWithEmailBlockedGlobal()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1795,15 +2511,15 @@ func getMockServiceSixthCaseOnlyRules() (rules.RuleRepository, lists.ListsServic
 	}
 
 	charge := testdata.GetChargeWithEmailBlockedGlobal()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1862,15 +2578,15 @@ func getMockServiceSevenCaseOnlyRules() (rules.RuleRepository, lists.ListsServic
 	}
 
 	charge := testdata.GetChargeWithEmailProximity()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1933,15 +2649,15 @@ func getMockServiceEighthCaseOnlyRules() (rules.RuleRepository, lists.ListsServi
 	}
 
 	charge := testdata.GetChargeYellowFlag()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -1983,15 +2699,15 @@ func getMockServiceNinthCaseOnlyRules() (rules.RuleRepository, lists.ListsServic
 	}
 
 	charge := testdata.GetChargeYellowFlagAndGlobal()
-	listServiceMock.On("GetLists", context.Background(), charge.NewListsSearch()).
+	listServiceMock.On("GetLists", mock.Anything, charge.NewListsSearch()).
 		Once().
 		Return([]entities.List{}, nil)
 
-	chargebacksRepositoryMock.On("Find", context.Background(), entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
+	chargebacksRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: testdata.GetChargeWithEmailBlockedGlobal().Details.Email}).
 		Once().
 		Return(entities.Payer{}, nil)
 
-	merchantsScoreRepositoryMock.On("FindByMerchantID", context.Background(), charge.CompanyID).
+	merchantsScoreRepositoryMock.On("FindByMerchantID", mock.Anything, charge.CompanyID).
 		Once().
 		Return(entities.MerchantScore{}, nil)
 
@@ -2017,3 +2733,97 @@ func getMockServiceNinthCaseOnlyRules() (rules.RuleRepository, lists.ListsServic
 
 	return rulesRepositoryMock, listServiceMock, familyServiceMock, familyCompaniesServiceMock, chargebacksRepositoryMock, merchantsScoreRepositoryMock
 }
+
+func TestChargeService_EvaluateChargeOnlyRulesScore(t *testing.T) {
+	log, _ := logs.New()
+	cfg := config.Config{}
+
+	charge := testdata.GetDefaultCharge()
+	charge.Console = []entities.Component{
+		{Name: entities.ScoreRulesType, Priority: []entities.Decision{entities.Declined, entities.Accepted}},
+	}
+
+	newScoreRule := func(value string, weight float64, isTest bool) entities.Rule {
+		rule := testdata.GetDefaultRuleWithID(isTest)
+		rule.IsScoreRule = true
+		rule.Decision = entities.Undecided
+		rule.Weight = &weight
+		rule.Rules = []entities.RuleContent{{Field: "amount", Operator: ">", Value: value, Condition: "and"}}
+		rule.Rule = "amount > " + value
+		return rule
+	}
+	scoreRules := []entities.Rule{
+		newScoreRule("0", 40, false),
+		newScoreRule("0", 50, false),
+		newScoreRule("0", 10, true),
+		newScoreRule("100000000", 30, false),
+	}
+
+	familyServiceMock := new(mocks.FamilyServiceMock)
+	familyServiceMock.On("GetFamily", mock.Anything, mock.AnythingOfType("entities.FamilyFilter")).
+		Return(entities.Family{}, nil)
+	familyCompaniesServiceMock := new(mocks.FamilyCompaniesServiceMock)
+	familyCompaniesServiceMock.On("GetFamiliesCompaniesFromFilter", mock.Anything,
+		mock.AnythingOfType("entities.FamilyCompaniesFilter")).Return([]entities.FamilyCompanies{}, nil)
+
+	rulesRepositoryMock := new(mocks.RulesRepositoryMock)
+	rulesRepositoryMock.On("GetRulesByFilters", context.Background(), mock.AnythingOfType("entities.RuleFilter"),
+		entities.ScoreRulesType).Return(scoreRules, nil)
+
+	threshold := testdata.GetDefaultScoreThreshold()
+	scoreThresholdServiceMock := new(mocks.ScoreThresholdServiceMock)
+	scoreThresholdServiceMock.On("GetThreshold", mock.Anything, charge.CompanyID, mock.Anything).
+		Return(threshold, nil)
+
+	chargebackRepositoryMock := new(mocks.ChargebackRepositoryMock)
+	chargebackRepositoryMock.On("Find", mock.Anything, entities.Payer{Email: charge.Details.Email}).
+		Return(entities.Payer{}, nil)
+
+	_, omniscoreIsOff := getOmniscoreTestCases()
+
+	chargeRepositoryMock := new(mocks.ChargeEvaluationRepositoryMock)
+	chargeRepositoryMock.On("SaveOnlyRules", mock.Anything, mock.AnythingOfType("entities.RulesEvaluationResponse")).
+		Return(nil)
+
+	validator := rules.NewRulesValidator(log, rules.NewRuleEvaluatorCache(cfg, log, new(datadog.MetricsDogMock)))
+	service := NewChargeService(cfg, validator, rulesRepositoryMock, nil, chargeRepositoryMock, familyServiceMock,
+		familyCompaniesServiceMock, scoreThresholdServiceMock, nil,
+		newEnrichers(cfg, chargebackRepositoryMock, omniscoreIsOff, nil), log, new(datadog.MetricsDogMock))
+
+	got, err := service.EvaluateChargeOnlyRules(context.Background(), charge)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Declined.String(), got.Decision)
+	assert.NotNil(t, got.RiskScore)
+	assert.Equal(t, float64(90), got.RiskScore.Score)
+	assert.Equal(t, float64(100), got.RiskScore.TestScore)
+	assert.Equal(t, entities.Declined, got.RiskScore.Decision)
+	assert.Equal(t, "company", got.RiskScore.ThresholdScope)
+	assert.Len(t, got.RiskScore.Contributions, 3)
+	assert.NotNil(t, got.RulesModules.ScoreRules)
+	assert.Len(t, got.RulesModules.ScoreRules.DecisionRules, 2)
+}
+
+func TestActiveRules(t *testing.T) {
+	now := time.Now().UTC()
+	expiredAt := now.Add(-time.Minute)
+	startsAt := now.Add(time.Hour)
+
+	expiredRule := testdata.GetDefaultRuleWithID(false)
+	expiredRule.ActiveUntil = &expiredAt
+	futureRule := testdata.GetDefaultRuleWithID(false)
+	futureRule.ActiveFrom = &startsAt
+	activeRule := testdata.GetDefaultRuleWithID(false)
+	pendingRule := testdata.GetDefaultRuleWithID(false)
+	pendingRule.Status = entities.RuleStatusPendingApproval
+	retiredRule := testdata.GetDefaultRuleWithID(false)
+	retiredRule.Status = entities.RuleStatusRetired
+	draftRule := testdata.GetDefaultRuleWithID(false)
+	draftRule.Status = entities.RuleStatusDraft
+	expectedDraftRule := draftRule
+	expectedDraftRule.IsTest = true
+
+	got := activeRules([]entities.Rule{expiredRule, futureRule, activeRule, pendingRule, retiredRule, draftRule}, now)
+
+	assert.Equal(t, []entities.Rule{activeRule, expectedDraftRule}, got)
+}
@@ -127,7 +127,15 @@ func (handler *conditionHandler) Delete(ctx echo.Context) error {
 		return nil
 	}
 
-	err = handler.service.Delete(ctx.Request().Context(), id)
+	author := ctx.QueryParam("author")
+	if str.IsEmpty(author) {
+		err := customHttp.NewBadRequestError("author is required")
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Delete"))
+		ctx.Error(err)
+		return nil
+	}
+
+	err = handler.service.Delete(ctx.Request().Context(), id, author)
 	if err != nil {
 		ctx.Error(err)
 		return nil
@@ -231,7 +231,8 @@ func Test_Delete_Condition_Handler(t *testing.T) {
 		id := "60f6f32ba0f965ae8ae2c87e"
 		request := `{}`
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, conditionsUri, id, request)
-		conditionServiceMock.Mock.On("Delete", context.Request().Context(), id).
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
+		conditionServiceMock.Mock.On("Delete", context.Request().Context(), id, "santiago.ceron@conekta.com").
 			Return(nil).Once()
 		handler := conditions.NewConditionsHandler(conditionServiceMock, logger)
 
@@ -248,9 +249,10 @@ func Test_Delete_Condition_Handler(t *testing.T) {
 		request := `{}`
 
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, conditionsUri, "60f6f32ba0f965ae8ae2c87e", request)
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
 		conditionServiceMock.Mock.On("Delete",
 			context.Request().Context(),
-			"60f6f32ba0f965ae8ae2c87e").Return(expectedError).Once()
+			"60f6f32ba0f965ae8ae2c87e", "santiago.ceron@conekta.com").Return(expectedError).Once()
 
 		handler := conditions.NewConditionsHandler(conditionServiceMock, logger)
 
@@ -276,6 +278,21 @@ func Test_Delete_Condition_Handler(t *testing.T) {
 		assert.True(t, strings.Contains(httpError.Message(), expectedError))
 	})
 
+	t.Run("when author is empty then return BadRequest", func(t *testing.T) {
+		conditionServiceMock := new(mocks.ConditionServiceMock)
+		request := `{}`
+
+		context, recorder := echo.SetupAsRecorder(http.MethodDelete, conditionsUri, "60f6f32ba0f965ae8ae2c87e", request)
+		handler := conditions.NewConditionsHandler(conditionServiceMock, logger)
+
+		handler.Delete(context)
+
+		httpError, _ := customHttp.NewRestErrorFromBytes(recorder.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, httpError.Status())
+		assert.Equal(t, "author is required", httpError.Message())
+		conditionServiceMock.AssertNotCalled(t, "Delete")
+	})
+
 	t.Run("when id empty then return BadRequest", func(t *testing.T) {
 		expectedError := "empty id"
 		request := `{}`
@@ -6,6 +6,7 @@ import (
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
@@ -28,14 +29,15 @@ type ConditionService interface {
 	Add(ctx context.Context, condition entities.Condition) error
 	GetAll(ctx context.Context, filter entities.ConditionsFilter, pagination entities.Pagination) (entities.PagedResponse, error)
 	Update(ctx context.Context, id string, condition entities.Condition) error
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, author string) error
 }
 
 type conditionsService struct {
-	repository ConditionRepository
-	log        logs.Logger
-	datadog    datadog.Metricer
-	config     config.Config
+	repository   ConditionRepository
+	auditService audit.AuditService
+	log          logs.Logger
+	datadog      datadog.Metricer
+	config       config.Config
 }
 
 func (service *conditionsService) Add(ctx context.Context, condition entities.Condition) error {
@@ -59,6 +61,8 @@ func (service *conditionsService) Add(ctx context.Context, condition entities.Co
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditConditions, condition.ID.Hex(),
+		entities.AuditCreated, condition.CreatedBy, nil))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.log, metricData, text.SaveConditionsMetricName)
 
@@ -83,6 +87,7 @@ func (service *conditionsService) Update(ctx context.Context, id string, conditi
 		return err
 	}
 
+	before := service.auditService.Snapshot(ctx, entities.AuditConditions, id)
 	err = service.repository.Update(ctx, id, condition)
 
 	metricData := metrics.NewMetricData(ctx, "Update Condition", serviceName, service.config.Env)
@@ -91,13 +96,16 @@ func (service *conditionsService) Update(ctx context.Context, id string, conditi
 		metrics.SendAsyncMetrics(service.datadog, service.log, metricData, text.UpdateConditionMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditConditions, id, entities.AuditUpdated,
+		strings.StringPointerToString(condition.UpdatedBy), before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.log, metricData, text.UpdateConditionMetricName)
 
 	return nil
 }
 
-func (service *conditionsService) Delete(ctx context.Context, id string) error {
+func (service *conditionsService) Delete(ctx context.Context, id string, author string) error {
+	before := service.auditService.Snapshot(ctx, entities.AuditConditions, id)
 	err := service.repository.Delete(ctx, id)
 
 	metricData := metrics.NewMetricData(ctx, "Delete Condition", serviceName, service.config.Env)
@@ -106,18 +114,21 @@ func (service *conditionsService) Delete(ctx context.Context, id string) error {
 		metrics.SendAsyncMetrics(service.datadog, service.log, metricData, text.DeleteConditionMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditConditions, id, entities.AuditDeleted,
+		author, before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.log, metricData, text.DeleteConditionMetricName)
 
 	return nil
 }
 
-func NewConditionsService(configs config.Config, repository ConditionRepository,
+func NewConditionsService(configs config.Config, repository ConditionRepository, auditService audit.AuditService,
 	logger logs.Logger, metricer datadog.Metricer) ConditionService {
 	return &conditionsService{
-		repository: repository,
-		log:        logger,
-		datadog:    metricer,
-		config:     configs,
+		repository:   repository,
+		auditService: auditService,
+		log:          logger,
+		datadog:      metricer,
+		config:       configs,
 	}
 }
@@ -30,7 +30,7 @@ func TestConditionService_Add_fails(t *testing.T) {
 		mockedRepository.On("FindByName", nil, condition).
 			Return(entities.Condition{}, expectedError).Once()
 
-		service := conditions.NewConditionsService(config.Config{}, mockedRepository, log, nil)
+		service := conditions.NewConditionsService(config.Config{}, mockedRepository, mocks.NewAuditServiceMock(), log, nil)
 
 		err := service.Add(nil, condition)
 
@@ -44,7 +44,7 @@ func TestConditionService_Add_fails(t *testing.T) {
 		mockedRepository.On("FindByName", nil, condition).
 			Return(condition, nil).Once()
 
-		service := conditions.NewConditionsService(config.Config{}, mockedRepository, log, nil)
+		service := conditions.NewConditionsService(config.Config{}, mockedRepository, mocks.NewAuditServiceMock(), log, nil)
 
 		err := service.Add(nil, condition)
 
@@ -63,7 +63,7 @@ func TestConditionService_Add_fails(t *testing.T) {
 			Return(expectedError).Once()
 
 		service := conditions.NewConditionsService(config.Config{},
-			mockedRepository, log, new(datadog.MetricsDogMock))
+			mockedRepository, mocks.NewAuditServiceMock(), log, new(datadog.MetricsDogMock))
 
 		err := service.Add(nil, condition)
 
@@ -87,7 +87,7 @@ func TestConditionService_Update_fails(t *testing.T) {
 			Return(condition, nil).Once()
 		id := "61086352928b571237eab678"
 
-		service := conditions.NewConditionsService(config.Config{}, mockedRepository, log, nil)
+		service := conditions.NewConditionsService(config.Config{}, mockedRepository, mocks.NewAuditServiceMock(), log, nil)
 
 		err := service.Update(nil, id, condition)
 
@@ -104,7 +104,7 @@ func TestConditionService_Update_fails(t *testing.T) {
 			Return(entities.Condition{}, expectedError).Once()
 		id := "61086352928b571237eab678"
 
-		service := conditions.NewConditionsService(config.Config{}, mockedRepository, log, nil)
+		service := conditions.NewConditionsService(config.Config{}, mockedRepository, mocks.NewAuditServiceMock(), log, nil)
 
 		err := service.Update(nil, id, condition)
 
@@ -129,7 +129,7 @@ func TestConditionService_Add_success(t *testing.T) {
 	mockedRepository.On("Add", nil, &condition).
 		Return(nil).Once()
 
-	service := conditions.NewConditionsService(config.Config{}, mockedRepository, log, new(datadog.MetricsDogMock))
+	service := conditions.NewConditionsService(config.Config{}, mockedRepository, mocks.NewAuditServiceMock(), log, new(datadog.MetricsDogMock))
 
 	err := service.Add(nil, condition)
 
@@ -140,7 +140,8 @@ func TestConditionService_Add_success(t *testing.T) {
 func TestUpdate_WhenUpdateOnRepositoryFailsThenReturnError(t *testing.T) {
 	logger, _ := logs.New()
 	conditionsRepositoryMock := new(mocks.ConditionsRepositoryMock)
-	service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, logger, new(datadog.MetricsDogMock))
+	service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, mocks.NewAuditServiceMock(),
+		logger, new(datadog.MetricsDogMock))
 	expectedError := errors.New("connection lost")
 
 	id := "61086352928b571237eab678"
@@ -175,7 +176,8 @@ func TestUpdate_WhenUpdateOnRepositoryFailsThenReturnError(t *testing.T) {
 func TestUpdate_WhenUpdateOkThenReturnNil(t *testing.T) {
 	logger, _ := logs.New()
 	conditionsRepositoryMock := new(mocks.ConditionsRepositoryMock)
-	service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, logger, new(datadog.MetricsDogMock))
+	service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, mocks.NewAuditServiceMock(),
+		logger, new(datadog.MetricsDogMock))
 
 	id := "61086352928b571237eab678"
 	conditionRequest := entities.ConditionRequest{
@@ -211,7 +213,8 @@ func Test_Delete_Condition_Sevice(t *testing.T) {
 
 	t.Run("when delete on repository fails then return error", func(t *testing.T) {
 		conditionsRepositoryMock := new(mocks.ConditionsRepositoryMock)
-		service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 		expectedError := errors.New("connection lost")
 
 		id := "60f6f32ba0f965ae8ae2c87e"
@@ -219,7 +222,7 @@ func Test_Delete_Condition_Sevice(t *testing.T) {
 		conditionsRepositoryMock.Mock.On("Delete", nil, id).
 			Return(expectedError).Once()
 
-		err := service.Delete(nil, id)
+		err := service.Delete(nil, id, "santiago.ceron@conekta.com")
 
 		assert.NotNil(t, err)
 		assert.Equal(t, expectedError, err)
@@ -228,14 +231,15 @@ func Test_Delete_Condition_Sevice(t *testing.T) {
 
 	t.Run("when delete o then return nil", func(t *testing.T) {
 		conditionsRepositoryMock := new(mocks.ConditionsRepositoryMock)
-		service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		id := "60f6f32ba0f965ae8ae2c87e"
 
 		conditionsRepositoryMock.Mock.On("Delete", nil, id).
 			Return(nil).Once()
 
-		err := service.Delete(nil, id)
+		err := service.Delete(nil, id, "santiago.ceron@conekta.com")
 
 		assert.Nil(t, err)
 		conditionsRepositoryMock.AssertExpectations(t)
@@ -263,7 +267,8 @@ func TestConditionsService_GetAll(t *testing.T) {
 			pagination).
 			Return(notConditionsFound, nil)
 
-		service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := conditions.NewConditionsService(config.Config{}, conditionsRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		pagedRules, err := service.GetAll(nil,
 			entities.ConditionsFilter{
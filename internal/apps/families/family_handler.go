@@ -129,7 +129,15 @@ func (handler *familyHandler) Delete(ctx echo.Context) error {
 		return nil
 	}
 
-	err := handler.service.Delete(ctx.Request().Context(), familyID)
+	author := ctx.QueryParam("author")
+	if str.IsEmpty(author) {
+		err := customHttp.NewBadRequestError("author is required")
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Delete"))
+		ctx.Error(err)
+		return nil
+	}
+
+	err := handler.service.Delete(ctx.Request().Context(), familyID, author)
 	if err != nil {
 		ctx.Error(err)
 		return nil
@@ -386,7 +386,7 @@ func Test_FamilyHandler_Delete(t *testing.T) {
 		expectedError := errors.New("empty id")
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPut, familiesUri, "", "")
-		service.On("Delete", context.Request().Context(), "").Return(expectedError)
+		service.On("Delete", context.Request().Context(), "", "santiago.ceron@conekta.com").Return(expectedError)
 
 		handler := families.NewFamilyHandler(service, logger)
 		handler.Delete(context)
@@ -395,12 +395,27 @@ func Test_FamilyHandler_Delete(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, httpError.Status())
 	})
 
+	t.Run("delete family when author is empty", func(t *testing.T) {
+		service := new(mocks.FamilyServiceMock)
+
+		context, recorder := echo.SetupAsRecorder(http.MethodDelete, familiesUri, familyID, "")
+
+		handler := families.NewFamilyHandler(service, logger)
+		handler.Delete(context)
+
+		httpError, _ := customHttp.NewRestErrorFromBytes(recorder.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, httpError.Status())
+		assert.Equal(t, "author is required", httpError.Message())
+		service.AssertNotCalled(t, "Delete")
+	})
+
 	t.Run("delete family service return error", func(t *testing.T) {
 		service := new(mocks.FamilyServiceMock)
 		expectedError := errors.New("Service error")
 
 		context, rec := echo.SetupAsRecorder(http.MethodDelete, familiesUri, familyID, "")
-		service.On("Delete", context.Request().Context(), familyID).Return(expectedError).Once()
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
+		service.On("Delete", context.Request().Context(), familyID, "santiago.ceron@conekta.com").Return(expectedError).Once()
 
 		handler := families.NewFamilyHandler(service, logger)
 		handler.Delete(context)
@@ -415,7 +430,8 @@ func Test_FamilyHandler_Delete(t *testing.T) {
 		service := new(mocks.FamilyServiceMock)
 
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, familiesUri, familyID, "")
-		service.On("Delete", context.Request().Context(), familyID).Return(nil)
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
+		service.On("Delete", context.Request().Context(), familyID, "santiago.ceron@conekta.com").Return(nil)
 
 		handler := families.NewFamilyHandler(service, logger)
 		err := handler.Delete(context)
@@ -7,11 +7,13 @@ import (
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
 	"github.com/conekta/risk-rules/internal/apps/rules"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/conekta/risk-rules/pkg/metrics"
+	customString "github.com/conekta/risk-rules/pkg/strings"
 	"github.com/conekta/risk-rules/pkg/text"
 )
 
@@ -19,7 +21,7 @@ const serviceMethodName = "families.service.%s"
 
 type FamilyService interface {
 	Create(ctx context.Context, family entities.Family) error
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, author string) error
 	Update(ctx context.Context, id string, family entities.Family) error
 	Get(ctx context.Context, pagination entities.Pagination,
 		filter entities.FamilyFilter) (interface{}, error)
@@ -33,17 +35,20 @@ type familyService struct {
 	config           config.Config
 	familyRepository FamilyRepository
 	ruleRepository   rules.RuleRepository
+	auditService     audit.AuditService
 }
 
 func NewFamilyService(cfg config.Config,
 	familyRepository FamilyRepository,
 	ruleRepository rules.RuleRepository,
+	auditService audit.AuditService,
 	logger logs.Logger,
 	datadogMetric datadog.Metricer) FamilyService {
 	return &familyService{
 		config:           cfg,
 		familyRepository: familyRepository,
 		ruleRepository:   ruleRepository,
+		auditService:     auditService,
 		logs:             logger,
 		datadog:          datadogMetric,
 	}
@@ -72,6 +77,8 @@ func (service *familyService) Create(ctx context.Context, family entities.Family
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFamilies, family.ID.Hex(),
+		entities.AuditCreated, family.CreatedBy, nil))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveListMetricName)
 
@@ -97,6 +104,7 @@ func (service *familyService) Update(ctx context.Context, id string, family enti
 		return service.BuildExistingFamilyError(ctx, filteredFamilies, family)
 	}
 
+	before := service.auditService.Snapshot(ctx, entities.AuditFamilies, id)
 	err = service.familyRepository.Update(ctx, id, &family)
 	if err != nil {
 		metricData.SetResult(false)
@@ -104,6 +112,8 @@ func (service *familyService) Update(ctx context.Context, id string, family enti
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFamilies, id, entities.AuditUpdated,
+		customString.StringPointerToString(family.UpdatedBy), before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateListMetricName)
 
@@ -180,7 +190,7 @@ func (service *familyService) Get(ctx context.Context, pagination entities.Pagin
 	}
 }
 
-func (service *familyService) Delete(ctx context.Context, id string) error {
+func (service *familyService) Delete(ctx context.Context, id string, author string) error {
 	pagination := entities.Pagination{}
 	pagedRules, err := service.ruleRepository.FindRulesPaged(ctx, entities.RuleFilter{FamilyID: id}, pagination)
 	if err != nil {
@@ -201,6 +211,7 @@ func (service *familyService) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
+	before := service.auditService.Snapshot(ctx, entities.AuditFamilies, id)
 	err = service.familyRepository.Delete(ctx, id)
 	metricData := metrics.NewMetricData(ctx, "Delete", serviceMethodName, service.config.Env)
 	if err != nil {
@@ -208,6 +219,8 @@ func (service *familyService) Delete(ctx context.Context, id string) error {
 		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteListMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFamilies, id, entities.AuditDeleted,
+		author, before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteListMetricName)
 
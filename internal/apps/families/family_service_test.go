@@ -29,7 +29,7 @@ func TestFamilyService_Create(t *testing.T) {
 		family := request.NewFamilyFromPostRequest()
 		expErr := errors.New("database connection lost")
 		service := families.
-			NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+			NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyRepositoryMock.On("AddFamily", ctx, &family).
 			Return(expErr).Once()
@@ -55,7 +55,7 @@ func TestFamilyService_Create(t *testing.T) {
 			family.Name, family.Mccs[0]))
 
 		service := families.
-			NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+			NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyFound := testdata.GetDefaultFamily()
 		pagedResponseFamilies := entities.PagedResponse{
@@ -91,7 +91,7 @@ func TestFamilyService_Create(t *testing.T) {
 		}
 		expErr := errors.New(fmt.Sprintf("family name: [%s] is duplicated", family.Name))
 		service := families.
-			NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+			NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyFound := testdata.GetDefaultFamily()
 		pagedResponseFamilies := entities.PagedResponse{
@@ -123,7 +123,7 @@ func TestFamilyService_Create(t *testing.T) {
 		family := request.NewFamilyFromPostRequest()
 		expErr := errors.New(fmt.Sprintf("error, family %s. already exist", family.Name))
 		service := families.
-			NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+			NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyRepositoryMock.On("SearchPaged", ctx, entities.NewDefaultPagination(), entities.FamilyFilter{
 			Mccs: family.Mccs,
@@ -143,7 +143,7 @@ func TestFamilyService_Create(t *testing.T) {
 		request := testdata.GetFamilyRequest()
 		family := request.NewFamilyFromPostRequest()
 		service := families.
-			NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+			NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyRepositoryMock.On("AddFamily", ctx, &family).
 			Return(nil).Once()
@@ -183,7 +183,7 @@ func TestFamilyService_SearchPaged(t *testing.T) {
 			Paged: true,
 		}).Return(serviceResponse, nil)
 
-		service := families.NewFamilyService(configs, repositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, repositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		pagedFamilies, err := service.Get(context.TODO(), entities.NewDefaultPagination(), filter)
 
@@ -209,7 +209,7 @@ func TestFamilyService_Search(t *testing.T) {
 			Paged: false,
 		}).Return([]entities.Family{}, nil)
 
-		service := families.NewFamilyService(configs, repositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, repositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		pagedFamilies, err := service.Get(context.TODO(), entities.NewDefaultPagination(), filter)
 
@@ -232,7 +232,7 @@ func TestFamilyService_Update(t *testing.T) {
 		id := "61685179378d2ad5c3405bc5"
 
 		repository := new(mocks.FamilyRepositoryMock)
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyRepositoryMock.On("Update", ctx, id, &family).
 			Return(expErr).Once()
@@ -258,7 +258,7 @@ func TestFamilyService_Update(t *testing.T) {
 		id := "61685179378d2ad5c3405bc5-x"
 		expErr := errors.New(fmt.Sprintf("invalid format for family_id: [%s]", id))
 
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyRepositoryMock.On("SearchPaged", ctx, entities.NewDefaultPagination(), entities.FamilyFilter{
 			Mccs: family.Mccs,
@@ -284,7 +284,7 @@ func TestFamilyService_Update(t *testing.T) {
 			family.Name,
 			family.Mccs[0]))
 		repository := new(mocks.FamilyRepositoryMock)
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyFound := testdata.GetDefaultFamily()
 		pagedResponseFamilies := entities.PagedResponse{
@@ -323,7 +323,7 @@ func TestFamilyService_Update(t *testing.T) {
 			"family name: [%s] is duplicated",
 			family.Name))
 		repository := new(mocks.FamilyRepositoryMock)
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyFound := testdata.GetDefaultFamily()
 		pagedResponseFamilies := entities.PagedResponse{
@@ -360,7 +360,7 @@ func TestFamilyService_Update(t *testing.T) {
 			family.Name,
 			family.Mccs))
 		repository := new(mocks.FamilyRepositoryMock)
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyRepositoryMock.On("Update", ctx, id, &family).
 			Return(nil).Once()
@@ -387,7 +387,7 @@ func TestFamilyService_Update(t *testing.T) {
 		family := request.NewFamilyFromPutRequest()
 		id := "61685179378d2ad5c3405bc5"
 
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyRepositoryMock.On("Update", ctx, id, &family).Return(nil).Once()
 
@@ -422,9 +422,10 @@ func Test_FamilyService_Delete(t *testing.T) {
 
 		repository := new(mocks.FamilyRepositoryMock)
 		repository.On("Delete", context.TODO(), familyID).Return(expectedError)
-		service := families.NewFamilyService(configs, repository, rulesRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, repository, rulesRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
-		err := service.Delete(context.TODO(), familyID)
+		err := service.Delete(context.TODO(), familyID, "santiago.ceron@conekta.com")
 
 		assert.NotNil(t, err)
 		assert.Equal(t, err, expectedError)
@@ -441,9 +442,9 @@ func Test_FamilyService_Delete(t *testing.T) {
 			mock.AnythingOfType("entities.Pagination"),
 		).Return(entities.PagedResponse{}, expectedError)
 
-		service := families.NewFamilyService(configs, nil, rulesRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, nil, rulesRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
-		err := service.Delete(context.TODO(), familyID)
+		err := service.Delete(context.TODO(), familyID, "santiago.ceron@conekta.com")
 
 		assert.NotNil(t, err)
 		assert.Equal(t, err, expectedError)
@@ -462,9 +463,10 @@ func Test_FamilyService_Delete(t *testing.T) {
 
 		repository := new(mocks.FamilyRepositoryMock)
 		repository.On("Delete", context.TODO(), familyID).Return(nil)
-		service := families.NewFamilyService(configs, repository, rulesRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, repository, rulesRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
-		err := service.Delete(context.TODO(), familyID)
+		err := service.Delete(context.TODO(), familyID, "santiago.ceron@conekta.com")
 
 		assert.Nil(t, err)
 		repository.AssertExpectations(t)
@@ -485,9 +487,10 @@ func Test_FamilyService_Delete(t *testing.T) {
 				Data: []entities.Rule{ruleAssociated},
 			}, nil)
 
-		service := families.NewFamilyService(configs, familyRepositoryMock, rulesRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, rulesRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
-		err := service.Delete(context.TODO(), *ruleAssociated.FamilyMccID)
+		err := service.Delete(context.TODO(), *ruleAssociated.FamilyMccID, "santiago.ceron@conekta.com")
 
 		assert.Error(t, err)
 		familyRepositoryMock.AssertExpectations(t)
@@ -512,7 +515,7 @@ func TestFamilyServiceGetFamily(t *testing.T) {
 			Once().
 			Return([]entities.Family{expectedFamilies[0]}, nil)
 
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		family, err := service.GetFamily(context.TODO(), familiesFilter)
 
 		assert.NoError(t, err)
@@ -536,7 +539,7 @@ func TestFamilyServiceGetFamily(t *testing.T) {
 			Once().
 			Return([]entities.Family{}, nil)
 
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		family, err := service.GetFamily(context.TODO(), familiesFilter)
 
 		assert.NoError(t, err)
@@ -559,7 +562,7 @@ func TestFamilyServiceGetFamily(t *testing.T) {
 			Once().
 			Return([]entities.Family{}, fmt.Errorf("error getting family with mcc %s", expectedFamilies[0].Mccs))
 
-		service := families.NewFamilyService(configs, familyRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := families.NewFamilyService(configs, familyRepositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		family, err := service.GetFamily(context.TODO(), familiesFilter)
 
 		assert.Error(t, err)
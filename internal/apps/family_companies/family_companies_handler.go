@@ -133,7 +133,15 @@ func (handler *familyCompaniesHandler) Delete(ctx echo.Context) error {
 		return nil
 	}
 
-	err := handler.service.Delete(ctx.Request().Context(), familyCompaniesID)
+	author := ctx.QueryParam("author")
+	if str.IsEmpty(author) {
+		err := customHttp.NewBadRequestError("author is required")
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Delete"))
+		ctx.Error(err)
+		return nil
+	}
+
+	err := handler.service.Delete(ctx.Request().Context(), familyCompaniesID, author)
 	if err != nil {
 		ctx.Error(err)
 		return nil
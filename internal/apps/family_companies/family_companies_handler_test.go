@@ -380,7 +380,7 @@ func TestFamilyCompaniesHandler_Delete(t *testing.T) {
 		expectedError := errors.New("empty id")
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPut, familyCompaniesUri, "", "")
-		familyCompaniesServiceMock.On("Delete", context.Request().Context(), "").Return(expectedError)
+		familyCompaniesServiceMock.On("Delete", context.Request().Context(), "", "santiago.ceron@conekta.com").Return(expectedError)
 
 		handler := familycom.NewFamilyCompaniesHandler(familyCompaniesServiceMock, logger)
 		handler.Delete(context)
@@ -389,12 +389,27 @@ func TestFamilyCompaniesHandler_Delete(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, httpError.Status())
 	})
 
+	t.Run("delete family companies when author is empty", func(t *testing.T) {
+		familyCompaniesServiceMock := new(mocks.FamilyCompaniesServiceMock)
+
+		context, recorder := echo.SetupAsRecorder(http.MethodDelete, familyCompaniesUri, familyID, "")
+
+		handler := familycom.NewFamilyCompaniesHandler(familyCompaniesServiceMock, logger)
+		handler.Delete(context)
+
+		httpError, _ := customHttp.NewRestErrorFromBytes(recorder.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, httpError.Status())
+		assert.Equal(t, "author is required", httpError.Message())
+		familyCompaniesServiceMock.AssertNotCalled(t, "Delete")
+	})
+
 	t.Run("delete family companies service return error", func(t *testing.T) {
 		familyCompaniesServiceMock := new(mocks.FamilyCompaniesServiceMock)
 		expectedError := errors.New("Service error")
 
 		context, rec := echo.SetupAsRecorder(http.MethodDelete, familyCompaniesUri, familyID, "")
-		familyCompaniesServiceMock.On("Delete", context.Request().Context(), familyID).Return(expectedError).Once()
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
+		familyCompaniesServiceMock.On("Delete", context.Request().Context(), familyID, "santiago.ceron@conekta.com").Return(expectedError).Once()
 
 		handler := familycom.NewFamilyCompaniesHandler(familyCompaniesServiceMock, logger)
 		handler.Delete(context)
@@ -409,7 +424,8 @@ func TestFamilyCompaniesHandler_Delete(t *testing.T) {
 		familyCompaniesServiceMock := new(mocks.FamilyCompaniesServiceMock)
 
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, familyCompaniesUri, familyID, "")
-		familyCompaniesServiceMock.On("Delete", context.Request().Context(), familyID).Return(nil)
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
+		familyCompaniesServiceMock.On("Delete", context.Request().Context(), familyID, "santiago.ceron@conekta.com").Return(nil)
 
 		handler := familycom.NewFamilyCompaniesHandler(familyCompaniesServiceMock, logger)
 		err := handler.Delete(context)
@@ -6,11 +6,13 @@ import (
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
 	"github.com/conekta/risk-rules/internal/apps/rules"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/strings"
 	"github.com/conekta/risk-rules/pkg/text"
 )
 
@@ -19,7 +21,7 @@ const serviceName = "familycom.service.%s"
 type FamilyCompaniesService interface {
 	Create(ctx context.Context, familyCompaniesRequest entities.FamilyCompanies) error
 	GetFamiliesCompaniesFromFilter(ctx context.Context, filter entities.FamilyCompaniesFilter) ([]entities.FamilyCompanies, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, author string) error
 	Update(ctx context.Context, id string, familyCompanies entities.FamilyCompanies) error
 	Get(ctx context.Context, pagination entities.Pagination,
 		filter entities.FamilyCompaniesFilter) (interface{}, error)
@@ -31,17 +33,20 @@ type familyCompaniesService struct {
 	config                    config.Config
 	familyCompaniesRepository FamilyCompaniesRepository
 	ruleRepository            rules.RuleRepository
+	auditService              audit.AuditService
 }
 
 func NewFamilyCompaniesService(cfg config.Config,
 	familyCompaniesRepository FamilyCompaniesRepository,
 	ruleRepository rules.RuleRepository,
+	auditService audit.AuditService,
 	logger logs.Logger,
 	datadogMetric datadog.Metricer) FamilyCompaniesService {
 	return &familyCompaniesService{
 		config:                    cfg,
 		familyCompaniesRepository: familyCompaniesRepository,
 		ruleRepository:            ruleRepository,
+		auditService:              auditService,
 		logs:                      logger,
 		datadog:                   datadogMetric,
 	}
@@ -75,6 +80,8 @@ func (service *familyCompaniesService) Create(ctx context.Context, familyCompani
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFamilyCompanies, familyCompanies.ID.Hex(),
+		entities.AuditCreated, familyCompanies.CreatedBy, nil))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveListMetricName)
 
@@ -118,6 +125,7 @@ func (service *familyCompaniesService) Update(ctx context.Context, id string, fa
 		return err
 	}
 
+	before := service.auditService.Snapshot(ctx, entities.AuditFamilyCompanies, id)
 	err = service.familyCompaniesRepository.Update(ctx, id, &familyCompanies)
 	if err != nil {
 		metricData.SetResult(false)
@@ -125,6 +133,8 @@ func (service *familyCompaniesService) Update(ctx context.Context, id string, fa
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFamilyCompanies, id, entities.AuditUpdated,
+		strings.StringPointerToString(familyCompanies.UpdatedBy), before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateListMetricName)
 
@@ -153,7 +163,7 @@ func (service *familyCompaniesService) GetFamiliesCompaniesFromFilter(ctx contex
 	return familiesFound, nil
 }
 
-func (service *familyCompaniesService) Delete(ctx context.Context, id string) error {
+func (service *familyCompaniesService) Delete(ctx context.Context, id string, author string) error {
 	pagination := entities.Pagination{}
 	pagedRules, err := service.ruleRepository.
 		FindRulesPaged(ctx, entities.RuleFilter{FamilyCompanyID: id}, pagination)
@@ -173,6 +183,7 @@ func (service *familyCompaniesService) Delete(ctx context.Context, id string) er
 		return err
 	}
 
+	before := service.auditService.Snapshot(ctx, entities.AuditFamilyCompanies, id)
 	err = service.familyCompaniesRepository.Delete(ctx, id)
 	metricData := metrics.NewMetricData(ctx, "Delete", serviceName, service.config.Env)
 	if err != nil {
@@ -180,6 +191,8 @@ func (service *familyCompaniesService) Delete(ctx context.Context, id string) er
 		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteListMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFamilyCompanies, id, entities.AuditDeleted,
+		author, before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteListMetricName)
 
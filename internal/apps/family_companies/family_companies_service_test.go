@@ -28,7 +28,8 @@ func TestFamilyCompaniesService_Create(t *testing.T) {
 		familyCompanies := request.NewFamilyCompaniesFromPostRequest()
 
 		expErr := errors.New("database connection lost")
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyCompaniesRepositoryMock.On("AddFamilyCompanies",
 			ctx,
@@ -62,6 +63,7 @@ func TestFamilyCompaniesService_Create(t *testing.T) {
 		service := NewFamilyCompaniesService(configs,
 			familyCompaniesRepositoryMock,
 			nil,
+			mocks.NewAuditServiceMock(),
 			logger,
 			new(datadog.MetricsDogMock))
 
@@ -86,7 +88,8 @@ func TestFamilyCompaniesService_Create(t *testing.T) {
 		ctx := context.TODO()
 		request := testdata.GetFamilyCompaniesRequest()
 		familyCompanies := request.NewFamilyCompaniesFromPostRequest()
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyCompaniesRepositoryMock.On("GetFamilyCompanies",
 			ctx,
@@ -122,7 +125,7 @@ func TestFamilyCompaniesService_Get(t *testing.T) {
 			Paged:      false,
 		}).Return([]entities.FamilyCompanies{}, nil)
 
-		service := NewFamilyCompaniesService(configs, repositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, repositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		familyCompanies, err := service.Get(context.TODO(), entities.NewDefaultPagination(), filter)
 
@@ -150,7 +153,7 @@ func TestFamilyCompaniesService_Get(t *testing.T) {
 				Paged:      true,
 			}).Return(serviceResponse, nil)
 
-		service := NewFamilyCompaniesService(configs, repositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, repositoryMock, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		pagedFamilyCompanies, err := service.Get(context.TODO(), entities.NewDefaultPagination(), filter)
 
@@ -171,7 +174,8 @@ func TestFamilyCompaniesService_GetFamilyCompaniesFromFilter(t *testing.T) {
 		familyCompaniesFilter := testdata.GetDefaultFamilyCompaniesFilter()
 
 		expErr := errors.New("database connection lost")
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyCompaniesRepositoryMock.On("GetFamilyCompanies",
 			ctx, familyCompaniesFilter).Return([]entities.FamilyCompanies{}, expErr).Once()
@@ -188,7 +192,8 @@ func TestFamilyCompaniesService_GetFamilyCompaniesFromFilter(t *testing.T) {
 		ctx := context.TODO()
 		FamilyCompaniesFilter := testdata.GetDefaultFamilyCompaniesFilter()
 
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyCompaniesRepositoryMock.On("GetFamilyCompanies",
 			ctx, FamilyCompaniesFilter).Return([]entities.FamilyCompanies{}, nil).Once()
@@ -207,7 +212,8 @@ func TestFamilyCompaniesService_GetFamilyCompaniesFromFilter(t *testing.T) {
 		FamilyCompaniesMock := []entities.FamilyCompanies{testdata.GetDefaultFamilyCompanies()}
 		expectedFamilyCompanies := FamilyCompaniesMock
 
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyCompaniesRepositoryMock.On("GetFamilyCompanies",
 			ctx, FamilyCompaniesFilter).Return(FamilyCompaniesMock, nil).Once()
@@ -239,9 +245,10 @@ func TestFamilyCompaniesService_Delete(t *testing.T) {
 
 		familyCompaniesRepositoryMock := new(mocks.FamilyCompaniesRepositoryMock)
 		familyCompaniesRepositoryMock.On("Delete", context.TODO(), familyCompanyID).Return(expectedError)
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, rulesRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, rulesRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
-		err := service.Delete(context.TODO(), familyCompanyID)
+		err := service.Delete(context.TODO(), familyCompanyID, "santiago.ceron@conekta.com")
 
 		assert.NotNil(t, err)
 		assert.Equal(t, err, expectedError)
@@ -260,9 +267,10 @@ func TestFamilyCompaniesService_Delete(t *testing.T) {
 
 		familyCompaniesRepositoryMock := new(mocks.FamilyCompaniesRepositoryMock)
 		familyCompaniesRepositoryMock.On("Delete", context.TODO(), familyCompanyID).Return(nil)
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, rulesRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, rulesRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
-		err := service.Delete(context.TODO(), familyCompanyID)
+		err := service.Delete(context.TODO(), familyCompanyID, "santiago.ceron@conekta.com")
 
 		assert.Nil(t, err)
 		familyCompaniesRepositoryMock.AssertExpectations(t)
@@ -289,9 +297,10 @@ func TestFamilyCompaniesService_Delete(t *testing.T) {
 				Data: []entities.Rule{ruleAssociated},
 			}, nil)
 
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, rulesRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, rulesRepositoryMock, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
-		err := service.Delete(context.TODO(), *ruleAssociated.FamilyCompanyID)
+		err := service.Delete(context.TODO(), *ruleAssociated.FamilyCompanyID, "santiago.ceron@conekta.com")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), expectedError)
@@ -313,7 +322,8 @@ func TestFamilyCompaniesService_Update(t *testing.T) {
 		id := "61685179378d2ad5c3405bc5"
 
 		repository := new(mocks.FamilyCompaniesRepositoryMock)
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyCompaniesRepositoryMock.On("Update", ctx, id, &familyCompanies).
 			Return(expErr).Once()
@@ -340,7 +350,8 @@ func TestFamilyCompaniesService_Update(t *testing.T) {
 		id := "61685179378d2ad5c3405bc5-x"
 		expErr := errors.New(fmt.Sprintf("invalid format for family_company_id: [%s]", id))
 
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyCompaniesRepositoryMock.On("GetFamilyCompanies",
 			ctx,
@@ -369,7 +380,8 @@ func TestFamilyCompaniesService_Update(t *testing.T) {
 			"family companies name: [%s] is duplicated",
 			family.Name))
 		repository := new(mocks.FamilyRepositoryMock)
-		service := NewFamilyCompaniesService(configs, damilyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, damilyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyFound := testdata.GetDefaultFamilyCompanies()
 		familyCompaniesFound := []entities.FamilyCompanies{
@@ -401,7 +413,8 @@ func TestFamilyCompaniesService_Update(t *testing.T) {
 		familyCompanies := request.NewFamilyCompaniesFromPutRequest()
 		id := "61685179378d2ad5c3405bc5"
 
-		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, logger, new(datadog.MetricsDogMock))
+		service := NewFamilyCompaniesService(configs, familyCompaniesRepositoryMock, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		familyCompaniesRepositoryMock.On("Update",
 			ctx,
@@ -113,7 +113,15 @@ func (h *fieldsHandler) Delete(ctx echo.Context) error {
 		return nil
 	}
 
-	err = h.service.Delete(ctx.Request().Context(), id)
+	author := ctx.QueryParam("author")
+	if str.IsEmpty(author) {
+		err := customHttp.NewBadRequestError("author is required")
+		h.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Delete"))
+		ctx.Error(err)
+		return nil
+	}
+
+	err = h.service.Delete(ctx.Request().Context(), id, author)
 	if err != nil {
 		ctx.Error(err)
 		return nil
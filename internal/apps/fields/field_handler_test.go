@@ -331,7 +331,8 @@ func TestFieldsHandler_Delete(t *testing.T) {
 		id := "60f6f32ba0f965ae8ae2c87e"
 		request := `{}`
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, fieldsUri, id, request)
-		fieldsServiceMock.Mock.On("Delete", context.Request().Context(), id).
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
+		fieldsServiceMock.Mock.On("Delete", context.Request().Context(), id, "santiago.ceron@conekta.com").
 			Return(nil).Once()
 		handler := fields.NewFieldsHandler(configs, fieldsServiceMock, logger)
 
@@ -356,6 +357,21 @@ func TestFieldsHandler_Delete(t *testing.T) {
 		assert.True(t, strings.Contains(httpError.Message(), expectedError))
 	})
 
+	t.Run("when author is empty then return BadRequest", func(t *testing.T) {
+		fieldsServiceMock := new(mocks.FieldsServiceMock)
+		request := `{}`
+
+		context, recorder := echo.SetupAsRecorder(http.MethodDelete, fieldsUri, "60f6f32ba0f965ae8ae2c87e", request)
+		handler := fields.NewFieldsHandler(configs, fieldsServiceMock, logger)
+
+		handler.Delete(context)
+
+		httpError, _ := customHttp.NewRestErrorFromBytes(recorder.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, httpError.Status())
+		assert.Equal(t, "author is required", httpError.Message())
+		fieldsServiceMock.AssertNotCalled(t, "Delete")
+	})
+
 	t.Run("when id is a not valid ObjectID then return BadRequest", func(t *testing.T) {
 		id := "60f6f32ba0f965ae8ae2c87e-x"
 		expectedError := "invalid id"
@@ -378,9 +394,10 @@ func TestFieldsHandler_Delete(t *testing.T) {
 		request := `{}`
 
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, fieldsUri, fieldID, request)
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
 		fieldsServiceMock.Mock.On("Delete",
 			context.Request().Context(),
-			fieldID).Return(expectedError).Once()
+			fieldID, "santiago.ceron@conekta.com").Return(expectedError).Once()
 
 		handler := fields.NewFieldsHandler(configs, fieldsServiceMock, logger)
 
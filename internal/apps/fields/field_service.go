@@ -6,10 +6,12 @@ import (
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/strings"
 	"github.com/conekta/risk-rules/pkg/text"
 )
 
@@ -17,25 +19,27 @@ const serviceName = "fields.service.%s"
 
 type FieldService interface {
 	AddField(ctx context.Context, field entities.Field) error
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, author string) error
 	Update(ctx context.Context, id string, field entities.Field) error
 	GetFields(ctx context.Context, filter entities.FieldsFilter, pagination entities.Pagination) (interface{}, error)
 }
 
 type fieldService struct {
-	config     config.Config
-	repository FieldsRepository
-	logs       logs.Logger
-	datadog    datadog.Metricer
+	config       config.Config
+	repository   FieldsRepository
+	auditService audit.AuditService
+	logs         logs.Logger
+	datadog      datadog.Metricer
 }
 
-func NewFieldsService(cfg config.Config, repository FieldsRepository, logger logs.Logger,
-	metric datadog.Metricer) FieldService {
+func NewFieldsService(cfg config.Config, repository FieldsRepository, auditService audit.AuditService,
+	logger logs.Logger, metric datadog.Metricer) FieldService {
 	return &fieldService{
-		config:     cfg,
-		repository: repository,
-		logs:       logger,
-		datadog:    metric,
+		config:       cfg,
+		repository:   repository,
+		auditService: auditService,
+		logs:         logger,
+		datadog:      metric,
 	}
 }
 
@@ -60,6 +64,8 @@ func (service *fieldService) AddField(ctx context.Context, field entities.Field)
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFields, field.ID.Hex(),
+		entities.AuditCreated, field.CreatedBy, nil))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveFieldMetricName)
 	return err
@@ -77,6 +83,7 @@ func (service *fieldService) Update(ctx context.Context, id string, field entiti
 		return err
 	}
 
+	before := service.auditService.Snapshot(ctx, entities.AuditFields, id)
 	err = service.repository.Update(ctx, id, &field)
 
 	metricData := metrics.NewMetricData(ctx, "Update Field", serviceName, service.config.Env)
@@ -86,12 +93,15 @@ func (service *fieldService) Update(ctx context.Context, id string, field entiti
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFields, id, entities.AuditUpdated,
+		strings.StringPointerToString(field.UpdatedBy), before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateFieldMetricName)
 	return nil
 }
 
-func (service *fieldService) Delete(ctx context.Context, id string) error {
+func (service *fieldService) Delete(ctx context.Context, id string, author string) error {
+	before := service.auditService.Snapshot(ctx, entities.AuditFields, id)
 	err := service.repository.Delete(ctx, id)
 	metricData := metrics.NewMetricData(context.TODO(), "Delete", serviceName, service.config.Env)
 	if err != nil {
@@ -99,6 +109,8 @@ func (service *fieldService) Delete(ctx context.Context, id string) error {
 		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteFieldMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditFields, id, entities.AuditDeleted,
+		author, before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteFieldMetricName)
 	return nil
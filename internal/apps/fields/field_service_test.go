@@ -26,7 +26,7 @@ func TestFieldService_Update(t *testing.T) {
 
 	t.Run("when update on repository fails then return error", func(t *testing.T) {
 		fieldsRepositoryMock := new(mocks.FieldsRepositoryMock)
-		service := NewFieldsService(configs, fieldsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFieldsService(configs, fieldsRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		expectedError := errors.New("connection lost")
 
 		id := "60f6f32ba0f965ae8ae2c87e"
@@ -67,7 +67,7 @@ func TestFieldService_Update(t *testing.T) {
 
 	t.Run("when update ok then return nil", func(t *testing.T) {
 		fieldsRepositoryMock := new(mocks.FieldsRepositoryMock)
-		service := NewFieldsService(configs, fieldsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFieldsService(configs, fieldsRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		id := "60f6f32ba0f965ae8ae2c87e"
 		fieldRequest := entities.FieldRequest{
@@ -110,7 +110,7 @@ func TestFieldService_Delete(t *testing.T) {
 
 	t.Run("when delete on repository fails then return error", func(t *testing.T) {
 		fieldsRepositoryMock := new(mocks.FieldsRepositoryMock)
-		service := NewFieldsService(configs, fieldsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFieldsService(configs, fieldsRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		expectedError := errors.New("connection lost")
 
 		id := "60f6f32ba0f965ae8ae2c87e"
@@ -118,7 +118,7 @@ func TestFieldService_Delete(t *testing.T) {
 		fieldsRepositoryMock.Mock.On("Delete", nil, id).
 			Return(expectedError).Once()
 
-		err := service.Delete(nil, id)
+		err := service.Delete(nil, id, "santiago.ceron@conekta.com")
 
 		assert.NotNil(t, err)
 		assert.Equal(t, expectedError, err)
@@ -127,14 +127,14 @@ func TestFieldService_Delete(t *testing.T) {
 
 	t.Run("when delete ok then return nil", func(t *testing.T) {
 		fieldsRepositoryMock := new(mocks.FieldsRepositoryMock)
-		service := NewFieldsService(configs, fieldsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFieldsService(configs, fieldsRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 		id := "60f6f32ba0f965ae8ae2c87e"
 
 		fieldsRepositoryMock.Mock.On("Delete", nil, id).
 			Return(nil).Once()
 
-		err := service.Delete(nil, id)
+		err := service.Delete(nil, id, "santiago.ceron@conekta.com")
 
 		assert.Nil(t, err)
 		fieldsRepositoryMock.AssertExpectations(t)
@@ -147,7 +147,7 @@ func TestFieldService_AddField(t *testing.T) {
 
 	t.Run("on database field exist connection lost then return error", func(t *testing.T) {
 		fieldsRepositoryMock := new(mocks.FieldsRepositoryMock)
-		service := NewFieldsService(configs, fieldsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFieldsService(configs, fieldsRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		field := testdata.GetDefaultField()
 		expectedError := errors.New("database connection lost")
 
@@ -166,7 +166,7 @@ func TestFieldService_AddField(t *testing.T) {
 
 	t.Run("on database add field fails if field exist", func(t *testing.T) {
 		fieldsRepositoryMock := new(mocks.FieldsRepositoryMock)
-		service := NewFieldsService(configs, fieldsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFieldsService(configs, fieldsRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		field := testdata.GetDefaultField()
 		expectedError := exceptions.NewDuplicatedException(fmt.Sprintf("the field '%s' of type '%s' already exist", field.Name, field.Type))
 
@@ -185,7 +185,7 @@ func TestFieldService_AddField(t *testing.T) {
 
 	t.Run("on database add field connection lost then return error", func(t *testing.T) {
 		fieldsRepositoryMock := new(mocks.FieldsRepositoryMock)
-		service := NewFieldsService(configs, fieldsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFieldsService(configs, fieldsRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		field := testdata.GetDefaultField()
 		expectedError := errors.New("database connection lost")
 
@@ -210,7 +210,7 @@ func TestFieldService_AddField(t *testing.T) {
 
 	t.Run("on database add field ok then return nil", func(t *testing.T) {
 		fieldsRepositoryMock := new(mocks.FieldsRepositoryMock)
-		service := NewFieldsService(configs, fieldsRepositoryMock, logger, new(datadog.MetricsDogMock))
+		service := NewFieldsService(configs, fieldsRepositoryMock, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 		field := testdata.GetDefaultField()
 
 		fieldsRepositoryMock.Mock.On(
@@ -259,6 +259,7 @@ func TestFieldService_GetFieldsPaged(t *testing.T) {
 		service := NewFieldsService(
 			configs,
 			fieldsRepositoryMock,
+			mocks.NewAuditServiceMock(),
 			logger,
 			nil)
 
@@ -295,6 +296,7 @@ func TestFieldService_GetFields(t *testing.T) {
 		service := NewFieldsService(
 			configs,
 			fieldsRepositoryMock,
+			mocks.NewAuditServiceMock(),
 			logger,
 			nil)
 
@@ -0,0 +1,79 @@
+package ipintelligence
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+)
+
+const (
+	enricherName               = "ip"
+	ipField                    = "ip"
+	ipCardCountryMismatchField = "ip_card_country_mismatch"
+)
+
+type ipIntelligenceEnricher struct {
+	config     config.Config
+	repository IPIntelligenceRepository
+}
+
+func NewIPIntelligenceEnricher(cfg config.Config, repository IPIntelligenceRepository) entities.Enricher {
+	return &ipIntelligenceEnricher{
+		config:     cfg,
+		repository: repository,
+	}
+}
+
+func (enricher *ipIntelligenceEnricher) Name() string {
+	return enricherName
+}
+
+func (enricher *ipIntelligenceEnricher) Fields() []string {
+	return []string{ipField, ipCardCountryMismatchField}
+}
+
+func (enricher *ipIntelligenceEnricher) TimeoutMilliseconds() int {
+	return enricher.config.Enrichment.IPTimeoutMilliseconds
+}
+
+func (enricher *ipIntelligenceEnricher) FailurePolicy() entities.EnrichmentFailurePolicy {
+	return entities.EnrichmentFailurePolicy{OnFailure: entities.EnrichmentOmitFields}
+}
+
+func (enricher *ipIntelligenceEnricher) IsEnabled() bool {
+	return enricher.config.IPIntelligence.IsEnabled
+}
+
+// Enrich exposes the IP data as ip.* and compares the country of the IP with the country of the card, an empty or
+// invalid IP is not found.
+func (enricher *ipIntelligenceEnricher) Enrich(ctx context.Context,
+	charge entities.ChargeRequest) (map[string]interface{}, error) {
+	info := entities.IPInfo{}
+	found := false
+	isPrivate := false
+
+	ip := net.ParseIP(strings.TrimSpace(charge.Details.IPAddress))
+	if ip != nil {
+		var err error
+		info, found, err = enricher.repository.FindByIP(ctx, ip)
+		if err != nil {
+			return nil, err
+		}
+		isPrivate = entities.IsPrivateIP(ip)
+	}
+
+	return map[string]interface{}{
+		ipField: map[string]interface{}{
+			"found":      found,
+			"country":    info.Country,
+			"asn":        info.ASN,
+			"is_hosting": info.IsHosting,
+			"is_tor":     info.IsTor,
+			"is_private": isPrivate,
+		},
+		ipCardCountryMismatchField: entities.IsIPCountryMismatch(info.Country, charge.PaymentMethod.Country),
+	}, nil
+}
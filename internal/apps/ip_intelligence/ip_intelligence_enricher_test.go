@@ -0,0 +1,85 @@
+package ipintelligence_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	ipintelligence "github.com/conekta/risk-rules/internal/apps/ip_intelligence"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIPIntelligenceEnricher_Enrich(t *testing.T) {
+	t.Run("the IP data is exposed and compared with the card country", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		charge.Details.IPAddress = "187.190.38.10"
+		charge.PaymentMethod.Country = "US"
+		repository := new(mocks.IPIntelligenceRepositoryMock)
+		repository.On("FindByIP", mock.Anything, net.ParseIP("187.190.38.10")).
+			Return(entities.IPInfo{Country: "MX", ASN: 8151, IsHosting: true}, true, nil)
+		enricher := ipintelligence.NewIPIntelligenceEnricher(config.Config{}, repository)
+
+		got, err := enricher.Enrich(context.TODO(), charge)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"ip": map[string]interface{}{
+				"found":      true,
+				"country":    "MX",
+				"asn":        int64(8151),
+				"is_hosting": true,
+				"is_tor":     false,
+				"is_private": false,
+			},
+			"ip_card_country_mismatch": true,
+		}, got)
+	})
+
+	t.Run("private IPs are flagged even when they are not in the databases", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		charge.Details.IPAddress = "10.1.2.3"
+		repository := new(mocks.IPIntelligenceRepositoryMock)
+		repository.On("FindByIP", mock.Anything, mock.Anything).Return(entities.IPInfo{}, false, nil)
+		enricher := ipintelligence.NewIPIntelligenceEnricher(config.Config{}, repository)
+
+		got, err := enricher.Enrich(context.TODO(), charge)
+
+		assert.NoError(t, err)
+		values := got["ip"].(map[string]interface{})
+		assert.Equal(t, true, values["is_private"])
+		assert.Equal(t, false, values["found"])
+		assert.Equal(t, false, got["ip_card_country_mismatch"])
+	})
+
+	t.Run("invalid IPs are not searched", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		charge.Details.IPAddress = "not-an-ip"
+		repository := new(mocks.IPIntelligenceRepositoryMock)
+		enricher := ipintelligence.NewIPIntelligenceEnricher(config.Config{}, repository)
+
+		got, err := enricher.Enrich(context.TODO(), charge)
+
+		assert.NoError(t, err)
+		assert.Equal(t, false, got["ip"].(map[string]interface{})["found"])
+		repository.AssertNotCalled(t, "FindByIP", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository errors are returned", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		charge.Details.IPAddress = "187.190.38.10"
+		repository := new(mocks.IPIntelligenceRepositoryMock)
+		repository.On("FindByIP", mock.Anything, mock.Anything).
+			Return(entities.IPInfo{}, false, errors.New("invalid MaxMind database"))
+		enricher := ipintelligence.NewIPIntelligenceEnricher(config.Config{}, repository)
+
+		_, err := enricher.Enrich(context.TODO(), charge)
+
+		assert.EqualError(t, err, "invalid MaxMind database")
+	})
+}
@@ -0,0 +1,253 @@
+package ipintelligence
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/mmdb"
+	"github.com/conekta/risk-rules/pkg/text"
+	"github.com/gocarina/gocsv"
+)
+
+const (
+	repositoryName = "ipintelligence.repository.%s"
+	mmdbExtension  = ".mmdb"
+)
+
+type IPIntelligenceRepository interface {
+	FindByIP(ctx context.Context, ip net.IP) (entities.IPInfo, bool, error)
+	Load(ctx context.Context) error
+	Watch(ctx context.Context)
+}
+
+type ipSource interface {
+	lookup(ip net.IP) (entities.IPInfo, bool, error)
+}
+
+type mmdbSource struct {
+	reader *mmdb.Reader
+}
+
+// csvSource keeps the networks of every family by prefix length so the lookup tries the longest prefixes first.
+type csvSource struct {
+	ipv4 *prefixTable
+	ipv6 *prefixTable
+}
+
+type prefixTable struct {
+	lengths  []int
+	networks map[int]map[string]entities.IPInfo
+}
+
+type ipIntelligenceRepository struct {
+	config  config.Config
+	log     logs.Logger
+	datadog datadog.Metricer
+	mutex   sync.RWMutex
+	sources []ipSource
+}
+
+func NewIPIntelligenceRepository(cfg config.Config, logger logs.Logger,
+	metric datadog.Metricer) IPIntelligenceRepository {
+	return &ipIntelligenceRepository{
+		config:  cfg,
+		log:     logger,
+		datadog: metric,
+	}
+}
+
+// FindByIP merges what every file knows about the IP, the first file that has a value wins.
+func (r *ipIntelligenceRepository) FindByIP(_ context.Context, ip net.IP) (entities.IPInfo, bool, error) {
+	r.mutex.RLock()
+	sources := r.sources
+	r.mutex.RUnlock()
+
+	info := entities.IPInfo{}
+	found := false
+	for _, source := range sources {
+		sourceInfo, ok, err := source.lookup(ip)
+		if err != nil {
+			return entities.IPInfo{}, false, err
+		}
+		if ok {
+			info.Merge(sourceInfo)
+			found = true
+		}
+	}
+
+	return info, found, nil
+}
+
+// Load reads every configured file, the loaded files are kept when any of the new ones can not be read.
+func (r *ipIntelligenceRepository) Load(ctx context.Context) error {
+	if !r.config.IPIntelligence.IsEnabled {
+		return nil
+	}
+
+	metricData := metrics.NewMetricData(ctx, "Load", repositoryName, r.config.Env)
+	sources := make([]ipSource, 0, len(r.config.IPIntelligence.FilePaths))
+	for _, path := range r.config.IPIntelligence.FilePaths {
+		source, err := readSource(strings.TrimSpace(path))
+		if err != nil {
+			err = fmt.Errorf("reading ip database %s: %w", path, err)
+			r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Load"))
+			metricData.SetResult(false)
+			metrics.SendAsyncMetrics(r.datadog, r.log, metricData, text.IPIntelligenceSyncMetricName)
+			return err
+		}
+		sources = append(sources, source)
+	}
+
+	r.mutex.Lock()
+	r.sources = sources
+	r.mutex.Unlock()
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(r.datadog, r.log, metricData, text.IPIntelligenceSyncMetricName)
+	return nil
+}
+
+func (r *ipIntelligenceRepository) Watch(ctx context.Context) {
+	if !r.config.IPIntelligence.IsEnabled || r.config.IPIntelligence.ReloadSeconds <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(r.config.IPIntelligence.ReloadSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = r.Load(ctx)
+		}
+	}
+}
+
+func readSource(path string) (ipSource, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), mmdbExtension) {
+		reader, err := mmdb.FromBytes(content)
+		if err != nil {
+			return nil, err
+		}
+		return &mmdbSource{reader: reader}, nil
+	}
+
+	rows := make([]entities.IPInfo, 0)
+	if err = gocsv.UnmarshalBytes(content, &rows); err != nil {
+		return nil, err
+	}
+
+	return newCSVSource(rows)
+}
+
+// lookup reads the fields of the MaxMind country, ASN and anonymous IP databases.
+func (source *mmdbSource) lookup(ip net.IP) (entities.IPInfo, bool, error) {
+	record, found, err := source.reader.Lookup(ip)
+	if err != nil || !found {
+		return entities.IPInfo{}, false, err
+	}
+
+	info := entities.IPInfo{
+		Country: recordString(record, "country", "iso_code"),
+		ASN:     recordInt(record, "autonomous_system_number"),
+	}
+	if info.Country == "" {
+		info.Country = recordString(record, "registered_country", "iso_code")
+	}
+	info.IsHosting, _ = record["is_hosting_provider"].(bool)
+	info.IsTor, _ = record["is_tor_exit_node"].(bool)
+
+	return info, true, nil
+}
+
+func newCSVSource(rows []entities.IPInfo) (*csvSource, error) {
+	source := &csvSource{ipv4: newPrefixTable(), ipv6: newPrefixTable()}
+	for _, row := range rows {
+		network, err := entities.ParseNetwork(row.Network)
+		if err != nil {
+			return nil, err
+		}
+
+		row.Network = network.String()
+		length, bits := network.Mask.Size()
+		if bits == net.IPv4len*8 {
+			source.ipv4.add(length, network.IP, row)
+		} else {
+			source.ipv6.add(length, network.IP, row)
+		}
+	}
+
+	return source, nil
+}
+
+func (source *csvSource) lookup(ip net.IP) (entities.IPInfo, bool, error) {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		info, found := source.ipv4.find(ipv4)
+		return info, found, nil
+	}
+
+	info, found := source.ipv6.find(ip.To16())
+	return info, found, nil
+}
+
+func newPrefixTable() *prefixTable {
+	return &prefixTable{networks: make(map[int]map[string]entities.IPInfo)}
+}
+
+func (table *prefixTable) add(length int, ip net.IP, info entities.IPInfo) {
+	if _, ok := table.networks[length]; !ok {
+		table.networks[length] = make(map[string]entities.IPInfo)
+		table.lengths = append(table.lengths, length)
+		sort.Sort(sort.Reverse(sort.IntSlice(table.lengths)))
+	}
+	table.networks[length][ip.String()] = info
+}
+
+func (table *prefixTable) find(ip net.IP) (entities.IPInfo, bool) {
+	bits := len(ip) * 8
+	for _, length := range table.lengths {
+		if info, ok := table.networks[length][ip.Mask(net.CIDRMask(length, bits)).String()]; ok {
+			return info, true
+		}
+	}
+
+	return entities.IPInfo{}, false
+}
+
+func recordString(record map[string]interface{}, keys ...string) string {
+	var value interface{} = record
+	for _, key := range keys {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = nested[key]
+	}
+
+	result, _ := value.(string)
+	return result
+}
+
+func recordInt(record map[string]interface{}, key string) int64 {
+	number, _ := record[key].(uint64)
+	return int64(number)
+}
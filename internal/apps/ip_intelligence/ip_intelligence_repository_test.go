@@ -0,0 +1,101 @@
+package ipintelligence_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	ipintelligence "github.com/conekta/risk-rules/internal/apps/ip_intelligence"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPIntelligenceRepository_FindByIP(t *testing.T) {
+	logger, _ := logs.New()
+	cfg := config.Config{}
+	cfg.IPIntelligence.IsEnabled = true
+	cfg.IPIntelligence.FilePaths = []string{
+		writeFile(t, "countries.csv", testdata.GetRawIPCountries()),
+		writeFile(t, "threats.csv", testdata.GetRawIPThreats()),
+	}
+	repository := ipintelligence.NewIPIntelligenceRepository(cfg, logger, new(datadog.MetricsDogMock))
+	assert.NoError(t, repository.Load(context.TODO()))
+
+	t.Run("the files are merged and the longest network wins", func(t *testing.T) {
+		info, found, err := repository.FindByIP(context.TODO(), net.ParseIP("187.190.38.10"))
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, entities.IPInfo{Network: "187.190.38.0/24", Country: "MX", IsHosting: true}, info)
+	})
+
+	t.Run("single IPs and ipv6 networks are found", func(t *testing.T) {
+		info, found, err := repository.FindByIP(context.TODO(), net.ParseIP("185.220.101.1"))
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.True(t, info.IsTor)
+
+		info, found, err = repository.FindByIP(context.TODO(), net.ParseIP("2001:db8::1"))
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, int64(64500), info.ASN)
+	})
+
+	t.Run("IPs out of the networks are not found", func(t *testing.T) {
+		_, found, err := repository.FindByIP(context.TODO(), net.ParseIP("185.220.101.2"))
+
+		assert.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestIPIntelligenceRepository_Load(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("when it is disabled nothing is read", func(t *testing.T) {
+		cfg := config.Config{}
+		cfg.IPIntelligence.FilePaths = []string{"missing.csv"}
+		repository := ipintelligence.NewIPIntelligenceRepository(cfg, logger, new(datadog.MetricsDogMock))
+
+		assert.NoError(t, repository.Load(context.TODO()))
+	})
+
+	t.Run("a failed reload keeps the loaded files", func(t *testing.T) {
+		path := writeFile(t, "countries.csv", testdata.GetRawIPCountries())
+		cfg := config.Config{}
+		cfg.IPIntelligence.IsEnabled = true
+		cfg.IPIntelligence.FilePaths = []string{path}
+		repository := ipintelligence.NewIPIntelligenceRepository(cfg, logger, new(datadog.MetricsDogMock))
+		assert.NoError(t, repository.Load(context.TODO()))
+		assert.NoError(t, os.WriteFile(path, []byte("network,country\nnot-a-network,MX\n"), 0o600))
+
+		err := repository.Load(context.TODO())
+
+		assert.Error(t, err)
+		_, found, _ := repository.FindByIP(context.TODO(), net.ParseIP("187.190.1.1"))
+		assert.True(t, found)
+	})
+
+	t.Run("invalid MaxMind databases are rejected", func(t *testing.T) {
+		cfg := config.Config{}
+		cfg.IPIntelligence.IsEnabled = true
+		cfg.IPIntelligence.FilePaths = []string{writeFile(t, "country.mmdb", testdata.GetRawIPCountries())}
+		repository := ipintelligence.NewIPIntelligenceRepository(cfg, logger, new(datadog.MetricsDogMock))
+
+		err := repository.Load(context.TODO())
+
+		assert.ErrorContains(t, err, "invalid MaxMind database")
+	})
+}
+
+func writeFile(t *testing.T, name string, content []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, content, 0o600))
+	return path
+}
@@ -0,0 +1,129 @@
+package lists
+
+import (
+	"fmt"
+	"net/http"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/entities"
+	str "github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+	"github.com/labstack/echo/v4"
+)
+
+const handlerName = "lists.handler.%s"
+
+type ListsHandler interface {
+	Create(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+	Get(ctx echo.Context) error
+}
+
+type listsHandler struct {
+	logs    logs.Logger
+	service ListsService
+}
+
+func NewListsHandler(service ListsService, logger logs.Logger) ListsHandler {
+	return &listsHandler{
+		logs:    logger,
+		service: service,
+	}
+}
+
+func (handler *listsHandler) Create(ctx echo.Context) error {
+	request, err := handler.bindRequest(ctx, "Create")
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	list, err := handler.service.Create(ctx.Request().Context(), request.NewListFromPostRequest())
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusCreated, list)
+}
+
+func (handler *listsHandler) Get(ctx echo.Context) error {
+	var filter entities.ListFilter
+	ctx.Bind(&filter)
+
+	foundLists, err := handler.service.Get(ctx.Request().Context(), filter)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, foundLists)
+}
+
+func (handler *listsHandler) Update(ctx echo.Context) error {
+	listID := ctx.Param("id")
+	if str.IsEmpty(listID) {
+		err := customHttp.NewBadRequestError("id cannot be empty to update a list")
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Update"))
+		ctx.Error(err)
+		return nil
+	}
+
+	request, err := handler.bindRequest(ctx, "Update")
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	err = handler.service.Update(ctx.Request().Context(), listID, request.NewListFromPutRequest())
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (handler *listsHandler) Delete(ctx echo.Context) error {
+	listID := ctx.Param("id")
+	if str.IsEmpty(listID) {
+		err := customHttp.NewBadRequestError("error: id cannot be empty to delete a list")
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Delete"))
+		ctx.Error(err)
+		return nil
+	}
+
+	err := handler.service.Delete(ctx.Request().Context(), listID)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (handler *listsHandler) bindRequest(ctx echo.Context,
+	methodName string) (*entities.ListRequest, error) {
+	request := new(entities.ListRequest)
+	if err := ctx.Bind(request); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		return nil, err
+	}
+
+	if err := ctx.Validate(request); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		return nil, err
+	}
+
+	return request, nil
+}
@@ -0,0 +1,112 @@
+package lists_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/lists"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/echo"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const listsUri = "/risk-rules/v1/lists"
+
+func TestListsHandler_Create(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("when the ip is not a valid range, then return BadRequest", func(t *testing.T) {
+		request := testdata.GetListRequest()
+		request.Field = entities.IPField
+		request.Value = "187.190.38.0/33"
+		bodyBytes, _ := json.Marshal(request)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, listsUri, "", string(bodyBytes))
+		handler := lists.NewListsHandler(nil, logger)
+
+		handler.Create(context)
+
+		restError, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.True(t, strings.Contains(restError.Message(), "invalid ip or cidr range"))
+	})
+
+	t.Run("when a global list has company, then return BadRequest", func(t *testing.T) {
+		request := testdata.GetListRequest()
+		isGlobal := true
+		request.IsGlobal = &isGlobal
+		bodyBytes, _ := json.Marshal(request)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, listsUri, "", string(bodyBytes))
+		handler := lists.NewListsHandler(nil, logger)
+
+		handler.Create(context)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when request is valid, then return Created", func(t *testing.T) {
+		serviceMock := new(mocks.ListsServiceMock)
+		bodyBytes, _ := json.Marshal(testdata.GetListRequest())
+		context, rec := echo.SetupAsRecorder(http.MethodPost, listsUri, "", string(bodyBytes))
+		handler := lists.NewListsHandler(serviceMock, logger)
+
+		serviceMock.On("Create", mock.Anything, mock.AnythingOfType("entities.List")).
+			Return(testdata.GetDefaultBlackList(false), nil).Once()
+
+		handler.Create(context)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		serviceMock.AssertExpectations(t)
+	})
+}
+
+func TestListsHandler_Update(t *testing.T) {
+	logger, _ := logs.New()
+	serviceMock := new(mocks.ListsServiceMock)
+	bodyBytes, _ := json.Marshal(testdata.GetListRequest())
+	context, rec := echo.SetupAsRecorder(http.MethodPut, listsUri, "6353f4a1b0e2d3c4f5a6b7c8", string(bodyBytes))
+	handler := lists.NewListsHandler(serviceMock, logger)
+
+	serviceMock.On("Update", mock.Anything, "6353f4a1b0e2d3c4f5a6b7c8", mock.AnythingOfType("entities.List")).
+		Return(nil).Once()
+
+	handler.Update(context)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestListsHandler_Delete(t *testing.T) {
+	logger, _ := logs.New()
+	serviceMock := new(mocks.ListsServiceMock)
+	context, rec := echo.SetupAsRecorder(http.MethodDelete, listsUri, "6353f4a1b0e2d3c4f5a6b7c8", "")
+	handler := lists.NewListsHandler(serviceMock, logger)
+
+	serviceMock.On("Delete", mock.Anything, "6353f4a1b0e2d3c4f5a6b7c8").Return(nil).Once()
+
+	handler.Delete(context)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestListsHandler_Get(t *testing.T) {
+	logger, _ := logs.New()
+	serviceMock := new(mocks.ListsServiceMock)
+	context, rec := echo.SetupAsRecorder(http.MethodGet, listsUri, "", "")
+	handler := lists.NewListsHandler(serviceMock, logger)
+
+	serviceMock.On("Get", mock.Anything, entities.ListFilter{}).
+		Return([]entities.List{testdata.GetDefaultBlackList(false)}, nil).Once()
+
+	handler.Get(context)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,216 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	http "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const repositoryName = "lists.repository.mongo.%s"
+
+type ListsRepository interface {
+	Add(ctx context.Context, list *entities.List) error
+	Update(ctx context.Context, id string, list entities.List) error
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, filter entities.ListFilter) ([]entities.List, error)
+	SearchByCharge(ctx context.Context, listsSearch entities.ListsSearch) ([]entities.List, error)
+}
+
+type listsMongoDBRepository struct {
+	config  config.Config
+	mongodb mongodb.MongoDBier
+	log     logs.Logger
+}
+
+func NewListsMongoDBRepository(cfg config.Config, mongoDBier mongodb.MongoDBier, logger logs.Logger) ListsRepository {
+	return &listsMongoDBRepository{
+		config:  cfg,
+		mongodb: mongoDBier,
+		log:     logger,
+	}
+}
+
+func (r *listsMongoDBRepository) Add(ctx context.Context, list *entities.List) error {
+	result, err := r.mongodb.Collection(r.config.MongoDB.Collections.Lists).InsertOne(ctx, list)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Add"))
+		return err
+	}
+
+	list.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *listsMongoDBRepository) Update(ctx context.Context, id string, list entities.List) error {
+	listID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Update"), text.ListID, id)
+		return err
+	}
+
+	update := bson.D{
+		{Key: "$set",
+			Value: bson.D{
+				primitive.E{Key: "company_id", Value: list.CompanyID},
+				primitive.E{Key: "is_global", Value: list.IsGlobal},
+				primitive.E{Key: "is_test", Value: list.IsTest},
+				primitive.E{Key: "type", Value: list.Type},
+				primitive.E{Key: "decision", Value: list.Decision},
+				primitive.E{Key: "field", Value: list.Field},
+				primitive.E{Key: "value", Value: list.Value},
+				primitive.E{Key: "range_start", Value: list.RangeStart},
+				primitive.E{Key: "range_end", Value: list.RangeEnd},
+				primitive.E{Key: "rule", Value: list.Rule},
+				primitive.E{Key: "description", Value: list.Description},
+				primitive.E{Key: "time_to_live", Value: list.TimeToLive},
+				primitive.E{Key: "expires", Value: list.Expires},
+				primitive.E{Key: "updated_at", Value: list.UpdatedAt},
+				primitive.E{Key: "updated_by", Value: list.UpdatedBy},
+			},
+		},
+	}
+
+	result, err := r.mongodb.Collection(r.config.MongoDB.Collections.Lists).
+		UpdateOne(ctx, bson.M{"_id": listID}, update)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Update"))
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		err = exceptions.NewNotFoundException(fmt.Sprintf("error: list not found: '%s'", id))
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Update"))
+		return err
+	}
+
+	return nil
+}
+
+func (r *listsMongoDBRepository) Delete(ctx context.Context, id string) error {
+	listID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Delete"), text.ListID, id)
+		return err
+	}
+
+	result, err := r.mongodb.Collection(r.config.MongoDB.Collections.Lists).DeleteOne(ctx, bson.M{"_id": listID})
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Delete"))
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		err = exceptions.NewNotFoundException(fmt.Sprintf("error: list not found: '%s'", id))
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Delete"))
+		return err
+	}
+
+	return nil
+}
+
+func (r *listsMongoDBRepository) Search(ctx context.Context, filter entities.ListFilter) ([]entities.List, error) {
+	query := bson.M{}
+
+	if !strings.IsEmpty(filter.ID) {
+		ID, err := primitive.ObjectIDFromHex(filter.ID)
+		if err != nil {
+			err = http.NewBadRequestError(fmt.Sprintf("error: invalid id of list: '%s'", filter.ID))
+			r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Search"))
+			return nil, err
+		}
+		query["_id"] = ID
+	}
+
+	if !strings.IsEmpty(filter.CompanyID) {
+		query["company_id"] = filter.CompanyID
+	}
+
+	if !strings.IsEmpty(filter.Type) {
+		query["type"] = filter.Type
+	}
+
+	if !strings.IsEmpty(filter.Field) {
+		query["field"] = filter.Field
+	}
+
+	if !strings.IsEmpty(filter.Value) {
+		query["value"] = filter.Value
+	}
+
+	if filter.IsGlobal {
+		query["is_global"] = true
+	}
+
+	return r.find(ctx, query, "Search")
+}
+
+// SearchByCharge finds the lists of the company and the global ones that have a key of the charge and are not expired
+// yet, the TTL index removes the expired lists with a delay. The lists of a range key are found by their bounds.
+func (r *listsMongoDBRepository) SearchByCharge(ctx context.Context,
+	listsSearch entities.ListsSearch) ([]entities.List, error) {
+	keys := make([]bson.M, 0)
+	for _, lookup := range listsSearch.Lookups() {
+		if keyType, _ := entities.GetListKeyType(lookup.Field); keyType.Match == entities.ListMatchRange {
+			keys = append(keys, buildRangeFilter(lookup))
+			continue
+		}
+		keys = append(keys, bson.M{"field": lookup.Field, "value": bson.M{"$in": lookup.Values}})
+	}
+
+	if len(keys) == 0 {
+		return []entities.List{}, nil
+	}
+
+	scopes := []bson.M{{"is_global": true}}
+	if !strings.IsEmpty(listsSearch.CompanyID) {
+		scopes = append(scopes, bson.M{"company_id": listsSearch.CompanyID})
+	}
+
+	query := bson.M{
+		"$and": []bson.M{
+			{"$or": scopes},
+			{"$or": keys},
+			{"$or": []bson.M{{"expires": nil}, {"expires": bson.M{"$gt": time.Now().UTC()}}}},
+		},
+	}
+
+	return r.find(ctx, query, "SearchByCharge")
+}
+
+// buildRangeFilter matches the ranges that contain the range key of the lookup, the lists stored before the ranges
+// had bounds are found too and matched once they are read.
+func buildRangeFilter(lookup entities.ListLookup) bson.M {
+	key := lookup.Values[0]
+	return bson.M{"field": lookup.Field, "$or": []bson.M{
+		{"range_start": bson.M{"$lte": key}, "range_end": bson.M{"$gte": key}},
+		{"range_start": nil},
+	}}
+}
+
+func (r *listsMongoDBRepository) find(ctx context.Context, query bson.M, methodName string) ([]entities.List, error) {
+	lists := make([]entities.List, 0)
+
+	cur, err := r.mongodb.Collection(r.config.MongoDB.Collections.Lists).Find(ctx, query)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, methodName))
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &lists); err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, methodName))
+		return nil, err
+	}
+
+	return lists, nil
+}
@@ -2,16 +2,27 @@ package lists
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/metrics"
 	"github.com/conekta/risk-rules/pkg/rest"
+	"github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
 )
 
+const serviceMethodName = "lists.service.%s"
+
 type ListsService interface {
 	GetLists(ctx context.Context, listsSearch entities.ListsSearch) ([]entities.List, error)
+	Create(ctx context.Context, list entities.List) (entities.List, error)
+	Update(ctx context.Context, id string, list entities.List) error
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, filter entities.ListFilter) ([]entities.List, error)
 }
 
 type listsService struct {
@@ -19,18 +30,118 @@ type listsService struct {
 	logs        logs.Logger
 	datadog     datadog.Metricer
 	listsClient rest.RkListsClient
+	repository  ListsRepository
 }
 
 func NewListsService(cfg config.Config, logger logs.Logger, datadogMetric datadog.Metricer,
-	client rest.RkListsClient) ListsService {
+	client rest.RkListsClient, repository ListsRepository) ListsService {
 	return &listsService{
 		config:      cfg,
 		logs:        logger,
 		datadog:     datadogMetric,
 		listsClient: client,
+		repository:  repository,
 	}
 }
 
+// GetLists searches the lists of the charge in the lists collection when the source is local, otherwise in rk-lists,
+// every list says which key of the charge it matched.
 func (service *listsService) GetLists(ctx context.Context, listsSearch entities.ListsSearch) ([]entities.List, error) {
-	return service.listsClient.ListsSearch(ctx, listsSearch)
+	if service.config.Lists.Source != entities.ListsSourceLocal {
+		foundLists, err := service.listsClient.ListsSearch(ctx, listsSearch)
+		for i := range foundLists {
+			foundLists[i].SetMatchedKey(listsSearch)
+		}
+		return foundLists, err
+	}
+
+	foundLists, err := service.repository.SearchByCharge(ctx, listsSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedLists := make([]entities.List, 0, len(foundLists))
+	for _, list := range foundLists {
+		if !list.Match(listsSearch) {
+			continue
+		}
+		list.SetMatchedKey(listsSearch)
+		matchedLists = append(matchedLists, list)
+	}
+
+	return matchedLists, nil
+}
+
+func (service *listsService) Create(ctx context.Context, list entities.List) (entities.List, error) {
+	metricData := metrics.NewMetricData(ctx, "Create", serviceMethodName, service.config.Env)
+
+	err := service.validateDuplicated(ctx, strings.Empty, list)
+	if err == nil {
+		err = service.repository.Add(ctx, &list)
+	}
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveListMetricName)
+		return entities.List{}, err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveListMetricName)
+	return list, nil
+}
+
+func (service *listsService) Update(ctx context.Context, id string, list entities.List) error {
+	metricData := metrics.NewMetricData(ctx, "Update", serviceMethodName, service.config.Env)
+
+	err := service.validateDuplicated(ctx, id, list)
+	if err == nil {
+		err = service.repository.Update(ctx, id, list)
+	}
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateListMetricName)
+		return err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateListMetricName)
+	return nil
+}
+
+func (service *listsService) Delete(ctx context.Context, id string) error {
+	metricData := metrics.NewMetricData(ctx, "Delete", serviceMethodName, service.config.Env)
+
+	err := service.repository.Delete(ctx, id)
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteListMetricName)
+		return err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteListMetricName)
+	return nil
+}
+
+func (service *listsService) Get(ctx context.Context, filter entities.ListFilter) ([]entities.List, error) {
+	return service.repository.Search(ctx, filter)
+}
+
+func (service *listsService) validateDuplicated(ctx context.Context, id string, list entities.List) error {
+	listsFound, err := service.repository.Search(ctx, list.GetListFilter())
+	if err != nil {
+		return err
+	}
+
+	for _, listFound := range listsFound {
+		if listFound.IsGlobal == list.IsGlobal && !listFound.IsTheSame(id) {
+			err = exceptions.NewDuplicatedException(
+				fmt.Sprintf("a %s with %s %s already exists with id [%s]", list.Type, list.Field, list.Value,
+					listFound.ID.Hex()))
+			service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(serviceMethodName, "validateDuplicated"))
+			return err
+		}
+	}
+
+	return nil
 }
@@ -10,10 +10,13 @@ import (
 
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/conekta/risk-rules/test/mocks"
 	"github.com/conekta/risk-rules/test/mocks/datadog"
 	"github.com/conekta/risk-rules/test/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func Test_ServiceList_Add(t *testing.T) {
@@ -25,7 +28,7 @@ func Test_ServiceList_Add(t *testing.T) {
 		ctx := context.TODO()
 		request := testdata.GetDefaultRequestLists()
 
-		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), listServiceMock)
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), listServiceMock, nil)
 		expectedList := testdata.GetDefaultWhiteList(true)
 
 		listServiceMock.Mock.On("ListsSearch", ctx, request).
@@ -44,7 +47,7 @@ func Test_ServiceList_Add(t *testing.T) {
 		expErr := errors.New("service connection lost")
 		request := testdata.GetDefaultRequestLists()
 
-		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), listServiceMock)
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), listServiceMock, nil)
 
 		listServiceMock.On("ListsSearch", ctx, request).
 			Return([]entities.List{}, expErr).Once()
@@ -57,3 +60,139 @@ func Test_ServiceList_Add(t *testing.T) {
 		listServiceMock.AssertExpectations(t)
 	})
 }
+
+func TestListsService_GetLists(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+	configs.Lists.Source = entities.ListsSourceLocal
+
+	t.Run("when the source is local, then search the repository and say which key matched", func(t *testing.T) {
+		repositoryMock := new(mocks.ListsRepositoryMock)
+		clientMock := new(mocks.RkListsRestClient)
+		ctx := context.TODO()
+		request := testdata.GetDefaultRequestLists()
+		request.IPAddress = "187.190.38.10"
+		emailList := testdata.GetDefaultBlackList(false)
+		ipList := testdata.GetDefaultIPBlackList("187.190.38.0/24")
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), clientMock, repositoryMock)
+
+		repositoryMock.On("SearchByCharge", ctx, request).
+			Return([]entities.List{emailList, ipList, testdata.GetDefaultIPBlackList("10.0.0.0/8")}, nil).Once()
+
+		foundLists, err := service.GetLists(ctx, request)
+
+		emailList.MatchedKey = "email:me@gmail.com"
+		ipList.MatchedKey = "ip:187.190.38.10"
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.List{emailList, ipList}, foundLists)
+		repositoryMock.AssertExpectations(t)
+		clientMock.AssertNotCalled(t, "ListsSearch", ctx, request)
+	})
+
+	t.Run("when the repository fails, then return error", func(t *testing.T) {
+		repositoryMock := new(mocks.ListsRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetDefaultRequestLists()
+		expErr := errors.New("database connection lost")
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), nil, repositoryMock)
+
+		repositoryMock.On("SearchByCharge", ctx, request).Return([]entities.List{}, expErr).Once()
+
+		_, err := service.GetLists(ctx, request)
+
+		assert.Equal(t, expErr, err)
+		repositoryMock.AssertExpectations(t)
+	})
+}
+
+func TestListsService_Create(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+
+	t.Run("when the list does not exist, then create it", func(t *testing.T) {
+		repositoryMock := new(mocks.ListsRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetListRequest()
+		list := request.NewListFromPostRequest()
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), nil, repositoryMock)
+
+		repositoryMock.On("Search", ctx, list.GetListFilter()).Return([]entities.List{}, nil).Once()
+		repositoryMock.On("Add", ctx, mock.AnythingOfType("*entities.List")).Return(nil).Once()
+
+		created, err := service.Create(ctx, list)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.Declined, created.Decision)
+		assert.NotNil(t, created.Expires)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("when the list already exists, then return duplicated error", func(t *testing.T) {
+		repositoryMock := new(mocks.ListsRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetListRequest()
+		list := request.NewListFromPostRequest()
+		found := testdata.GetDefaultBlackList(false)
+		found.ID = primitive.NewObjectID()
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), nil, repositoryMock)
+
+		repositoryMock.On("Search", ctx, list.GetListFilter()).Return([]entities.List{found}, nil).Once()
+
+		_, err := service.Create(ctx, list)
+
+		assert.IsType(t, exceptions.NewDuplicatedException(""), err)
+		repositoryMock.AssertNotCalled(t, "Add", ctx, mock.Anything)
+	})
+
+	t.Run("when a global list has the same value, then create the company list", func(t *testing.T) {
+		repositoryMock := new(mocks.ListsRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetListRequest()
+		list := request.NewListFromPostRequest()
+		globalList := testdata.GetDefaultBlackList(false)
+		globalList.IsGlobal = true
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), nil, repositoryMock)
+
+		repositoryMock.On("Search", ctx, list.GetListFilter()).Return([]entities.List{globalList}, nil).Once()
+		repositoryMock.On("Add", ctx, mock.AnythingOfType("*entities.List")).Return(nil).Once()
+
+		_, err := service.Create(ctx, list)
+
+		assert.NoError(t, err)
+		repositoryMock.AssertExpectations(t)
+	})
+}
+
+func TestListsService_UpdateAndDelete(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+	id := "6353f4a1b0e2d3c4f5a6b7c8"
+
+	t.Run("when the list is updated with its own value, then update it", func(t *testing.T) {
+		repositoryMock := new(mocks.ListsRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetListRequest()
+		list := request.NewListFromPutRequest()
+		found := testdata.GetDefaultBlackList(false)
+		found.ID, _ = primitive.ObjectIDFromHex(id)
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), nil, repositoryMock)
+
+		repositoryMock.On("Search", ctx, list.GetListFilter()).Return([]entities.List{found}, nil).Once()
+		repositoryMock.On("Update", ctx, id, list).Return(nil).Once()
+
+		assert.NoError(t, service.Update(ctx, id, list))
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("when the list does not exist, then delete returns not found", func(t *testing.T) {
+		repositoryMock := new(mocks.ListsRepositoryMock)
+		ctx := context.TODO()
+		expErr := exceptions.NewNotFoundException("error: list not found")
+		service := lists.NewListsService(configs, logger, new(datadog.MetricsDogMock), nil, repositoryMock)
+
+		repositoryMock.On("Delete", ctx, id).Return(expErr).Once()
+
+		assert.Equal(t, expErr, service.Delete(ctx, id))
+		repositoryMock.AssertExpectations(t)
+	})
+}
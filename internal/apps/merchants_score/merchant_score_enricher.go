@@ -0,0 +1,59 @@
+package merchantsscore
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/rest"
+)
+
+const (
+	enricherName       = "merchant_score"
+	merchantScoreField = "merchant_score"
+)
+
+type merchantScoreEnricher struct {
+	config     config.Config
+	repository MerchantsScoreRepository
+}
+
+func NewMerchantScoreEnricher(cfg config.Config, repository MerchantsScoreRepository) entities.Enricher {
+	return &merchantScoreEnricher{
+		config:     cfg,
+		repository: repository,
+	}
+}
+
+func (enricher *merchantScoreEnricher) Name() string {
+	return enricherName
+}
+
+func (enricher *merchantScoreEnricher) Fields() []string {
+	return []string{merchantScoreField}
+}
+
+func (enricher *merchantScoreEnricher) TimeoutMilliseconds() int {
+	return enricher.config.Enrichment.MerchantScoreTimeoutMilliseconds
+}
+
+func (enricher *merchantScoreEnricher) FailurePolicy() entities.EnrichmentFailurePolicy {
+	return entities.EnrichmentFailurePolicy{
+		OnFailure: entities.EnrichmentUseDefaults,
+		Defaults:  map[string]interface{}{merchantScoreField: rest.DefaultScore},
+	}
+}
+
+func (enricher *merchantScoreEnricher) IsEnabled() bool {
+	return enricher.config.MerchantScore.IsEnabled
+}
+
+func (enricher *merchantScoreEnricher) Enrich(ctx context.Context,
+	charge entities.ChargeRequest) (map[string]interface{}, error) {
+	merchantScore, err := enricher.repository.FindByMerchantID(ctx, charge.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{merchantScoreField: merchantScore.Score}, nil
+}
@@ -227,7 +227,8 @@ func TestDelete_WhenProcessedOkThenReturnStatusOK(t *testing.T) {
 	id := "60f6f32ba0f965ae8ae2c87e"
 	request := `{}`
 	context, recorder := echo.SetupAsRecorder(http.MethodGet, modulesUri, id, request)
-	modulesServiceMock.Mock.On("Delete", context.Request().Context(), id).
+	context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
+	modulesServiceMock.Mock.On("Delete", context.Request().Context(), id, "santiago.ceron@conekta.com").
 		Return(nil).Once()
 	handler := modules.NewModuleHandler(modulesServiceMock, logger)
 
@@ -245,9 +246,10 @@ func TestDelete_WhenServiceFailsThenReturnError(t *testing.T) {
 	request := `{}`
 
 	context, recorder := echo.SetupAsRecorder(http.MethodDelete, modulesUri, "60f6f32ba0f965ae8ae2c87e", request)
+	context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
 	modulesServiceMock.Mock.On("Delete",
 		context.Request().Context(),
-		"60f6f32ba0f965ae8ae2c87e").Return(expectedError).Once()
+		"60f6f32ba0f965ae8ae2c87e", "santiago.ceron@conekta.com").Return(expectedError).Once()
 	handler := modules.NewModuleHandler(modulesServiceMock, logger)
 
 	handler.Delete(context)
@@ -273,6 +275,23 @@ func TestDelete_WhenIdIsEmptyThenReturnBadRequest(t *testing.T) {
 	assert.True(t, strings.Contains(restError.Message(), expectedError))
 }
 
+func TestDelete_WhenAuthorIsEmptyThenReturnBadRequest(t *testing.T) {
+	logger, _ := logs.New()
+	modulesServiceMock := new(mocks.ModuleServiceMock)
+	request := `{}`
+
+	context, rec := echo.SetupAsRecorder(http.MethodDelete, modulesUri, "60f6f32ba0f965ae8ae2c87e", request)
+	handler := modules.NewModuleHandler(modulesServiceMock, logger)
+
+	handler.Delete(context)
+
+	restError, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "author is required", restError.Message())
+	modulesServiceMock.AssertNotCalled(t, "Delete")
+}
+
 func TestModuleHandler_GetAll(t *testing.T) {
 	logger, _ := logs.New()
 
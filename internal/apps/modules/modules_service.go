@@ -6,6 +6,7 @@ import (
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
@@ -19,15 +20,16 @@ const serviceName = "modules.service.%s"
 type ModuleService interface {
 	Add(ctx context.Context, module entities.Module) error
 	GetAll(ctx context.Context, pagination entities.Pagination, filter entities.ModuleFilter) (interface{}, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, author string) error
 	Update(ctx context.Context, id string, module entities.Module) error
 }
 
 type moduleService struct {
-	repository ModuleRepository
-	logs       logs.Logger
-	metrics    datadog.Metricer
-	config     config.Config
+	repository   ModuleRepository
+	auditService audit.AuditService
+	logs         logs.Logger
+	metrics      datadog.Metricer
+	config       config.Config
 }
 
 func (service *moduleService) Add(ctx context.Context, module entities.Module) error {
@@ -52,6 +54,8 @@ func (service *moduleService) Add(ctx context.Context, module entities.Module) e
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditModules, module.ID.Hex(),
+		entities.AuditCreated, module.CreatedBy, nil))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.metrics, service.logs, metricData, text.SaveModuleMetricName)
 	return nil
@@ -70,6 +74,7 @@ func (service *moduleService) Update(ctx context.Context, id string, module enti
 		return err
 	}
 
+	before := service.auditService.Snapshot(ctx, entities.AuditModules, id)
 	err = service.repository.Update(ctx, id, module)
 
 	metricData := metrics.NewMetricData(ctx, "Update Module", serviceName, service.config.Env)
@@ -78,13 +83,16 @@ func (service *moduleService) Update(ctx context.Context, id string, module enti
 		metrics.SendAsyncMetrics(service.metrics, service.logs, metricData, text.UpdateModuleMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditModules, id, entities.AuditUpdated,
+		strings.StringPointerToString(module.UpdatedBy), before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.metrics, service.logs, metricData, text.UpdateModuleMetricName)
 
 	return nil
 }
 
-func (service *moduleService) Delete(ctx context.Context, id string) error {
+func (service *moduleService) Delete(ctx context.Context, id string, author string) error {
+	before := service.auditService.Snapshot(ctx, entities.AuditModules, id)
 	err := service.repository.Delete(ctx, id)
 
 	metricData := metrics.NewMetricData(ctx, "Delete Module", serviceName, service.config.Env)
@@ -93,18 +101,22 @@ func (service *moduleService) Delete(ctx context.Context, id string) error {
 		metrics.SendAsyncMetrics(service.metrics, service.logs, metricData, text.DeleteModuleMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditModules, id, entities.AuditDeleted,
+		author, before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.metrics, service.logs, metricData, text.DeleteModuleMetricName)
 
 	return nil
 }
 
-func NewModuleService(conf config.Config, repo ModuleRepository, logger logs.Logger, metric datadog.Metricer) ModuleService {
+func NewModuleService(conf config.Config, repo ModuleRepository, auditService audit.AuditService, logger logs.Logger,
+	metric datadog.Metricer) ModuleService {
 	return &moduleService{
-		repository: repo,
-		logs:       logger,
-		metrics:    metric,
-		config:     conf,
+		repository:   repo,
+		auditService: auditService,
+		logs:         logger,
+		metrics:      metric,
+		config:       conf,
 	}
 }
 
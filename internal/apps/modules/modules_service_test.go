@@ -14,6 +14,7 @@ import (
 	"github.com/conekta/risk-rules/test/mocks"
 	"github.com/conekta/risk-rules/test/mocks/datadog"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -33,7 +34,7 @@ func getModule() entities.Module {
 func TestServiceAdd_WhenSearchOnFindRepositoryFailsThenReturnError(t *testing.T) {
 
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, nil, nil)
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), nil, nil)
 	expectedError := errors.New("connection lost")
 	module := getModule()
 
@@ -50,7 +51,7 @@ func TestServiceAdd_WhenSearchOnFindRepositoryFailsThenReturnError(t *testing.T)
 func TestAdd_WhenFoundADuplicatedModuleThenReturnError(t *testing.T) {
 	logger, _ := logs.New()
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, logger, nil)
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, nil)
 	module := getModule()
 	modulesFound := make([]entities.Module, 0)
 	modulesFound = append(modulesFound, module)
@@ -68,7 +69,7 @@ func TestAdd_WhenFoundADuplicatedModuleThenReturnError(t *testing.T) {
 func TestAdd_WhenSaveOnRepositoryFailsThenReturnError(t *testing.T) {
 	logger, _ := logs.New()
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, logger, new(datadog.MetricsDogMock))
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 	module := getModule()
 	expectedError := errors.New("connection lost")
 
@@ -87,7 +88,7 @@ func TestAdd_WhenSaveOnRepositoryFailsThenReturnError(t *testing.T) {
 func TestAdd_WhenIsSavedOkThenReturnNil(t *testing.T) {
 	logger, _ := logs.New()
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, logger, new(datadog.MetricsDogMock))
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 	module := getModule()
 
 	modulesRepository.Mock.On("Get", nil, module.GetModuleFilter(false)).
@@ -104,7 +105,7 @@ func TestAdd_WhenIsSavedOkThenReturnNil(t *testing.T) {
 func TestUpdate_WhenUpdateOnRepositoryFailsThenReturnError(t *testing.T) {
 	logger, _ := logs.New()
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, logger, new(datadog.MetricsDogMock))
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 	expectedError := errors.New("connection lost")
 
 	id := "60f6f32ba0f965ae8ae2c87e"
@@ -137,7 +138,7 @@ func TestUpdate_WhenUpdateOnRepositoryFailsThenReturnError(t *testing.T) {
 func TestUpdate_WhenUpdateOkThenReturnNil(t *testing.T) {
 	logger, _ := logs.New()
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, logger, new(datadog.MetricsDogMock))
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 	id := "60f6f32ba0f965ae8ae2c87e"
 	moduleRequest := entities.ModuleRequest{
@@ -186,7 +187,7 @@ func TestUpdate_WhenUpdateFindByNameReturnError(t *testing.T) {
 	modulesRepository.On("Get", nil, module.GetModuleFilter(false)).
 		Return([]entities.Module{}, expectedError).Once()
 
-	service := NewModuleService(config.Config{}, modulesRepository, logger, nil)
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, nil)
 
 	err := service.Update(nil, id, module)
 
@@ -199,7 +200,7 @@ func TestUpdate_WhenUpdateFindByNameReturnError(t *testing.T) {
 func TestUpdate_WhenFoundADuplicatedModuleThenReturnError(t *testing.T) {
 	logger, _ := logs.New()
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, logger, nil)
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, nil)
 	module := getModule()
 	id := "60f6f32ba0f965ae8ae2c87e"
 	modulesFound := make([]entities.Module, 0)
@@ -219,7 +220,7 @@ func TestUpdate_WhenFoundADuplicatedModuleThenReturnError(t *testing.T) {
 func TestDelete_WhenDeleteOnRepositoryFailsThenReturnError(t *testing.T) {
 	logger, _ := logs.New()
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, logger, new(datadog.MetricsDogMock))
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 	expectedError := errors.New("connection lost")
 
 	id := "60f6f32ba0f965ae8ae2c87e"
@@ -227,7 +228,7 @@ func TestDelete_WhenDeleteOnRepositoryFailsThenReturnError(t *testing.T) {
 	modulesRepository.Mock.On("Delete", nil, id).
 		Return(expectedError).Once()
 
-	err := service.Delete(nil, id)
+	err := service.Delete(nil, id, "santiago.ceron@conekta.com")
 
 	assert.NotNil(t, err)
 	assert.Equal(t, expectedError, err)
@@ -237,19 +238,41 @@ func TestDelete_WhenDeleteOnRepositoryFailsThenReturnError(t *testing.T) {
 func TestDelete_WhenDeleteOkThenReturnNil(t *testing.T) {
 	logger, _ := logs.New()
 	modulesRepository := new(mocks.ModulesRepositoryMock)
-	service := NewModuleService(config.Config{}, modulesRepository, logger, new(datadog.MetricsDogMock))
+	service := NewModuleService(config.Config{}, modulesRepository, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
 
 	id := "60f6f32ba0f965ae8ae2c87e"
 
 	modulesRepository.Mock.On("Delete", nil, id).
 		Return(nil).Once()
 
-	err := service.Delete(nil, id)
+	err := service.Delete(nil, id, "santiago.ceron@conekta.com")
 
 	assert.Nil(t, err)
 	modulesRepository.AssertExpectations(t)
 }
 
+func TestDelete_WhenDeleteOkThenRecordTheAuditEntry(t *testing.T) {
+	logger, _ := logs.New()
+	modulesRepository := new(mocks.ModulesRepositoryMock)
+	auditService := new(mocks.AuditServiceMock)
+	service := NewModuleService(config.Config{}, modulesRepository, auditService, logger, new(datadog.MetricsDogMock))
+
+	id := "60f6f32ba0f965ae8ae2c87e"
+	before := bson.M{"name": "policy_compliance"}
+
+	auditService.On("Snapshot", nil, entities.AuditModules, id).Return(before).Once()
+	modulesRepository.Mock.On("Delete", nil, id).
+		Return(nil).Once()
+	auditService.On("Record", nil,
+		entities.NewAuditEntry(entities.AuditModules, id, entities.AuditDeleted, "santiago.ceron@conekta.com", before)).Return().Once()
+
+	err := service.Delete(nil, id, "santiago.ceron@conekta.com")
+
+	assert.Nil(t, err)
+	modulesRepository.AssertExpectations(t)
+	auditService.AssertExpectations(t)
+}
+
 func TestModuleService_GetAll(t *testing.T) {
 
 	t.Run("test when repository passes directly data to service", func(t *testing.T) {
@@ -262,7 +285,7 @@ func TestModuleService_GetAll(t *testing.T) {
 		}
 		modulesRepositoryMock := new(mocks.ModulesRepositoryMock)
 		modulesRepositoryMock.On("GetPaged", nil, pagination, entities.ModuleFilter{Paged: true}).Return(notOperatorsFound, nil)
-		service := NewModuleService(config.Config{}, modulesRepositoryMock, nil, nil)
+		service := NewModuleService(config.Config{}, modulesRepositoryMock, mocks.NewAuditServiceMock(), nil, nil)
 
 		pagedRules, err := service.GetAll(nil, pagination, entities.ModuleFilter{Paged: true})
 
@@ -284,7 +307,7 @@ func TestModuleService_Get(t *testing.T) {
 			nil,
 			moduleFilter).
 			Return([]entities.Module{}, nil)
-		service := NewModuleService(config.Config{}, repositoryMock, nil, nil)
+		service := NewModuleService(config.Config{}, repositoryMock, mocks.NewAuditServiceMock(), nil, nil)
 
 		modules, err := service.GetAll(nil, entities.NewDefaultPagination(), entities.ModuleFilter{Paged: false})
 
@@ -307,7 +330,7 @@ func TestModuleService_Get(t *testing.T) {
 			context.TODO(),
 			moduleFilter).
 			Return([]entities.Module{}, expectedError)
-		service := NewModuleService(config.Config{}, repositoryMock, nil, nil)
+		service := NewModuleService(config.Config{}, repositoryMock, mocks.NewAuditServiceMock(), nil, nil)
 
 		_, err := service.GetAll(context.TODO(), entities.NewDefaultPagination(), moduleFilter)
 
@@ -0,0 +1,55 @@
+package omniscores
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/rest"
+)
+
+const (
+	enricherName   = "omniscore"
+	omniscoreField = "omniscore"
+)
+
+type omniscoreEnricher struct {
+	config  config.Config
+	service OmniscoreService
+}
+
+func NewOmniscoreEnricher(cfg config.Config, service OmniscoreService) entities.Enricher {
+	return &omniscoreEnricher{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (enricher *omniscoreEnricher) Name() string {
+	return enricherName
+}
+
+func (enricher *omniscoreEnricher) Fields() []string {
+	return []string{omniscoreField}
+}
+
+func (enricher *omniscoreEnricher) TimeoutMilliseconds() int {
+	return enricher.config.Enrichment.OmniscoreTimeoutMilliseconds
+}
+
+func (enricher *omniscoreEnricher) FailurePolicy() entities.EnrichmentFailurePolicy {
+	return entities.EnrichmentFailurePolicy{
+		OnFailure: entities.EnrichmentUseDefaults,
+		Defaults:  map[string]interface{}{omniscoreField: rest.DefaultScore},
+	}
+}
+
+// IsEnabled is always true, the client answers the default score when omniscore is turned off.
+func (enricher *omniscoreEnricher) IsEnabled() bool {
+	return true
+}
+
+func (enricher *omniscoreEnricher) Enrich(ctx context.Context,
+	charge entities.ChargeRequest) (map[string]interface{}, error) {
+	return map[string]interface{}{omniscoreField: enricher.service.GetScore(ctx, charge)}, nil
+}
@@ -186,7 +186,8 @@ func TestOperatorHandler_Delete(t *testing.T) {
 		id := "60f6f32ba0f965ae8ae2c87e"
 		request := `{}`
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, "/risk-rules/v1/operators/", id, request)
-		operatorServiceMock.Mock.On("Delete", context.Request().Context(), id).
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
+		operatorServiceMock.Mock.On("Delete", context.Request().Context(), id, "santiago.ceron@conekta.com").
 			Return(nil).Once()
 		handler := operators.NewOperatorHandler(logger, operatorServiceMock)
 
@@ -211,15 +212,31 @@ func TestOperatorHandler_Delete(t *testing.T) {
 		assert.True(t, strings.Contains(httpError.Message(), expectedError))
 	})
 
+	t.Run("when author is empty then return BadRequest", func(t *testing.T) {
+		operatorServiceMock := new(mocks.OperatorServiceMock)
+		request := `{}`
+
+		context, recorder := echo.SetupAsRecorder(http.MethodDelete, "/risk-rules/v1/operators/", "60f6f32ba0f965ae8ae2c87e", request)
+		handler := operators.NewOperatorHandler(logger, operatorServiceMock)
+
+		handler.Delete(context)
+
+		httpError, _ := customHttp.NewRestErrorFromBytes(recorder.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, httpError.Status())
+		assert.Equal(t, "author is required", httpError.Message())
+		operatorServiceMock.AssertNotCalled(t, "Delete")
+	})
+
 	t.Run("when service fails then return error", func(t *testing.T) {
 		operatorServiceMock := new(mocks.OperatorServiceMock)
 		expectedError := errors.New("Internal Server Error")
 		request := `{}`
 
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, "/risk-rules/v1/operators/", "60f6f32ba0f965ae8ae2c87e", request)
+		context.Request().URL.RawQuery = "author=santiago.ceron@conekta.com"
 		operatorServiceMock.Mock.On("Delete",
 			context.Request().Context(),
-			"60f6f32ba0f965ae8ae2c87e").Return(expectedError).Once()
+			"60f6f32ba0f965ae8ae2c87e", "santiago.ceron@conekta.com").Return(expectedError).Once()
 
 		handler := operators.NewOperatorHandler(logger, operatorServiceMock)
 
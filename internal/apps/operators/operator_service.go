@@ -6,10 +6,12 @@ import (
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/strings"
 	"github.com/conekta/risk-rules/pkg/text"
 )
 
@@ -19,22 +21,24 @@ type OperatorService interface {
 	AddOperator(ctx context.Context, operator entities.Operator) error
 	Get(ctx context.Context, operatorFilter entities.OperatorFilter,
 		pagination entities.Pagination) (interface{}, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, author string) error
 	Update(ctx context.Context, id string, operator entities.Operator) error
 }
 
 type operatorService struct {
 	logs               logs.Logger
 	operatorRepository OperatorRepository
+	auditService       audit.AuditService
 	datadog            datadog.Metricer
 	config             config.Config
 }
 
 func NewOperatorService(configs config.Config, logger logs.Logger,
-	operatorRepository OperatorRepository, metric datadog.Metricer) OperatorService {
+	operatorRepository OperatorRepository, auditService audit.AuditService, metric datadog.Metricer) OperatorService {
 	return &operatorService{
 		logs:               logger,
 		operatorRepository: operatorRepository,
+		auditService:       auditService,
 		datadog:            metric,
 		config:             configs,
 	}
@@ -67,6 +71,8 @@ func (service *operatorService) AddOperator(ctx context.Context, operator entiti
 		return err
 	}
 
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditOperators, operator.ID.Hex(),
+		entities.AuditCreated, operator.CreatedBy, nil))
 	metricData.SetResult(false)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveOperatorMetricName)
 
@@ -108,19 +114,23 @@ func (service *operatorService) Update(ctx context.Context, id string, operator
 		return err
 	}
 
+	before := service.auditService.Snapshot(ctx, entities.AuditOperators, id)
 	err = service.operatorRepository.Update(ctx, id, operator)
 	if err != nil {
 		metricData.SetResult(false)
 		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateOperatorMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditOperators, id, entities.AuditUpdated,
+		strings.StringPointerToString(operator.UpdatedBy), before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateOperatorMetricName)
 
 	return nil
 }
 
-func (service *operatorService) Delete(ctx context.Context, id string) error {
+func (service *operatorService) Delete(ctx context.Context, id string, author string) error {
+	before := service.auditService.Snapshot(ctx, entities.AuditOperators, id)
 	err := service.operatorRepository.Delete(ctx, id)
 
 	metricData := metrics.NewMetricData(ctx, "Delete Operator", serviceName, service.config.Env)
@@ -129,6 +139,8 @@ func (service *operatorService) Delete(ctx context.Context, id string) error {
 		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteOperatorMetricName)
 		return err
 	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditOperators, id, entities.AuditDeleted,
+		author, before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteOperatorMetricName)
 
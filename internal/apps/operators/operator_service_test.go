@@ -26,7 +26,7 @@ func TestOperatorService_AddOperator_Fail(t *testing.T) {
 
 	t.Run("When the operator already exist Fail", func(t *testing.T) {
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.NewConfig(), log, operatorRepositoryMock, nil)
+		service := NewOperatorService(config.NewConfig(), log, operatorRepositoryMock, mocks.NewAuditServiceMock(), nil)
 		operator := testdata.GetOperators()[0]
 		filter := entities.OperatorFilter{
 			Type: operator.Type,
@@ -50,7 +50,7 @@ func TestOperatorService_AddOperator_Fail(t *testing.T) {
 
 	t.Run("when the database returns a error", func(t *testing.T) {
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.NewConfig(), log, operatorRepositoryMock, nil)
+		service := NewOperatorService(config.NewConfig(), log, operatorRepositoryMock, mocks.NewAuditServiceMock(), nil)
 		operator := testdata.GetOperators()[0]
 		filter := entities.OperatorFilter{
 			Type: operator.Type,
@@ -74,7 +74,7 @@ func TestOperatorService_AddOperator_Fail(t *testing.T) {
 
 	t.Run("save operator unsuccessfully ", func(t *testing.T) {
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.NewConfig(), log, operatorRepositoryMock, new(datadog.MetricsDogMock))
+		service := NewOperatorService(config.NewConfig(), log, operatorRepositoryMock, mocks.NewAuditServiceMock(), new(datadog.MetricsDogMock))
 		operator := testdata.GetOperators()[0]
 		filter := entities.OperatorFilter{
 			Type: operator.Type,
@@ -104,7 +104,7 @@ func TestOperatorService_AddOperator_Success(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.NewConfig(), log, operatorRepositoryMock, new(datadog.MetricsDogMock))
+		service := NewOperatorService(config.NewConfig(), log, operatorRepositoryMock, mocks.NewAuditServiceMock(), new(datadog.MetricsDogMock))
 		operator := testdata.GetOperators()[0]
 		filter := entities.OperatorFilter{
 			Type: operator.Type,
@@ -147,7 +147,7 @@ func TestOperatorService_GetPaged(t *testing.T) {
 			operatorFilter,
 			entities.NewDefaultPagination()).
 			Return(serviceResponse, nil)
-		service := NewOperatorService(config.Config{}, nil, operatorRepositoryMock, nil)
+		service := NewOperatorService(config.Config{}, nil, operatorRepositoryMock, mocks.NewAuditServiceMock(), nil)
 
 		pagedRules, err := service.Get(nil, operatorFilter, entities.NewDefaultPagination())
 
@@ -171,7 +171,7 @@ func TestOperatorService_GetPaged(t *testing.T) {
 			operatorFilter,
 			entities.NewDefaultPagination()).
 			Return(entities.PagedResponse{}, expectedError)
-		service := NewOperatorService(config.Config{}, nil, operatorRepositoryMock, nil)
+		service := NewOperatorService(config.Config{}, nil, operatorRepositoryMock, mocks.NewAuditServiceMock(), nil)
 
 		_, err := service.Get(context.TODO(), operatorFilter, entities.NewDefaultPagination())
 
@@ -194,7 +194,7 @@ func TestOperatorService_Get(t *testing.T) {
 			nil,
 			operatorFilter).
 			Return([]entities.Operator{}, nil)
-		service := NewOperatorService(config.Config{}, nil, operatorRepositoryMock, nil)
+		service := NewOperatorService(config.Config{}, nil, operatorRepositoryMock, mocks.NewAuditServiceMock(), nil)
 
 		pagedRules, err := service.Get(nil, operatorFilter, entities.NewDefaultPagination())
 
@@ -209,7 +209,7 @@ func Test_Operator_Service_Update(t *testing.T) {
 
 	t.Run("when update on repository fails then return error", func(t *testing.T) {
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, new(datadog.MetricsDogMock))
+		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, mocks.NewAuditServiceMock(), new(datadog.MetricsDogMock))
 		expectedError := exceptions.NewDuplicatedException("the operator + of type  already exist")
 
 		id := "61086352928b571237eab678"
@@ -254,7 +254,7 @@ func Test_Operator_Service_Update(t *testing.T) {
 
 	t.Run("when update ok then return nil", func(t *testing.T) {
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, new(datadog.MetricsDogMock))
+		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, mocks.NewAuditServiceMock(), new(datadog.MetricsDogMock))
 
 		id := "61086352928b571237eab678"
 		operatorRequest := entities.OperatorRequest{
@@ -298,7 +298,7 @@ func Test_Operator_Service_Update(t *testing.T) {
 
 	t.Run("when update with invalid ID return error", func(t *testing.T) {
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, new(datadog.MetricsDogMock))
+		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, mocks.NewAuditServiceMock(), new(datadog.MetricsDogMock))
 
 		id := "xxx"
 		operatorRequest := entities.OperatorRequest{
@@ -332,7 +332,7 @@ func Test_Delete_Operator_Sevice(t *testing.T) {
 
 	t.Run("when delete on repository fails then return error", func(t *testing.T) {
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, new(datadog.MetricsDogMock))
+		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, mocks.NewAuditServiceMock(), new(datadog.MetricsDogMock))
 		expectedError := errors.New("connection lost")
 
 		id := "60f6f32ba0f965ae8ae2c87e"
@@ -340,7 +340,7 @@ func Test_Delete_Operator_Sevice(t *testing.T) {
 		operatorRepositoryMock.Mock.On("Delete", nil, id).
 			Return(expectedError).Once()
 
-		err := service.Delete(nil, id)
+		err := service.Delete(nil, id, "santiago.ceron@conekta.com")
 
 		assert.NotNil(t, err)
 		assert.Equal(t, expectedError, err)
@@ -349,14 +349,14 @@ func Test_Delete_Operator_Sevice(t *testing.T) {
 
 	t.Run("when delete ok then return nil", func(t *testing.T) {
 		operatorRepositoryMock := new(mocks.OperatorRepositoryMock)
-		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, new(datadog.MetricsDogMock))
+		service := NewOperatorService(config.Config{}, logger, operatorRepositoryMock, mocks.NewAuditServiceMock(), new(datadog.MetricsDogMock))
 
 		id := "60f6f32ba0f965ae8ae2c87e"
 
 		operatorRepositoryMock.Mock.On("Delete", nil, id).
 			Return(nil).Once()
 
-		err := service.Delete(nil, id)
+		err := service.Delete(nil, id, "santiago.ceron@conekta.com")
 
 		assert.Nil(t, err)
 		operatorRepositoryMock.AssertExpectations(t)
@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+const ruleCatalogServiceMethod = "rules.catalog.service.%s"
+
+type FieldsFinder interface {
+	GetFields(ctx context.Context, filter entities.FieldsFilter) ([]entities.Field, error)
+}
+
+type OperatorsFinder interface {
+	Get(ctx context.Context, filter entities.OperatorFilter) ([]entities.Operator, error)
+}
+
+type ConditionsFinder interface {
+	GetAll(ctx context.Context, filter entities.ConditionsFilter, pagination entities.Pagination) (entities.PagedResponse, error)
+}
+
+type RuleCatalogService interface {
+	Load(ctx context.Context) (entities.RuleCatalog, error)
+	Validate(ctx context.Context, contents []entities.RuleContent) error
+}
+
+type ruleCatalogService struct {
+	fields     FieldsFinder
+	operators  OperatorsFinder
+	conditions ConditionsFinder
+	logs       logs.Logger
+}
+
+func NewRuleCatalogService(fields FieldsFinder, operators OperatorsFinder, conditions ConditionsFinder,
+	logger logs.Logger) RuleCatalogService {
+	return &ruleCatalogService{
+		fields:     fields,
+		operators:  operators,
+		conditions: conditions,
+		logs:       logger,
+	}
+}
+
+func (service *ruleCatalogService) Load(ctx context.Context) (entities.RuleCatalog, error) {
+	fields, err := service.fields.GetFields(ctx, entities.FieldsFilter{})
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleCatalogServiceMethod, "Load"))
+		return entities.RuleCatalog{}, err
+	}
+
+	operators, err := service.operators.Get(ctx, entities.OperatorFilter{})
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleCatalogServiceMethod, "Load"))
+		return entities.RuleCatalog{}, err
+	}
+
+	conditionsFound, err := service.conditions.GetAll(ctx, entities.ConditionsFilter{}, entities.Pagination{})
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleCatalogServiceMethod, "Load"))
+		return entities.RuleCatalog{}, err
+	}
+	conditions, _ := conditionsFound.Data.([]entities.Condition)
+
+	return entities.NewRuleCatalog(fields, operators, conditions), nil
+}
+
+func (service *ruleCatalogService) Validate(ctx context.Context, contents []entities.RuleContent) error {
+	catalog, err := service.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	if clauses := catalog.Check(contents); len(clauses) > 0 {
+		return exceptions.NewInvalidRuleException(clauses)
+	}
+
+	return nil
+}
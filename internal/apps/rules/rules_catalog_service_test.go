@@ -0,0 +1,115 @@
+package rules_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func getCatalogFields() []entities.Field {
+	return []entities.Field{
+		{Name: "device_fingerprint", Type: entities.OperatorTypeString},
+		{Name: "amount", Type: entities.OperatorTypeNumber},
+		{Name: "shipping.amount", Type: entities.OperatorTypeNumber},
+		{Name: "live_mode", Type: entities.OperatorTypeBoolean},
+	}
+}
+
+func getCatalogOperators() []entities.Operator {
+	return []entities.Operator{
+		{Name: "==", Type: entities.OperatorTypeString},
+		{Name: "==", Type: entities.OperatorTypeNumber},
+		{Name: "==", Type: entities.OperatorTypeBoolean},
+		{Name: ">", Type: entities.OperatorTypeNumber},
+		{Name: "in", Type: entities.OperatorTypeString},
+		{Name: "in", Type: entities.OperatorTypeNumber},
+	}
+}
+
+func newRuleCatalogService(fieldsErr error) rules.RuleCatalogService {
+	logger, _ := logs.New()
+	fields := new(mocks.FieldsRepositoryMock)
+	fields.On("GetFields", context.TODO(), entities.FieldsFilter{}).Return(getCatalogFields(), fieldsErr)
+	operators := new(mocks.OperatorRepositoryMock)
+	operators.On("Get", context.TODO(), entities.OperatorFilter{}).Return(getCatalogOperators(), nil)
+	conditions := new(mocks.ConditionsRepositoryMock)
+	conditions.On("GetAll", context.TODO(), entities.ConditionsFilter{}, entities.Pagination{}).
+		Return(entities.PagedResponse{Data: []entities.Condition{{Name: "and"}, {Name: "or"}}}, nil)
+
+	return rules.NewRuleCatalogService(fields, operators, conditions, logger)
+}
+
+func Test_ruleCatalogService_Validate(t *testing.T) {
+	t.Run("when the clauses match the catalog then return no error", func(t *testing.T) {
+		fields, operation := []string{"amount", "shipping.amount"}, entities.SUM
+		expression := "MAX(shipping.amount, 10) / amount"
+		contents := []entities.RuleContent{
+			{Field: "device_fingerprint", Operator: "in", Value: `["fp_1","fp_2"]`, Condition: "and"},
+			{Field: "amount", Operator: ">", Value: "100.5", Condition: "OR"},
+			{Field: "live_mode", Operator: "==", Value: "true", Condition: "and"},
+			{Field: "SUM (amount,shipping.amount)", Operator: ">", Value: "300", Condition: "and",
+				FormulaContent: entities.FormulaContent{Fields: &fields, MathOperation: &operation}},
+			{Operator: ">", Value: "3.5", Condition: "and",
+				FormulaContent: entities.FormulaContent{Expression: &expression}},
+		}
+
+		err := newRuleCatalogService(nil).Validate(context.TODO(), contents)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("when the clauses do not match the catalog then return an error per clause", func(t *testing.T) {
+		fields, operation := []string{"amount", "device_fingerprint"}, entities.SUM
+		expression := "amount / device_fingerprint"
+		contents := []entities.RuleContent{
+			{Field: "email", Operator: "==", Value: "fraud@mail.com", Condition: "and"},
+			{Field: "amount", Operator: "~", Value: "100", Condition: "and"},
+			{Field: "device_fingerprint", Operator: ">", Value: "fp_1", Condition: "and"},
+			{Field: "amount", Operator: "in", Value: "[100,abc]", Condition: "and"},
+			{Field: "live_mode", Operator: "==", Value: "yes", Condition: "and"},
+			{Field: "amount", Operator: ">", Value: "100", Condition: "xor"},
+			{Field: "SUM (amount,device_fingerprint)", Operator: ">", Value: "300", Condition: "and",
+				FormulaContent: entities.FormulaContent{Fields: &fields, MathOperation: &operation}},
+			{Field: "amount", Operator: ">", Value: "100", Condition: "and"},
+			{Operator: ">", Value: "3", Condition: "and", FormulaContent: entities.FormulaContent{Expression: &expression}},
+		}
+
+		err := newRuleCatalogService(nil).Validate(context.TODO(), contents)
+
+		exception, ok := err.(exceptions.InvalidRuleException)
+		assert.True(t, ok)
+		assert.Equal(t, []exceptions.ClauseError{
+			{Clause: 0, Field: "email", Operator: "==", Code: exceptions.RuleFieldNotFound,
+				Message: "field 'email' not found"},
+			{Clause: 1, Field: "amount", Operator: "~", Code: exceptions.RuleOperatorNotFound,
+				Message: "operator '~' not found"},
+			{Clause: 2, Field: "device_fingerprint", Operator: ">", Code: exceptions.RuleOperatorNotCompatible,
+				Message: "operator '>' can not be applied to the string field 'device_fingerprint'"},
+			{Clause: 3, Field: "amount", Operator: "in", Code: exceptions.RuleValueNotValid,
+				Message: "value '[100,abc]' is not a valid number"},
+			{Clause: 4, Field: "live_mode", Operator: "==", Code: exceptions.RuleValueNotValid,
+				Message: "value 'yes' is not a valid boolean"},
+			{Clause: 5, Field: "amount", Operator: ">", Code: exceptions.RuleConditionNotFound,
+				Message: "condition 'xor' not found"},
+			{Clause: 6, Field: "device_fingerprint", Operator: ">", Code: exceptions.RuleOperatorNotCompatible,
+				Message: "math operation 'SUM' can not be applied to the string field 'device_fingerprint'"},
+			{Clause: 8, Field: "device_fingerprint", Operator: ">", Code: exceptions.RuleOperatorNotCompatible,
+				Message: "formula can not be applied to the string field 'device_fingerprint'"},
+		}, exception.Clauses())
+	})
+
+	t.Run("when the catalog can not be loaded then return the error", func(t *testing.T) {
+		contents := []entities.RuleContent{{Field: "amount", Operator: ">", Value: "100", Condition: "and"}}
+
+		err := newRuleCatalogService(errors.New("mongo is down")).Validate(context.TODO(), contents)
+
+		assert.EqualError(t, err, "mongo is down")
+	})
+}
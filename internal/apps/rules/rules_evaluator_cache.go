@@ -0,0 +1,209 @@
+package rules
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/conekta/Conekta-Golang-Rules-Engine/parser"
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+const (
+	evaluatorCacheMethod = "rules_evaluator.cache.%s"
+	cacheHit             = "hit"
+	cacheMiss            = "miss"
+)
+
+type RuleEvaluatorCache interface {
+	GetEvaluator(ctx context.Context, rule entities.Rule) (*CompiledEvaluator, error)
+	Invalidate(ruleID string)
+}
+
+// CompiledEvaluator keeps a pool of parsed evaluators of a rule version. The engine evaluator keeps the last debug
+// error between Process and LastDebugErr, so every evaluation takes its own evaluator from the pool.
+type CompiledEvaluator struct {
+	pool        sync.Pool
+	version     string
+	formulas    []entities.FormulaExpression
+	clausesOnce sync.Once
+	clauses     []compiledClause
+}
+
+// compiledClause is the evaluator of a single clause of the rule, used to explain which clauses matched.
+type compiledClause struct {
+	compiled *CompiledEvaluator
+	err      error
+}
+
+type cachedEvaluator struct {
+	ruleID   string
+	compiled *CompiledEvaluator
+}
+
+// ruleEvaluatorCache keeps the evaluators of the most recently used rules, the least recently used one is evicted
+// when the cache is full.
+type ruleEvaluatorCache struct {
+	config     config.Config
+	logs       logs.Logger
+	datadog    datadog.Metricer
+	mutex      sync.Mutex
+	evaluators map[string]*list.Element
+	recent     *list.List
+}
+
+func NewRuleEvaluatorCache(cfg config.Config, logger logs.Logger, metric datadog.Metricer) RuleEvaluatorCache {
+	return &ruleEvaluatorCache{
+		config:     cfg,
+		logs:       logger,
+		datadog:    metric,
+		evaluators: make(map[string]*list.Element),
+		recent:     list.New(),
+	}
+}
+
+func (cache *ruleEvaluatorCache) GetEvaluator(ctx context.Context, rule entities.Rule) (*CompiledEvaluator, error) {
+	if !cache.config.EvaluatorCache.IsEnabled || rule.ID.IsZero() {
+		return compile(rule, "")
+	}
+
+	ruleID := rule.ID.Hex()
+	version := getRuleVersion(rule)
+
+	if compiled, ok := cache.get(ruleID, version); ok {
+		cache.sendMetrics(ctx, cacheHit)
+		return compiled, nil
+	}
+
+	cache.sendMetrics(ctx, cacheMiss)
+	compiled, err := compile(rule, version)
+	if err != nil {
+		return nil, err
+	}
+	cache.put(ruleID, compiled)
+
+	return compiled, nil
+}
+
+func (cache *ruleEvaluatorCache) Invalidate(ruleID string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.evaluators[ruleID]; ok {
+		cache.recent.Remove(element)
+		delete(cache.evaluators, ruleID)
+	}
+}
+
+func (cache *ruleEvaluatorCache) get(ruleID, version string) (*CompiledEvaluator, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.evaluators[ruleID]
+	if !ok || element.Value.(*cachedEvaluator).compiled.version != version {
+		return nil, false
+	}
+	cache.recent.MoveToFront(element)
+
+	return element.Value.(*cachedEvaluator).compiled, true
+}
+
+func (cache *ruleEvaluatorCache) put(ruleID string, compiled *CompiledEvaluator) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.evaluators[ruleID]; ok {
+		element.Value = &cachedEvaluator{ruleID: ruleID, compiled: compiled}
+		cache.recent.MoveToFront(element)
+		return
+	}
+
+	cache.evaluators[ruleID] = cache.recent.PushFront(&cachedEvaluator{ruleID: ruleID, compiled: compiled})
+	for cache.recent.Len() > cache.config.EvaluatorCache.MaxEntries {
+		oldest := cache.recent.Back()
+		cache.recent.Remove(oldest)
+		delete(cache.evaluators, oldest.Value.(*cachedEvaluator).ruleID)
+	}
+}
+
+func (cache *ruleEvaluatorCache) sendMetrics(ctx context.Context, result string) {
+	metricData := metrics.NewMetricData(ctx, "GetEvaluator", evaluatorCacheMethod, cache.config.Env)
+	metricData.AddCustomTags([]string{fmt.Sprintf(text.MetricTagCacheResult, result)})
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(cache.datadog, cache.logs, metricData, text.RuleEvaluatorCacheMetricName)
+}
+
+func compile(rule entities.Rule, version string) (*CompiledEvaluator, error) {
+	expression := strings.ReplaceAll(rule.Rule, "\\", "")
+	evaluator, err := parser.NewEvaluator(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &CompiledEvaluator{version: version, formulas: parseFormulas(rule.Rules)}
+	compiled.pool.New = func() interface{} {
+		// the expression was already parsed once, so it does not fail
+		evaluator, _ := parser.NewEvaluator(expression)
+		return evaluator
+	}
+	compiled.pool.Put(evaluator)
+
+	return compiled, nil
+}
+
+// acquire takes an evaluator that no other evaluation is using, it must be released after LastDebugErr.
+func (compiled *CompiledEvaluator) acquire() *parser.Evaluator {
+	return compiled.pool.Get().(*parser.Evaluator)
+}
+
+func (compiled *CompiledEvaluator) release(evaluator *parser.Evaluator) {
+	compiled.pool.Put(evaluator)
+}
+
+// getClauses compiles the clauses of the rule the first time they are explained and keeps them with the evaluator of
+// the rule, so they are evicted and invalidated with it.
+func (compiled *CompiledEvaluator) getClauses(rule entities.Rule) []compiledClause {
+	compiled.clausesOnce.Do(func() {
+		compiled.clauses = compileClauses(rule, compiled.version)
+	})
+
+	return compiled.clauses
+}
+
+func compileClauses(rule entities.Rule, version string) []compiledClause {
+	clauses := make([]compiledClause, 0, len(rule.Rules))
+	for _, content := range rule.Rules {
+		clause := entities.Rule{Rule: content.RuleAsString(true), Rules: []entities.RuleContent{content}}
+		clauseCompiled, err := compile(clause, version)
+		clauses = append(clauses, compiledClause{compiled: clauseCompiled, err: err})
+	}
+
+	return clauses
+}
+
+// parseFormulas parses the formula expressions of the clauses once, so the evaluations only calculate them.
+func parseFormulas(contents []entities.RuleContent) []entities.FormulaExpression {
+	var formulas []entities.FormulaExpression
+	for _, content := range contents {
+		if expression, ok := content.FormulaExpression(); ok {
+			formulas = append(formulas, expression)
+		}
+	}
+
+	return formulas
+}
+
+func getRuleVersion(rule entities.Rule) string {
+	if rule.UpdatedAt == nil {
+		return "0"
+	}
+
+	return fmt.Sprintf("%d", rule.UpdatedAt.UnixNano())
+}
@@ -0,0 +1,169 @@
+package rules_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func getEnabledEvaluatorCacheConfig() config.Config {
+	cfg := config.Config{}
+	cfg.EvaluatorCache.IsEnabled = true
+	cfg.EvaluatorCache.MaxEntries = 10
+
+	return cfg
+}
+
+func TestRuleEvaluatorCache_GetEvaluator(t *testing.T) {
+	logger, _ := logs.New()
+	updatedAt := time.Date(2022, 5, 10, 0, 0, 0, 0, time.UTC)
+	rule := entities.Rule{ID: primitive.NewObjectID(), Rule: "monthly_installments eq 10", UpdatedAt: &updatedAt}
+
+	t.Run("when the rule was already compiled the same evaluator is returned", func(t *testing.T) {
+		cache := rules.NewRuleEvaluatorCache(getEnabledEvaluatorCacheConfig(), logger, new(datadog.MetricsDogMock))
+
+		first, err := cache.GetEvaluator(context.TODO(), rule)
+		assert.NoError(t, err)
+		second, err := cache.GetEvaluator(context.TODO(), rule)
+		assert.NoError(t, err)
+
+		assert.Same(t, first, second)
+	})
+
+	t.Run("when the rule version changes it is compiled again", func(t *testing.T) {
+		cache := rules.NewRuleEvaluatorCache(getEnabledEvaluatorCacheConfig(), logger, new(datadog.MetricsDogMock))
+
+		first, err := cache.GetEvaluator(context.TODO(), rule)
+		assert.NoError(t, err)
+
+		updatedRule := rule
+		newUpdatedAt := updatedAt.Add(time.Minute)
+		updatedRule.UpdatedAt = &newUpdatedAt
+		updatedRule.Rule = "monthly_installments eq 12"
+		second, err := cache.GetEvaluator(context.TODO(), updatedRule)
+		assert.NoError(t, err)
+
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("when the rule is invalidated it is compiled again", func(t *testing.T) {
+		cache := rules.NewRuleEvaluatorCache(getEnabledEvaluatorCacheConfig(), logger, new(datadog.MetricsDogMock))
+
+		first, err := cache.GetEvaluator(context.TODO(), rule)
+		assert.NoError(t, err)
+		cache.Invalidate(rule.ID.Hex())
+		second, err := cache.GetEvaluator(context.TODO(), rule)
+		assert.NoError(t, err)
+
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("when the cache is full the least recently used rule is evicted", func(t *testing.T) {
+		cfg := getEnabledEvaluatorCacheConfig()
+		cfg.EvaluatorCache.MaxEntries = 2
+		cache := rules.NewRuleEvaluatorCache(cfg, logger, new(datadog.MetricsDogMock))
+		secondRule := entities.Rule{ID: primitive.NewObjectID(), Rule: "monthly_installments eq 12"}
+		thirdRule := entities.Rule{ID: primitive.NewObjectID(), Rule: "monthly_installments eq 3"}
+
+		first, _ := cache.GetEvaluator(context.TODO(), rule)
+		second, _ := cache.GetEvaluator(context.TODO(), secondRule)
+		firstAgain, _ := cache.GetEvaluator(context.TODO(), rule)
+		_, _ = cache.GetEvaluator(context.TODO(), thirdRule)
+		firstAfterEviction, _ := cache.GetEvaluator(context.TODO(), rule)
+		secondAfterEviction, _ := cache.GetEvaluator(context.TODO(), secondRule)
+
+		assert.Same(t, first, firstAgain)
+		assert.Same(t, first, firstAfterEviction)
+		assert.NotSame(t, second, secondAfterEviction)
+	})
+
+	t.Run("when the cache is disabled the rule is always compiled", func(t *testing.T) {
+		cache := rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock))
+
+		first, err := cache.GetEvaluator(context.TODO(), rule)
+		assert.NoError(t, err)
+		second, err := cache.GetEvaluator(context.TODO(), rule)
+		assert.NoError(t, err)
+
+		assert.NotSame(t, first, second)
+	})
+}
+
+func TestRulesValidator_EvaluateWithCache(t *testing.T) {
+	logger, _ := logs.New()
+	cache := rules.NewRuleEvaluatorCache(getEnabledEvaluatorCacheConfig(), logger, new(datadog.MetricsDogMock))
+	rulesValidator := rules.NewRulesValidator(logger, cache)
+	rule := entities.Rule{ID: primitive.NewObjectID(), Rule: "monthly_installments eq 10"}
+
+	for _, installments := range []int{10, 12, 10} {
+		isValid, err := rulesValidator.Evaluate(context.TODO(), rule, map[string]interface{}{"monthly_installments": installments})
+		assert.NoError(t, err)
+		assert.Equal(t, installments == 10, isValid)
+	}
+
+	_, err := rulesValidator.Evaluate(context.TODO(), rule, map[string]interface{}{})
+	assert.Contains(t, err.Error(), "Eval operand missing in input object")
+
+	isValid, err := rulesValidator.Evaluate(context.TODO(), rule, map[string]interface{}{"monthly_installments": 10})
+	assert.NoError(t, err)
+	assert.True(t, isValid)
+}
+
+func TestRulesValidator_EvaluateConcurrently(t *testing.T) {
+	logger, _ := logs.New()
+	cache := rules.NewRuleEvaluatorCache(getEnabledEvaluatorCacheConfig(), logger, new(datadog.MetricsDogMock))
+	rulesValidator := rules.NewRulesValidator(logger, cache)
+	rule := entities.Rule{ID: primitive.NewObjectID(), Rule: "monthly_installments eq 10"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(installments int) {
+			defer wg.Done()
+			isValid, err := rulesValidator.Evaluate(context.TODO(), rule,
+				map[string]interface{}{"monthly_installments": installments})
+			assert.NoError(t, err)
+			assert.Equal(t, installments == 10, isValid)
+		}(10 + i%2*2)
+	}
+	wg.Wait()
+}
+
+func TestRulesValidator_ExplainClausesWithCache(t *testing.T) {
+	logger, _ := logs.New()
+	cache := rules.NewRuleEvaluatorCache(getEnabledEvaluatorCacheConfig(), logger, new(datadog.MetricsDogMock))
+	rulesValidator := rules.NewRulesValidator(logger, cache)
+	rule := entities.Rule{
+		ID:   primitive.NewObjectID(),
+		Rule: "monthly_installments eq 10 and amount gt 100",
+		Rules: []entities.RuleContent{
+			{Field: "monthly_installments", Operator: "eq", Value: "10"},
+			{Field: "amount", Operator: "gt", Value: "100", Condition: "and"},
+		},
+	}
+
+	for _, amount := range []int{200, 50} {
+		clauses := rulesValidator.ExplainClauses(context.TODO(), rule,
+			map[string]interface{}{"monthly_installments": 10, "amount": amount})
+
+		assert.Len(t, clauses, 2)
+		assert.True(t, clauses[0].Result)
+		assert.Equal(t, amount > 100, clauses[1].Result)
+		assert.Empty(t, clauses[1].Error)
+	}
+
+	clauses := rulesValidator.ExplainClauses(context.TODO(), rule, map[string]interface{}{"monthly_installments": 12})
+
+	assert.False(t, clauses[0].Result)
+	assert.Empty(t, clauses[0].Error)
+	assert.Contains(t, clauses[1].Error, "Eval operand missing in input object")
+}
@@ -1,9 +1,11 @@
 package rules
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	customHttp "github.com/conekta/go_common/http/resterror"
 	"github.com/conekta/go_common/logs"
@@ -12,6 +14,7 @@ import (
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/pkg/text"
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 const handlerName = "rule.handler.%s"
@@ -20,6 +23,14 @@ type RuleHandler interface {
 	AddRule(c echo.Context) error
 	UpdateRule(c echo.Context) error
 	RemoveRule(c echo.Context) error
+	GetVersions(c echo.Context) error
+	GetVersion(c echo.Context) error
+	RollbackRule(c echo.Context) error
+	SubmitRule(c echo.Context) error
+	ApproveRule(c echo.Context) error
+	RejectRule(c echo.Context) error
+	RetireRule(c echo.Context) error
+	LintRule(c echo.Context) error
 	GetPaged(c echo.Context) error
 }
 
@@ -66,6 +77,7 @@ func (handler *ruleHandler) AddRule(ctx echo.Context) error {
 		return nil
 	}
 
+	handler.addLintWarnings(ctx, rule, "AddRule")
 	return ctx.JSON(http.StatusOK, rule)
 }
 
@@ -101,15 +113,69 @@ func (handler *ruleHandler) UpdateRule(ctx echo.Context) error {
 		return nil
 	}
 
-	err = handler.service.UpdateRule(ctx.Request().Context(), ruleID, ruleReq.NewRuleFromPutRequest())
+	rule := ruleReq.NewRuleFromPutRequest()
+	err = handler.service.UpdateRule(ctx.Request().Context(), ruleID, rule)
 	if err != nil {
 		ctx.Error(err)
 		return nil
 	}
 
+	rule.ID, _ = primitive.ObjectIDFromHex(ruleID)
+	handler.addLintWarnings(ctx, rule, "UpdateRule")
+
 	return ctx.NoContent(http.StatusNoContent)
 }
 
+func (handler *ruleHandler) LintRule(ctx echo.Context) error {
+	ruleReq := new(entities.RuleRequest)
+	if err := ctx.Bind(ruleReq); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "LintRule"))
+		ctx.Error(err)
+		return nil
+	}
+
+	if err := ctx.Validate(ruleReq); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "LintRule"))
+		ctx.Error(err)
+		return nil
+	}
+
+	if err := ruleReq.Validate(); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "LintRule"))
+		ctx.Error(err)
+		return nil
+	}
+
+	rule := ruleReq.NewRuleFromPostRequest()
+	if ruleID := ctx.QueryParam("id"); !strings.IsEmpty(ruleID) {
+		rule.ID, _ = primitive.ObjectIDFromHex(ruleID)
+	}
+
+	lint, err := handler.service.LintRule(ctx.Request().Context(), rule)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, lint)
+}
+
+// addLintWarnings reports the lint findings of a stored rule as Warning headers, a failed lint never fails the write.
+func (handler *ruleHandler) addLintWarnings(ctx echo.Context, rule entities.Rule, methodName string) {
+	lint, err := handler.service.LintRule(ctx.Request().Context(), rule)
+	if err != nil {
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		return
+	}
+
+	for _, warning := range lint.Warnings {
+		ctx.Response().Header().Add("Warning", fmt.Sprintf("299 - %q", warning.Message))
+	}
+}
+
 func (handler *ruleHandler) RemoveRule(ctx echo.Context) error {
 	ruleID := ctx.Param("id")
 	if strings.IsEmpty(ruleID) {
@@ -119,7 +185,7 @@ func (handler *ruleHandler) RemoveRule(ctx echo.Context) error {
 		return nil
 	}
 
-	err := handler.service.RemoveRule(ctx.Request().Context(), ruleID)
+	err := handler.service.RemoveRule(ctx.Request().Context(), ruleID, ctx.QueryParam("author"))
 	if err != nil {
 		ctx.Error(err)
 		return nil
@@ -141,6 +207,20 @@ func (handler *ruleHandler) GetPaged(ctx echo.Context) error {
 		return nil
 	}
 
+	if !ruleFilter.IsActiveValid() {
+		err := customHttp.NewBadRequestError("active must be true or false")
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "GetPaged"))
+		ctx.Error(err)
+		return nil
+	}
+
+	if !ruleFilter.IsStatusValid() {
+		err := customHttp.NewBadRequestError(fmt.Sprintf("status [%s] is not a valid value", ruleFilter.Status))
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "GetPaged"))
+		ctx.Error(err)
+		return nil
+	}
+
 	pagedRules, err := handler.service.ListRules(ctx.Request().Context(), ruleFilter, pagination)
 	if err != nil {
 		ctx.Error(err)
@@ -149,3 +229,134 @@ func (handler *ruleHandler) GetPaged(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, pagedRules)
 }
+
+func (handler *ruleHandler) GetVersions(ctx echo.Context) error {
+	ruleID := ctx.Param("id")
+	if strings.IsEmpty(ruleID) {
+		err := customHttp.NewBadRequestError(errors.New("empty id").Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "GetVersions"))
+		ctx.Error(err)
+		return nil
+	}
+
+	versions, err := handler.service.GetVersions(ctx.Request().Context(), ruleID)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, versions)
+}
+
+func (handler *ruleHandler) GetVersion(ctx echo.Context) error {
+	ruleID, revision, err := getRuleRevisionParams(ctx)
+	if err != nil {
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "GetVersion"))
+		ctx.Error(err)
+		return nil
+	}
+
+	version, err := handler.service.GetVersion(ctx.Request().Context(), ruleID, revision)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, version)
+}
+
+func (handler *ruleHandler) RollbackRule(ctx echo.Context) error {
+	ruleID, revision, err := getRuleRevisionParams(ctx)
+	if err != nil {
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "RollbackRule"))
+		ctx.Error(err)
+		return nil
+	}
+
+	rollbackReq := new(entities.RuleRollbackRequest)
+	if err = ctx.Bind(rollbackReq); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "RollbackRule"))
+		ctx.Error(err)
+		return nil
+	}
+
+	if err = ctx.Validate(rollbackReq); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "RollbackRule"))
+		ctx.Error(err)
+		return nil
+	}
+
+	rule, err := handler.service.RollbackRule(ctx.Request().Context(), ruleID, revision, rollbackReq.Author)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, rule)
+}
+
+func (handler *ruleHandler) SubmitRule(ctx echo.Context) error {
+	return handler.reviewRule(ctx, "SubmitRule", handler.service.SubmitRule)
+}
+
+func (handler *ruleHandler) ApproveRule(ctx echo.Context) error {
+	return handler.reviewRule(ctx, "ApproveRule", handler.service.ApproveRule)
+}
+
+func (handler *ruleHandler) RejectRule(ctx echo.Context) error {
+	return handler.reviewRule(ctx, "RejectRule", handler.service.RejectRule)
+}
+
+func (handler *ruleHandler) RetireRule(ctx echo.Context) error {
+	return handler.reviewRule(ctx, "RetireRule", handler.service.RetireRule)
+}
+
+func (handler *ruleHandler) reviewRule(ctx echo.Context, methodName string,
+	review func(context.Context, string, entities.RuleReviewRequest) (entities.Rule, error)) error {
+	ruleID := ctx.Param("id")
+	if strings.IsEmpty(ruleID) {
+		err := customHttp.NewBadRequestError(errors.New("empty id").Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		ctx.Error(err)
+		return nil
+	}
+
+	reviewReq := new(entities.RuleReviewRequest)
+	if err := ctx.Bind(reviewReq); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		ctx.Error(err)
+		return nil
+	}
+
+	if err := ctx.Validate(reviewReq); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		ctx.Error(err)
+		return nil
+	}
+
+	rule, err := review(ctx.Request().Context(), ruleID, *reviewReq)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, rule)
+}
+
+func getRuleRevisionParams(ctx echo.Context) (string, int64, error) {
+	ruleID := ctx.Param("id")
+	if strings.IsEmpty(ruleID) {
+		return ruleID, 0, customHttp.NewBadRequestError(errors.New("empty id").Error())
+	}
+
+	revision, err := strconv.ParseInt(ctx.Param("n"), 10, 64)
+	if err != nil || revision < 0 {
+		return ruleID, 0, customHttp.NewBadRequestError(fmt.Sprintf("invalid revision [%s]", ctx.Param("n")))
+	}
+
+	return ruleID, revision, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/conekta/risk-rules/pkg/echo"
@@ -91,6 +92,18 @@ func Test_ruleHandler_List(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, httpErrorResponse.Status())
 		assert.Contains(t, httpErrorResponse.Message(), expectedErr.Error())
 	})
+
+	t.Run("when status is invalid", func(t *testing.T) {
+		rulesServiceMock := new(mocks.RuleServiceMock)
+		context, rec := echo.SetupAsRecorder(http.MethodGet, rulesUri+"?status=enabled", "", "")
+		handler := rules.NewRulesHandler(config.Config{}, rulesServiceMock, logger)
+
+		handler.GetPaged(context)
+
+		httpErrorResponse, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, httpErrorResponse.Status())
+		rulesServiceMock.AssertNotCalled(t, "ListRules", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func Test_ruleHandler_AddRule(t *testing.T) {
@@ -106,6 +119,7 @@ func Test_ruleHandler_AddRule(t *testing.T) {
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPost, "/risk-rules/v1/rules", "", string(request))
 		ruleService.On("AddRule", context.Request().Context(), mock.Anything).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 
 		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
 		handler.AddRule(context)
@@ -195,6 +209,7 @@ func Test_ruleHandler_AddRule(t *testing.T) {
 
 		ruleService := new(mocks.RuleServiceMock)
 		ruleService.On("AddRule", context.Request().Context(), mock.Anything).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 
 		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
 		handler.AddRule(context)
@@ -212,6 +227,7 @@ func Test_ruleHandler_AddRule(t *testing.T) {
 
 		ruleService := new(mocks.RuleServiceMock)
 		ruleService.On("AddRule", context.Request().Context(), mock.Anything).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 
 		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
 		handler.AddRule(context)
@@ -255,6 +271,7 @@ func Test_ruleHandler_AddRule(t *testing.T) {
 
 		ruleService := new(mocks.RuleServiceMock)
 		ruleService.On("AddRule", context.Request().Context(), mock.Anything).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 
 		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
 		err := handler.AddRule(context)
@@ -321,6 +338,7 @@ func Test_ruleHandler_AddRule(t *testing.T) {
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPost, "/risk-rules/v1/rules", "", string(request))
 		ruleService.On("AddRule", context.Request().Context(), mock.Anything).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 
 		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
 		handler.AddRule(context)
@@ -336,6 +354,7 @@ func Test_ruleHandler_AddRule(t *testing.T) {
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPost, "/risk-rules/v1/rules", "", string(request))
 		ruleService.On("AddRule", context.Request().Context(), mock.Anything).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 
 		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
 		handler.AddRule(context)
@@ -361,6 +380,71 @@ func Test_ruleHandler_AddRule(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, recorder.Code)
 		assert.Equal(t, expectedError.Error(), httpError.Message())
 	})
+
+	t.Run("create rule with group flattens its clauses", func(t *testing.T) {
+		ruleService := new(mocks.RuleServiceMock)
+		request, _ := json.Marshal(testdata.GetDefaultRuleRequestWithGroup())
+
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, "/risk-rules/v1/rules", "", string(request))
+		ruleService.On("AddRule", context.Request().Context(), mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.Group != nil && len(rule.Rules) == 3 && rule.Rules[2].Condition == "and"
+		})).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
+
+		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
+		handler.AddRule(context)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("create rule with formula expression generates its field", func(t *testing.T) {
+		ruleService := new(mocks.RuleServiceMock)
+		rule := testdata.GetDefaultRuleRequestWithAmount()
+		rule.Rules = testdata.GetExpressionFormulaRules("aggregation.card_hash.charge.h1.sum / amount")
+		request, _ := json.Marshal(rule)
+
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, "/risk-rules/v1/rules", "", string(request))
+		ruleService.On("AddRule", context.Request().Context(), mock.MatchedBy(func(rule entities.Rule) bool {
+			return strings.HasPrefix(rule.Rules[0].Field, entities.FormulaField+".")
+		})).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
+
+		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
+		handler.AddRule(context)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("create rule with rules and group return bad request", func(t *testing.T) {
+		ruleService := new(mocks.RuleServiceMock)
+		rule := testdata.GetDefaultRuleRequestWithGroup()
+		rule.Rules = testdata.GetDefaultRuleRequestWithAmount().Rules
+		request, _ := json.Marshal(rule)
+
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, "/risk-rules/v1/rules", "", string(request))
+
+		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
+		handler.AddRule(context)
+
+		httpError, _ := customHttp.NewRestErrorFromBytes(recorder.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, "rules and group could not be passed at same time", httpError.Message())
+	})
+
+	t.Run("create rule without rules and group return bad request", func(t *testing.T) {
+		ruleService := new(mocks.RuleServiceMock)
+		rule := testdata.GetDefaultRuleRequestWithAmount()
+		rule.Rules = nil
+		request, _ := json.Marshal(rule)
+
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, "/risk-rules/v1/rules", "", string(request))
+
+		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
+		handler.AddRule(context)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		ruleService.AssertNotCalled(t, "AddRule", mock.Anything, mock.Anything)
+	})
 }
 
 func Test_ruleHandler_UpdateRule(t *testing.T) {
@@ -377,6 +461,7 @@ func Test_ruleHandler_UpdateRule(t *testing.T) {
 		request, _ := json.Marshal(req)
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPut, uriWithID, ruleID, string(request))
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 		ruleService.On("UpdateRule", context.Request().Context(), ruleID, mock.AnythingOfType("entities.Rule")).
 			Return(nil)
 
@@ -505,6 +590,7 @@ func Test_ruleHandler_UpdateRule(t *testing.T) {
 		request, _ := json.Marshal(req)
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPut, uriWithID, ruleID, string(request))
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 		ruleService.On("UpdateRule", context.Request().Context(), ruleID, mock.AnythingOfType("entities.Rule")).
 			Return(nil)
 
@@ -523,6 +609,7 @@ func Test_ruleHandler_UpdateRule(t *testing.T) {
 		request, _ := json.Marshal(rule)
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPut, "/", ruleID, string(request))
+		ruleService.On("LintRule", mock.Anything, mock.Anything).Return(entities.RuleLintResponse{}, nil)
 		ruleService.On("UpdateRule", context.Request().Context(), ruleID, mock.Anything).Return(nil)
 
 		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
@@ -562,7 +649,7 @@ func Test_ruleHandler_DeleteRule(t *testing.T) {
 		expectedError := errors.New("empty id")
 
 		context, recorder := echo.SetupAsRecorder(http.MethodPut, uriWithID, "", "")
-		ruleService.On("RemoveRule", context.Request().Context(), "").Return(expectedError)
+		ruleService.On("RemoveRule", context.Request().Context(), "", "").Return(expectedError)
 
 		handler := rules.NewRulesHandler(configs, ruleService, logger)
 		handler.RemoveRule(context)
@@ -576,7 +663,7 @@ func Test_ruleHandler_DeleteRule(t *testing.T) {
 		expectedError := errors.New("Service error")
 
 		context, rec := echo.SetupAsRecorder(http.MethodDelete, "/risk-rules/v1/rules/", "611709bb70cbe3606baa3f8d", "")
-		ruleService.On("RemoveRule", context.Request().Context(), "611709bb70cbe3606baa3f8d").Return(expectedError).Once()
+		ruleService.On("RemoveRule", context.Request().Context(), "611709bb70cbe3606baa3f8d", "").Return(expectedError).Once()
 
 		handler := rules.NewRulesHandler(configs, ruleService, logger)
 		handler.RemoveRule(context)
@@ -591,7 +678,7 @@ func Test_ruleHandler_DeleteRule(t *testing.T) {
 		ruleService := new(mocks.RuleServiceMock)
 
 		context, recorder := echo.SetupAsRecorder(http.MethodDelete, "/risk-rules/v1/rules/", "611709bb70cbe3606baa3f8d", "")
-		ruleService.On("RemoveRule", context.Request().Context(), "611709bb70cbe3606baa3f8d").Return(nil)
+		ruleService.On("RemoveRule", context.Request().Context(), "611709bb70cbe3606baa3f8d", "").Return(nil)
 
 		handler := rules.NewRulesHandler(configs, ruleService, logger)
 		err := handler.RemoveRule(context)
@@ -601,6 +688,68 @@ func Test_ruleHandler_DeleteRule(t *testing.T) {
 	})
 }
 
+func Test_ruleHandler_Versions(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+	ruleID := "611709bb70cbe3606baa3f8d"
+
+	t.Run("get versions successful", func(t *testing.T) {
+		ruleService := new(mocks.RuleServiceMock)
+		rule := testdata.GetDefaultRuleWithID(false)
+		versions := []entities.RuleVersion{entities.NewRuleVersion(entities.RuleCreated, "me", nil, rule)}
+
+		context, recorder := echo.SetupAsRecorder(http.MethodGet, rulesUri, ruleID, "")
+		ruleService.On("GetVersions", context.Request().Context(), ruleID).Return(versions, nil).Once()
+
+		handler := rules.NewRulesHandler(configs, ruleService, logger)
+		handler.GetVersions(context)
+
+		var got []entities.RuleVersion
+		_ = json.Unmarshal(recorder.Body.Bytes(), &got)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Len(t, got, 1)
+		assert.Equal(t, entities.RuleCreated, got[0].Action)
+	})
+
+	t.Run("get version with invalid revision return bad request", func(t *testing.T) {
+		context, recorder := echo.SetupAsRecorder(http.MethodGet, rulesUri, ruleID, "")
+		context.SetParamNames("id", "n")
+		context.SetParamValues(ruleID, "last")
+
+		handler := rules.NewRulesHandler(configs, new(mocks.RuleServiceMock), logger)
+		handler.GetVersion(context)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("rollback without author return bad request", func(t *testing.T) {
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, rulesUri, ruleID, `{}`)
+		context.SetParamNames("id", "n")
+		context.SetParamValues(ruleID, "2")
+
+		handler := rules.NewRulesHandler(configs, new(mocks.RuleServiceMock), logger)
+		handler.RollbackRule(context)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("rollback successful", func(t *testing.T) {
+		ruleService := new(mocks.RuleServiceMock)
+		rule := testdata.GetDefaultRuleWithID(false)
+
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, rulesUri, ruleID, `{"author": "me"}`)
+		context.SetParamNames("id", "n")
+		context.SetParamValues(ruleID, "2")
+		ruleService.On("RollbackRule", context.Request().Context(), ruleID, int64(2), "me").Return(rule, nil).Once()
+
+		handler := rules.NewRulesHandler(configs, ruleService, logger)
+		handler.RollbackRule(context)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		ruleService.AssertExpectations(t)
+	})
+}
+
 func interfaceToRules(in interface{}) []entities.Rule {
 	interfaceArray := in.([]interface{})
 	var ruleItem entities.Rule
@@ -614,3 +763,108 @@ func interfaceToRules(in interface{}) []entities.Rule {
 	}
 	return rules
 }
+
+func Test_ruleHandler_Review(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+	ruleID := "611709bb70cbe3606baa3f8d"
+
+	t.Run("submit without author return bad request", func(t *testing.T) {
+		ruleService := new(mocks.RuleServiceMock)
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, rulesUri, ruleID, `{}`)
+
+		handler := rules.NewRulesHandler(configs, ruleService, logger)
+		handler.SubmitRule(context)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		ruleService.AssertNotCalled(t, "SubmitRule", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("approve successful", func(t *testing.T) {
+		ruleService := new(mocks.RuleServiceMock)
+		rule := testdata.GetDefaultRuleWithID(false)
+		rule.Status = entities.RuleStatusActive
+		review := entities.RuleReviewRequest{Author: "reviewer@conekta.com", Comment: "looks good"}
+
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, rulesUri, ruleID,
+			`{"author": "reviewer@conekta.com", "comment": "looks good"}`)
+		ruleService.On("ApproveRule", context.Request().Context(), ruleID, review).Return(rule, nil).Once()
+
+		handler := rules.NewRulesHandler(configs, ruleService, logger)
+		handler.ApproveRule(context)
+
+		var got entities.Rule
+		_ = json.Unmarshal(recorder.Body.Bytes(), &got)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, entities.RuleStatusActive, got.Status)
+		ruleService.AssertExpectations(t)
+	})
+}
+
+func Test_ruleHandler_LintRule(t *testing.T) {
+	logger, _ := logs.New()
+	warning := entities.RuleLintWarning{
+		Code:    entities.RuleLintContradiction,
+		Message: "the clauses on 'amount' can not be true at the same time, the rule never matches",
+		Clauses: []int{0, 1},
+	}
+
+	t.Run("when the rule is linted then return the warnings", func(t *testing.T) {
+		request, _ := json.Marshal(testdata.GetDefaultRuleRequestWithAmount())
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, rulesUri+"/lint", "", string(request))
+		ruleService := new(mocks.RuleServiceMock)
+		ruleService.On("LintRule", context.Request().Context(), mock.AnythingOfType("entities.Rule")).
+			Return(entities.RuleLintResponse{Rule: "amount > 100", Warnings: []entities.RuleLintWarning{warning}}, nil)
+
+		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
+		err := handler.LintRule(context)
+
+		var response entities.RuleLintResponse
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, []entities.RuleLintWarning{warning}, response.Warnings)
+	})
+
+	t.Run("when the rule is not valid then return bad request", func(t *testing.T) {
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, rulesUri+"/lint", "", testdata.GetJsonMalformed())
+		ruleService := new(mocks.RuleServiceMock)
+
+		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
+		handler.LintRule(context)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		ruleService.AssertNotCalled(t, "LintRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when a rule is created with warnings then add them as headers", func(t *testing.T) {
+		request, _ := json.Marshal(testdata.GetDefaultRuleRequestWithAmount())
+		context, recorder := echo.SetupAsRecorder(http.MethodPost, rulesUri, "", string(request))
+		ruleService := new(mocks.RuleServiceMock)
+		ruleService.On("AddRule", context.Request().Context(), mock.Anything).Return(entities.Rule{}, nil)
+		ruleService.On("LintRule", context.Request().Context(), mock.Anything).
+			Return(entities.RuleLintResponse{Warnings: []entities.RuleLintWarning{warning}}, nil)
+
+		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
+		handler.AddRule(context)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, fmt.Sprintf("299 - %q", warning.Message), recorder.Header().Get("Warning"))
+	})
+
+	t.Run("when the lint of an updated rule fails then keep the response", func(t *testing.T) {
+		ruleID := "611709bb70cbe3606baa3f8d"
+		request, _ := json.Marshal(testdata.GetDefaultRuleRequestWithAmount())
+		context, recorder := echo.SetupAsRecorder(http.MethodPut, rulesUri+"/", ruleID, string(request))
+		ruleService := new(mocks.RuleServiceMock)
+		ruleService.On("UpdateRule", context.Request().Context(), ruleID, mock.Anything).Return(nil)
+		ruleService.On("LintRule", context.Request().Context(), mock.Anything).
+			Return(entities.RuleLintResponse{}, errors.New("mongo is down"))
+
+		handler := rules.NewRulesHandler(config.Config{}, ruleService, logger)
+		handler.UpdateRule(context)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Warning"))
+	})
+}
@@ -0,0 +1,514 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/conekta/risk-rules/internal/entities"
+)
+
+const (
+	lintOperatorEqual        = "=="
+	lintOperatorNotEqual     = "!="
+	lintOperatorGreater      = ">"
+	lintOperatorGreaterEqual = ">="
+	lintOperatorLess         = "<"
+	lintOperatorLessEqual    = "<="
+	lintOperatorIn           = "in"
+
+	conditionAnd = "and"
+	conditionOr  = "or"
+)
+
+var lintOperators = map[string]string{
+	"eq": lintOperatorEqual, "==": lintOperatorEqual,
+	"ne": lintOperatorNotEqual, "!=": lintOperatorNotEqual,
+	"gt": lintOperatorGreater, ">": lintOperatorGreater,
+	"ge": lintOperatorGreaterEqual, ">=": lintOperatorGreaterEqual,
+	"lt": lintOperatorLess, "<": lintOperatorLess,
+	"le": lintOperatorLessEqual, "<=": lintOperatorLessEqual,
+	"in": lintOperatorIn,
+}
+
+var negatedLintOperators = map[string]string{
+	lintOperatorEqual:        lintOperatorNotEqual,
+	lintOperatorNotEqual:     lintOperatorEqual,
+	lintOperatorGreater:      lintOperatorLessEqual,
+	lintOperatorGreaterEqual: lintOperatorLess,
+	lintOperatorLess:         lintOperatorGreaterEqual,
+	lintOperatorLessEqual:    lintOperatorGreater,
+}
+
+type lintValueKind int
+
+const (
+	lintText lintValueKind = iota
+	lintNumber
+	lintBoolean
+)
+
+// lintClause is a clause with the operator aliases and the not resolved, negated is only kept for the
+// operators that have no opposite one.
+type lintClause struct {
+	index    int
+	field    string
+	operator string
+	values   []string
+	negated  bool
+	kind     lintValueKind
+}
+
+type lintBound struct {
+	value     float64
+	inclusive bool
+}
+
+// lintDomain is the set of values a field can take after applying a conjunction of clauses.
+type lintDomain struct {
+	kind     lintValueKind
+	lower    *lintBound
+	upper    *lintBound
+	allowed  map[string]bool
+	excluded map[string]bool
+	opaque   bool
+}
+
+// lintRule looks for contradictions and tautologies inside the rule, and for the stored rules that are
+// equivalent to it or that make it unreachable. The engine evaluates and/or from left to right with the same
+// precedence, so only the rules joined by a single kind of condition are analyzed.
+func lintRule(rule entities.Rule, rules []entities.Rule) []entities.RuleLintWarning {
+	warnings := make([]entities.RuleLintWarning, 0)
+	if !isLintable(rule) {
+		return warnings
+	}
+
+	clauses := newLintClauses(rule.Rules)
+	connector := lintConnector(rule.Rules)
+
+	if connector == conditionAnd || len(clauses) == 1 {
+		warnings = append(warnings, findContradictions(clauses)...)
+	}
+	if connector == conditionOr || len(clauses) == 1 {
+		warnings = append(warnings, findTautologies(clauses)...)
+	}
+
+	for _, other := range rules {
+		if other.ID == rule.ID || !other.IsEvaluable() || !isLintable(other) {
+			continue
+		}
+
+		if isEquivalent(rule, other) {
+			warnings = append(warnings, entities.RuleLintWarning{
+				Code:          entities.RuleLintEquivalent,
+				Message:       fmt.Sprintf("the rule is equivalent to the rule '%s' with decision %s", other.Rule, other.Decision),
+				RelatedRuleID: other.ID.Hex(),
+			})
+		}
+
+		if isShadowedBy(rule, other) {
+			warnings = append(warnings, entities.RuleLintWarning{
+				Code: entities.RuleLintShadowed,
+				Message: fmt.Sprintf("the rule is unreachable for the charges of %s: the rule '%s' of %s matches them first "+
+					"with the same decision", describeScope(other), other.Rule, other.ConsoleComponent()),
+				RelatedRuleID: other.ID.Hex(),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// isLintable skips the groups with negations or mixed conditions, their flat clauses do not mean the same as the tree.
+func isLintable(rule entities.Rule) bool {
+	return rule.Group == nil || rule.Group.IsFlat()
+}
+
+func lintConnector(contents []entities.RuleContent) string {
+	connector := ""
+	for i := 1; i < len(contents); i++ {
+		content := contents[i]
+		condition := strings.ToLower(content.Condition)
+		if connector != "" && condition != connector {
+			return ""
+		}
+		connector = condition
+	}
+
+	return connector
+}
+
+func findContradictions(clauses []lintClause) []entities.RuleLintWarning {
+	warnings := make([]entities.RuleLintWarning, 0)
+	for _, fieldClauses := range groupByField(clauses) {
+		domain := newLintDomain(fieldClauses)
+		if domain.isEmpty() {
+			warnings = append(warnings, entities.RuleLintWarning{
+				Code: entities.RuleLintContradiction,
+				Message: fmt.Sprintf("the clauses on '%s' can not be true at the same time, the rule never matches",
+					fieldClauses[0].field),
+				Clauses: clauseIndexes(fieldClauses),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// findTautologies negates the clauses joined by or, when no value survives the negations the clauses match any value.
+func findTautologies(clauses []lintClause) []entities.RuleLintWarning {
+	warnings := make([]entities.RuleLintWarning, 0)
+	for _, fieldClauses := range groupByField(clauses) {
+		negations := make([]lintClause, 0, len(fieldClauses))
+		for _, clause := range fieldClauses {
+			negations = append(negations, clause.negate())
+		}
+
+		if newLintDomain(negations).isEmpty() {
+			warnings = append(warnings, entities.RuleLintWarning{
+				Code:    entities.RuleLintTautology,
+				Message: fmt.Sprintf("the clauses on '%s' match any value, the rule always matches", fieldClauses[0].field),
+				Clauses: clauseIndexes(fieldClauses),
+			})
+		}
+	}
+
+	return warnings
+}
+
+func isEquivalent(rule, other entities.Rule) bool {
+	if rule.IsTest != other.IsTest || !rule.HasSameScope(other) || len(rule.Rules) != len(other.Rules) {
+		return false
+	}
+
+	connector := lintConnector(rule.Rules)
+	if len(rule.Rules) > 1 && (connector == "" || connector != lintConnector(other.Rules)) {
+		return false
+	}
+
+	keys, otherKeys := clauseKeys(newLintClauses(rule.Rules)), clauseKeys(newLintClauses(other.Rules))
+	for i := range keys {
+		if keys[i] != otherKeys[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isShadowedBy checks if the other rule is evaluated on an earlier component of the default console for the same
+// charges and matches every charge the rule matches with the same decision.
+func isShadowedBy(rule, other entities.Rule) bool {
+	if rule.IsTest || other.IsTest || rule.Decision != other.Decision || !rule.IsGlobal {
+		return false
+	}
+
+	position, otherPosition := consolePosition(rule.ConsoleComponent()), consolePosition(other.ConsoleComponent())
+	if position < 0 || otherPosition < 0 || otherPosition >= position {
+		return false
+	}
+
+	if len(rule.Rules) > 1 && lintConnector(rule.Rules) != conditionAnd {
+		return false
+	}
+
+	clauses, otherClauses := newLintClauses(rule.Rules), newLintClauses(other.Rules)
+	otherConnector := lintConnector(other.Rules)
+	if len(otherClauses) > 1 && otherConnector == "" {
+		return false
+	}
+
+	for _, otherClause := range otherClauses {
+		implied := implies(clauses, otherClause)
+		if otherConnector == conditionOr && implied {
+			return true
+		}
+		if otherConnector != conditionOr && !implied {
+			return false
+		}
+	}
+
+	return otherConnector != conditionOr
+}
+
+// implies checks if every value allowed by the conjunction of clauses satisfies the given clause.
+func implies(clauses []lintClause, clause lintClause) bool {
+	key := clause.key()
+	fieldClauses := make([]lintClause, 0)
+	for _, current := range clauses {
+		if current.key() == key {
+			return true
+		}
+		if current.field == clause.field {
+			fieldClauses = append(fieldClauses, current)
+		}
+	}
+
+	if len(fieldClauses) == 0 {
+		return false
+	}
+
+	return newLintDomain(append(fieldClauses, clause.negate())).isEmpty()
+}
+
+// shadowingComponents returns the components of the default console evaluated before the rule, only a global rule is
+// checked for shadowing.
+func shadowingComponents(rule entities.Rule) []entities.ConsoleComponent {
+	components := make([]entities.ConsoleComponent, 0)
+	if rule.IsTest || !rule.IsGlobal {
+		return components
+	}
+
+	var charge entities.ChargeRequest
+	charge.SetDefaultConsoleOnlyRules()
+	position := consolePosition(rule.ConsoleComponent())
+	for current, component := range charge.Console {
+		if current >= position {
+			break
+		}
+		components = append(components, component.Name)
+	}
+
+	return components
+}
+
+func consolePosition(component entities.ConsoleComponent) int {
+	var charge entities.ChargeRequest
+	charge.SetDefaultConsoleOnlyRules()
+	for position, consoleComponent := range charge.Console {
+		if consoleComponent.Name == component {
+			return position
+		}
+	}
+
+	return -1
+}
+
+func describeScope(rule entities.Rule) string {
+	switch rule.ConsoleComponent() {
+	case entities.CompanyRulesType:
+		return fmt.Sprintf("the company %s", *rule.CompanyID)
+	case entities.FamilyCompanyRulesType:
+		return fmt.Sprintf("the family %s", *rule.FamilyMccID)
+	case entities.FamilyMccRulesType:
+		return fmt.Sprintf("the family company %s", *rule.FamilyCompanyID)
+	}
+
+	return "every company"
+}
+
+func newLintClauses(contents []entities.RuleContent) []lintClause {
+	clauses := make([]lintClause, 0, len(contents))
+	for index, content := range contents {
+		clause := lintClause{index: index, field: content.Field, operator: strings.ToLower(content.Operator)}
+		if operator, ok := lintOperators[clause.operator]; ok {
+			clause.operator = operator
+		}
+
+		clause.values = []string{content.Value}
+		if clause.operator == lintOperatorIn {
+			clause.values = splitListValue(content.Value)
+		}
+		clause.kind = lintText
+		for i, value := range clause.values {
+			clause.kind, clause.values[i] = normalizeLintValue(value, i == 0, clause.kind)
+		}
+		sort.Strings(clause.values)
+
+		if content.Not {
+			clause = clause.negate()
+		}
+		clauses = append(clauses, clause)
+	}
+
+	return clauses
+}
+
+func splitListValue(value string) []string {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value), "["), "]")
+	return strings.Split(trimmed, ",")
+}
+
+func normalizeLintValue(value string, isFirst bool, kind lintValueKind) (lintValueKind, string) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) {
+		return lintText, strings.Trim(value, `"`)
+	}
+
+	if number, err := strconv.ParseFloat(value, 64); err == nil && (isFirst || kind == lintNumber) {
+		return lintNumber, strconv.FormatFloat(number, 'g', -1, 64)
+	}
+
+	if lowered := strings.ToLower(value); (lowered == "true" || lowered == "false") && (isFirst || kind == lintBoolean) {
+		return lintBoolean, lowered
+	}
+
+	return lintText, value
+}
+
+func (clause lintClause) negate() lintClause {
+	if operator, ok := negatedLintOperators[clause.operator]; ok {
+		clause.operator = operator
+		return clause
+	}
+
+	clause.negated = !clause.negated
+	return clause
+}
+
+func (clause lintClause) key() string {
+	return fmt.Sprintf("%s %s %t %s", clause.field, clause.operator, clause.negated, strings.Join(clause.values, ","))
+}
+
+func clauseKeys(clauses []lintClause) []string {
+	keys := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		keys = append(keys, clause.key())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func clauseIndexes(clauses []lintClause) []int {
+	indexes := make([]int, 0, len(clauses))
+	for _, clause := range clauses {
+		indexes = append(indexes, clause.index)
+	}
+
+	return indexes
+}
+
+func groupByField(clauses []lintClause) [][]lintClause {
+	fields := make([]string, 0)
+	groups := make(map[string][]lintClause)
+	for _, clause := range clauses {
+		if _, ok := groups[clause.field]; !ok {
+			fields = append(fields, clause.field)
+		}
+		groups[clause.field] = append(groups[clause.field], clause)
+	}
+
+	grouped := make([][]lintClause, 0, len(fields))
+	for _, field := range fields {
+		grouped = append(grouped, groups[field])
+	}
+
+	return grouped
+}
+
+func newLintDomain(clauses []lintClause) lintDomain {
+	domain := lintDomain{kind: clauses[0].kind, excluded: make(map[string]bool)}
+	for _, clause := range clauses {
+		domain.apply(clause)
+	}
+
+	return domain
+}
+
+func (domain *lintDomain) apply(clause lintClause) {
+	if clause.kind != domain.kind {
+		domain.opaque = true
+		return
+	}
+
+	switch {
+	case clause.operator == lintOperatorEqual:
+		domain.restrict(clause.values)
+	case clause.operator == lintOperatorNotEqual:
+		domain.excluded[clause.values[0]] = true
+	case clause.operator == lintOperatorIn && !clause.negated:
+		domain.restrict(clause.values)
+	case clause.operator == lintOperatorIn && clause.negated:
+		for _, value := range clause.values {
+			domain.excluded[value] = true
+		}
+	case domain.kind == lintNumber && isRangeOperator(clause.operator):
+		value, _ := strconv.ParseFloat(clause.values[0], 64)
+		domain.limit(clause.operator, value)
+	default:
+		domain.opaque = true
+	}
+}
+
+func isRangeOperator(operator string) bool {
+	return operator == lintOperatorGreater || operator == lintOperatorGreaterEqual ||
+		operator == lintOperatorLess || operator == lintOperatorLessEqual
+}
+
+func (domain *lintDomain) restrict(values []string) {
+	allowed := make(map[string]bool, len(values))
+	for _, value := range values {
+		if domain.allowed == nil || domain.allowed[value] {
+			allowed[value] = true
+		}
+	}
+	domain.allowed = allowed
+}
+
+func (domain *lintDomain) limit(operator string, value float64) {
+	inclusive := operator == lintOperatorGreaterEqual || operator == lintOperatorLessEqual
+	bound := &lintBound{value: value, inclusive: inclusive}
+
+	if operator == lintOperatorGreater || operator == lintOperatorGreaterEqual {
+		if domain.lower == nil || value > domain.lower.value || (value == domain.lower.value && !inclusive) {
+			domain.lower = bound
+		}
+		return
+	}
+
+	if domain.upper == nil || value < domain.upper.value || (value == domain.upper.value && !inclusive) {
+		domain.upper = bound
+	}
+}
+
+// isEmpty is only true when no value can satisfy the clauses, the domains with operators the linter does not
+// understand are never empty.
+func (domain lintDomain) isEmpty() bool {
+	if domain.opaque {
+		return false
+	}
+
+	allowed := domain.allowed
+	if allowed == nil && domain.kind == lintBoolean {
+		allowed = map[string]bool{"true": true, "false": true}
+	}
+
+	if allowed != nil {
+		for value := range allowed {
+			if domain.contains(value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if domain.lower == nil || domain.upper == nil {
+		return false
+	}
+
+	if domain.lower.value != domain.upper.value {
+		return domain.lower.value > domain.upper.value
+	}
+
+	return !domain.lower.inclusive || !domain.upper.inclusive ||
+		domain.excluded[strconv.FormatFloat(domain.lower.value, 'g', -1, 64)]
+}
+
+func (domain lintDomain) contains(value string) bool {
+	if domain.excluded[value] {
+		return false
+	}
+
+	if domain.kind != lintNumber {
+		return true
+	}
+
+	number, _ := strconv.ParseFloat(value, 64)
+	if domain.lower != nil && (number < domain.lower.value || (number == domain.lower.value && !domain.lower.inclusive)) {
+		return false
+	}
+
+	return domain.upper == nil || number < domain.upper.value || (number == domain.upper.value && domain.upper.inclusive)
+}
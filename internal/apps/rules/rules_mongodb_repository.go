@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/conekta/risk-rules/pkg/strings"
 	"github.com/conekta/risk-rules/pkg/text"
@@ -25,6 +26,7 @@ type RuleRepository interface {
 	RemoveRule(ctx context.Context, ruleID string) error
 	FindRulesPaged(ctx context.Context, filter entities.RuleFilter, pagination entities.Pagination) (entities.PagedResponse, error)
 	GetRulesByFilters(ctx context.Context, filter entities.RuleFilter, component entities.ConsoleComponent) ([]entities.Rule, error)
+	FindLintCandidates(ctx context.Context, rule entities.Rule, components []entities.ConsoleComponent) ([]entities.Rule, error)
 }
 
 type RuleMongoDBRepository struct {
@@ -73,12 +75,22 @@ func (r *RuleMongoDBRepository) UpdateRule(ctx context.Context, ruleID string, r
 				primitive.E{Key: "is_global", Value: rule.IsGlobal},
 				primitive.E{Key: "description", Value: rule.Description},
 				primitive.E{Key: "rules", Value: rule.Rules},
+				primitive.E{Key: "group", Value: rule.Group},
 				primitive.E{Key: "rule", Value: rule.Rule},
 				primitive.E{Key: "company_id", Value: rule.CompanyID},
 				primitive.E{Key: "family_company_id", Value: rule.FamilyCompanyID},
 				primitive.E{Key: "family_id", Value: rule.FamilyMccID},
 				primitive.E{Key: "decision", Value: rule.Decision},
 				primitive.E{Key: "is_yellow_flag", Value: rule.IsYellowFlag},
+				primitive.E{Key: "is_score_rule", Value: rule.IsScoreRule},
+				primitive.E{Key: "weight", Value: rule.Weight},
+				primitive.E{Key: "active_from", Value: rule.ActiveFrom},
+				primitive.E{Key: "active_until", Value: rule.ActiveUntil},
+				primitive.E{Key: "schedule", Value: rule.Schedule},
+				primitive.E{Key: "revision", Value: rule.Revision},
+				primitive.E{Key: "status", Value: rule.Status},
+				primitive.E{Key: "review", Value: rule.Review},
+				primitive.E{Key: "pending", Value: rule.Pending},
 			},
 		},
 	}
@@ -122,7 +134,7 @@ func (r *RuleMongoDBRepository) GetRulesByFilters(ctx context.Context, filter en
 	component entities.ConsoleComponent) ([]entities.Rule, error) {
 	collection := r.mongodb.Collection(r.config.MongoDB.Collections.Rules)
 
-	query := buildRulesFilter(component, filter)
+	query := bson.M{"$and": []bson.M{buildRulesFilter(component, filter), buildEvaluableFilter()}}
 
 	rules := make([]entities.Rule, 0)
 	cur, err := collection.Find(ctx, query)
@@ -142,6 +154,45 @@ func (r *RuleMongoDBRepository) GetRulesByFilters(ctx context.Context, filter en
 	return rules, nil
 }
 
+// FindLintCandidates returns the evaluable rules of the scope of the rule and the ones of the given components with its
+// decision, which are the only ones the linter compares it against.
+func (r *RuleMongoDBRepository) FindLintCandidates(ctx context.Context, rule entities.Rule,
+	components []entities.ConsoleComponent) ([]entities.Rule, error) {
+	collection := r.mongodb.Collection(r.config.MongoDB.Collections.Rules)
+
+	scopes := []bson.M{{
+		"company_id":        buildScopeValueFilter(rule.CompanyID),
+		"family_id":         buildScopeValueFilter(rule.FamilyMccID),
+		"family_company_id": buildScopeValueFilter(rule.FamilyCompanyID),
+		"is_test":           rule.IsTest,
+	}}
+	for _, component := range components {
+		scopes = append(scopes, bson.M{"$and": []bson.M{
+			buildComponentScopeFilter(component),
+			{"decision": rule.Decision},
+			{"is_test": bson.M{"$ne": true}},
+		}})
+	}
+	query := bson.M{"$and": []bson.M{{"$or": scopes}, buildEvaluableFilter()}}
+
+	rules := make([]entities.Rule, 0)
+	cur, err := collection.Find(ctx, query)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "FindLintCandidates"),
+			text.RuleID, rule.ID.Hex())
+		return nil, err
+	}
+
+	err = cur.All(ctx, &rules)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "FindLintCandidates"),
+			text.RuleID, rule.ID.Hex())
+		return nil, err
+	}
+
+	return rules, nil
+}
+
 func (r *RuleMongoDBRepository) FindRulesPaged(ctx context.Context, filter entities.RuleFilter,
 	pagination entities.Pagination) (entities.PagedResponse, error) {
 	collection := r.mongodb.Collection(r.config.MongoDB.Collections.Rules)
@@ -177,6 +228,14 @@ func (r *RuleMongoDBRepository) FindRulesPaged(ctx context.Context, filter entit
 		query = append(query, bson.E{Key: "family_company_id", Value: filter.FamilyCompanyID})
 	}
 
+	if !strings.IsEmpty(filter.Active) {
+		query = append(query, buildActiveFilter(filter.Active == "true", time.Now().UTC()))
+	}
+
+	if !strings.IsEmpty(filter.Status) {
+		query = append(query, buildStatusFilter(entities.RuleStatus(filter.Status)))
+	}
+
 	total, _ := collection.CountDocuments(ctx, query)
 	hasMore := pagination.HasMorePages(total)
 
@@ -203,6 +262,34 @@ func (r *RuleMongoDBRepository) FindRulesPaged(ctx context.Context, filter entit
 	return entities.NewPagedResponse(rules, hasMore, total), nil
 }
 
+// buildActiveFilter matches the activation dates only, recurring schedules are checked at evaluation time.
+func buildActiveFilter(active bool, now time.Time) bson.E {
+	if active {
+		return bson.E{Key: "$and", Value: []bson.M{
+			{"$or": []bson.M{{"active_from": nil}, {"active_from": bson.M{"$lte": now}}}},
+			{"$or": []bson.M{{"active_until": nil}, {"active_until": bson.M{"$gt": now}}}},
+		}}
+	}
+
+	return bson.E{Key: "$or", Value: []bson.M{
+		{"active_from": bson.M{"$gt": now}},
+		{"active_until": bson.M{"$lte": now}},
+	}}
+}
+
+// buildStatusFilter matches the rules without status as active ones, they were stored before the approval workflow.
+func buildStatusFilter(status entities.RuleStatus) bson.E {
+	if status == entities.RuleStatusActive {
+		return bson.E{Key: "status", Value: bson.M{"$in": []interface{}{status, nil}}}
+	}
+
+	return bson.E{Key: "status", Value: status}
+}
+
+func buildEvaluableFilter() bson.M {
+	return bson.M{"status": bson.M{"$in": []interface{}{entities.RuleStatusActive, entities.RuleStatusDraft, nil}}}
+}
+
 func buildRulesFilter(component entities.ConsoleComponent, filter entities.RuleFilter) bson.M {
 	var query []bson.M
 	findQuery := bson.M{}
@@ -232,13 +319,41 @@ func buildRulesFilter(component entities.ConsoleComponent, filter entities.RuleF
 		return buildRulesIdentityModule(filter)
 	}
 
+	if component == entities.ScoreRulesType {
+		return buildRulesScoreRules(filter)
+	}
+
 	query = append(query, bson.M{"rules.field": bson.M{"$not": bson.M{"$regex": `email_proximity.*`}}})
+	query = append(query, bson.M{"is_score_rule": bson.M{"$ne": true}})
 
 	findQuery["$and"] = query
 
 	return findQuery
 }
 
+// buildScopeValueFilter matches the rules without the scope field when the value is empty, like HasSameScope does.
+func buildScopeValueFilter(value *string) interface{} {
+	if strings.IsStringPointerEmpty(value) {
+		return bson.M{"$in": []interface{}{nil, strings.Empty}}
+	}
+
+	return *value
+}
+
+func buildComponentScopeFilter(component entities.ConsoleComponent) bson.M {
+	notEmpty := bson.M{"$nin": []interface{}{nil, strings.Empty}}
+	switch component {
+	case entities.CompanyRulesType:
+		return bson.M{"company_id": notEmpty}
+	case entities.FamilyCompanyRulesType:
+		return bson.M{"family_id": notEmpty}
+	case entities.FamilyMccRulesType:
+		return bson.M{"family_company_id": notEmpty}
+	}
+
+	return bson.M{"is_global": true}
+}
+
 func buildRulesIdentityModule(filter entities.RuleFilter) bson.M {
 	var query []bson.M
 	findQuery := bson.M{}
@@ -260,8 +375,30 @@ func buildRulesIdentityModule(filter entities.RuleFilter) bson.M {
 	}
 
 	query = append(query, bson.M{"rules.field": bson.M{"$regex": `email_proximity.*`}})
+	query = append(query, bson.M{"is_score_rule": bson.M{"$ne": true}})
 
 	findQuery["$and"] = query
 
 	return findQuery
 }
+
+func buildRulesScoreRules(filter entities.RuleFilter) bson.M {
+	scopes := []bson.M{{"is_global": true}}
+
+	if !strings.IsEmpty(filter.CompanyID) {
+		scopes = append(scopes, bson.M{"company_id": filter.CompanyID})
+	}
+
+	if !strings.IsEmpty(filter.FamilyID) {
+		scopes = append(scopes, bson.M{"family_id": filter.FamilyID})
+	}
+
+	if len(filter.FamilyCompaniesIDs) > 0 {
+		scopes = append(scopes, bson.M{"family_company_id": bson.M{"$in": filter.FamilyCompaniesIDs}})
+	}
+
+	return bson.M{"$and": []bson.M{
+		{"is_score_rule": true},
+		{"$or": scopes},
+	}}
+}
@@ -3,51 +3,81 @@ package rules
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/conekta/risk-rules/internal/entities/exceptions"
 
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/strings"
 	"github.com/conekta/risk-rules/pkg/text"
 )
 
 const ruleServiceMethod = "rules.service.%s"
 
+// RuleWriter is the part of the rule service that validates and stores the rules, the services that write rules in
+// bulk share it so the rules are written the same way.
+type RuleWriter interface {
+	BuildExpression(rule entities.Rule) string
+	ValidateSyntax(ctx context.Context, rule entities.Rule) error
+	AddVersion(ctx context.Context, version entities.RuleVersion) error
+	InvalidateRule(ruleID string)
+}
+
 type RuleService interface {
+	RuleWriter
 	AddRule(ctx context.Context, rule entities.Rule) (entities.Rule, error)
 	UpdateRule(ctx context.Context, ruleID string, ruleReq entities.Rule) error
-	RemoveRule(ctx context.Context, ID string) error
+	RemoveRule(ctx context.Context, ID string, author string) error
 	ListRules(ctx context.Context, ruleFilter entities.RuleFilter, pagination entities.Pagination) (entities.PagedResponse, error)
 	BuildRule(ruleContent []entities.RuleContent) string
+	GetVersions(ctx context.Context, ruleID string) ([]entities.RuleVersion, error)
+	GetVersion(ctx context.Context, ruleID string, revision int64) (entities.RuleVersion, error)
+	RollbackRule(ctx context.Context, ruleID string, revision int64, author string) (entities.Rule, error)
+	SubmitRule(ctx context.Context, ruleID string, review entities.RuleReviewRequest) (entities.Rule, error)
+	ApproveRule(ctx context.Context, ruleID string, review entities.RuleReviewRequest) (entities.Rule, error)
+	RejectRule(ctx context.Context, ruleID string, review entities.RuleReviewRequest) (entities.Rule, error)
+	RetireRule(ctx context.Context, ruleID string, review entities.RuleReviewRequest) (entities.Rule, error)
+	LintRule(ctx context.Context, rule entities.Rule) (entities.RuleLintResponse, error)
 }
 
 type ruleService struct {
-	config         config.Config
-	ruleRepository RuleRepository
-	rules          RuleValidator
-	logs           logs.Logger
-	datadog        datadog.Metricer
+	config            config.Config
+	ruleRepository    RuleRepository
+	versionRepository RuleVersionRepository
+	auditService      audit.AuditService
+	rules             RuleValidator
+	catalog           RuleCatalogService
+	logs              logs.Logger
+	datadog           datadog.Metricer
 }
 
 func NewRulesService(cfg config.Config,
 	rules RuleValidator,
+	catalog RuleCatalogService,
 	ruleRepository RuleRepository,
+	versionRepository RuleVersionRepository,
+	auditService audit.AuditService,
 	logger logs.Logger,
 	metric datadog.Metricer) RuleService {
 	return &ruleService{
-		config:         cfg,
-		ruleRepository: ruleRepository,
-		rules:          rules,
-		logs:           logger,
-		datadog:        metric,
+		config:            cfg,
+		ruleRepository:    ruleRepository,
+		versionRepository: versionRepository,
+		auditService:      auditService,
+		rules:             rules,
+		catalog:           catalog,
+		logs:              logger,
+		datadog:           metric,
 	}
 }
 
 func (service *ruleService) AddRule(ctx context.Context, rule entities.Rule) (entities.Rule, error) {
-	rule.Rule = service.BuildRule(rule.Rules)
+	rule.Rule = service.BuildExpression(rule)
 	err := service.validate(ctx, rule)
 	if err != nil {
 		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleServiceMethod, "AddRule"))
@@ -73,13 +103,25 @@ func (service *ruleService) AddRule(ctx context.Context, rule entities.Rule) (en
 		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveRuleMetricName)
 		return rule, err
 	}
+	if err = service.AddVersion(ctx, entities.NewRuleVersion(entities.RuleCreated, ruleResp.CreatedBy, nil,
+		ruleResp)); err != nil {
+		if removeErr := service.ruleRepository.RemoveRule(ctx, ruleResp.ID.Hex()); removeErr != nil {
+			service.logs.Error(ctx, removeErr.Error(), text.LogTagMethod, fmt.Sprintf(ruleServiceMethod, "AddRule"),
+				text.RuleID, ruleResp.ID.Hex())
+		}
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveRuleMetricName)
+		return rule, err
+	}
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditRules, ruleResp.ID.Hex(),
+		entities.AuditCreated, ruleResp.CreatedBy, nil))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveRuleMetricName)
 	return ruleResp, err
 }
 
 func (service *ruleService) UpdateRule(ctx context.Context, ruleID string, rule entities.Rule) error {
-	rule.Rule = service.BuildRule(rule.Rules)
+	rule.Rule = service.BuildExpression(rule)
 	err := service.validate(ctx, rule)
 	if err != nil {
 		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleServiceMethod, "UpdateRule"))
@@ -91,43 +133,307 @@ func (service *ruleService) UpdateRule(ctx context.Context, ruleID string, rule
 		return err
 	}
 
-	if rule.IsContained(rulesFound.Data.([]entities.Rule)) && !service.isTheSame(rulesFound.Data.([]entities.Rule), ruleID) {
+	if rule.IsContained(rulesFound.Data.([]entities.Rule)) && !isTheSame(rulesFound.Data.([]entities.Rule), ruleID) {
 		err = exceptions.NewDuplicatedException(fmt.Sprintf("the rule '%s' already exist", rule.Rule))
 		service.logs.Error(ctx, err.Error())
 		return err
 	}
 
-	err = service.ruleRepository.UpdateRule(ctx, ruleID, rule)
+	current, err := service.findRule(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+	author := strings.StringPointerToString(rule.UpdatedBy)
+	rule.ID = current.ID
+	rule.CreatedAt = current.CreatedAt
+	rule.CreatedBy = current.CreatedBy
+	rule.Revision = current.Revision + 1
+	rule = current.Edit(rule)
+
+	before := service.auditService.Snapshot(ctx, entities.AuditRules, ruleID)
+	version := entities.NewRuleVersion(entities.RuleUpdated, author, &current, rule)
+	err = service.writeRevision(ctx, version, func() error {
+		return service.ruleRepository.UpdateRule(ctx, ruleID, rule)
+	})
 	metricData := metrics.NewMetricData(ctx, "Update", ruleServiceMethod, service.config.Env)
 	if err != nil {
 		metricData.SetResult(false)
 		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateRuleMetricName)
 		return err
 	}
+	service.rules.Invalidate(ruleID)
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditRules, ruleID, entities.AuditUpdated,
+		author, before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateRuleMetricName)
 	return nil
 }
 
-func (service *ruleService) RemoveRule(ctx context.Context, ruleID string) error {
-	err := service.ruleRepository.RemoveRule(ctx, ruleID)
+func (service *ruleService) RemoveRule(ctx context.Context, ruleID string, author string) error {
+	current, err := service.findRule(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+
+	before := service.auditService.Snapshot(ctx, entities.AuditRules, ruleID)
+	deleted := current
+	deleted.Revision = current.Revision + 1
+	err = service.writeRevision(ctx, entities.NewRuleVersion(entities.RuleDeleted, author, nil, deleted), func() error {
+		return service.ruleRepository.RemoveRule(ctx, ruleID)
+	})
 	metricData := metrics.NewMetricData(context.TODO(), "Remove", ruleServiceMethod, service.config.Env)
 	if err != nil {
 		metricData.SetResult(false)
 		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteRuleMetricName)
 		return err
 	}
+	service.rules.Invalidate(ruleID)
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditRules, ruleID, entities.AuditDeleted,
+		author, before))
 	metricData.SetResult(true)
 	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteRuleMetricName)
 	return nil
 }
 
+// LintRule never blocks a write, the warnings are compared against the stored rules of the same scope and, because
+// shadowing crosses scopes, the ones of the console components evaluated before the rule.
+func (service *ruleService) LintRule(ctx context.Context, rule entities.Rule) (entities.RuleLintResponse, error) {
+	metricData := metrics.NewMetricData(ctx, "Lint", ruleServiceMethod, service.config.Env)
+	rule.Rule = service.BuildExpression(rule)
+
+	rules, err := service.ruleRepository.FindLintCandidates(ctx, rule, shadowingComponents(rule))
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleServiceMethod, "LintRule"))
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.LintRuleMetricName)
+		return entities.RuleLintResponse{}, err
+	}
+
+	warnings := lintRule(rule, rules)
+	metricData.AddCustomTags([]string{fmt.Sprintf(text.MetricTagHasWarnings, len(warnings) > 0)})
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.LintRuleMetricName)
+	return entities.RuleLintResponse{Rule: rule.Rule, Warnings: warnings}, nil
+}
+
 func (service *ruleService) ListRules(ctx context.Context, ruleFilter entities.RuleFilter,
 	pagination entities.Pagination) (entities.PagedResponse, error) {
 	return service.ruleRepository.FindRulesPaged(ctx, ruleFilter, pagination)
 }
 
+func (service *ruleService) GetVersions(ctx context.Context, ruleID string) ([]entities.RuleVersion, error) {
+	return service.versionRepository.FindByRuleID(ctx, ruleID)
+}
+
+func (service *ruleService) GetVersion(ctx context.Context, ruleID string,
+	revision int64) (entities.RuleVersion, error) {
+	return service.versionRepository.FindByRevision(ctx, ruleID, revision)
+}
+
+func (service *ruleService) RollbackRule(ctx context.Context, ruleID string, revision int64,
+	author string) (entities.Rule, error) {
+	metricData := metrics.NewMetricData(ctx, "Rollback", ruleServiceMethod, service.config.Env)
+	rule, err := service.rollbackRule(ctx, ruleID, revision, author)
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleServiceMethod, "RollbackRule"),
+			text.RuleID, ruleID)
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.RollbackRuleMetricName)
+		return entities.Rule{}, err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.RollbackRuleMetricName)
+	return rule, nil
+}
+
+func (service *ruleService) rollbackRule(ctx context.Context, ruleID string, revision int64,
+	author string) (entities.Rule, error) {
+	version, err := service.versionRepository.FindByRevision(ctx, ruleID, revision)
+	if err != nil {
+		return entities.Rule{}, err
+	}
+
+	if version.Action == entities.RuleDeleted {
+		return entities.Rule{}, exceptions.NewInvalidRequest(
+			fmt.Sprintf("revision %d is a deletion, choose a previous revision", revision))
+	}
+
+	versions, err := service.versionRepository.FindByRuleID(ctx, ruleID)
+	if err != nil {
+		return entities.Rule{}, err
+	}
+
+	now := time.Now().UTC()
+	rule := version.Snapshot
+	rule.Pending = nil
+	rule.Rule = service.BuildExpression(rule)
+	rule.UpdatedAt = &now
+	rule.UpdatedBy = &author
+	rule.Revision = versions[len(versions)-1].Revision + 1
+	if err = service.validate(ctx, rule); err != nil {
+		return entities.Rule{}, err
+	}
+
+	before := service.auditService.Snapshot(ctx, entities.AuditRules, ruleID)
+	current, err := service.findRule(ctx, ruleID)
+	if err != nil && !isNotFound(err) {
+		return entities.Rule{}, err
+	}
+
+	var previous *entities.Rule
+	if err == nil {
+		previous = &current
+		rule = current.Edit(rule)
+	} else {
+		rule.Status = entities.RuleStatusDraft
+		rule.Review = nil
+	}
+	rolledBack := entities.NewRuleVersion(entities.RuleRolledBack, author, previous, rule)
+	rolledBack.RolledBackFrom = &revision
+	err = service.writeRevision(ctx, rolledBack, func() error {
+		if previous == nil {
+			_, err := service.ruleRepository.AddRule(ctx, rule)
+			return err
+		}
+		return service.ruleRepository.UpdateRule(ctx, ruleID, rule)
+	})
+	if err != nil {
+		return entities.Rule{}, err
+	}
+
+	service.rules.Invalidate(ruleID)
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditRules, ruleID,
+		entities.AuditAction(entities.RuleRolledBack), author, before))
+
+	return rule, nil
+}
+
+func (service *ruleService) SubmitRule(ctx context.Context, ruleID string,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	return service.reviewRule(ctx, ruleID, entities.RuleSubmitted, review)
+}
+
+func (service *ruleService) ApproveRule(ctx context.Context, ruleID string,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	return service.reviewRule(ctx, ruleID, entities.RuleApproved, review)
+}
+
+func (service *ruleService) RejectRule(ctx context.Context, ruleID string,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	return service.reviewRule(ctx, ruleID, entities.RuleRejected, review)
+}
+
+func (service *ruleService) RetireRule(ctx context.Context, ruleID string,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	return service.reviewRule(ctx, ruleID, entities.RuleRetired, review)
+}
+
+func (service *ruleService) reviewRule(ctx context.Context, ruleID string, action entities.RuleAction,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	metricData := metrics.NewMetricData(ctx, "Review", ruleServiceMethod, service.config.Env)
+	metricData.AddCustomTags([]string{fmt.Sprintf(text.MetricStatus, action)})
+
+	current, err := service.findRule(ctx, ruleID)
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.ReviewRuleMetricName)
+		return entities.Rule{}, err
+	}
+
+	rule := current
+	if err = rule.Transition(action, review, time.Now().UTC()); err != nil {
+		err = exceptions.NewInvalidRequest(err.Error())
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleServiceMethod, "reviewRule"),
+			text.RuleID, ruleID)
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.ReviewRuleMetricName)
+		return entities.Rule{}, err
+	}
+	rule.Revision = current.Revision + 1
+
+	before := service.auditService.Snapshot(ctx, entities.AuditRules, ruleID)
+	err = service.writeRevision(ctx, entities.NewRuleVersion(action, review.Author, &current, rule), func() error {
+		return service.ruleRepository.UpdateRule(ctx, ruleID, rule)
+	})
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.ReviewRuleMetricName)
+		return entities.Rule{}, err
+	}
+
+	service.rules.Invalidate(ruleID)
+	service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditRules, ruleID, entities.AuditAction(action),
+		review.Author, before))
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.ReviewRuleMetricName)
+	return rule, nil
+}
+
+func (service *ruleService) findRule(ctx context.Context, ruleID string) (entities.Rule, error) {
+	rulesFound, err := service.ruleRepository.FindRulesPaged(ctx, entities.RuleFilter{ID: ruleID}, entities.Pagination{})
+	if err != nil {
+		return entities.Rule{}, err
+	}
+
+	rules, _ := rulesFound.Data.([]entities.Rule)
+	if len(rules) == 0 {
+		return entities.Rule{}, exceptions.NewNotFoundException(fmt.Sprintf("rule '%s' not found", ruleID))
+	}
+
+	return rules[0], nil
+}
+
+// writeRevision records the version before writing the rule, the unique index on the rule and revision rejects a
+// concurrent change of the same revision, and the version is removed when the rule can not be written.
+func (service *ruleService) writeRevision(ctx context.Context, version entities.RuleVersion, write func() error) error {
+	if err := service.AddVersion(ctx, version); err != nil {
+		return err
+	}
+
+	if err := write(); err != nil {
+		service.removeVersion(ctx, version)
+		return err
+	}
+
+	return nil
+}
+
+func (service *ruleService) AddVersion(ctx context.Context, version entities.RuleVersion) error {
+	if err := service.versionRepository.Add(ctx, version); err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleServiceMethod, "AddVersion"),
+			text.RuleID, version.RuleID.Hex())
+		return err
+	}
+
+	return nil
+}
+
+func (service *ruleService) InvalidateRule(ruleID string) {
+	service.rules.Invalidate(ruleID)
+}
+
+func (service *ruleService) removeVersion(ctx context.Context, version entities.RuleVersion) {
+	if err := service.versionRepository.Remove(ctx, version); err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleServiceMethod, "removeVersion"),
+			text.RuleID, version.RuleID.Hex())
+	}
+}
+
+func isNotFound(err error) bool {
+	_, ok := err.(exceptions.NotFoundException)
+	return ok
+}
+
 func (service *ruleService) validate(ctx context.Context, rule entities.Rule) error {
+	if err := service.catalog.Validate(ctx, rule.Rules); err != nil {
+		return err
+	}
+
+	return service.ValidateSyntax(ctx, rule)
+}
+
+func (service *ruleService) ValidateSyntax(ctx context.Context, rule entities.Rule) error {
 	entity := entities.ChargeRequest{}
 	data, _ := entity.ToMap()
 
@@ -146,7 +452,16 @@ func (service *ruleService) BuildRule(ruleContent []entities.RuleContent) string
 	return ruleResult
 }
 
-func (service *ruleService) isTheSame(rules []entities.Rule, ruleID string) bool {
+// BuildExpression builds the engine rule from the group of the rule when it has one, otherwise from its flat clauses.
+func (service *ruleService) BuildExpression(rule entities.Rule) string {
+	if rule.Group != nil {
+		return rule.Group.RuleAsString()
+	}
+
+	return service.BuildRule(rule.Rules)
+}
+
+func isTheSame(rules []entities.Rule, ruleID string) bool {
 	for _, rule := range rules {
 		if rule.ID.Hex() == ruleID {
 			return true
@@ -10,10 +10,13 @@ import (
 	"github.com/conekta/risk-rules/internal/apps/rules"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
 	"github.com/conekta/risk-rules/test/mocks"
 	"github.com/conekta/risk-rules/test/mocks/datadog"
 	"github.com/conekta/risk-rules/test/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func Test_ruleService_List(t *testing.T) {
@@ -30,7 +33,7 @@ func Test_ruleService_List(t *testing.T) {
 		}
 		ruleRepository := new(mocks.RulesRepositoryMock)
 		ruleRepository.On("FindRulesPaged", nil, ruleFilter, pagination).Return(notRulesFound, nil)
-		service := rules.NewRulesService(config.Config{}, nil, ruleRepository, nil, nil)
+		service := rules.NewRulesService(config.Config{}, nil, nil, ruleRepository, nil, mocks.NewAuditServiceMock(), nil, nil)
 
 		pagedRules, err := service.ListRules(nil, ruleFilter, pagination)
 
@@ -55,7 +58,7 @@ func Test_ruleService_Build(t *testing.T) {
 		},
 		}
 
-		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil)
+		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil, mocks.NewAuditServiceMock(), nil, nil)
 		ruleBuilt := service.BuildRule(rulesContent)
 
 		assert.Equal(t, rulesAsString, ruleBuilt)
@@ -71,7 +74,7 @@ func Test_ruleService_Build(t *testing.T) {
 		},
 		}
 
-		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil)
+		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil, mocks.NewAuditServiceMock(), nil, nil)
 		ruleBuilt := service.BuildRule(rulesContent)
 
 		assert.Equal(t, ruleExpected, ruleBuilt)
@@ -87,7 +90,7 @@ func Test_ruleService_Build(t *testing.T) {
 		},
 		}
 
-		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil)
+		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil, mocks.NewAuditServiceMock(), nil, nil)
 		ruleBuilt := service.BuildRule(rulesContent)
 
 		assert.Equal(t, ruleExpected, ruleBuilt)
@@ -111,7 +114,7 @@ func Test_ruleService_Build(t *testing.T) {
 			},
 		}
 		ruleExpected := fmt.Sprintf("not payment_method.country in %s and live_mode eq true", rulesContent[0].Value)
-		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil)
+		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil, mocks.NewAuditServiceMock(), nil, nil)
 		ruleBuilt := service.BuildRule(rulesContent)
 
 		assert.Equalf(t, ruleExpected, ruleBuilt, "The rule should be %s", ruleExpected)
@@ -126,7 +129,7 @@ func Test_ruleService_Build(t *testing.T) {
 		},
 		}
 		ruleExpected := fmt.Sprintf("payment_method.country in %s", rulesContent[0].Value)
-		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil)
+		service := rules.NewRulesService(config.Config{}, nil, nil, nil, nil, mocks.NewAuditServiceMock(), nil, nil)
 		ruleBuilt := service.BuildRule(rulesContent)
 
 		assert.Equalf(t, ruleExpected, ruleBuilt, "The rule should be %s", ruleExpected)
@@ -142,11 +145,13 @@ func Test_ruleService_CreateRule(t *testing.T) {
 		cxt := context.TODO()
 		rule := testdata.GetRuleWithRuleEmpty(true)
 		expectedError := fmt.Errorf("[RuleValidator.evaluate] empty rule: [%v]", rule)
-		rulesValidator := rules.NewRulesValidator(logger)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
 
-		service := rules.NewRulesService(config.Config{}, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		_, err := service.AddRule(cxt, rule)
 
@@ -154,17 +159,35 @@ func Test_ruleService_CreateRule(t *testing.T) {
 		assert.EqualValues(t, err, expectedError)
 	})
 
+	t.Run("test when rule does not match the catalog", func(t *testing.T) {
+		rule := testdata.GetDefaultRule(true)
+		rule.Rules = []entities.RuleContent{{Field: "email", Operator: "==", Value: "fraud@mail.com", Condition: "and"}}
+		ruleRepository := new(mocks.RulesRepositoryMock)
+
+		service := rules.NewRulesService(config.Config{}, nil, newRuleCatalogService(nil), ruleRepository,
+			newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
+
+		_, err := service.AddRule(context.TODO(), rule)
+
+		exception, ok := err.(exceptions.InvalidRuleException)
+		assert.True(t, ok)
+		assert.Equal(t, exceptions.RuleFieldNotFound, exception.Clauses()[0].Code)
+		ruleRepository.AssertNotCalled(t, "AddRule", mock.Anything, mock.Anything)
+	})
+
 	t.Run("test when add rule fail", func(t *testing.T) {
 		rule := testdata.GetDefaultRule(true)
 		expectedError := errors.New("error")
-		rulesValidator := rules.NewRulesValidator(logger)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
 
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
 		ruleRepository.On("AddRule", rule, context.TODO()).Return(entities.Rule{}, expectedError)
 
-		service := rules.NewRulesService(config.Config{}, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		_, err := service.AddRule(context.TODO(), rule)
 
@@ -175,7 +198,7 @@ func Test_ruleService_CreateRule(t *testing.T) {
 	t.Run("test when add rule successful", func(t *testing.T) {
 
 		rule := testdata.GetDefaultRule(true)
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 		rulesList := make([]entities.Rule, 0)
 		rulesList = append(rulesList, testdata.GetDefaultRuleEmailBlockedGlobal(true))
 		ruleRepository := new(mocks.RulesRepositoryMock)
@@ -185,7 +208,9 @@ func Test_ruleService_CreateRule(t *testing.T) {
 		ruleRepository.On("GetFamilyCompaniesFromFilter", context.TODO(), entities.FamilyFilter{}).
 			Return(entities.Family{}, nil)
 
-		service := rules.NewRulesService(config.Config{}, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		response, err := service.AddRule(context.TODO(), rule)
 
@@ -193,9 +218,55 @@ func Test_ruleService_CreateRule(t *testing.T) {
 		assert.Equal(t, rule, response)
 	})
 
+	t.Run("test when the version can not be written, then the rule is removed", func(t *testing.T) {
+		rule := testdata.GetDefaultRule(true)
+		expectedError := errors.New("connection lost")
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
+		rulesList := []entities.Rule{testdata.GetDefaultRuleEmailBlockedGlobal(true)}
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
+		ruleRepository.On("AddRule", rule, context.TODO()).Return(rule, nil)
+		ruleRepository.On("RemoveRule", context.TODO(), rule.ID.Hex()).Return(nil).Once()
+		versionRepository := new(mocks.RuleVersionRepositoryMock)
+		versionRepository.On("Add", context.TODO(), mock.AnythingOfType("entities.RuleVersion")).Return(expectedError)
+
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, versionRepository, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		_, err := service.AddRule(context.TODO(), rule)
+
+		assert.Equal(t, expectedError, err)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("test when add rule with group then build the rule from the group", func(t *testing.T) {
+		rule := testdata.GetDefaultRule(true)
+		request := testdata.GetDefaultRuleRequestWithGroup()
+		rule.Group = request.Group
+		rule.Rules = request.Group.Contents()
+		rule.Rule = "amount < 8 or (amount > 1000 and live_mode == false)"
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: rulesList}, nil)
+		ruleRepository.On("AddRule", rule, context.TODO()).Return(rule, nil)
+
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+		building := rule
+		building.Rule = ""
+
+		response, err := service.AddRule(context.TODO(), building)
+
+		assert.NoError(t, err)
+		assert.Equal(t, rule, response)
+	})
+
 	t.Run("test when add rule fail per rule duplicated", func(t *testing.T) {
 		rule := testdata.GetDefaultRule(true)
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 		expectedError := errors.New("the rule 'device_fingerprint == \"w45345\"' already exist")
 		ruleRepository := new(mocks.RulesRepositoryMock)
 
@@ -207,7 +278,9 @@ func Test_ruleService_CreateRule(t *testing.T) {
 			Return(entities.PagedResponse{Data: rulesReturn}, nil)
 		ruleRepository.On("AddRule", rule, context.TODO()).Return(rule, nil)
 
-		service := rules.NewRulesService(config.Config{}, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		_, err := service.AddRule(context.TODO(), rule)
 
@@ -217,12 +290,14 @@ func Test_ruleService_CreateRule(t *testing.T) {
 
 	t.Run("test when add rule successful and operator is IN", func(t *testing.T) {
 		rule := testdata.GetDefaultRuleIn(true)
-		rulesValidator := rules.NewRulesValidator(logger)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
 		ruleRepository.On("AddRule", rule, context.TODO()).Return(rule, nil)
-		service := rules.NewRulesService(config.Config{}, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		response, err := service.AddRule(context.TODO(), rule)
 
@@ -233,12 +308,14 @@ func Test_ruleService_CreateRule(t *testing.T) {
 	t.Run("test when add rule successful and operator is IN and value are a couple of number", func(t *testing.T) {
 
 		rule := testdata.GetDefaultRuleInNumber(true)
-		rulesValidator := rules.NewRulesValidator(logger)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
 		ruleRepository.On("AddRule", rule, context.TODO()).Return(rule, nil)
-		service := rules.NewRulesService(config.Config{}, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		response, err := service.AddRule(context.TODO(), rule)
 
@@ -248,14 +325,16 @@ func Test_ruleService_CreateRule(t *testing.T) {
 
 	t.Run("test when add rule fail where GetRulesByFilters return error", func(t *testing.T) {
 		rule := testdata.GetDefaultRule(true)
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 		expectedError := errors.New("error")
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{}, expectedError)
 		ruleRepository.On("AddRule", context.TODO(), rule).Return(nil)
 
-		service := rules.NewRulesService(config.Config{}, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		_, err := service.AddRule(context.TODO(), rule)
 
@@ -266,7 +345,7 @@ func Test_ruleService_CreateRule(t *testing.T) {
 	t.Run("test when add rule successful", func(t *testing.T) {
 
 		rule := testdata.GetDefaultRule(true)
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 		rulesList := make([]entities.Rule, 0)
 		rulesList = append(rulesList, testdata.GetDefaultRuleEmailBlockedGlobal(true))
 		ruleRepository := new(mocks.RulesRepositoryMock)
@@ -276,7 +355,9 @@ func Test_ruleService_CreateRule(t *testing.T) {
 		ruleRepository.On("GetFamilyCompaniesFromFilter", context.TODO(), entities.FamilyFilter{}).
 			Return(entities.Family{}, nil)
 
-		service := rules.NewRulesService(config.Config{}, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(config.Config{}, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		response, err := service.AddRule(context.TODO(), rule)
 
@@ -294,12 +375,14 @@ func Test_ruleService_UpdateRule(t *testing.T) {
 	t.Run("test when validate rule fail", func(t *testing.T) {
 		rule := testdata.GetRuleWithRuleEmptyServiceError(true)
 		expectedError := fmt.Errorf("[RuleValidator.evaluate] empty rule: [%v]", rule)
-		rulesValidator := rules.NewRulesValidator(logger)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
 		ruleRepository.On("UpdateRule", rule, context.TODO()).Return(expectedError)
 
-		service := rules.NewRulesService(configs, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
 
@@ -310,13 +393,15 @@ func Test_ruleService_UpdateRule(t *testing.T) {
 	t.Run("test when update rule fail", func(t *testing.T) {
 		rule := testdata.GetDefaultRule(true)
 		expectedError := errors.New("error")
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{}, expectedError)
 		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", rule).Return(expectedError)
 
-		service := rules.NewRulesService(configs, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
 
@@ -326,13 +411,18 @@ func Test_ruleService_UpdateRule(t *testing.T) {
 
 	t.Run("test when update rule successful", func(t *testing.T) {
 		rule := testdata.GetDefaultRule(true)
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
+		current := getCurrentRule()
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
-		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", rule).Return(nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: "611709bb70cbe3606baa3f8d"}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil)
+		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", getExpectedUpdatedRule(rule, current)).Return(nil)
 
-		service := rules.NewRulesService(configs, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
 
@@ -341,22 +431,106 @@ func Test_ruleService_UpdateRule(t *testing.T) {
 
 	t.Run("test when update rule successful", func(t *testing.T) {
 		rule := testdata.GetDefaultRuleWithFormula(true)
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
+		current := getCurrentRule()
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
-		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", rule).Return(nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: "611709bb70cbe3606baa3f8d"}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil)
+		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", getExpectedUpdatedRule(rule, current)).Return(nil)
 
-		service := rules.NewRulesService(configs, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
 
 		assert.Nil(t, err)
 	})
 
+	t.Run("test when the rule is active, then it keeps deciding and the edit waits as a pending revision", func(t *testing.T) {
+		rule := testdata.GetDefaultRule(true)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
+
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		current := getCurrentRule()
+		current.Status = entities.RuleStatusActive
+		current.Rule = "amount > 100"
+		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: "611709bb70cbe3606baa3f8d"}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil)
+		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", mock.MatchedBy(func(stored entities.Rule) bool {
+			return stored.Status == entities.RuleStatusActive && stored.Rule == current.Rule &&
+				stored.Revision == current.Revision+1 && stored.Pending != nil &&
+				stored.Pending.Status == entities.RuleStatusDraft && stored.Pending.Rule == rule.Rule
+		})).Return(nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
+
+		assert.NoError(t, err)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("test when a concurrent change wrote the revision, then the rule is not updated", func(t *testing.T) {
+		rule := testdata.GetDefaultRule(true)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
+		expectedError := exceptions.NewDuplicatedException("revision 4 of rule '611709bb70cbe3606baa3f8d' was written by another change")
+
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		current := getCurrentRule()
+		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: "611709bb70cbe3606baa3f8d"}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil)
+		versionRepository := new(mocks.RuleVersionRepositoryMock)
+		versionRepository.On("Add", context.TODO(), mock.MatchedBy(func(version entities.RuleVersion) bool {
+			return version.Revision == current.Revision+1
+		})).Return(expectedError).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, versionRepository, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
+
+		assert.Equal(t, expectedError, err)
+		ruleRepository.AssertNotCalled(t, "UpdateRule", mock.Anything, mock.Anything, mock.Anything)
+		versionRepository.AssertExpectations(t)
+	})
+
+	t.Run("test when the rule can not be written, then its version is removed", func(t *testing.T) {
+		rule := testdata.GetDefaultRule(true)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
+		expectedError := errors.New("connection lost")
+
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		current := getCurrentRule()
+		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesList}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: "611709bb70cbe3606baa3f8d"}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil)
+		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", getExpectedUpdatedRule(rule, current)).
+			Return(expectedError)
+		versionRepository := newRuleVersionRepositoryMock()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, versionRepository, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
+
+		assert.Equal(t, expectedError, err)
+		versionRepository.AssertCalled(t, "Remove", context.TODO(), mock.MatchedBy(func(version entities.RuleVersion) bool {
+			return version.Revision == current.Revision+1
+		}))
+	})
+
 	t.Run("test when update rule fail per rule duplicated", func(t *testing.T) {
 		rule := testdata.GetDefaultRule(true)
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 		expectedError := errors.New("the rule 'device_fingerprint == \"w45345\"' already exist")
 		rulesReturn := make([]entities.Rule, 0)
 		rulesReturn = append(rulesReturn, rule)
@@ -366,7 +540,9 @@ func Test_ruleService_UpdateRule(t *testing.T) {
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{Data: rulesReturn}, expectedError)
 		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", rule).Return(nil)
 
-		service := rules.NewRulesService(configs, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
 
@@ -376,14 +552,16 @@ func Test_ruleService_UpdateRule(t *testing.T) {
 
 	t.Run("test when update rule fail where GetRulesByFilters return error", func(t *testing.T) {
 		rule := testdata.GetDefaultRule(true)
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 		expectedError := errors.New("error")
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
 		ruleRepository.On("FindRulesPaged", context.TODO(), rule.GetRuleFilter(), entities.Pagination{}).Return(entities.PagedResponse{}, expectedError)
 		ruleRepository.On("UpdateRule", context.TODO(), "611709bb70cbe3606baa3f8d", rule).Return(nil)
 
-		service := rules.NewRulesService(configs, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
 		err := service.UpdateRule(context.TODO(), "611709bb70cbe3606baa3f8d", rule)
 
@@ -398,29 +576,460 @@ func Test_ruleService_DeleteRule(t *testing.T) {
 
 	t.Run("test when delete rule fail", func(t *testing.T) {
 		expectedError := errors.New("error")
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: "611709bb70cbe3606baa3f8d"}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{getCurrentRule()}}, nil)
 		ruleRepository.On("RemoveRule", context.TODO(), "611709bb70cbe3606baa3f8d").Return(expectedError)
 
-		service := rules.NewRulesService(configs, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
-		err := service.RemoveRule(context.TODO(), "611709bb70cbe3606baa3f8d")
+		err := service.RemoveRule(context.TODO(), "611709bb70cbe3606baa3f8d", "carlos.maldonado@conekta.com")
 
 		assert.NotNil(t, err)
 		assert.Equal(t, err, expectedError)
 	})
 
 	t.Run("test when delete rule successful", func(t *testing.T) {
-		rulesValidator := rules.NewRulesValidator(nil)
+		rulesValidator := rules.NewRulesValidator(nil, rules.NewRuleEvaluatorCache(config.Config{}, nil, new(datadog.MetricsDogMock)))
 
 		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: "611709bb70cbe3606baa3f8d"}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{getCurrentRule()}}, nil)
 		ruleRepository.On("RemoveRule", context.TODO(), "611709bb70cbe3606baa3f8d").Return(nil)
 
-		service := rules.NewRulesService(configs, rulesValidator, ruleRepository, logger, new(datadog.MetricsDogMock))
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
 
-		err := service.RemoveRule(context.TODO(), "611709bb70cbe3606baa3f8d")
+		err := service.RemoveRule(context.TODO(), "611709bb70cbe3606baa3f8d", "carlos.maldonado@conekta.com")
 
 		assert.Nil(t, err)
 	})
 }
+
+func newRuleVersionRepositoryMock() *mocks.RuleVersionRepositoryMock {
+	versionRepository := new(mocks.RuleVersionRepositoryMock)
+	versionRepository.On("Add", mock.Anything, mock.AnythingOfType("entities.RuleVersion")).Return(nil)
+	versionRepository.On("Remove", mock.Anything, mock.AnythingOfType("entities.RuleVersion")).Return(nil)
+	return versionRepository
+}
+
+func getCurrentRule() entities.Rule {
+	rule := testdata.GetDefaultRule(false)
+	rule.ID, _ = primitive.ObjectIDFromHex("611709bb70cbe3606baa3f8d")
+	rule.Revision = 3
+	rule.Status = entities.RuleStatusDraft
+	return rule
+}
+
+func getExpectedUpdatedRule(rule, current entities.Rule) entities.Rule {
+	rule.ID = current.ID
+	rule.CreatedAt = current.CreatedAt
+	rule.CreatedBy = current.CreatedBy
+	rule.Revision = current.Revision + 1
+	rule.Status = entities.RuleStatusDraft
+	return rule
+}
+
+func Test_ruleService_RollbackRule(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+	ruleID := "611709bb70cbe3606baa3f8d"
+
+	getVersions := func(current entities.Rule) []entities.RuleVersion {
+		first := current
+		first.Revision = 1
+		first.Rules = []entities.RuleContent{{Field: "amount", Operator: ">", Value: "100", Condition: "and"}}
+		return []entities.RuleVersion{
+			entities.NewRuleVersion(entities.RuleCreated, "carlos.maldonado@conekta.com", nil, first),
+			entities.NewRuleVersion(entities.RuleUpdated, "carlos.maldonado@conekta.com", &first, current),
+		}
+	}
+
+	t.Run("when rule exists, then update it with the snapshot as a new revision", func(t *testing.T) {
+		current := getCurrentRule()
+		versions := getVersions(current)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(configs, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		versionRepository := new(mocks.RuleVersionRepositoryMock)
+
+		versionRepository.On("FindByRevision", context.TODO(), ruleID, int64(1)).Return(versions[0], nil).Once()
+		versionRepository.On("FindByRuleID", context.TODO(), ruleID).Return(versions, nil).Once()
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), ruleID, mock.AnythingOfType("entities.Rule")).Return(nil).Once()
+		versionRepository.On("Add", context.TODO(), mock.MatchedBy(func(version entities.RuleVersion) bool {
+			return version.Action == entities.RuleRolledBack && version.Revision == current.Revision+1 &&
+				*version.RolledBackFrom == 1 && len(version.Diff) > 0
+		})).Return(nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, versionRepository, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		rule, err := service.RollbackRule(context.TODO(), ruleID, 1, "carlos.maldonado@conekta.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "amount > 100", rule.Rule)
+		assert.Equal(t, current.Revision+1, rule.Revision)
+		ruleRepository.AssertExpectations(t)
+		versionRepository.AssertExpectations(t)
+	})
+
+	t.Run("when rule was deleted, then restore it with the same id", func(t *testing.T) {
+		current := getCurrentRule()
+		versions := getVersions(current)
+		deleted := current
+		deleted.Revision++
+		versions = append(versions, entities.NewRuleVersion(entities.RuleDeleted, "carlos.maldonado@conekta.com", nil, deleted))
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(configs, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		versionRepository := newRuleVersionRepositoryMock()
+
+		versionRepository.On("FindByRevision", context.TODO(), ruleID, int64(1)).Return(versions[0], nil).Once()
+		versionRepository.On("FindByRuleID", context.TODO(), ruleID).Return(versions, nil).Once()
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil).Once()
+		ruleRepository.On("AddRule", mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.ID == current.ID && rule.Revision == deleted.Revision+1
+		}), context.TODO()).Return(current, nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, versionRepository, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		_, err := service.RollbackRule(context.TODO(), ruleID, 1, "carlos.maldonado@conekta.com")
+
+		assert.NoError(t, err)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("when revision is a deletion, then return invalid request", func(t *testing.T) {
+		deleted := getCurrentRule()
+		versionRepository := new(mocks.RuleVersionRepositoryMock)
+		versionRepository.On("FindByRevision", context.TODO(), ruleID, int64(4)).
+			Return(entities.NewRuleVersion(entities.RuleDeleted, "carlos.maldonado@conekta.com", nil, deleted), nil).Once()
+
+		service := rules.NewRulesService(configs, nil, mocks.NewRuleCatalogServiceMock(),
+			nil, versionRepository, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
+
+		_, err := service.RollbackRule(context.TODO(), ruleID, 4, "carlos.maldonado@conekta.com")
+
+		assert.IsType(t, exceptions.NewInvalidRequest(""), err)
+	})
+}
+
+func Test_ruleService_ReviewRule(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+	ruleID := "611709bb70cbe3606baa3f8d"
+
+	getRuleWithStatus := func(status entities.RuleStatus) entities.Rule {
+		rule := getCurrentRule()
+		rule.CreatedBy = "carlos.maldonado@conekta.com"
+		rule.Status = status
+		return rule
+	}
+
+	t.Run("when draft is submitted, then it waits for approval", func(t *testing.T) {
+		current := getRuleWithStatus(entities.RuleStatusDraft)
+		review := entities.RuleReviewRequest{Author: "carlos.maldonado@conekta.com"}
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(configs, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		versionRepository := new(mocks.RuleVersionRepositoryMock)
+
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), ruleID, mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.Status == entities.RuleStatusPendingApproval && rule.Revision == current.Revision+1 &&
+				rule.Review.SubmittedBy == review.Author
+		})).Return(nil).Once()
+		versionRepository.On("Add", context.TODO(), mock.MatchedBy(func(version entities.RuleVersion) bool {
+			return version.Action == entities.RuleSubmitted && version.Author == review.Author &&
+				version.Revision == current.Revision+1
+		})).Return(nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, versionRepository, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		rule, err := service.SubmitRule(context.TODO(), ruleID, review)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.RuleStatusPendingApproval, rule.Status)
+		ruleRepository.AssertExpectations(t)
+		versionRepository.AssertExpectations(t)
+	})
+
+	t.Run("when the pending revision of an active rule is approved, then it replaces the active content", func(t *testing.T) {
+		current := getRuleWithStatus(entities.RuleStatusActive)
+		pending := getRuleWithStatus(entities.RuleStatusPendingApproval)
+		pending.Rule = "amount > 100"
+		current.Pending = &pending
+		review := entities.RuleReviewRequest{Author: "reviewer@conekta.com"}
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(configs, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), ruleID, mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.Status == entities.RuleStatusActive && rule.Rule == pending.Rule && rule.Pending == nil &&
+				rule.Revision == current.Revision+1
+		})).Return(nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		rule, err := service.ApproveRule(context.TODO(), ruleID, review)
+
+		assert.NoError(t, err)
+		assert.Equal(t, pending.Rule, rule.Rule)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("when the pending revision of an active rule is submitted, then the active content is kept", func(t *testing.T) {
+		current := getRuleWithStatus(entities.RuleStatusActive)
+		pending := getRuleWithStatus(entities.RuleStatusDraft)
+		pending.Rule = "amount > 100"
+		current.Pending = &pending
+		review := entities.RuleReviewRequest{Author: "carlos.maldonado@conekta.com"}
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(configs, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), ruleID, mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.Status == entities.RuleStatusActive && rule.Rule == current.Rule &&
+				rule.Pending.Status == entities.RuleStatusPendingApproval
+		})).Return(nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		_, err := service.SubmitRule(context.TODO(), ruleID, review)
+
+		assert.NoError(t, err)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("when another author approves, then the rule is active", func(t *testing.T) {
+		current := getRuleWithStatus(entities.RuleStatusPendingApproval)
+		review := entities.RuleReviewRequest{Author: "reviewer@conekta.com", Comment: "looks good"}
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(configs, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		versionRepository := newRuleVersionRepositoryMock()
+
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), ruleID, mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.Status == entities.RuleStatusActive && rule.Review.ReviewedBy == review.Author
+		})).Return(nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, versionRepository, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		rule, err := service.ApproveRule(context.TODO(), ruleID, review)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.RuleStatusActive, rule.Status)
+		assert.Equal(t, "looks good", rule.Review.Comment)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("when the author approves its own rule, then return invalid request", func(t *testing.T) {
+		current := getRuleWithStatus(entities.RuleStatusPendingApproval)
+		ruleRepository := new(mocks.RulesRepositoryMock)
+
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+
+		service := rules.NewRulesService(configs, nil, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
+
+		_, err := service.ApproveRule(context.TODO(), ruleID,
+			entities.RuleReviewRequest{Author: "Carlos.Maldonado@conekta.com"})
+
+		assert.IsType(t, exceptions.NewInvalidRequest(""), err)
+		ruleRepository.AssertNotCalled(t, "UpdateRule", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("when pending rule is rejected, then it goes back to draft", func(t *testing.T) {
+		current := getRuleWithStatus(entities.RuleStatusPendingApproval)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(configs, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), ruleID, mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.Status == entities.RuleStatusDraft
+		})).Return(nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(), logger,
+			new(datadog.MetricsDogMock))
+
+		_, err := service.RejectRule(context.TODO(), ruleID,
+			entities.RuleReviewRequest{Author: "reviewer@conekta.com", Comment: "too broad"})
+
+		assert.NoError(t, err)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("when rule without status is retired, then it is treated as active", func(t *testing.T) {
+		current := getRuleWithStatus("")
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(configs, logger, new(datadog.MetricsDogMock)))
+		ruleRepository := new(mocks.RulesRepositoryMock)
+
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), ruleID, mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.Status == entities.RuleStatusRetired
+		})).Return(nil).Once()
+
+		service := rules.NewRulesService(configs, rulesValidator, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, newRuleVersionRepositoryMock(), mocks.NewAuditServiceMock(), logger,
+			new(datadog.MetricsDogMock))
+
+		_, err := service.RetireRule(context.TODO(), ruleID, entities.RuleReviewRequest{Author: "reviewer@conekta.com"})
+
+		assert.NoError(t, err)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("when draft is approved, then return invalid request", func(t *testing.T) {
+		current := getRuleWithStatus(entities.RuleStatusDraft)
+		ruleRepository := new(mocks.RulesRepositoryMock)
+
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{ID: ruleID}, entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{current}}, nil).Once()
+
+		service := rules.NewRulesService(configs, nil, mocks.NewRuleCatalogServiceMock(),
+			ruleRepository, nil, mocks.NewAuditServiceMock(), logger, new(datadog.MetricsDogMock))
+
+		_, err := service.ApproveRule(context.TODO(), ruleID, entities.RuleReviewRequest{Author: "reviewer@conekta.com"})
+
+		assert.IsType(t, exceptions.NewInvalidRequest(""), err)
+	})
+}
+
+func Test_ruleService_LintRule(t *testing.T) {
+	logger, _ := logs.New()
+	companyID := "2"
+	newService := func(stored []entities.Rule, err error) rules.RuleService {
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindLintCandidates", context.TODO(), mock.AnythingOfType("entities.Rule"),
+			[]entities.ConsoleComponent{entities.CompanyRulesType, entities.FamilyCompanyRulesType,
+				entities.FamilyMccRulesType}).
+			Return(stored, err)
+		return rules.NewRulesService(config.Config{}, nil, nil, ruleRepository, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+	}
+	newRule := func(contents ...entities.RuleContent) entities.Rule {
+		return entities.Rule{ID: primitive.NewObjectID(), IsGlobal: true, Decision: entities.Declined, Rules: contents}
+	}
+
+	t.Run("when the clauses can not be true at the same time then warn a contradiction", func(t *testing.T) {
+		rule := newRule(
+			entities.RuleContent{Field: "amount", Operator: ">", Value: "100", Condition: "and"},
+			entities.RuleContent{Field: "email", Operator: "==", Value: "fraud@mail.com", Condition: "and"},
+			entities.RuleContent{Field: "amount", Operator: "<", Value: "50", Condition: "and"},
+		)
+
+		lint, err := newService(nil, nil).LintRule(context.TODO(), rule)
+
+		assert.NoError(t, err)
+		assert.Equal(t, `amount > 100 and email == "fraud@mail.com" and amount < 50`, lint.Rule)
+		assert.Len(t, lint.Warnings, 1)
+		assert.Equal(t, entities.RuleLintContradiction, lint.Warnings[0].Code)
+		assert.Equal(t, []int{0, 2}, lint.Warnings[0].Clauses)
+	})
+
+	t.Run("when the clauses match any value then warn a tautology", func(t *testing.T) {
+		rule := newRule(
+			entities.RuleContent{Field: "amount", Operator: ">", Value: "5", Condition: "and"},
+			entities.RuleContent{Field: "amount", Operator: "<=", Value: "5", Condition: "or"},
+		)
+
+		lint, err := newService(nil, nil).LintRule(context.TODO(), rule)
+
+		assert.NoError(t, err)
+		assert.Len(t, lint.Warnings, 1)
+		assert.Equal(t, entities.RuleLintTautology, lint.Warnings[0].Code)
+	})
+
+	t.Run("when a stored rule has the same clauses in another order then warn an equivalent rule", func(t *testing.T) {
+		stored := newRule(
+			entities.RuleContent{Field: "email", Operator: "==", Value: "fraud@mail.com", Condition: "and"},
+			entities.RuleContent{Field: "amount", Operator: ">", Value: "100", Condition: "and"},
+		)
+		rule := newRule(
+			entities.RuleContent{Field: "amount", Operator: ">", Value: "100", Condition: "and"},
+			entities.RuleContent{Field: "email", Operator: "==", Value: "fraud@mail.com", Condition: "and"},
+		)
+
+		lint, err := newService([]entities.Rule{stored, rule}, nil).LintRule(context.TODO(), rule)
+
+		assert.NoError(t, err)
+		assert.Len(t, lint.Warnings, 1)
+		assert.Equal(t, entities.RuleLintEquivalent, lint.Warnings[0].Code)
+		assert.Equal(t, stored.ID.Hex(), lint.Warnings[0].RelatedRuleID)
+	})
+
+	t.Run("when a company rule matches first with the same decision then warn a shadowed rule", func(t *testing.T) {
+		stored := newRule(entities.RuleContent{Field: "amount", Operator: ">", Value: "100", Condition: "and"})
+		stored.IsGlobal, stored.CompanyID = false, &companyID
+		rule := newRule(
+			entities.RuleContent{Field: "amount", Operator: ">", Value: "500", Condition: "and"},
+			entities.RuleContent{Field: "email", Operator: "==", Value: "fraud@mail.com", Condition: "and"},
+		)
+
+		lint, err := newService([]entities.Rule{stored}, nil).LintRule(context.TODO(), rule)
+
+		assert.NoError(t, err)
+		assert.Len(t, lint.Warnings, 1)
+		assert.Equal(t, entities.RuleLintShadowed, lint.Warnings[0].Code)
+		assert.Equal(t, stored.ID.Hex(), lint.Warnings[0].RelatedRuleID)
+	})
+
+	t.Run("when the company rule has another decision then return no warnings", func(t *testing.T) {
+		stored := newRule(entities.RuleContent{Field: "amount", Operator: ">", Value: "100", Condition: "and"})
+		stored.IsGlobal, stored.CompanyID, stored.Decision = false, &companyID, entities.Accepted
+		rule := newRule(entities.RuleContent{Field: "amount", Operator: ">", Value: "500", Condition: "and"})
+
+		lint, err := newService([]entities.Rule{stored}, nil).LintRule(context.TODO(), rule)
+
+		assert.NoError(t, err)
+		assert.Empty(t, lint.Warnings)
+	})
+
+	t.Run("when the rule is not global then only the rules of its scope are compared", func(t *testing.T) {
+		rule := newRule(entities.RuleContent{Field: "amount", Operator: ">", Value: "100", Condition: "and"})
+		rule.IsGlobal, rule.CompanyID = false, &companyID
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindLintCandidates", context.TODO(), mock.AnythingOfType("entities.Rule"),
+			[]entities.ConsoleComponent{}).Return([]entities.Rule{}, nil).Once()
+		service := rules.NewRulesService(config.Config{}, nil, nil, ruleRepository, nil, mocks.NewAuditServiceMock(),
+			logger, new(datadog.MetricsDogMock))
+
+		lint, err := service.LintRule(context.TODO(), rule)
+
+		assert.NoError(t, err)
+		assert.Empty(t, lint.Warnings)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("when the rules can not be found then return the error", func(t *testing.T) {
+		rule := newRule(entities.RuleContent{Field: "amount", Operator: ">", Value: "100", Condition: "and"})
+
+		_, err := newService(nil, errors.New("mongo is down")).LintRule(context.TODO(), rule)
+
+		assert.EqualError(t, err, "mongo is down")
+	})
+}
@@ -0,0 +1,468 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	snapshotRepositoryName = "rules.repository.snapshot.%s"
+
+	operationInsert     = "insert"
+	operationUpdate     = "update"
+	operationReplace    = "replace"
+	operationDelete     = "delete"
+	operationDrop       = "drop"
+	operationInvalidate = "invalidate"
+)
+
+type RuleSnapshotRepository interface {
+	RuleRepository
+	Load(ctx context.Context) error
+	Watch(ctx context.Context)
+}
+
+type ruleChangeEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID primitive.ObjectID `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument *entities.Rule `bson:"fullDocument"`
+}
+
+type rulesIndex struct {
+	byCompanyID       map[string][]entities.Rule
+	byFamilyID        map[string][]entities.Rule
+	byFamilyCompanyID map[string][]entities.Rule
+	global            []entities.Rule
+	yellowFlag        []entities.Rule
+	identityModule    []entities.Rule
+	scoreRules        []entities.Rule
+}
+
+type ruleSnapshotRepository struct {
+	RuleRepository
+	config  config.Config
+	mongodb mongodb.MongoDBier
+	log     logs.Logger
+	datadog datadog.Metricer
+	loading sync.Mutex
+	mutex   sync.RWMutex
+	rules   map[primitive.ObjectID]entities.Rule
+	index   *rulesIndex
+	// changed keeps the rules changed by the stream while a load runs, a nil rule is a removed one
+	changed map[primitive.ObjectID]*entities.Rule
+}
+
+func NewRuleSnapshotRepository(cfg config.Config, mongoDBier mongodb.MongoDBier, ruleRepository RuleRepository,
+	logger logs.Logger, metric datadog.Metricer) RuleSnapshotRepository {
+	return &ruleSnapshotRepository{
+		RuleRepository: ruleRepository,
+		config:         cfg,
+		mongodb:        mongoDBier,
+		log:            logger,
+		datadog:        metric,
+	}
+}
+
+func (r *ruleSnapshotRepository) GetRulesByFilters(ctx context.Context, filter entities.RuleFilter,
+	component entities.ConsoleComponent) ([]entities.Rule, error) {
+	if !r.config.RulesSnapshot.IsEnabled {
+		return r.RuleRepository.GetRulesByFilters(ctx, filter, component)
+	}
+
+	r.mutex.RLock()
+	if r.index != nil {
+		defer r.mutex.RUnlock()
+		return r.index.find(filter, component), nil
+	}
+	r.mutex.RUnlock()
+
+	return r.RuleRepository.GetRulesByFilters(ctx, filter, component)
+}
+
+// Load replaces the snapshot with the stored rules, the changes the stream applies while the rules are read are
+// replayed over them so a change is never overwritten by the older content of the load.
+func (r *ruleSnapshotRepository) Load(ctx context.Context) error {
+	if !r.config.RulesSnapshot.IsEnabled {
+		return nil
+	}
+
+	r.loading.Lock()
+	defer r.loading.Unlock()
+
+	r.mutex.Lock()
+	r.changed = make(map[primitive.ObjectID]*entities.Rule)
+	r.mutex.Unlock()
+
+	metricData := metrics.NewMetricData(ctx, "Load", snapshotRepositoryName, r.config.Env)
+	collection := r.mongodb.Collection(r.config.MongoDB.Collections.Rules)
+
+	rulesFound := make([]entities.Rule, 0)
+	cur, err := collection.Find(ctx, bson.M{})
+	if err == nil {
+		err = cur.All(ctx, &rulesFound)
+	}
+	if err != nil {
+		r.mutex.Lock()
+		r.changed = nil
+		r.mutex.Unlock()
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(snapshotRepositoryName, "Load"))
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(r.datadog, r.log, metricData, text.RulesSnapshotSyncMetricName)
+		return err
+	}
+
+	rulesByID := make(map[primitive.ObjectID]entities.Rule, len(rulesFound))
+	for _, rule := range rulesFound {
+		rulesByID[rule.ID] = rule
+	}
+
+	r.mutex.Lock()
+	changed := r.changed
+	r.changed = nil
+	if err = ctx.Err(); err != nil {
+		r.mutex.Unlock()
+		return err
+	}
+	for ruleID, rule := range changed {
+		if rule == nil {
+			delete(rulesByID, ruleID)
+			continue
+		}
+		rulesByID[ruleID] = *rule
+	}
+	r.rules = rulesByID
+	r.index = newRulesIndex(rulesByID)
+	r.mutex.Unlock()
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(r.datadog, r.log, metricData, text.RulesSnapshotSyncMetricName)
+	return nil
+}
+
+// Watch keeps the snapshot in sync with the change stream of the rules, the snapshot is only served while the stream
+// is open: it is loaded after the stream is opened so no change is lost in between, and it is discarded when the stream
+// can not be opened or fails, e.g. on a standalone mongod, so the rules are searched in the repository meanwhile.
+func (r *ruleSnapshotRepository) Watch(ctx context.Context) {
+	if !r.config.RulesSnapshot.IsEnabled {
+		return
+	}
+
+	resyncInterval := time.Duration(r.config.RulesSnapshot.ResyncSeconds) * time.Second
+	for {
+		r.watchChanges(ctx, resyncInterval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resyncInterval):
+		}
+	}
+}
+
+func (r *ruleSnapshotRepository) resync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = r.Load(ctx)
+		}
+	}
+}
+
+func (r *ruleSnapshotRepository) watchChanges(ctx context.Context, resyncInterval time.Duration) {
+	collection := r.mongodb.Collection(r.config.MongoDB.Collections.Rules)
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+
+	stream, err := collection.Watch(ctx, mongo.Pipeline{}, opts)
+	if err != nil {
+		r.stopWatching(ctx, err)
+		return
+	}
+	defer stream.Close(context.Background())
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err = r.Load(watchCtx); err != nil {
+		cancel()
+		r.stopWatching(ctx, err)
+		return
+	}
+	r.sendWatchMetrics(ctx, true)
+	go r.resync(watchCtx, resyncInterval)
+
+	for stream.Next(ctx) {
+		var event ruleChangeEvent
+		if err = stream.Decode(&event); err != nil {
+			r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(snapshotRepositoryName, "Watch"))
+			continue
+		}
+		r.apply(watchCtx, event)
+	}
+
+	cancel()
+	err = stream.Err()
+	if err == nil && ctx.Err() == nil {
+		err = fmt.Errorf("change stream of %s closed", r.config.MongoDB.Collections.Rules)
+	}
+	r.stopWatching(ctx, err)
+}
+
+// stopWatching discards the snapshot, which can not be kept in sync without the change stream.
+func (r *ruleSnapshotRepository) stopWatching(ctx context.Context, err error) {
+	r.mutex.Lock()
+	r.rules = nil
+	r.index = nil
+	r.mutex.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+	r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(snapshotRepositoryName, "Watch"))
+	r.sendWatchMetrics(ctx, false)
+}
+
+func (r *ruleSnapshotRepository) sendWatchMetrics(ctx context.Context, isWatching bool) {
+	metricData := metrics.NewMetricData(ctx, "Watch", snapshotRepositoryName, r.config.Env)
+	metricData.SetResult(isWatching)
+	metrics.SendAsyncMetrics(r.datadog, r.log, metricData, text.RulesSnapshotWatchMetricName)
+}
+
+func (r *ruleSnapshotRepository) apply(ctx context.Context, event ruleChangeEvent) {
+	switch event.OperationType {
+	case operationInsert, operationUpdate, operationReplace:
+		if event.FullDocument == nil {
+			r.remove(event.DocumentKey.ID)
+			return
+		}
+		r.upsert(*event.FullDocument)
+	case operationDelete:
+		r.remove(event.DocumentKey.ID)
+	case operationDrop, operationInvalidate:
+		_ = r.Load(ctx)
+	}
+}
+
+// upsert and remove only update the entries of the changed rule in the index.
+func (r *ruleSnapshotRepository) upsert(rule entities.Rule) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.changed != nil {
+		r.changed[rule.ID] = &rule
+	}
+	if r.rules == nil {
+		return
+	}
+	if previous, ok := r.rules[rule.ID]; ok {
+		r.index.remove(previous)
+	}
+	r.rules[rule.ID] = rule
+	r.index.add(rule)
+}
+
+func (r *ruleSnapshotRepository) remove(ruleID primitive.ObjectID) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.changed != nil {
+		r.changed[ruleID] = nil
+	}
+	if r.rules == nil {
+		return
+	}
+	if previous, ok := r.rules[ruleID]; ok {
+		r.index.remove(previous)
+	}
+	delete(r.rules, ruleID)
+}
+
+func newRulesIndex(rulesByID map[primitive.ObjectID]entities.Rule) *rulesIndex {
+	sortedRules := make([]entities.Rule, 0, len(rulesByID))
+	for _, rule := range rulesByID {
+		sortedRules = append(sortedRules, rule)
+	}
+	sort.Slice(sortedRules, func(i, j int) bool {
+		return sortedRules[i].ID.Hex() < sortedRules[j].ID.Hex()
+	})
+
+	index := &rulesIndex{
+		byCompanyID:       make(map[string][]entities.Rule),
+		byFamilyID:        make(map[string][]entities.Rule),
+		byFamilyCompanyID: make(map[string][]entities.Rule),
+	}
+
+	for _, rule := range sortedRules {
+		index.add(rule)
+	}
+
+	return index
+}
+
+func (index *rulesIndex) add(rule entities.Rule) {
+	index.update(rule, func(rules []entities.Rule) []entities.Rule {
+		return insertRule(rules, rule)
+	})
+}
+
+func (index *rulesIndex) remove(rule entities.Rule) {
+	index.update(rule, func(rules []entities.Rule) []entities.Rule {
+		return removeRule(rules, rule.ID)
+	})
+}
+
+// update changes the entries the rule is searched by.
+func (index *rulesIndex) update(rule entities.Rule, change func(rules []entities.Rule) []entities.Rule) {
+	if !rule.IsEvaluable() {
+		return
+	}
+	if rule.IsScoreRule {
+		index.scoreRules = change(index.scoreRules)
+		return
+	}
+	if rule.IsIdentityModuleRule() {
+		index.identityModule = change(index.identityModule)
+		return
+	}
+	if rule.CompanyID != nil {
+		index.byCompanyID[*rule.CompanyID] = change(index.byCompanyID[*rule.CompanyID])
+	}
+	if rule.FamilyMccID != nil {
+		index.byFamilyID[*rule.FamilyMccID] = change(index.byFamilyID[*rule.FamilyMccID])
+	}
+	if rule.FamilyCompanyID != nil {
+		index.byFamilyCompanyID[*rule.FamilyCompanyID] = change(index.byFamilyCompanyID[*rule.FamilyCompanyID])
+	}
+	if rule.IsGlobal {
+		index.global = change(index.global)
+	}
+	if rule.IsYellowFlag {
+		index.yellowFlag = change(index.yellowFlag)
+	}
+}
+
+// insertRule keeps the rules sorted by id, the order the index is built in.
+func insertRule(rules []entities.Rule, rule entities.Rule) []entities.Rule {
+	position := sort.Search(len(rules), func(i int) bool {
+		return rules[i].ID.Hex() >= rule.ID.Hex()
+	})
+	rules = append(rules, entities.Rule{})
+	copy(rules[position+1:], rules[position:])
+	rules[position] = rule
+
+	return rules
+}
+
+func removeRule(rules []entities.Rule, ruleID primitive.ObjectID) []entities.Rule {
+	for i, rule := range rules {
+		if rule.ID == ruleID {
+			return append(rules[:i], rules[i+1:]...)
+		}
+	}
+
+	return rules
+}
+
+func (index *rulesIndex) find(filter entities.RuleFilter, component entities.ConsoleComponent) []entities.Rule {
+	rulesFound := make([]entities.Rule, 0)
+
+	switch component {
+	case entities.CompanyRulesType:
+		rulesFound = append(rulesFound, index.byCompanyID[filter.CompanyID]...)
+	case entities.FamilyCompanyRulesType:
+		rulesFound = append(rulesFound, index.byFamilyID[filter.FamilyID]...)
+	case entities.FamilyMccRulesType:
+		for _, familyCompanyID := range uniqueValues(filter.FamilyCompaniesIDs) {
+			rulesFound = append(rulesFound, index.byFamilyCompanyID[familyCompanyID]...)
+		}
+	case entities.GlobalRulesType:
+		rulesFound = append(rulesFound, index.global...)
+	case entities.YellowFlagType:
+		rulesFound = append(rulesFound, index.yellowFlag...)
+	case entities.IdentityModuleType:
+		for _, rule := range index.identityModule {
+			if matchesIdentityModuleFilter(rule, filter) {
+				rulesFound = append(rulesFound, rule)
+			}
+		}
+	case entities.ScoreRulesType:
+		for _, rule := range index.scoreRules {
+			if matchesScoreRulesFilter(rule, filter) {
+				rulesFound = append(rulesFound, rule)
+			}
+		}
+	}
+
+	return rulesFound
+}
+
+func matchesIdentityModuleFilter(rule entities.Rule, filter entities.RuleFilter) bool {
+	if filter.CompanyID != "" && !isPointerEqual(rule.CompanyID, filter.CompanyID) {
+		return false
+	}
+	if filter.FamilyID != "" && !isPointerEqual(rule.FamilyMccID, filter.FamilyID) {
+		return false
+	}
+	if len(filter.FamilyCompaniesIDs) > 0 && !isPointerIn(rule.FamilyCompanyID, filter.FamilyCompaniesIDs) {
+		return false
+	}
+	if filter.IsGlobal && !rule.IsGlobal {
+		return false
+	}
+
+	return true
+}
+
+func matchesScoreRulesFilter(rule entities.Rule, filter entities.RuleFilter) bool {
+	return rule.IsGlobal ||
+		filter.CompanyID != "" && isPointerEqual(rule.CompanyID, filter.CompanyID) ||
+		filter.FamilyID != "" && isPointerEqual(rule.FamilyMccID, filter.FamilyID) ||
+		isPointerIn(rule.FamilyCompanyID, filter.FamilyCompaniesIDs)
+}
+
+func isPointerEqual(value *string, expected string) bool {
+	return value != nil && *value == expected
+}
+
+func isPointerIn(value *string, expected []string) bool {
+	for _, item := range expected {
+		if isPointerEqual(value, item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func uniqueValues(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,52 @@
+package rules_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSnapshotRepository_GetRulesByFilters(t *testing.T) {
+	logger, _ := logs.New()
+	rule := testdata.GetDefaultRule(true)
+	filter := rule.GetRuleFilter()
+
+	t.Run("when the snapshot is disabled the rules are searched in the repository", func(t *testing.T) {
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("GetRulesByFilters", context.TODO(), filter, entities.CompanyRulesType).
+			Return([]entities.Rule{rule}, nil)
+
+		repository := rules.NewRuleSnapshotRepository(config.Config{}, nil, ruleRepository, logger, new(datadog.MetricsDogMock))
+		assert.NoError(t, repository.Load(context.TODO()))
+
+		rulesFound, err := repository.GetRulesByFilters(context.TODO(), filter, entities.CompanyRulesType)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Rule{rule}, rulesFound)
+		ruleRepository.AssertExpectations(t)
+	})
+
+	t.Run("when the snapshot was not loaded yet the rules are searched in the repository", func(t *testing.T) {
+		cfg := config.Config{}
+		cfg.RulesSnapshot.IsEnabled = true
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("GetRulesByFilters", context.TODO(), filter, entities.GlobalRulesType).
+			Return([]entities.Rule{}, nil)
+
+		repository := rules.NewRuleSnapshotRepository(cfg, nil, ruleRepository, logger, new(datadog.MetricsDogMock))
+
+		rulesFound, err := repository.GetRulesByFilters(context.TODO(), filter, entities.GlobalRulesType)
+
+		assert.NoError(t, err)
+		assert.Empty(t, rulesFound)
+		ruleRepository.AssertExpectations(t)
+	})
+}
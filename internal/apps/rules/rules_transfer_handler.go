@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/entities"
+	customString "github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+	"github.com/labstack/echo/v4"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	transferHandlerName = "rule.transfer.handler.%s"
+	mimeApplicationYAML = "application/x-yaml"
+)
+
+type RuleTransferHandler interface {
+	Export(c echo.Context) error
+	Import(c echo.Context) error
+}
+
+type ruleTransferHandler struct {
+	service RuleTransferService
+	logs    logs.Logger
+}
+
+func NewRuleTransferHandler(service RuleTransferService, logger logs.Logger) RuleTransferHandler {
+	return &ruleTransferHandler{
+		service: service,
+		logs:    logger,
+	}
+}
+
+func (handler *ruleTransferHandler) Export(ctx echo.Context) error {
+	var ruleFilter entities.RuleFilter
+	ctx.Bind(&ruleFilter)
+	format := strings.ToLower(ctx.QueryParam("format"))
+
+	if err := validateExportRequest(ruleFilter, format); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod,
+			fmt.Sprintf(transferHandlerName, "Export"))
+		ctx.Error(err)
+		return nil
+	}
+
+	exports, err := handler.service.Export(ctx.Request().Context(), ruleFilter)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	if format == entities.TransferFormatYAML {
+		document, err := toYAMLDocument(exports)
+		if err != nil {
+			ctx.Error(err)
+			return nil
+		}
+		writeAttachmentHeaders(ctx, mimeApplicationYAML, "rules.yaml")
+		return yaml.NewEncoder(ctx.Response()).Encode(document)
+	}
+
+	writeAttachmentHeaders(ctx, echo.MIMEApplicationJSONCharsetUTF8, "rules.json")
+	return json.NewEncoder(ctx.Response()).Encode(exports)
+}
+
+func (handler *ruleTransferHandler) Import(ctx echo.Context) error {
+	exports, author, dryRun, err := handler.bindImportRequest(ctx)
+	if err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod,
+			fmt.Sprintf(transferHandlerName, "Import"))
+		ctx.Error(err)
+		return nil
+	}
+
+	report, err := handler.service.Import(ctx.Request().Context(), exports, author, dryRun)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	if !dryRun && report.HasConflicts() {
+		return ctx.JSON(http.StatusConflict, report)
+	}
+
+	return ctx.JSON(http.StatusOK, report)
+}
+
+func (handler *ruleTransferHandler) bindImportRequest(ctx echo.Context) ([]entities.RuleExport, string, bool, error) {
+	author := ctx.QueryParam("author")
+	if customString.IsEmpty(author) {
+		return nil, author, false, errors.New("author is required")
+	}
+
+	dryRun := false
+	if value := ctx.QueryParam("dry_run"); !customString.IsEmpty(value) {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, author, false, errors.New("dry_run must be true or false")
+		}
+		dryRun = parsed
+	}
+
+	format := strings.ToLower(ctx.QueryParam("format"))
+	if err := entities.ValidateTransferFormat(format); err != nil {
+		return nil, author, dryRun, err
+	}
+	if strings.Contains(ctx.Request().Header.Get(echo.HeaderContentType), entities.TransferFormatYAML) {
+		format = entities.TransferFormatYAML
+	}
+
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return nil, author, dryRun, err
+	}
+
+	exports, err := decodeRuleExports(body, format)
+	if err != nil {
+		return nil, author, dryRun, err
+	}
+	if len(exports) == 0 {
+		return nil, author, dryRun, errors.New("there are no rules to import")
+	}
+
+	for i := range exports {
+		if err = ctx.Validate(&exports[i]); err != nil {
+			return nil, author, dryRun, fmt.Errorf("rule %d: %s", i, err.Error())
+		}
+	}
+
+	return exports, author, dryRun, nil
+}
+
+func validateExportRequest(ruleFilter entities.RuleFilter, format string) error {
+	if err := entities.ValidateTransferFormat(format); err != nil {
+		return err
+	}
+
+	if !ruleFilter.IsIDValid() {
+		return errors.New("invalid id")
+	}
+
+	if !ruleFilter.IsActiveValid() {
+		return errors.New("active must be true or false")
+	}
+
+	if !ruleFilter.IsStatusValid() {
+		return fmt.Errorf("status [%s] is not a valid value", ruleFilter.Status)
+	}
+
+	return nil
+}
+
+func writeAttachmentHeaders(ctx echo.Context, contentType, fileName string) {
+	ctx.Response().Header().Set(echo.HeaderContentType, contentType)
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	ctx.Response().WriteHeader(http.StatusOK)
+}
+
+// toYAMLDocument goes through the json form of the rules so the yaml keys are the same as the json ones.
+func toYAMLDocument(exports []entities.RuleExport) (interface{}, error) {
+	body, err := json.Marshal(exports)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	err = json.Unmarshal(body, &document)
+	return document, err
+}
+
+func decodeRuleExports(body []byte, format string) ([]entities.RuleExport, error) {
+	if format == entities.TransferFormatYAML {
+		var document interface{}
+		if err := yaml.Unmarshal(body, &document); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if body, err = json.Marshal(document); err != nil {
+			return nil, err
+		}
+	}
+
+	exports := make([]entities.RuleExport, 0)
+	if err := json.Unmarshal(body, &exports); err != nil {
+		return nil, err
+	}
+
+	return exports, nil
+}
@@ -0,0 +1,141 @@
+package rules_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/echo"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	exportUri = rulesUri + "/export"
+	importUri = rulesUri + "/import"
+)
+
+func Test_ruleTransferHandler_Export(t *testing.T) {
+	logger, _ := logs.New()
+	exports := []entities.RuleExport{getRuleExport(transferExistingRuleID, "gambling", "fp_1")}
+
+	t.Run("when format is not valid then return bad request", func(t *testing.T) {
+		ctx, rec := echo.SetupAsRecorder(http.MethodGet, exportUri+"?format=csv", "", "")
+		handler := rules.NewRuleTransferHandler(nil, logger)
+
+		handler.Export(ctx)
+
+		restError, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "format [csv] is not a valid value", restError.Message())
+	})
+
+	t.Run("exports the rules as json by default", func(t *testing.T) {
+		ctx, rec := echo.SetupAsRecorder(http.MethodGet, exportUri+"?module=policy_compliance", "", "")
+		service := new(mocks.RuleTransferServiceMock)
+		service.On("Export", mock.Anything, entities.RuleFilter{Module: "policy_compliance"}).Return(exports, nil)
+		handler := rules.NewRuleTransferHandler(service, logger)
+
+		handler.Export(ctx)
+
+		var got []entities.RuleExport
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, exports, got)
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "rules.json")
+	})
+
+	t.Run("exports the rules as yaml", func(t *testing.T) {
+		ctx, rec := echo.SetupAsRecorder(http.MethodGet, exportUri+"?format=yaml", "", "")
+		service := new(mocks.RuleTransferServiceMock)
+		service.On("Export", mock.Anything, entities.RuleFilter{}).Return(exports, nil)
+		handler := rules.NewRuleTransferHandler(service, logger)
+
+		handler.Export(ctx)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/x-yaml", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), "family: gambling")
+		assert.Contains(t, rec.Body.String(), "is_test: false")
+	})
+}
+
+func Test_ruleTransferHandler_Import(t *testing.T) {
+	logger, _ := logs.New()
+	author := "santiago.ceron@conekta.com"
+	exports := []entities.RuleExport{getRuleExport(transferNewRuleID, "gambling", "fp_1")}
+	body, _ := json.Marshal(exports)
+
+	t.Run("when author is missing then return bad request", func(t *testing.T) {
+		ctx, rec := echo.SetupAsRecorder(http.MethodPost, importUri, "", string(body))
+		handler := rules.NewRuleTransferHandler(nil, logger)
+
+		handler.Import(ctx)
+
+		restError, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "author is required", restError.Message())
+	})
+
+	t.Run("when a rule is invalid then return bad request", func(t *testing.T) {
+		invalid, _ := json.Marshal([]entities.RuleExport{{Module: "policy_compliance"}})
+		ctx, rec := echo.SetupAsRecorder(http.MethodPost, importUri+"?author="+author, "", string(invalid))
+		handler := rules.NewRuleTransferHandler(nil, logger)
+
+		handler.Import(ctx)
+
+		restError, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.True(t, strings.HasPrefix(restError.Message(), "rule 0: "))
+	})
+
+	t.Run("dry run of a yaml file returns the report", func(t *testing.T) {
+		yamlBody := `
+- id: 62a0e0b6f5b5c1a6f1a0c333
+  module: policy_compliance
+  description: block fingerprint
+  decision: D
+  is_test: false
+  is_global: false
+  family: gambling
+  rules:
+    - field: device_fingerprint
+      operator: "=="
+      value: fp_1
+      condition: and
+`
+		report := entities.NewRuleImportReport(true)
+		ctx, rec := echo.SetupAsRecorder(http.MethodPost,
+			importUri+"?format=yaml&dry_run=true&author="+author, "", yamlBody)
+		service := new(mocks.RuleTransferServiceMock)
+		service.On("Import", mock.Anything, exports, author, true).Return(report, nil).Once()
+		handler := rules.NewRuleTransferHandler(service, logger)
+
+		handler.Import(ctx)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("when the import has conflicts then return conflict", func(t *testing.T) {
+		report := entities.NewRuleImportReport(false)
+		report.Add(entities.RuleImportItem{Status: entities.RuleImportConflict, Reason: "family 'travel' not found"})
+		ctx, rec := echo.SetupAsRecorder(http.MethodPost, importUri+"?author="+author, "", string(body))
+		service := new(mocks.RuleTransferServiceMock)
+		service.On("Import", mock.Anything, exports, author, false).Return(report, nil).Once()
+		handler := rules.NewRuleTransferHandler(service, logger)
+
+		handler.Import(ctx)
+
+		var got entities.RuleImportReport
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, report, got)
+	})
+}
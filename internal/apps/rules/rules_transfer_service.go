@@ -0,0 +1,402 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	customString "github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ruleTransferServiceMethod = "rules.transfer.service.%s"
+	importTransactionCause    = "transaction"
+)
+
+type FamilyFinder interface {
+	Search(ctx context.Context, filter entities.FamilyFilter) ([]entities.Family, error)
+}
+
+type FamilyCompaniesFinder interface {
+	Search(ctx context.Context, filter entities.FamilyCompaniesFilter) ([]entities.FamilyCompanies, error)
+}
+
+// Transactor commits every write of the function together.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type RuleTransferService interface {
+	Export(ctx context.Context, filter entities.RuleFilter) ([]entities.RuleExport, error)
+	Import(ctx context.Context, exports []entities.RuleExport, author string,
+		dryRun bool) (entities.RuleImportReport, error)
+}
+
+type ruleTransferService struct {
+	config          config.Config
+	ruleService     RuleWriter
+	catalog         RuleCatalogService
+	ruleRepository  RuleRepository
+	families        FamilyFinder
+	familyCompanies FamilyCompaniesFinder
+	auditService    audit.AuditService
+	transactor      Transactor
+	logs            logs.Logger
+	datadog         datadog.Metricer
+}
+
+type ruleImportPlan struct {
+	item     entities.RuleImportItem
+	content  entities.Rule
+	rule     entities.Rule
+	previous *entities.Rule
+}
+
+type familyNames struct {
+	families        map[string]string
+	familyCompanies map[string]string
+}
+
+func NewRuleTransferService(cfg config.Config,
+	ruleService RuleWriter,
+	catalog RuleCatalogService,
+	ruleRepository RuleRepository,
+	families FamilyFinder,
+	familyCompanies FamilyCompaniesFinder,
+	auditService audit.AuditService,
+	transactor Transactor,
+	logger logs.Logger,
+	metric datadog.Metricer) RuleTransferService {
+	return &ruleTransferService{
+		config:          cfg,
+		ruleService:     ruleService,
+		catalog:         catalog,
+		ruleRepository:  ruleRepository,
+		families:        families,
+		familyCompanies: familyCompanies,
+		auditService:    auditService,
+		transactor:      transactor,
+		logs:            logger,
+		datadog:         metric,
+	}
+}
+
+func (service *ruleTransferService) Export(ctx context.Context,
+	filter entities.RuleFilter) ([]entities.RuleExport, error) {
+	metricData := metrics.NewMetricData(ctx, "Export", ruleTransferServiceMethod, service.config.Env)
+	exports, err := service.export(ctx, filter)
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleTransferServiceMethod, "Export"))
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.ExportRulesMetricName)
+		return nil, err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.ExportRulesMetricName)
+	return exports, nil
+}
+
+func (service *ruleTransferService) export(ctx context.Context,
+	filter entities.RuleFilter) ([]entities.RuleExport, error) {
+	rulesFound, err := service.ruleRepository.FindRulesPaged(ctx, filter, entities.Pagination{})
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := service.loadFamilyNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, _ := rulesFound.Data.([]entities.Rule)
+	exports := make([]entities.RuleExport, 0, len(rules))
+	for _, rule := range rules {
+		exports = append(exports, entities.NewRuleExport(rule,
+			names.families[customString.StringPointerToString(rule.FamilyMccID)],
+			names.familyCompanies[customString.StringPointerToString(rule.FamilyCompanyID)]))
+	}
+
+	return exports, nil
+}
+
+// Import plans every rule before writing any of them, nothing is written on dry runs or when a rule conflicts.
+func (service *ruleTransferService) Import(ctx context.Context, exports []entities.RuleExport, author string,
+	dryRun bool) (entities.RuleImportReport, error) {
+	metricData := metrics.NewMetricData(ctx, "Import", ruleTransferServiceMethod, service.config.Env)
+	metricData.AddCustomTags([]string{fmt.Sprintf(text.MetricTagDryRun, dryRun)})
+
+	report, err := service.importRules(ctx, exports, author, dryRun)
+	if err != nil {
+		service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(ruleTransferServiceMethod, "Import"))
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.ImportRulesMetricName)
+		return entities.RuleImportReport{}, err
+	}
+
+	metricData.SetResult(!report.HasConflicts())
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.ImportRulesMetricName)
+	return report, nil
+}
+
+func (service *ruleTransferService) importRules(ctx context.Context, exports []entities.RuleExport, author string,
+	dryRun bool) (entities.RuleImportReport, error) {
+	report := entities.NewRuleImportReport(dryRun)
+	names, err := service.loadFamilyNames(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	catalog, err := service.catalog.Load(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	plans := make([]ruleImportPlan, 0, len(exports))
+	for index, export := range exports {
+		plan, err := service.plan(ctx, index, export, author, names, catalog, plans)
+		if err != nil {
+			return report, err
+		}
+
+		report.Add(plan.item)
+		if plan.item.Status != entities.RuleImportConflict {
+			plans = append(plans, plan)
+		}
+	}
+
+	if dryRun || report.HasConflicts() {
+		return report, nil
+	}
+
+	if err = service.apply(ctx, plans, author); err != nil {
+		return report, err
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+func (service *ruleTransferService) plan(ctx context.Context, index int, export entities.RuleExport, author string,
+	names familyNames, catalog entities.RuleCatalog, planned []ruleImportPlan) (ruleImportPlan, error) {
+	plan := ruleImportPlan{item: entities.RuleImportItem{Index: index, ID: export.ID}}
+
+	familyID, ok := names.findFamilyID(export.Family)
+	if !ok {
+		return plan.conflict(fmt.Sprintf("family '%s' not found", export.Family)), nil
+	}
+
+	familyCompanyID, ok := names.findFamilyCompanyID(export.FamilyCompany)
+	if !ok {
+		return plan.conflict(fmt.Sprintf("family company '%s' not found", export.FamilyCompany)), nil
+	}
+
+	request := export.ToRuleRequest(author, familyID, familyCompanyID)
+	if err := request.Validate(); err != nil {
+		return plan.conflict(err.Error()), nil
+	}
+
+	current, err := service.findCurrent(ctx, export.ID)
+	if err != nil {
+		return plan, err
+	}
+
+	// the imported content is validated before it is stored, as the pending revision of an active rule
+	if current != nil {
+		plan.content = request.NewRuleFromPutRequest()
+		plan.content.ID = current.ID
+		plan.content.CreatedAt = current.CreatedAt
+		plan.content.CreatedBy = current.CreatedBy
+		plan.content.Revision = current.Revision + 1
+		plan.previous = current
+		plan.item.Status = entities.RuleImportUpdate
+	} else {
+		plan.content = request.NewRuleFromPostRequest()
+		if ID, err := primitive.ObjectIDFromHex(export.ID); err == nil {
+			plan.content.ID = ID
+		}
+		plan.item.Status = entities.RuleImportCreate
+	}
+	plan.content.Rule = service.ruleService.BuildExpression(plan.content)
+	plan.item.ID = plan.content.ID.Hex()
+	plan.item.Rule = plan.content.Rule
+
+	if clauses := catalog.Check(plan.content.Rules); len(clauses) > 0 {
+		return plan.conflict(exceptions.NewInvalidRuleException(clauses).Error()), nil
+	}
+
+	if err = service.ruleService.ValidateSyntax(ctx, plan.content); err != nil {
+		return plan.conflict(err.Error()), nil
+	}
+
+	rulesFound, err := service.ruleRepository.FindRulesPaged(ctx, plan.content.GetRuleFilter(),
+		entities.Pagination{})
+	if err != nil {
+		return plan, err
+	}
+
+	rules, _ := rulesFound.Data.([]entities.Rule)
+	if plan.content.IsContained(rules) && !isTheSame(rules, plan.item.ID) {
+		return plan.conflict(fmt.Sprintf("the rule '%s' already exist", plan.content.Rule)), nil
+	}
+
+	for _, other := range planned {
+		if other.content.ID == plan.content.ID {
+			return plan.conflict(fmt.Sprintf("the rule id is repeated on the rule %d", other.item.Index)), nil
+		}
+		if other.content.IsContained([]entities.Rule{plan.content}) && other.content.HasSameScope(plan.content) {
+			return plan.conflict(fmt.Sprintf("the rule '%s' is repeated on the rule %d", plan.content.Rule,
+				other.item.Index)), nil
+		}
+	}
+
+	plan.rule = plan.content
+	if current != nil {
+		plan.rule = current.Edit(plan.content)
+	}
+
+	return plan, nil
+}
+
+func (service *ruleTransferService) findCurrent(ctx context.Context, ruleID string) (*entities.Rule, error) {
+	if _, err := primitive.ObjectIDFromHex(ruleID); err != nil {
+		return nil, nil
+	}
+
+	rulesFound, err := service.ruleRepository.FindRulesPaged(ctx, entities.RuleFilter{ID: ruleID},
+		entities.Pagination{})
+	if err != nil {
+		return nil, err
+	}
+
+	rules, _ := rulesFound.Data.([]entities.Rule)
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	return &rules[0], nil
+}
+
+// apply writes the versions and the rules of every plan in a single transaction, so the import is stored whole or not
+// at all, a deployment without transactions can only run dry imports. Audit entries are recorded once the
+// transaction is committed.
+func (service *ruleTransferService) apply(ctx context.Context, plans []ruleImportPlan, author string) error {
+	befores := make([]bson.M, len(plans))
+	for i, plan := range plans {
+		if plan.previous != nil {
+			befores[i] = service.auditService.Snapshot(ctx, entities.AuditRules, plan.rule.ID.Hex())
+		}
+	}
+
+	err := service.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, plan := range plans {
+			if err := service.write(ctx, plan, author); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, mongodb.ErrTransactionsNotSupported) {
+		return exceptions.NewServiceUnavailableExceptionWithCause("the rules can only be imported as a dry run",
+			exceptions.Causes{Code: importTransactionCause, Message: err.Error()})
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, plan := range plans {
+		ruleID := plan.rule.ID.Hex()
+		service.ruleService.InvalidateRule(ruleID)
+		if plan.previous == nil {
+			service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditRules, ruleID,
+				entities.AuditCreated, author, nil))
+			continue
+		}
+		service.auditService.Record(ctx, entities.NewAuditEntry(entities.AuditRules, ruleID,
+			entities.AuditUpdated, author, befores[i]))
+	}
+
+	return nil
+}
+
+func (service *ruleTransferService) write(ctx context.Context, plan ruleImportPlan, author string) error {
+	if err := service.ruleService.AddVersion(ctx, plan.version(author)); err != nil {
+		return err
+	}
+
+	if plan.previous == nil {
+		_, err := service.ruleRepository.AddRule(ctx, plan.rule)
+		return err
+	}
+
+	return service.ruleRepository.UpdateRule(ctx, plan.rule.ID.Hex(), plan.rule)
+}
+
+func (service *ruleTransferService) loadFamilyNames(ctx context.Context) (familyNames, error) {
+	names := familyNames{families: map[string]string{}, familyCompanies: map[string]string{}}
+
+	families, err := service.families.Search(ctx, entities.FamilyFilter{})
+	if err != nil {
+		return names, err
+	}
+	for _, family := range families {
+		names.families[family.ID.Hex()] = family.Name
+	}
+
+	familyCompanies, err := service.familyCompanies.Search(ctx, entities.FamilyCompaniesFilter{})
+	if err != nil {
+		return names, err
+	}
+	for _, familyCompany := range familyCompanies {
+		names.familyCompanies[familyCompany.ID.Hex()] = familyCompany.Name
+	}
+
+	return names, nil
+}
+
+func (names familyNames) findFamilyID(name string) (string, bool) {
+	return findIDByName(names.families, name)
+}
+
+func (names familyNames) findFamilyCompanyID(name string) (string, bool) {
+	return findIDByName(names.familyCompanies, name)
+}
+
+func findIDByName(namesByID map[string]string, name string) (string, bool) {
+	if customString.IsEmpty(name) {
+		return customString.Empty, true
+	}
+
+	for ID, found := range namesByID {
+		if found == name {
+			return ID, true
+		}
+	}
+
+	return customString.Empty, false
+}
+
+func (plan ruleImportPlan) version(author string) entities.RuleVersion {
+	if plan.previous == nil {
+		return entities.NewRuleVersion(entities.RuleCreated, author, nil, plan.rule)
+	}
+
+	return entities.NewRuleVersion(entities.RuleUpdated, author, plan.previous, plan.rule)
+}
+
+func (plan ruleImportPlan) conflict(reason string) ruleImportPlan {
+	plan.item.Status = entities.RuleImportConflict
+	plan.item.Reason = reason
+	return plan
+}
@@ -0,0 +1,292 @@
+package rules_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	transferFamilyID        = "61e4dd6da5997ad4d9e76945"
+	transferFamilyCompanyID = "62a0e0b6f5b5c1a6f1a0c111"
+	transferExistingRuleID  = "62a0e0b6f5b5c1a6f1a0c222"
+	transferNewRuleID       = "62a0e0b6f5b5c1a6f1a0c333"
+)
+
+func newTransferFamiliesMocks() (*mocks.FamilyRepositoryMock, *mocks.FamilyCompaniesRepositoryMock) {
+	familyID, _ := primitive.ObjectIDFromHex(transferFamilyID)
+	familyCompanyID, _ := primitive.ObjectIDFromHex(transferFamilyCompanyID)
+
+	families := new(mocks.FamilyRepositoryMock)
+	families.On("Search", context.TODO(), entities.FamilyFilter{}).
+		Return([]entities.Family{{ID: familyID, Name: "gambling"}}, nil)
+	familyCompanies := new(mocks.FamilyCompaniesRepositoryMock)
+	familyCompanies.On("Search", context.TODO(), entities.FamilyCompaniesFilter{}).
+		Return([]entities.FamilyCompanies{{ID: familyCompanyID, Name: "marketplaces"}}, nil)
+
+	return families, familyCompanies
+}
+
+func getRuleExport(ID, family string, value string) entities.RuleExport {
+	isTest, isGlobal := false, false
+	return entities.RuleExport{
+		ID:          ID,
+		Module:      "policy_compliance",
+		Description: "block fingerprint",
+		Decision:    entities.Declined,
+		IsTest:      &isTest,
+		IsGlobal:    &isGlobal,
+		Family:      family,
+		Rules: []entities.RuleContent{
+			{Field: "device_fingerprint", Operator: "==", Value: value, Condition: "and"},
+		},
+	}
+}
+
+func getTransferRule(status entities.RuleStatus) entities.Rule {
+	rule := getCurrentRule()
+	rule.Status = status
+	return rule
+}
+
+func isLookupByID(ID string) interface{} {
+	return mock.MatchedBy(func(filter entities.RuleFilter) bool { return filter.ID == ID })
+}
+
+func isDuplicatedLookup() interface{} {
+	return mock.MatchedBy(func(filter entities.RuleFilter) bool { return filter.ID == "" })
+}
+
+func newTransferService(ruleRepository *mocks.RulesRepositoryMock,
+	versionRepository *mocks.RuleVersionRepositoryMock) rules.RuleTransferService {
+	transactor := new(mocks.TransactorMock)
+	transactor.On("WithTransaction", context.TODO()).Return(nil)
+	return newTransferServiceWithTransactor(ruleRepository, versionRepository, transactor)
+}
+
+func newTransferServiceWithTransactor(ruleRepository *mocks.RulesRepositoryMock,
+	versionRepository *mocks.RuleVersionRepositoryMock, transactor rules.Transactor) rules.RuleTransferService {
+	logger, _ := logs.New()
+	families, familyCompanies := newTransferFamiliesMocks()
+	rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger,
+		new(datadog.MetricsDogMock)))
+
+	auditService := mocks.NewAuditServiceMock()
+	ruleService := rules.NewRulesService(config.Config{}, rulesValidator, newRuleCatalogService(nil), ruleRepository,
+		versionRepository, auditService, logger, new(datadog.MetricsDogMock))
+
+	return rules.NewRuleTransferService(config.Config{}, ruleService, newRuleCatalogService(nil), ruleRepository,
+		families, familyCompanies, auditService, transactor, logger, new(datadog.MetricsDogMock))
+}
+
+func Test_ruleTransferService_Export(t *testing.T) {
+	t.Run("exports the rules with the family names", func(t *testing.T) {
+		familyID, familyCompanyID := transferFamilyID, transferFamilyCompanyID
+		fields, operation := []string{"amount", "shipping.amount"}, "SUM"
+		familyRule := getTransferRule(entities.RuleStatusActive)
+		familyRule.FamilyMccID = &familyID
+		familyCompanyRule := getTransferRule(entities.RuleStatusDraft)
+		familyCompanyRule.FamilyCompanyID = &familyCompanyID
+		familyCompanyRule.Rules = []entities.RuleContent{{Field: "SUM (amount,shipping.amount)", Operator: ">",
+			Value: "100", Condition: "and", FormulaContent: entities.FormulaContent{Fields: &fields, MathOperation: &operation}}}
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{Module: "policy_compliance"},
+			entities.Pagination{}).Return(entities.PagedResponse{Data: []entities.Rule{familyRule, familyCompanyRule}}, nil)
+
+		service := newTransferService(ruleRepository, nil)
+		exports, err := service.Export(context.TODO(), entities.RuleFilter{Module: "policy_compliance"})
+
+		assert.NoError(t, err)
+		assert.Len(t, exports, 2)
+		assert.Equal(t, familyRule.ID.Hex(), exports[0].ID)
+		assert.Equal(t, "gambling", exports[0].Family)
+		assert.Equal(t, "marketplaces", exports[1].FamilyCompany)
+		assert.Empty(t, exports[1].Rules[0].Field)
+		assert.Equal(t, "SUM (amount,shipping.amount)", familyCompanyRule.Rules[0].Field)
+	})
+
+	t.Run("when repository fails then return error", func(t *testing.T) {
+		expectedError := errors.New("connection lost")
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), entities.RuleFilter{}, entities.Pagination{}).
+			Return(entities.PagedResponse{}, expectedError)
+
+		service := newTransferService(ruleRepository, nil)
+		_, err := service.Export(context.TODO(), entities.RuleFilter{})
+
+		assert.Equal(t, expectedError, err)
+	})
+}
+
+func Test_ruleTransferService_Import(t *testing.T) {
+	author := "santiago.ceron@conekta.com"
+	existingID, _ := primitive.ObjectIDFromHex(transferExistingRuleID)
+	existing := getTransferRule(entities.RuleStatusActive)
+	existing.ID = existingID
+
+	t.Run("dry run reports creates, updates and conflicts without writing", func(t *testing.T) {
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferExistingRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{existing}}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferNewRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isDuplicatedLookup(), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+
+		service := newTransferService(ruleRepository, nil)
+		report, err := service.Import(context.TODO(), []entities.RuleExport{
+			getRuleExport(transferNewRuleID, "gambling", "fp_1"),
+			getRuleExport(transferExistingRuleID, "gambling", "fp_2"),
+			getRuleExport("", "travel", "fp_3"),
+		}, author, true)
+
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.False(t, report.Applied)
+		assert.Len(t, report.Creates, 1)
+		assert.Equal(t, transferNewRuleID, report.Creates[0].ID)
+		assert.Len(t, report.Updates, 1)
+		assert.Equal(t, transferExistingRuleID, report.Updates[0].ID)
+		assert.Len(t, report.Conflicts, 1)
+		assert.Equal(t, 2, report.Conflicts[0].Index)
+		assert.Equal(t, "family 'travel' not found", report.Conflicts[0].Reason)
+		ruleRepository.AssertNotCalled(t, "AddRule", mock.Anything, mock.Anything)
+		ruleRepository.AssertNotCalled(t, "UpdateRule", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("duplicated rules are conflicts and nothing is written", func(t *testing.T) {
+		duplicated := getTransferRule(entities.RuleStatusActive)
+		duplicated.Rule = `device_fingerprint == "fp_1"`
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferNewRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isDuplicatedLookup(), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{duplicated}}, nil).Once()
+		ruleRepository.On("FindRulesPaged", context.TODO(), isDuplicatedLookup(), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+
+		service := newTransferService(ruleRepository, nil)
+		report, err := service.Import(context.TODO(), []entities.RuleExport{
+			getRuleExport(transferNewRuleID, "gambling", "fp_1"),
+			getRuleExport("", "gambling", "fp_2"),
+			getRuleExport("", "gambling", "fp_2"),
+		}, author, false)
+
+		assert.NoError(t, err)
+		assert.False(t, report.Applied)
+		assert.Len(t, report.Creates, 1)
+		assert.Len(t, report.Conflicts, 2)
+		assert.Equal(t, `the rule 'device_fingerprint == "fp_1"' already exist`, report.Conflicts[0].Reason)
+		assert.Equal(t, `the rule 'device_fingerprint == "fp_2"' is repeated on the rule 1`, report.Conflicts[1].Reason)
+		ruleRepository.AssertNotCalled(t, "AddRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rules that do not match the catalog are conflicts", func(t *testing.T) {
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferNewRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		export := getRuleExport(transferNewRuleID, "gambling", "fp_1")
+		export.Rules[0].Operator = ">"
+
+		service := newTransferService(ruleRepository, nil)
+		report, err := service.Import(context.TODO(), []entities.RuleExport{export}, author, true)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Conflicts, 1)
+		assert.Equal(t, "the rule does not match the catalog: clause 0: operator '>' can not be applied to the string "+
+			"field 'device_fingerprint'", report.Conflicts[0].Reason)
+	})
+
+	t.Run("applies every rule keeping the active ones and records the versions", func(t *testing.T) {
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		versionRepository := newRuleVersionRepositoryMock()
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferExistingRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{existing}}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferNewRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isDuplicatedLookup(), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		ruleRepository.On("AddRule", mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.ID.Hex() == transferNewRuleID && rule.Status == entities.RuleStatusDraft &&
+				*rule.FamilyMccID == transferFamilyID
+		}), context.TODO()).Return(entities.Rule{}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), transferExistingRuleID, mock.MatchedBy(func(rule entities.Rule) bool {
+			return rule.Revision == existing.Revision+1 && rule.Status == entities.RuleStatusActive &&
+				rule.Rule == existing.Rule && rule.Pending.Status == entities.RuleStatusDraft &&
+				rule.Pending.Rules[0].Value == "fp_2" && rule.Pending.Rule == `device_fingerprint == "fp_2"`
+		})).Return(nil).Once()
+
+		service := newTransferService(ruleRepository, versionRepository)
+		report, err := service.Import(context.TODO(), []entities.RuleExport{
+			getRuleExport(transferNewRuleID, "gambling", "fp_1"),
+			getRuleExport(transferExistingRuleID, "gambling", "fp_2"),
+		}, author, false)
+
+		assert.NoError(t, err)
+		assert.True(t, report.Applied)
+		ruleRepository.AssertExpectations(t)
+		versionRepository.AssertNumberOfCalls(t, "Add", 2)
+	})
+
+	t.Run("when a write fails the transaction returns its error without reverting the written rules", func(t *testing.T) {
+		expectedError := errors.New("connection lost")
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		versionRepository := newRuleVersionRepositoryMock()
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferExistingRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{existing}}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferNewRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isDuplicatedLookup(), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		ruleRepository.On("AddRule", mock.AnythingOfType("entities.Rule"), context.TODO()).
+			Return(entities.Rule{}, nil).Once()
+		ruleRepository.On("UpdateRule", context.TODO(), transferExistingRuleID, mock.AnythingOfType("entities.Rule")).
+			Return(expectedError).Once()
+
+		service := newTransferService(ruleRepository, versionRepository)
+		report, err := service.Import(context.TODO(), []entities.RuleExport{
+			getRuleExport(transferNewRuleID, "gambling", "fp_1"),
+			getRuleExport(transferExistingRuleID, "gambling", "fp_2"),
+		}, author, false)
+
+		assert.Equal(t, expectedError, err)
+		assert.False(t, report.Applied)
+		ruleRepository.AssertExpectations(t)
+		ruleRepository.AssertNotCalled(t, "RemoveRule", mock.Anything, mock.Anything)
+		versionRepository.AssertNotCalled(t, "Remove", mock.Anything, mock.Anything)
+	})
+
+	t.Run("when the transactions are not supported the rules are not imported", func(t *testing.T) {
+		ruleRepository := new(mocks.RulesRepositoryMock)
+		versionRepository := newRuleVersionRepositoryMock()
+		ruleRepository.On("FindRulesPaged", context.TODO(), isLookupByID(transferNewRuleID), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		ruleRepository.On("FindRulesPaged", context.TODO(), isDuplicatedLookup(), entities.Pagination{}).
+			Return(entities.PagedResponse{Data: []entities.Rule{}}, nil)
+		transactor := new(mocks.TransactorMock)
+		transactor.On("WithTransaction", context.TODO()).Return(mongodb.ErrTransactionsNotSupported)
+
+		service := newTransferServiceWithTransactor(ruleRepository, versionRepository, transactor)
+		report, err := service.Import(context.TODO(), []entities.RuleExport{
+			getRuleExport(transferNewRuleID, "gambling", "fp_1"),
+		}, author, false)
+
+		_, isUnavailable := err.(exceptions.ServiceUnavailableException)
+		assert.True(t, isUnavailable)
+		assert.False(t, report.Applied)
+		ruleRepository.AssertNotCalled(t, "AddRule", mock.Anything, mock.Anything)
+		versionRepository.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+	})
+}
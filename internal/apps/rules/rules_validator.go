@@ -5,26 +5,29 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/conekta/Conekta-Golang-Rules-Engine/parser"
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/pkg/text"
-
-	"github.com/conekta/Conekta-Golang-Rules-Engine/parser"
 )
 
 const validatorServiceMethod = "rules_validator.service.%s"
 
 type RuleValidator interface {
 	Evaluate(ctx context.Context, rule entities.Rule, info map[string]interface{}) (bool, error)
+	Invalidate(ruleID string)
+	ExplainClauses(ctx context.Context, rule entities.Rule, info map[string]interface{}) []entities.ClauseTrace
 }
 
 type rulesValidator struct {
-	logs logs.Logger
+	logs           logs.Logger
+	evaluatorCache RuleEvaluatorCache
 }
 
-func NewRulesValidator(logger logs.Logger) RuleValidator {
+func NewRulesValidator(logger logs.Logger, evaluatorCache RuleEvaluatorCache) RuleValidator {
 	return &rulesValidator{
-		logs: logger,
+		logs:           logger,
+		evaluatorCache: evaluatorCache,
 	}
 }
 
@@ -33,8 +36,7 @@ func (v *rulesValidator) Evaluate(ctx context.Context, rule entities.Rule, info
 		return false, fmt.Errorf("[RuleValidator.evaluate] empty rule: [%v]", rule)
 	}
 
-	newRuleString := strings.ReplaceAll(rule.Rule, "\\", "")
-	ev, err := parser.NewEvaluator(newRuleString)
+	compiled, err := v.evaluatorCache.GetEvaluator(ctx, rule)
 	if err != nil {
 		er := fmt.Errorf(
 			"[RuleValidator.evaluate] On creating new evaluator for rule=[%+v] id=[%s] error=[%v]",
@@ -43,7 +45,11 @@ func (v *rulesValidator) Evaluate(ctx context.Context, rule entities.Rule, info
 
 		return false, er
 	}
-	ans, err := ev.Process(info)
+
+	ev := compiled.acquire()
+	defer compiled.release(ev)
+
+	ans, err := ev.Process(withFormulaValues(compiled.formulas, info))
 	if err != nil {
 		er := fmt.Errorf(
 			"[RuleValidator.evaluate] On process rule=[%v] id=[%s] error=[%v]",
@@ -63,3 +69,131 @@ func (v *rulesValidator) Evaluate(ctx context.Context, rule entities.Rule, info
 
 	return ans, nil
 }
+
+func (v *rulesValidator) Invalidate(ruleID string) {
+	v.evaluatorCache.Invalidate(ruleID)
+}
+
+func (v *rulesValidator) ExplainClauses(ctx context.Context, rule entities.Rule,
+	info map[string]interface{}) []entities.ClauseTrace {
+	clauseEvaluators := v.getClauseEvaluators(ctx, rule)
+
+	clauses := make([]entities.ClauseTrace, 0, len(rule.Rules))
+	for i, content := range rule.Rules {
+		clause := entities.ClauseTrace{RuleContent: content}
+		clause.Expression = content.RuleAsString(true)
+
+		if expression, ok := content.FormulaExpression(); ok {
+			value, steps := expression.Calculate(formulaLookup(info))
+			clause.FormulaValue = &value
+			clause.FormulaSteps = steps
+		} else if content.Fields != nil && content.MathOperation != nil {
+			value := calculateFormula(content.FormulaContent, info)
+			clause.FormulaValue = &value
+		}
+
+		result, err := explainClause(clauseEvaluators[i], content, info)
+		if err != nil {
+			clause.Error = err.Error()
+		}
+		clause.Result = result
+
+		clauses = append(clauses, clause)
+	}
+
+	return clauses
+}
+
+// getClauseEvaluators returns the clause evaluators cached with the rule, they are compiled apart when the rule
+// itself does not compile.
+func (v *rulesValidator) getClauseEvaluators(ctx context.Context, rule entities.Rule) []compiledClause {
+	compiled, err := v.evaluatorCache.GetEvaluator(ctx, rule)
+	if err != nil {
+		return compileClauses(rule, "")
+	}
+
+	return compiled.getClauses(rule)
+}
+
+// explainClause evaluates a single clause without logging, a clause that fails is reported in its trace.
+func explainClause(clause compiledClause, content entities.RuleContent, info map[string]interface{}) (bool, error) {
+	if clause.err != nil {
+		return false, clause.err
+	}
+
+	ev := clause.compiled.acquire()
+	defer clause.compiled.release(ev)
+
+	result, err := ev.Process(withFormulaValues(clause.compiled.formulas, info))
+	if err != nil {
+		return false, err
+	}
+	if err = ev.LastDebugErr(); err != nil {
+		return false, err
+	}
+
+	return result, nil
+}
+
+// withFormulaValues returns the info with the values of the parsed formula expressions of the rule under the formula
+// field, the info is only copied for the rules with formulas because it is shared by the evaluations of every rule.
+func withFormulaValues(formulas []entities.FormulaExpression, info map[string]interface{}) map[string]interface{} {
+	if len(formulas) == 0 {
+		return info
+	}
+
+	values := make(map[string]interface{}, len(formulas))
+	lookup := formulaLookup(info)
+	for _, expression := range formulas {
+		values[expression.Key()], _ = expression.Calculate(lookup)
+	}
+
+	infoWithValues := make(map[string]interface{}, len(info)+1)
+	for key, value := range info {
+		infoWithValues[key] = value
+	}
+	infoWithValues[entities.FormulaField] = values
+
+	return infoWithValues
+}
+
+func formulaLookup(info map[string]interface{}) func(string) float64 {
+	return func(field string) float64 {
+		value, _ := parser.NestedMapLookup(info, strings.Split(field, ".")...)
+		return parser.ToFloat64(value)
+	}
+}
+
+func calculateFormula(formula entities.FormulaContent, info map[string]interface{}) float64 {
+	values := make([]float64, 0, len(*formula.Fields))
+	for _, field := range *formula.Fields {
+		value, _ := parser.NestedMapLookup(info, strings.Split(field, ".")...)
+		values = append(values, parser.ToFloat64(value))
+	}
+
+	var result float64
+	switch *formula.MathOperation {
+	case entities.SUM:
+		for _, value := range values {
+			result += value
+		}
+	case entities.MLP:
+		// mirrors the engine, which restarts the product after a zero factor
+		for _, value := range values {
+			if result == 0 {
+				result = 1
+			}
+			result *= value
+		}
+	case entities.SUBTRACT:
+		if len(values) > 1 {
+			result = values[0] - values[1]
+		}
+	case entities.DIV:
+		if len(values) > 1 && values[1] != 0 {
+			result = values[0] / values[1]
+		}
+	}
+
+	return result
+}
@@ -6,10 +6,13 @@ import (
 
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
 	"github.com/conekta/risk-rules/test/testdata"
 
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestEvaluation(t *testing.T) {
@@ -42,7 +45,7 @@ func TestEvaluation(t *testing.T) {
 		panic(err)
 	}
 
-	rulesValidator := rules.NewRulesValidator(logger)
+	rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 	tests := []struct {
 		name string
 		rule string
@@ -212,7 +215,7 @@ func TestWrongEvaluation(t *testing.T) {
 		panic(err)
 	}
 
-	rulesValidator := rules.NewRulesValidator(logger)
+	rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 	tests := []struct {
 		name string
 		rule string
@@ -263,7 +266,7 @@ func TestMathOperations(t *testing.T) {
 			panic(err)
 		}
 
-		rulesValidator := rules.NewRulesValidator(logger)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 		rule := "SUBTRACT (aggregation.payer_company.charge.h1.count,aggregation.payer_company.charge.h2.count) EQ -2"
 		result, err := rulesValidator.Evaluate(context.TODO(), entities.Rule{Rule: rule}, chargeMap)
 
@@ -279,7 +282,7 @@ func TestMathOperations(t *testing.T) {
 			panic(err)
 		}
 
-		rulesValidator := rules.NewRulesValidator(logger)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 		rule := "DIV (aggregation.payer.charge.h12.count,aggregation.payer.charge.h1.count) EQ 3"
 		result, err := rulesValidator.Evaluate(context.TODO(), entities.Rule{Rule: rule}, chargeMap)
 
@@ -296,7 +299,7 @@ func TestMathOperations(t *testing.T) {
 			panic(err)
 		}
 
-		rulesValidator := rules.NewRulesValidator(logger)
+		rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
 		rule := "DIV (aggregation.payer_company.charge.h1.count,aggregation.payer_company.charge.h12.count) EQ 0"
 		result, err := rulesValidator.Evaluate(context.TODO(), entities.Rule{Rule: rule}, chargeMap)
 
@@ -304,3 +307,115 @@ func TestMathOperations(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestGroupEvaluation(t *testing.T) {
+	logger, _ := logs.New()
+	charge := entities.ChargeRequest{Amount: 28, MonthlyInstallments: 10, LiveMode: false}
+	chargeMap, _ := charge.ToMap()
+	rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
+	group := entities.RuleGroup{Condition: "or", Items: []entities.RuleGroupItem{
+		{Clause: &entities.RuleContent{Field: "amount", Operator: "<", Value: "100"}},
+		{Group: &entities.RuleGroup{Condition: "and", Items: []entities.RuleGroupItem{
+			{Clause: &entities.RuleContent{Field: "monthly_installments", Operator: ">", Value: "12"}},
+			{Clause: &entities.RuleContent{Field: "live_mode", Operator: "==", Value: "true"}},
+		}}},
+	}}
+
+	t.Run("when the clauses are grouped then evaluate the group first", func(t *testing.T) {
+		isValid, err := rulesValidator.Evaluate(context.TODO(), entities.Rule{Rule: group.RuleAsString()}, chargeMap)
+
+		assert.Nil(t, err)
+		assert.True(t, isValid)
+	})
+
+	t.Run("when the same clauses are flat then evaluate them from left to right", func(t *testing.T) {
+		rule := rules.NewRulesService(config.Config{}, nil, nil, nil, nil, nil, nil, nil).BuildRule(group.Contents())
+
+		isValid, err := rulesValidator.Evaluate(context.TODO(), entities.Rule{Rule: rule}, chargeMap)
+
+		assert.Nil(t, err)
+		assert.False(t, isValid)
+	})
+
+	t.Run("when the group is negated then negate its result", func(t *testing.T) {
+		negated := group
+		negated.Not = true
+
+		isValid, err := rulesValidator.Evaluate(context.TODO(), entities.Rule{Rule: negated.RuleAsString()}, chargeMap)
+
+		assert.Nil(t, err)
+		assert.False(t, isValid)
+	})
+}
+
+func TestFormulaExpressionEvaluation(t *testing.T) {
+	logger, _ := logs.New()
+	charge := entities.ChargeRequest{Amount: 28, Aggregation: testdata.GetDefaultAggregation()}
+	chargeMap, _ := charge.ToMap()
+	rulesValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(config.Config{}, logger, new(datadog.MetricsDogMock)))
+	ruleService := rules.NewRulesService(config.Config{}, nil, nil, nil, nil, nil, nil, nil)
+
+	newRule := func(expression, value string) entities.Rule {
+		contents := testdata.GetExpressionFormulaRules(expression)
+		contents[0].Value = value
+		contents = append(contents, entities.RuleContent{Field: "amount", Operator: ">", Value: "10", Condition: "and"})
+		entities.GenerateRuleFieldWithFormulaFields(&contents)
+		rule := entities.Rule{ID: primitive.NewObjectID(), Rules: contents}
+		rule.Rule = ruleService.BuildRule(rule.Rules)
+		return rule
+	}
+
+	t.Run("when the computed value matches then the rule matches", func(t *testing.T) {
+		rule := newRule("aggregation.payer.charge.h1.sum / amount", "3.5")
+
+		isValid, err := rulesValidator.Evaluate(context.TODO(), rule, chargeMap)
+
+		assert.Nil(t, err)
+		assert.True(t, isValid)
+		assert.NotContains(t, chargeMap, entities.FormulaField)
+	})
+
+	t.Run("when the divisor is zero then the value is zero", func(t *testing.T) {
+		rule := newRule("amount / aggregation.payer.charge.h24.sum", "0")
+
+		isValid, err := rulesValidator.Evaluate(context.TODO(), rule, chargeMap)
+
+		assert.Nil(t, err)
+		assert.False(t, isValid)
+	})
+
+	t.Run("when the rule is explained then show the intermediate values", func(t *testing.T) {
+		rule := newRule("MAX(aggregation.payer.charge.h1.sum, 50) / amount", "3.5")
+
+		clauses := rulesValidator.ExplainClauses(context.TODO(), rule, chargeMap)
+
+		assert.True(t, clauses[0].Result)
+		assert.InDelta(t, 100.0/28, *clauses[0].FormulaValue, 0.000001)
+		assert.Equal(t, []entities.FormulaStep{
+			{Expression: "aggregation.payer.charge.h1.sum", Value: 100},
+			{Expression: "MAX(aggregation.payer.charge.h1.sum, 50)", Value: 100},
+			{Expression: "amount", Value: 28},
+			{Expression: "MAX(aggregation.payer.charge.h1.sum, 50) / amount", Value: 100.0 / 28},
+		}, clauses[0].FormulaSteps)
+		assert.True(t, clauses[1].Result)
+	})
+
+	t.Run("when the evaluator is cached then its parsed formula is calculated for every charge", func(t *testing.T) {
+		cachedValidator := rules.NewRulesValidator(logger, rules.NewRuleEvaluatorCache(getEnabledEvaluatorCacheConfig(),
+			logger, new(datadog.MetricsDogMock)))
+		rule := newRule("aggregation.payer.charge.h1.sum / amount", "3.5")
+		otherCharge := entities.ChargeRequest{Amount: 50, Aggregation: testdata.GetDefaultAggregation()}
+		otherChargeMap, _ := otherCharge.ToMap()
+
+		isValid, err := cachedValidator.Evaluate(context.TODO(), rule, chargeMap)
+		otherIsValid, otherErr := cachedValidator.Evaluate(context.TODO(), rule, otherChargeMap)
+		clauses := cachedValidator.ExplainClauses(context.TODO(), rule, otherChargeMap)
+
+		assert.NoError(t, err)
+		assert.True(t, isValid)
+		assert.NoError(t, otherErr)
+		assert.False(t, otherIsValid)
+		assert.False(t, clauses[0].Result)
+		assert.Equal(t, 2.0, *clauses[0].FormulaValue)
+	})
+}
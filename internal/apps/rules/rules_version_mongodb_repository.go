@@ -0,0 +1,116 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const versionRepositoryName = "rules.versions.repository.mongo.%s"
+
+type RuleVersionRepository interface {
+	Add(ctx context.Context, version entities.RuleVersion) error
+	Remove(ctx context.Context, version entities.RuleVersion) error
+	FindByRuleID(ctx context.Context, ruleID string) ([]entities.RuleVersion, error)
+	FindByRevision(ctx context.Context, ruleID string, revision int64) (entities.RuleVersion, error)
+}
+
+type ruleVersionMongoDBRepository struct {
+	config  config.Config
+	mongodb mongodb.MongoDBier
+	log     logs.Logger
+}
+
+func NewRuleVersionMongoDBRepository(cfg config.Config, mongoDBier mongodb.MongoDBier,
+	logger logs.Logger) RuleVersionRepository {
+	return &ruleVersionMongoDBRepository{
+		config:  cfg,
+		mongodb: mongoDBier,
+		log:     logger,
+	}
+}
+
+func (r *ruleVersionMongoDBRepository) Add(ctx context.Context, version entities.RuleVersion) error {
+	_, err := r.mongodb.Collection(r.config.MongoDB.Collections.RuleVersions).InsertOne(ctx, version)
+	if mongo.IsDuplicateKeyError(err) {
+		return exceptions.NewDuplicatedException(fmt.Sprintf("revision %d of rule '%s' was written by another change",
+			version.Revision, version.RuleID.Hex()))
+	}
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(versionRepositoryName, "Add"),
+			text.RuleID, version.RuleID.Hex())
+		return err
+	}
+
+	return nil
+}
+
+func (r *ruleVersionMongoDBRepository) Remove(ctx context.Context, version entities.RuleVersion) error {
+	_, err := r.mongodb.Collection(r.config.MongoDB.Collections.RuleVersions).
+		DeleteOne(ctx, bson.M{"rule_id": version.RuleID, "revision": version.Revision})
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(versionRepositoryName, "Remove"),
+			text.RuleID, version.RuleID.Hex())
+		return err
+	}
+
+	return nil
+}
+
+func (r *ruleVersionMongoDBRepository) FindByRuleID(ctx context.Context,
+	ruleID string) ([]entities.RuleVersion, error) {
+	ID, err := primitive.ObjectIDFromHex(ruleID)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(versionRepositoryName, "FindByRuleID"))
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{primitive.E{Key: "revision", Value: 1}})
+	cur, err := r.mongodb.Collection(r.config.MongoDB.Collections.RuleVersions).Find(ctx, bson.M{"rule_id": ID}, opts)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(versionRepositoryName, "FindByRuleID"))
+		return nil, err
+	}
+
+	versions := make([]entities.RuleVersion, 0)
+	if err = cur.All(ctx, &versions); err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(versionRepositoryName, "FindByRuleID"))
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (r *ruleVersionMongoDBRepository) FindByRevision(ctx context.Context, ruleID string,
+	revision int64) (entities.RuleVersion, error) {
+	ID, err := primitive.ObjectIDFromHex(ruleID)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(versionRepositoryName, "FindByRevision"))
+		return entities.RuleVersion{}, err
+	}
+
+	var version entities.RuleVersion
+	err = r.mongodb.Collection(r.config.MongoDB.Collections.RuleVersions).
+		FindOne(ctx, bson.M{"rule_id": ID, "revision": revision}).Decode(&version)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return entities.RuleVersion{}, exceptions.NewNotFoundException(
+			fmt.Sprintf("revision %d of rule '%s' not found", revision, ruleID))
+	}
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(versionRepositoryName, "FindByRevision"))
+		return entities.RuleVersion{}, err
+	}
+
+	return version, nil
+}
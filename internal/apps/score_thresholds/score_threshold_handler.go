@@ -0,0 +1,129 @@
+package scorethresholds
+
+import (
+	"fmt"
+	"net/http"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/entities"
+	str "github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+	"github.com/labstack/echo/v4"
+)
+
+const handlerName = "score_thresholds.handler.%s"
+
+type ScoreThresholdHandler interface {
+	Create(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+	Get(ctx echo.Context) error
+}
+
+type scoreThresholdHandler struct {
+	logs    logs.Logger
+	service ScoreThresholdService
+}
+
+func NewScoreThresholdHandler(service ScoreThresholdService, logger logs.Logger) ScoreThresholdHandler {
+	return &scoreThresholdHandler{
+		logs:    logger,
+		service: service,
+	}
+}
+
+func (handler *scoreThresholdHandler) Create(ctx echo.Context) error {
+	request, err := handler.bindRequest(ctx, "Create")
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	threshold, err := handler.service.Create(ctx.Request().Context(), request.NewScoreThresholdFromPostRequest())
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusCreated, threshold)
+}
+
+func (handler *scoreThresholdHandler) Get(ctx echo.Context) error {
+	var filter entities.ScoreThresholdFilter
+	ctx.Bind(&filter)
+
+	thresholds, err := handler.service.Get(ctx.Request().Context(), filter)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.JSON(http.StatusOK, thresholds)
+}
+
+func (handler *scoreThresholdHandler) Update(ctx echo.Context) error {
+	thresholdID := ctx.Param("id")
+	if str.IsEmpty(thresholdID) {
+		err := customHttp.NewBadRequestError("id cannot be empty to update a score threshold")
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Update"))
+		ctx.Error(err)
+		return nil
+	}
+
+	request, err := handler.bindRequest(ctx, "Update")
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	err = handler.service.Update(ctx.Request().Context(), thresholdID, request.NewScoreThresholdFromPutRequest())
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (handler *scoreThresholdHandler) Delete(ctx echo.Context) error {
+	thresholdID := ctx.Param("id")
+	if str.IsEmpty(thresholdID) {
+		err := customHttp.NewBadRequestError("error: id cannot be empty to delete a score threshold")
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, "Delete"))
+		ctx.Error(err)
+		return nil
+	}
+
+	err := handler.service.Delete(ctx.Request().Context(), thresholdID)
+	if err != nil {
+		ctx.Error(err)
+		return nil
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (handler *scoreThresholdHandler) bindRequest(ctx echo.Context,
+	methodName string) (*entities.ScoreThresholdRequest, error) {
+	request := new(entities.ScoreThresholdRequest)
+	if err := ctx.Bind(request); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		return nil, err
+	}
+
+	if err := ctx.Validate(request); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		err = customHttp.NewBadRequestError(err.Error())
+		handler.logs.Error(ctx.Request().Context(), err.Error(), text.LogTagMethod, fmt.Sprintf(handlerName, methodName))
+		return nil, err
+	}
+
+	return request, nil
+}
@@ -0,0 +1,134 @@
+package scorethresholds_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	customHttp "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	scorethresholds "github.com/conekta/risk-rules/internal/apps/score_thresholds"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/echo"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const scoreThresholdsUri = "/risk-rules/v1/score_thresholds"
+
+func TestScoreThresholdHandler_Create(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("when accept score is not lower than decline score, then return BadRequest", func(t *testing.T) {
+		request := testdata.GetScoreThresholdRequest()
+		acceptScore := 90.0
+		request.AcceptScore = &acceptScore
+		bodyBytes, _ := json.Marshal(request)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, scoreThresholdsUri, "", string(bodyBytes))
+		handler := scorethresholds.NewScoreThresholdHandler(nil, logger)
+
+		handler.Create(context)
+
+		restError, _ := customHttp.NewRestErrorFromBytes(rec.Body.Bytes())
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.True(t, strings.Contains(restError.Message(), "accept_score must be lower than decline_score"))
+	})
+
+	t.Run("when global threshold has company, then return BadRequest", func(t *testing.T) {
+		request := testdata.GetScoreThresholdRequest()
+		isGlobal := true
+		request.IsGlobal = &isGlobal
+		bodyBytes, _ := json.Marshal(request)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, scoreThresholdsUri, "", string(bodyBytes))
+		handler := scorethresholds.NewScoreThresholdHandler(nil, logger)
+
+		handler.Create(context)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("when request is valid, then return Created", func(t *testing.T) {
+		serviceMock := new(mocks.ScoreThresholdServiceMock)
+		request := testdata.GetScoreThresholdRequest()
+		bodyBytes, _ := json.Marshal(request)
+		context, rec := echo.SetupAsRecorder(http.MethodPost, scoreThresholdsUri, "", string(bodyBytes))
+		handler := scorethresholds.NewScoreThresholdHandler(serviceMock, logger)
+
+		serviceMock.On("Create", mock.Anything, mock.AnythingOfType("entities.ScoreThreshold")).
+			Return(testdata.GetDefaultScoreThreshold(), nil).Once()
+
+		handler.Create(context)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		serviceMock.AssertExpectations(t)
+	})
+}
+
+func TestScoreThresholdHandler_Update(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("when service fails, then return error", func(t *testing.T) {
+		serviceMock := new(mocks.ScoreThresholdServiceMock)
+		bodyBytes, _ := json.Marshal(testdata.GetScoreThresholdRequest())
+		context, rec := echo.SetupAsRecorder(http.MethodPut, scoreThresholdsUri, "6353f4a1b0e2d3c4f5a6b7c8",
+			string(bodyBytes))
+		handler := scorethresholds.NewScoreThresholdHandler(serviceMock, logger)
+
+		serviceMock.On("Update", mock.Anything, "6353f4a1b0e2d3c4f5a6b7c8",
+			mock.AnythingOfType("entities.ScoreThreshold")).Return(errors.New("database connection lost")).Once()
+
+		handler.Update(context)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		serviceMock.AssertExpectations(t)
+	})
+
+	t.Run("when request is valid, then return NoContent", func(t *testing.T) {
+		serviceMock := new(mocks.ScoreThresholdServiceMock)
+		bodyBytes, _ := json.Marshal(testdata.GetScoreThresholdRequest())
+		context, rec := echo.SetupAsRecorder(http.MethodPut, scoreThresholdsUri, "6353f4a1b0e2d3c4f5a6b7c8",
+			string(bodyBytes))
+		handler := scorethresholds.NewScoreThresholdHandler(serviceMock, logger)
+
+		serviceMock.On("Update", mock.Anything, "6353f4a1b0e2d3c4f5a6b7c8",
+			mock.AnythingOfType("entities.ScoreThreshold")).Return(nil).Once()
+
+		handler.Update(context)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		serviceMock.AssertExpectations(t)
+	})
+}
+
+func TestScoreThresholdHandler_Delete(t *testing.T) {
+	logger, _ := logs.New()
+	serviceMock := new(mocks.ScoreThresholdServiceMock)
+	context, rec := echo.SetupAsRecorder(http.MethodDelete, scoreThresholdsUri, "6353f4a1b0e2d3c4f5a6b7c8", "")
+	handler := scorethresholds.NewScoreThresholdHandler(serviceMock, logger)
+
+	serviceMock.On("Delete", mock.Anything, "6353f4a1b0e2d3c4f5a6b7c8").Return(nil).Once()
+
+	handler.Delete(context)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestScoreThresholdHandler_Get(t *testing.T) {
+	logger, _ := logs.New()
+	serviceMock := new(mocks.ScoreThresholdServiceMock)
+	context, rec := echo.SetupAsRecorder(http.MethodGet, scoreThresholdsUri, "", "")
+	handler := scorethresholds.NewScoreThresholdHandler(serviceMock, logger)
+
+	serviceMock.On("Get", mock.Anything, entities.ScoreThresholdFilter{}).
+		Return([]entities.ScoreThreshold{testdata.GetDefaultScoreThreshold()}, nil).Once()
+
+	handler.Get(context)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,176 @@
+package scorethresholds
+
+import (
+	"context"
+	"fmt"
+
+	http "github.com/conekta/go_common/http/resterror"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const repositoryName = "score_thresholds.repository.mongo.%s"
+
+type ScoreThresholdRepository interface {
+	Add(ctx context.Context, threshold *entities.ScoreThreshold) error
+	Update(ctx context.Context, id string, threshold entities.ScoreThreshold) error
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, filter entities.ScoreThresholdFilter) ([]entities.ScoreThreshold, error)
+	SearchByScope(ctx context.Context, companyID, familyID string) ([]entities.ScoreThreshold, error)
+}
+
+type scoreThresholdMongoDBRepository struct {
+	config  config.Config
+	mongodb mongodb.MongoDBier
+	log     logs.Logger
+}
+
+func NewScoreThresholdMongoDBRepository(cfg config.Config, mongoDBier mongodb.MongoDBier,
+	logger logs.Logger) ScoreThresholdRepository {
+	return &scoreThresholdMongoDBRepository{
+		config:  cfg,
+		mongodb: mongoDBier,
+		log:     logger,
+	}
+}
+
+func (r *scoreThresholdMongoDBRepository) Add(ctx context.Context, threshold *entities.ScoreThreshold) error {
+	result, err := r.mongodb.Collection(r.config.MongoDB.Collections.ScoreThresholds).InsertOne(ctx, threshold)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Add"))
+		return err
+	}
+
+	threshold.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *scoreThresholdMongoDBRepository) Update(ctx context.Context, id string,
+	threshold entities.ScoreThreshold) error {
+	thresholdID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Update"), text.ThresholdID, id)
+		return err
+	}
+
+	update := bson.D{
+		{Key: "$set",
+			Value: bson.D{
+				primitive.E{Key: "company_id", Value: threshold.CompanyID},
+				primitive.E{Key: "family_id", Value: threshold.FamilyID},
+				primitive.E{Key: "is_global", Value: threshold.IsGlobal},
+				primitive.E{Key: "decline_score", Value: threshold.DeclineScore},
+				primitive.E{Key: "accept_score", Value: threshold.AcceptScore},
+				primitive.E{Key: "updated_at", Value: threshold.UpdatedAt},
+				primitive.E{Key: "updated_by", Value: threshold.UpdatedBy},
+			},
+		},
+	}
+
+	result, err := r.mongodb.Collection(r.config.MongoDB.Collections.ScoreThresholds).
+		UpdateOne(ctx, bson.M{"_id": thresholdID}, update)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Update"))
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		err = exceptions.NewNotFoundException(fmt.Sprintf("error: score threshold not found: '%s'", id))
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Update"))
+		return err
+	}
+
+	return nil
+}
+
+func (r *scoreThresholdMongoDBRepository) Delete(ctx context.Context, id string) error {
+	thresholdID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Delete"), text.ThresholdID, id)
+		return err
+	}
+
+	result, err := r.mongodb.Collection(r.config.MongoDB.Collections.ScoreThresholds).
+		DeleteOne(ctx, bson.M{"_id": thresholdID})
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Delete"))
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		err = exceptions.NewNotFoundException(fmt.Sprintf("error: score threshold not found: '%s'", id))
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Delete"))
+		return err
+	}
+
+	return nil
+}
+
+func (r *scoreThresholdMongoDBRepository) Search(ctx context.Context,
+	filter entities.ScoreThresholdFilter) ([]entities.ScoreThreshold, error) {
+	query := bson.M{}
+
+	if !strings.IsEmpty(filter.ID) {
+		ID, err := primitive.ObjectIDFromHex(filter.ID)
+		if err != nil {
+			err = http.NewBadRequestError(fmt.Sprintf("error: invalid id of score threshold: '%s'", filter.ID))
+			r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, "Search"))
+			return nil, err
+		}
+		query["_id"] = ID
+	}
+
+	if !strings.IsEmpty(filter.CompanyID) {
+		query["company_id"] = filter.CompanyID
+	}
+
+	if !strings.IsEmpty(filter.FamilyID) {
+		query["family_id"] = filter.FamilyID
+	}
+
+	if filter.IsGlobal {
+		query["is_global"] = true
+	}
+
+	return r.find(ctx, query, "Search")
+}
+
+func (r *scoreThresholdMongoDBRepository) SearchByScope(ctx context.Context,
+	companyID, familyID string) ([]entities.ScoreThreshold, error) {
+	scopes := []bson.M{{"is_global": true}}
+
+	if !strings.IsEmpty(companyID) {
+		scopes = append(scopes, bson.M{"company_id": companyID})
+	}
+
+	if !strings.IsEmpty(familyID) {
+		scopes = append(scopes, bson.M{"family_id": familyID})
+	}
+
+	return r.find(ctx, bson.M{"$or": scopes}, "SearchByScope")
+}
+
+func (r *scoreThresholdMongoDBRepository) find(ctx context.Context, query bson.M,
+	methodName string) ([]entities.ScoreThreshold, error) {
+	thresholds := make([]entities.ScoreThreshold, 0)
+
+	cur, err := r.mongodb.Collection(r.config.MongoDB.Collections.ScoreThresholds).Find(ctx, query)
+	if err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, methodName))
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &thresholds); err != nil {
+		r.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(repositoryName, methodName))
+		return nil, err
+	}
+
+	return thresholds, nil
+}
@@ -0,0 +1,144 @@
+package scorethresholds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/strings"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+const serviceMethodName = "score_thresholds.service.%s"
+
+type ScoreThresholdService interface {
+	Create(ctx context.Context, threshold entities.ScoreThreshold) (entities.ScoreThreshold, error)
+	Update(ctx context.Context, id string, threshold entities.ScoreThreshold) error
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, filter entities.ScoreThresholdFilter) ([]entities.ScoreThreshold, error)
+	GetThreshold(ctx context.Context, companyID, familyID string) (entities.ScoreThreshold, error)
+}
+
+type scoreThresholdService struct {
+	config     config.Config
+	repository ScoreThresholdRepository
+	logs       logs.Logger
+	datadog    datadog.Metricer
+}
+
+func NewScoreThresholdService(cfg config.Config, repository ScoreThresholdRepository, logger logs.Logger,
+	metric datadog.Metricer) ScoreThresholdService {
+	return &scoreThresholdService{
+		config:     cfg,
+		repository: repository,
+		logs:       logger,
+		datadog:    metric,
+	}
+}
+
+func (service *scoreThresholdService) Create(ctx context.Context,
+	threshold entities.ScoreThreshold) (entities.ScoreThreshold, error) {
+	metricData := metrics.NewMetricData(ctx, "Create", serviceMethodName, service.config.Env)
+
+	err := service.validateDuplicated(ctx, strings.Empty, threshold)
+	if err == nil {
+		err = service.repository.Add(ctx, &threshold)
+	}
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveThresholdMetricName)
+		return entities.ScoreThreshold{}, err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.SaveThresholdMetricName)
+	return threshold, nil
+}
+
+func (service *scoreThresholdService) Update(ctx context.Context, id string, threshold entities.ScoreThreshold) error {
+	metricData := metrics.NewMetricData(ctx, "Update", serviceMethodName, service.config.Env)
+
+	err := service.validateDuplicated(ctx, id, threshold)
+	if err == nil {
+		err = service.repository.Update(ctx, id, threshold)
+	}
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateThresholdMetricName)
+		return err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.UpdateThresholdMetricName)
+	return nil
+}
+
+func (service *scoreThresholdService) Delete(ctx context.Context, id string) error {
+	metricData := metrics.NewMetricData(ctx, "Delete", serviceMethodName, service.config.Env)
+
+	err := service.repository.Delete(ctx, id)
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteThresholdMetricName)
+		return err
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.DeleteThresholdMetricName)
+	return nil
+}
+
+func (service *scoreThresholdService) Get(ctx context.Context,
+	filter entities.ScoreThresholdFilter) ([]entities.ScoreThreshold, error) {
+	return service.repository.Search(ctx, filter)
+}
+
+func (service *scoreThresholdService) GetThreshold(ctx context.Context,
+	companyID, familyID string) (entities.ScoreThreshold, error) {
+	thresholds, err := service.repository.SearchByScope(ctx, companyID, familyID)
+	if err != nil {
+		return entities.ScoreThreshold{}, err
+	}
+
+	var familyThreshold, globalThreshold entities.ScoreThreshold
+	for _, threshold := range thresholds {
+		switch {
+		case !strings.IsEmpty(companyID) && strings.StringPointerToString(threshold.CompanyID) == companyID:
+			return threshold, nil
+		case !strings.IsEmpty(familyID) && strings.StringPointerToString(threshold.FamilyID) == familyID:
+			familyThreshold = threshold
+		case threshold.IsGlobal:
+			globalThreshold = threshold
+		}
+	}
+
+	if !familyThreshold.IsEmpty() {
+		return familyThreshold, nil
+	}
+
+	return globalThreshold, nil
+}
+
+func (service *scoreThresholdService) validateDuplicated(ctx context.Context, id string,
+	threshold entities.ScoreThreshold) error {
+	thresholdsFound, err := service.repository.Search(ctx, threshold.GetScoreThresholdFilter())
+	if err != nil {
+		return err
+	}
+
+	for _, thresholdFound := range thresholdsFound {
+		if !thresholdFound.IsTheSame(id) {
+			err = exceptions.NewDuplicatedException(
+				fmt.Sprintf("a %s score threshold already exists with id [%s]", threshold.GetScope(), thresholdFound.ID.Hex()))
+			service.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(serviceMethodName, "validateDuplicated"))
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package scorethresholds_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conekta/go_common/logs"
+	scorethresholds "github.com/conekta/risk-rules/internal/apps/score_thresholds"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScoreThresholdService_Create(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+
+	t.Run("when threshold scope is free, then create it", func(t *testing.T) {
+		repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetScoreThresholdRequest()
+		threshold := request.NewScoreThresholdFromPostRequest()
+		service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+		repositoryMock.On("Search", ctx, threshold.GetScoreThresholdFilter()).
+			Return([]entities.ScoreThreshold{}, nil).Once()
+		repositoryMock.On("Add", ctx, mock.AnythingOfType("*entities.ScoreThreshold")).
+			Return(nil).Once()
+
+		created, err := service.Create(ctx, threshold)
+
+		assert.NoError(t, err)
+		assert.Equal(t, threshold.CompanyID, created.CompanyID)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("when threshold scope already exists, then return duplicated error", func(t *testing.T) {
+		repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetScoreThresholdRequest()
+		threshold := request.NewScoreThresholdFromPostRequest()
+		service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+		repositoryMock.On("Search", ctx, threshold.GetScoreThresholdFilter()).
+			Return([]entities.ScoreThreshold{testdata.GetDefaultScoreThreshold()}, nil).Once()
+
+		_, err := service.Create(ctx, threshold)
+
+		assert.Error(t, err)
+		assert.IsType(t, exceptions.NewDuplicatedException(""), err)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("when repository fails, then return error", func(t *testing.T) {
+		repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetScoreThresholdRequest()
+		threshold := request.NewScoreThresholdFromPostRequest()
+		expErr := errors.New("database connection lost")
+		service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+		repositoryMock.On("Search", ctx, threshold.GetScoreThresholdFilter()).
+			Return([]entities.ScoreThreshold{}, nil).Once()
+		repositoryMock.On("Add", ctx, mock.AnythingOfType("*entities.ScoreThreshold")).
+			Return(expErr).Once()
+
+		_, err := service.Create(ctx, threshold)
+
+		assert.Equal(t, expErr, err)
+		repositoryMock.AssertExpectations(t)
+	})
+}
+
+func TestScoreThresholdService_Update(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+
+	t.Run("when the only threshold found is the updated one, then update it", func(t *testing.T) {
+		repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetScoreThresholdRequest()
+		threshold := request.NewScoreThresholdFromPutRequest()
+		existing := testdata.GetDefaultScoreThreshold()
+		service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+		repositoryMock.On("Search", ctx, threshold.GetScoreThresholdFilter()).
+			Return([]entities.ScoreThreshold{existing}, nil).Once()
+		repositoryMock.On("Update", ctx, existing.ID.Hex(), threshold).Return(nil).Once()
+
+		err := service.Update(ctx, existing.ID.Hex(), threshold)
+
+		assert.NoError(t, err)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("when another threshold has the same scope, then return duplicated error", func(t *testing.T) {
+		repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+		ctx := context.TODO()
+		request := testdata.GetScoreThresholdRequest()
+		threshold := request.NewScoreThresholdFromPutRequest()
+		service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+		repositoryMock.On("Search", ctx, threshold.GetScoreThresholdFilter()).
+			Return([]entities.ScoreThreshold{testdata.GetDefaultScoreThreshold()}, nil).Once()
+
+		err := service.Update(ctx, "6353f4a1b0e2d3c4f5a6b7ca", threshold)
+
+		assert.IsType(t, exceptions.NewDuplicatedException(""), err)
+		repositoryMock.AssertExpectations(t)
+	})
+}
+
+func TestScoreThresholdService_Delete(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+	repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+	ctx := context.TODO()
+	service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+	repositoryMock.On("Delete", ctx, "6353f4a1b0e2d3c4f5a6b7c8").Return(nil).Once()
+
+	err := service.Delete(ctx, "6353f4a1b0e2d3c4f5a6b7c8")
+
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestScoreThresholdService_GetThreshold(t *testing.T) {
+	logger, _ := logs.New()
+	configs := config.NewConfig()
+	companyID := "6262cb610211a64464781a5f"
+	familyID := "615324eb5bc1dea9ce66068f"
+
+	t.Run("when company and global thresholds exist, then company wins", func(t *testing.T) {
+		repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+		ctx := context.TODO()
+		service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+		repositoryMock.On("SearchByScope", ctx, companyID, familyID).Return([]entities.ScoreThreshold{
+			testdata.GetGlobalScoreThreshold(), testdata.GetDefaultScoreThreshold(),
+		}, nil).Once()
+
+		threshold, err := service.GetThreshold(ctx, companyID, familyID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "company", threshold.GetScope())
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("when only global threshold exists, then return it", func(t *testing.T) {
+		repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+		ctx := context.TODO()
+		service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+		repositoryMock.On("SearchByScope", ctx, companyID, familyID).
+			Return([]entities.ScoreThreshold{testdata.GetGlobalScoreThreshold()}, nil).Once()
+
+		threshold, err := service.GetThreshold(ctx, companyID, familyID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "global", threshold.GetScope())
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("when no threshold exists, then return an empty threshold", func(t *testing.T) {
+		repositoryMock := new(mocks.ScoreThresholdRepositoryMock)
+		ctx := context.TODO()
+		service := scorethresholds.NewScoreThresholdService(configs, repositoryMock, logger, new(datadog.MetricsDogMock))
+
+		repositoryMock.On("SearchByScope", ctx, companyID, familyID).
+			Return([]entities.ScoreThreshold{}, nil).Once()
+
+		threshold, err := service.GetThreshold(ctx, companyID, familyID)
+
+		assert.NoError(t, err)
+		assert.True(t, threshold.IsEmpty())
+		assert.Equal(t, entities.Undecided, threshold.Decide(100))
+		repositoryMock.AssertExpectations(t)
+	})
+}
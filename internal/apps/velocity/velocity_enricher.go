@@ -0,0 +1,57 @@
+package velocity
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+)
+
+const (
+	enricherName  = "velocity"
+	velocityField = "velocity"
+)
+
+type velocityEnricher struct {
+	config  config.Config
+	service VelocityService
+}
+
+func NewVelocityEnricher(cfg config.Config, service VelocityService) entities.Enricher {
+	return &velocityEnricher{
+		config:  cfg,
+		service: service,
+	}
+}
+
+func (enricher *velocityEnricher) Name() string {
+	return enricherName
+}
+
+func (enricher *velocityEnricher) Fields() []string {
+	return []string{velocityField}
+}
+
+func (enricher *velocityEnricher) TimeoutMilliseconds() int {
+	return enricher.config.Enrichment.VelocityTimeoutMilliseconds
+}
+
+func (enricher *velocityEnricher) FailurePolicy() entities.EnrichmentFailurePolicy {
+	return entities.EnrichmentFailurePolicy{OnFailure: entities.EnrichmentOmitFields}
+}
+
+func (enricher *velocityEnricher) IsEnabled() bool {
+	return enricher.config.Velocity.IsEnabled
+}
+
+// Enrich returns the counters of the charge with the windows sent in the aggregation taking precedence.
+func (enricher *velocityEnricher) Enrich(ctx context.Context,
+	charge entities.ChargeRequest) (map[string]interface{}, error) {
+	velocity, err := enricher.service.GetVelocity(ctx, charge)
+	if err != nil {
+		return nil, err
+	}
+
+	velocity.OverrideWith(charge.Aggregation)
+	return map[string]interface{}{velocityField: velocity}, nil
+}
@@ -0,0 +1,122 @@
+package velocity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/pkg/text"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	repositoryName = "velocity.repository"
+
+	trackedChargeID = "tracked:%s"
+)
+
+type VelocityRepository interface {
+	MarkTracked(ctx context.Context, chargeID string, at time.Time) (bool, error)
+	Increment(ctx context.Context, keys []entities.VelocityKey, amount float64, at time.Time) error
+	FindBuckets(ctx context.Context, keys []entities.VelocityKey, now time.Time) ([]entities.VelocityBucket, error)
+}
+
+type velocityRepository struct {
+	logs    logs.Logger
+	mongodb mongodb.MongoDBier
+	config  config.Config
+}
+
+func NewVelocityMongoDBRepository(cfg config.Config, mongoDBier mongodb.MongoDBier,
+	logger logs.Logger) VelocityRepository {
+	return &velocityRepository{
+		logs:    logger,
+		mongodb: mongoDBier,
+		config:  cfg,
+	}
+}
+
+// MarkTracked stores a marker of the charge next to the buckets, it returns false when the charge was already counted
+// by another endpoint, a retry or a reevaluation. The marker is removed by the same TTL index once the longest
+// resolution no longer keeps the charge.
+func (repository *velocityRepository) MarkTracked(ctx context.Context, chargeID string, at time.Time) (bool, error) {
+	marker := bson.M{
+		"_id":     fmt.Sprintf(trackedChargeID, chargeID),
+		"expires": at.Add(resolutions[len(resolutions)-1].retention),
+	}
+
+	_, err := repository.mongodb.Collection(repository.config.MongoDB.Collections.Velocity).InsertOne(ctx, marker)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		repository.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf("%s.%s", repositoryName, "MarkTracked"))
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Increment adds the charge to the bucket of every resolution, the buckets are created on the first charge
+// and removed by the TTL index on expires.
+func (repository *velocityRepository) Increment(ctx context.Context, keys []entities.VelocityKey, amount float64,
+	at time.Time) error {
+	models := make([]mongo.WriteModel, 0, len(keys)*len(resolutions))
+	for _, key := range keys {
+		for _, resolution := range resolutions {
+			start := at.Truncate(resolution.size)
+			filter := bson.M{"key": key.Hash(), "resolution": resolution.name, "start": start}
+			update := bson.M{
+				"$inc":         bson.M{"count": 1, "sum": amount},
+				"$setOnInsert": bson.M{"dimension": key.Dimension, "expires": start.Add(resolution.retention)},
+			}
+			models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+		}
+	}
+
+	_, err := repository.mongodb.Collection(repository.config.MongoDB.Collections.Velocity).
+		BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		repository.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf("%s.%s", repositoryName, "Increment"))
+		return err
+	}
+
+	return nil
+}
+
+func (repository *velocityRepository) FindBuckets(ctx context.Context, keys []entities.VelocityKey,
+	now time.Time) ([]entities.VelocityBucket, error) {
+	hashes := make([]string, 0, len(keys))
+	for _, key := range keys {
+		hashes = append(hashes, key.Hash())
+	}
+
+	ranges := make(bson.A, 0, len(resolutions))
+	for _, resolution := range resolutions {
+		ranges = append(ranges, bson.M{
+			"resolution": resolution.name,
+			"start":      bson.M{"$gt": now.Add(-resolution.retention)},
+		})
+	}
+
+	query := bson.M{"key": bson.M{"$in": hashes}, "$or": ranges}
+	cursor, err := repository.mongodb.Collection(repository.config.MongoDB.Collections.Velocity).Find(ctx, query)
+	if err != nil {
+		repository.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf("%s.%s", repositoryName, "FindBuckets"))
+		return nil, err
+	}
+
+	buckets := make([]entities.VelocityBucket, 0)
+	if err = cursor.All(ctx, &buckets); err != nil {
+		repository.logs.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf("%s.%s", repositoryName, "FindBuckets"))
+		return nil, err
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,130 @@
+package velocity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/metrics"
+	"github.com/conekta/risk-rules/pkg/text"
+)
+
+const serviceMethodName = "velocity.service.%s"
+
+type resolution struct {
+	name      string
+	size      time.Duration
+	retention time.Duration
+}
+
+// resolutions are the bucket sizes of the counters, each window is read from the first one that keeps it,
+// so a window can count up to one bucket older than its duration.
+var resolutions = []resolution{
+	{name: "s10", size: 10 * time.Second, retention: 10 * time.Minute},
+	{name: "m1", size: time.Minute, retention: 4 * time.Hour},
+	{name: "h1", size: time.Hour, retention: 5 * 24 * time.Hour},
+	{name: "d1", size: 24 * time.Hour, retention: 122 * 24 * time.Hour},
+}
+
+type VelocityService interface {
+	GetVelocity(ctx context.Context, charge entities.ChargeRequest) (entities.Velocity, error)
+	Track(ctx context.Context, charge entities.ChargeRequest) error
+}
+
+type velocityService struct {
+	config     config.Config
+	repository VelocityRepository
+	logs       logs.Logger
+	datadog    datadog.Metricer
+}
+
+func NewVelocityService(cfg config.Config, repository VelocityRepository, logger logs.Logger,
+	metric datadog.Metricer) VelocityService {
+	return &velocityService{
+		config:     cfg,
+		repository: repository,
+		logs:       logger,
+		datadog:    metric,
+	}
+}
+
+// GetVelocity sums the buckets of the charge keys into the windows, the charge itself is not counted.
+func (service *velocityService) GetVelocity(ctx context.Context,
+	charge entities.ChargeRequest) (entities.Velocity, error) {
+	velocity := entities.Velocity{}
+	keys := charge.VelocityKeys()
+	if len(keys) == 0 {
+		return velocity, nil
+	}
+
+	metricData := metrics.NewMetricData(ctx, "GetVelocity", serviceMethodName, service.config.Env)
+	now := time.Now().UTC()
+	buckets, err := service.repository.FindBuckets(ctx, keys, now)
+	if err != nil {
+		metricData.SetResult(false)
+		metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.GetVelocityMetricName)
+		return velocity, err
+	}
+
+	for _, bucket := range buckets {
+		event := velocity.Dimension(bucket.Dimension)
+		if event == nil {
+			continue
+		}
+
+		windows := event.Windows()
+		for i, duration := range entities.AggregationWindows {
+			bucketResolution := resolutionFor(duration)
+			if bucketResolution.name != bucket.Resolution ||
+				!bucket.Start.Add(bucketResolution.size).After(now.Add(-duration)) {
+				continue
+			}
+			windows[i].Count += bucket.Count
+			windows[i].Sum += int(bucket.Sum)
+		}
+	}
+
+	metricData.SetResult(true)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.GetVelocityMetricName)
+	return velocity, nil
+}
+
+// Track counts the charge once no matter how many times it is evaluated, a charge whose increment fails after it was
+// marked is not counted rather than counted twice.
+func (service *velocityService) Track(ctx context.Context, charge entities.ChargeRequest) error {
+	keys := charge.VelocityKeys()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	metricData := metrics.NewMetricData(ctx, "Track", serviceMethodName, service.config.Env)
+	now := time.Now().UTC()
+	isFirst := true
+	var err error
+	if charge.ID != "" {
+		isFirst, err = service.repository.MarkTracked(ctx, charge.ID, now)
+	}
+	if err == nil && isFirst {
+		err = service.repository.Increment(ctx, keys, charge.Amount, now)
+	}
+
+	metricData.AddCustomTags([]string{fmt.Sprintf(text.MetricTagAlreadyTracked, err == nil && !isFirst)})
+	metricData.SetResult(err == nil)
+	metrics.SendAsyncMetrics(service.datadog, service.logs, metricData, text.TrackVelocityMetricName)
+
+	return err
+}
+
+func resolutionFor(duration time.Duration) resolution {
+	for _, candidate := range resolutions {
+		if candidate.retention > duration+candidate.size {
+			return candidate
+		}
+	}
+
+	return resolutions[len(resolutions)-1]
+}
@@ -0,0 +1,138 @@
+package velocity_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/velocity"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/mocks"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVelocityService_GetVelocity(t *testing.T) {
+	logger, _ := logs.New()
+	charge := testdata.GetDefaultCharge()
+
+	t.Run("buckets are summed into the windows that cover them", func(t *testing.T) {
+		now := time.Now().UTC()
+		tenDaysAgo := now.Add(-10 * 24 * time.Hour)
+		buckets := []entities.VelocityBucket{
+			{Dimension: entities.VelocityCardHash, Resolution: "s10", Start: now.Truncate(10 * time.Second),
+				Count: 2, Sum: 200},
+			{Dimension: entities.VelocityCardHash, Resolution: "m1", Start: now.Truncate(time.Minute),
+				Count: 2, Sum: 200},
+			{Dimension: entities.VelocityCardHash, Resolution: "m1", Start: now.Add(-90 * time.Minute).Truncate(time.Minute),
+				Count: 1, Sum: 50},
+			{Dimension: entities.VelocityCardHash, Resolution: "h1", Start: now.Truncate(time.Hour),
+				Count: 2, Sum: 200},
+			{Dimension: entities.VelocityCardHash, Resolution: "h1", Start: now.Add(-90 * time.Minute).Truncate(time.Hour),
+				Count: 1, Sum: 50},
+			{Dimension: entities.VelocityCardHash, Resolution: "d1", Start: now.Truncate(24 * time.Hour),
+				Count: 3, Sum: 250},
+			{Dimension: entities.VelocityCardHash, Resolution: "d1", Start: tenDaysAgo.Truncate(24 * time.Hour),
+				Count: 4, Sum: 400},
+			{Dimension: entities.VelocityIP, Resolution: "s10", Start: now.Truncate(10 * time.Second),
+				Count: 1, Sum: 100},
+		}
+		repository := new(mocks.VelocityRepositoryMock)
+		repository.On("FindBuckets", mock.Anything, charge.VelocityKeys(), mock.Anything).Return(buckets, nil)
+		service := velocity.NewVelocityService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+
+		got, err := service.GetVelocity(context.TODO(), charge)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 200, Count: 2}, got.CardHash.M1)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 200, Count: 2}, got.CardHash.H1)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 250, Count: 3}, got.CardHash.H2)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 250, Count: 3}, got.CardHash.D1)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 250, Count: 3}, got.CardHash.D7)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 650, Count: 7}, got.CardHash.D15)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 100, Count: 1}, got.IP.M5)
+		assert.Empty(t, got.Email)
+	})
+
+	t.Run("charge without keys does not read the counters", func(t *testing.T) {
+		repository := new(mocks.VelocityRepositoryMock)
+		service := velocity.NewVelocityService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+
+		got, err := service.GetVelocity(context.TODO(), entities.ChargeRequest{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+		repository.AssertNotCalled(t, "FindBuckets", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error is returned", func(t *testing.T) {
+		repository := new(mocks.VelocityRepositoryMock)
+		repository.On("FindBuckets", mock.Anything, mock.Anything, mock.Anything).
+			Return([]entities.VelocityBucket{}, errors.New("connection lost"))
+		service := velocity.NewVelocityService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+
+		_, err := service.GetVelocity(context.TODO(), charge)
+
+		assert.EqualError(t, err, "connection lost")
+	})
+}
+
+func TestVelocityService_Track(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("charge is counted by every key with its amount", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		repository := new(mocks.VelocityRepositoryMock)
+		repository.On("MarkTracked", mock.Anything, charge.ID, mock.Anything).Return(true, nil)
+		repository.On("Increment", mock.Anything, charge.VelocityKeys(), charge.Amount, mock.Anything).Return(nil)
+		service := velocity.NewVelocityService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+
+		err := service.Track(context.TODO(), charge)
+
+		assert.NoError(t, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("charge already counted is not counted again", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		repository := new(mocks.VelocityRepositoryMock)
+		repository.On("MarkTracked", mock.Anything, charge.ID, mock.Anything).Return(false, nil)
+		service := velocity.NewVelocityService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+
+		err := service.Track(context.TODO(), charge)
+
+		assert.NoError(t, err)
+		repository.AssertNotCalled(t, "Increment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("marker error is returned without counting the charge", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		repository := new(mocks.VelocityRepositoryMock)
+		repository.On("MarkTracked", mock.Anything, charge.ID, mock.Anything).
+			Return(false, errors.New("connection lost"))
+		service := velocity.NewVelocityService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+
+		err := service.Track(context.TODO(), charge)
+
+		assert.EqualError(t, err, "connection lost")
+		repository.AssertNotCalled(t, "Increment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repository error is returned", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		repository := new(mocks.VelocityRepositoryMock)
+		repository.On("MarkTracked", mock.Anything, charge.ID, mock.Anything).Return(true, nil)
+		repository.On("Increment", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("connection lost"))
+		service := velocity.NewVelocityService(config.Config{}, repository, logger, new(datadog.MetricsDogMock))
+
+		err := service.Track(context.TODO(), charge)
+
+		assert.EqualError(t, err, "connection lost")
+	})
+}
@@ -24,6 +24,10 @@ type (
 				FamilyCompanies            string `envconfig:"FAMILY_COMPANIES" default:"family_companies"`
 				Payers                     string `envconfig:"PAYERS" default:"payers"`
 				MerchantsScore             string `envconfig:"MERCHANTS_SCORE" default:"merchants_score"`
+				ScoreThresholds            string `envconfig:"SCORE_THRESHOLDS" default:"score_thresholds"`
+				RuleVersions               string `envconfig:"RULE_VERSIONS" default:"rule_versions"`
+				AuditLogs                  string `envconfig:"AUDIT_LOGS" default:"audit_logs"`
+				Velocity                   string `envconfig:"VELOCITY" default:"velocity_counters"`
 			}
 			Database string `envconfig:"MONGODB_DATABASE" default:"rules"`
 			URI      string `envconfig:"MONGODB_URI" default:"mongodb://localhost:27017"`
@@ -61,6 +65,70 @@ type (
 			S3PrefixFile string `envconfig:"S3_PREFIX_FILE" default:"merchant_score"`
 			Region       string `envconfig:"AWS_REGION" default:"us-east-1"`
 		}
+		EvaluatorCache struct {
+			IsEnabled  bool `envconfig:"IS_EVALUATOR_CACHE_ENABLED" default:"true"`
+			MaxEntries int  `envconfig:"EVALUATOR_CACHE_MAX_ENTRIES" default:"10000"`
+		}
+		RulesSnapshot struct {
+			IsEnabled     bool `envconfig:"IS_RULES_SNAPSHOT_ENABLED" default:"false"`
+			ResyncSeconds int  `envconfig:"RULES_SNAPSHOT_RESYNC_SECONDS" default:"300"`
+		}
+		Enrichment struct {
+			BudgetMilliseconds               int `envconfig:"ENRICHMENT_BUDGET_MILLISECONDS" default:"3000"`
+			ChargebacksTimeoutMilliseconds   int `envconfig:"ENRICHMENT_CHARGEBACKS_TIMEOUT_MILLISECONDS" default:"500"`
+			OmniscoreTimeoutMilliseconds     int `envconfig:"ENRICHMENT_OMNISCORE_TIMEOUT_MILLISECONDS" default:"2000"`
+			MerchantScoreTimeoutMilliseconds int `envconfig:"ENRICHMENT_MERCHANT_SCORE_TIMEOUT_MILLISECONDS" default:"500"`
+			ListsTimeoutMilliseconds         int `envconfig:"ENRICHMENT_LISTS_TIMEOUT_MILLISECONDS" default:"1000"`
+			VelocityTimeoutMilliseconds      int `envconfig:"ENRICHMENT_VELOCITY_TIMEOUT_MILLISECONDS" default:"500"`
+			BinTimeoutMilliseconds           int `envconfig:"ENRICHMENT_BIN_TIMEOUT_MILLISECONDS" default:"100"`
+			IPTimeoutMilliseconds            int `envconfig:"ENRICHMENT_IP_TIMEOUT_MILLISECONDS" default:"100"`
+		}
+		Velocity struct {
+			IsEnabled bool `envconfig:"IS_VELOCITY_ENABLED" default:"false"`
+		}
+		BinTable struct {
+			IsEnabled     bool   `envconfig:"IS_BIN_TABLE_ENABLED" default:"false"`
+			FilePath      string `envconfig:"BIN_TABLE_FILE_PATH"`
+			S3Bucket      string `envconfig:"BIN_TABLE_S3_BUCKET" default:"testbucket"`
+			S3File        string `envconfig:"BIN_TABLE_S3_FILE" default:"bins/bin_table.csv"`
+			ReloadSeconds int    `envconfig:"BIN_TABLE_RELOAD_SECONDS" default:"3600"`
+		}
+		IPIntelligence struct {
+			IsEnabled     bool     `envconfig:"IS_IP_INTELLIGENCE_ENABLED" default:"false"`
+			FilePaths     []string `envconfig:"IP_INTELLIGENCE_FILE_PATHS"`
+			ReloadSeconds int      `envconfig:"IP_INTELLIGENCE_RELOAD_SECONDS" default:"86400"`
+		}
+		Lists struct {
+			Source string `envconfig:"LISTS_SOURCE" default:"remote"`
+		}
+		HTTPClient struct {
+			MaxIdleConnsPerHost         int `envconfig:"HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST" default:"50"`
+			IdleConnTimeoutSeconds      int `envconfig:"HTTP_CLIENT_IDLE_CONN_TIMEOUT_SECONDS" default:"90"`
+			MaxRetries                  int `envconfig:"HTTP_CLIENT_MAX_RETRIES" default:"2"`
+			RetryBaseMilliseconds       int `envconfig:"HTTP_CLIENT_RETRY_BASE_MILLISECONDS" default:"50"`
+			RetryMaxMilliseconds        int `envconfig:"HTTP_CLIENT_RETRY_MAX_MILLISECONDS" default:"500"`
+			BreakerFailureThreshold     int `envconfig:"HTTP_CLIENT_BREAKER_FAILURE_THRESHOLD" default:"5"`
+			BreakerOpenMilliseconds     int `envconfig:"HTTP_CLIENT_BREAKER_OPEN_MILLISECONDS" default:"30000"`
+			MaxConcurrentRequestsByHost int `envconfig:"HTTP_CLIENT_MAX_CONCURRENT_REQUESTS_BY_HOST" default:"100"`
+		}
+		Degradation struct {
+			DefaultPolicy     string            `envconfig:"DEGRADATION_DEFAULT_POLICY" default:"continue"`
+			ComponentPolicies map[string]string `envconfig:"DEGRADATION_COMPONENT_POLICIES"`
+			CompanyPolicies   map[string]string `envconfig:"DEGRADATION_COMPANY_POLICIES"`
+		}
+		Idempotency struct {
+			IsEnabled     bool `envconfig:"IS_IDEMPOTENCY_ENABLED" default:"false"`
+			WindowSeconds int  `envconfig:"IDEMPOTENCY_WINDOW_SECONDS" default:"86400"`
+		}
+		BatchEvaluation struct {
+			MaxSize     int   `envconfig:"BATCH_EVALUATION_MAX_SIZE" default:"1000"`
+			MaxBytes    int64 `envconfig:"BATCH_EVALUATION_MAX_BYTES" default:"10485760"`
+			Concurrency int   `envconfig:"BATCH_EVALUATION_CONCURRENCY" default:"10"`
+		}
+		Backtest struct {
+			MaxEvaluations    int64 `envconfig:"BACKTEST_MAX_EVALUATIONS" default:"50000"`
+			DefaultSampleSize int   `envconfig:"BACKTEST_DEFAULT_SAMPLE_SIZE" default:"20"`
+		}
 	}
 )
 
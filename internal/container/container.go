@@ -6,21 +6,29 @@ import (
 	"github.com/conekta/go_common/datadog"
 	"github.com/conekta/go_common/logs"
 
+	"github.com/conekta/risk-rules/internal/apps/audit"
+	"github.com/conekta/risk-rules/internal/apps/backtests"
+	"github.com/conekta/risk-rules/internal/apps/bins"
 	"github.com/conekta/risk-rules/internal/apps/chargebacks"
 	"github.com/conekta/risk-rules/internal/apps/charges"
 	"github.com/conekta/risk-rules/internal/apps/conditions"
 	"github.com/conekta/risk-rules/internal/apps/families"
 	familycom "github.com/conekta/risk-rules/internal/apps/family_companies"
 	"github.com/conekta/risk-rules/internal/apps/fields"
+	ipintelligence "github.com/conekta/risk-rules/internal/apps/ip_intelligence"
 	"github.com/conekta/risk-rules/internal/apps/lists"
 	merchantsscore "github.com/conekta/risk-rules/internal/apps/merchants_score"
 	"github.com/conekta/risk-rules/internal/apps/modules"
 	"github.com/conekta/risk-rules/internal/apps/omniscores"
 	"github.com/conekta/risk-rules/internal/apps/operators"
 	"github.com/conekta/risk-rules/internal/apps/rules"
+	scorethresholds "github.com/conekta/risk-rules/internal/apps/score_thresholds"
 	"github.com/conekta/risk-rules/internal/apps/status"
+	"github.com/conekta/risk-rules/internal/apps/velocity"
 	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/pkg/csv"
+	"github.com/conekta/risk-rules/pkg/httpclient"
 	"github.com/conekta/risk-rules/pkg/mongodb"
 	"github.com/conekta/risk-rules/pkg/rest"
 )
@@ -28,6 +36,8 @@ import (
 type Dependencies struct {
 	StatusHandler          status.StatusHandler
 	RulesHandler           rules.RuleHandler
+	RuleTransferHandler    rules.RuleTransferHandler
+	BacktestHandler        backtests.BacktestHandler
 	ChargeHandler          charges.ChargeHandler
 	ModulesHandler         modules.ModuleHandler
 	OperatorHandler        operators.OperatorHandler
@@ -37,6 +47,12 @@ type Dependencies struct {
 	FamilyCompaniesHandler familycom.FamilyCompaniesHandler
 	ChargebacksHandler     chargebacks.ChargebackHandler
 	MerchantsScoreHandler  merchantsscore.MerchantsScoreHandler
+	ScoreThresholdHandler  scorethresholds.ScoreThresholdHandler
+	ListsHandler           lists.ListsHandler
+	AuditHandler           audit.AuditHandler
+	RulesSnapshot          rules.RuleSnapshotRepository
+	BinTable               bins.BinTableRepository
+	IPIntelligence         ipintelligence.IPIntelligenceRepository
 	Config                 config.Config
 	S3Reader               csv.S3Reader
 	Logs                   logs.Logger
@@ -56,36 +72,68 @@ func Build() Dependencies {
 	mongoDB := mongodb.NewMongoDB(configs)
 	metric := datadog.NewMetric(context.TODO(), logger, configs.Metrics.Host, configs.Metrics.Port)
 
-	rulesValidator := rules.NewRulesValidator(dependencies.Logs)
+	ruleEvaluatorCache := rules.NewRuleEvaluatorCache(configs, dependencies.Logs, metric)
+	rulesValidator := rules.NewRulesValidator(dependencies.Logs, ruleEvaluatorCache)
 
 	rulesMongoDBRepository := rules.NewRuleMongoDBRepository(configs, mongoDB, dependencies.Logs)
+	ruleVersionMongoDBRepository := rules.NewRuleVersionMongoDBRepository(configs, mongoDB, dependencies.Logs)
+	rulesSnapshotRepository := rules.NewRuleSnapshotRepository(configs, mongoDB, rulesMongoDBRepository, dependencies.Logs, metric)
 	modulesMongoDBRepository := modules.NewModulesMongoRepository(configs, mongoDB, dependencies.Logs)
 	operatorMongoDBRepository := operators.NewOperatorMongoDBRepository(configs, mongoDB, dependencies.Logs)
 	fieldsMongoDBRepository := fields.NewFieldsMongoDBRepository(configs, mongoDB, dependencies.Logs)
 	conditionsMongoDBRepository := conditions.NewConditionsRepository(configs, mongoDB, dependencies.Logs)
 	chargesMongoDBRepository := charges.NewChargeMongoDBRepository(configs, mongoDB, dependencies.Logs)
+	backtestMongoDBRepository := backtests.NewBacktestMongoDBRepository(configs, mongoDB, dependencies.Logs)
 	familiesMongoDBRepository := families.NewFamilyMongoDBRepository(configs, mongoDB, dependencies.Logs)
 	familyCompaniesMongoDBRepository := familycom.NewFamilyCompaniesMongoDBRepository(configs, mongoDB, dependencies.Logs)
 	chargebacksMongoDBRepository := chargebacks.NewChargebacksMongoDBRepository(configs, mongoDB, dependencies.Logs)
-	omniscoreRestClient := rest.NewOmniscoreClient(configs, dependencies.Logs)
-	listsClient := rest.NewRkListsRestClient(configs, logger)
+	httpClient := httpclient.NewClient(configs, dependencies.Logs, metric)
+	omniscoreRestClient := rest.NewOmniscoreClient(configs, httpClient, dependencies.Logs)
+	listsClient := rest.NewRkListsRestClient(configs, httpClient, logger)
 	merchantsScoreMongoDBRepository := merchantsscore.NewMerchantsMongoDBRepository(configs, mongoDB, dependencies.Logs)
+	auditMongoDBRepository := audit.NewAuditMongoDBRepository(configs, mongoDB, dependencies.Logs)
+	scoreThresholdMongoDBRepository := scorethresholds.NewScoreThresholdMongoDBRepository(configs, mongoDB, dependencies.Logs)
+	listsMongoDBRepository := lists.NewListsMongoDBRepository(configs, mongoDB, dependencies.Logs)
+	velocityMongoDBRepository := velocity.NewVelocityMongoDBRepository(configs, mongoDB, dependencies.Logs)
 	s3CsvReader := csv.NewS3Reader(configs, dependencies.Logs)
 	merchantRepositoryS3 := merchantsscore.NewMerchantScoreS3Repository(configs, dependencies.Logs, s3CsvReader)
+	binTableRepository := bins.NewBinTableRepository(configs, s3CsvReader, dependencies.Logs, metric)
+	_ = binTableRepository.Load(context.TODO())
+	ipIntelligenceRepository := ipintelligence.NewIPIntelligenceRepository(configs, dependencies.Logs, metric)
+	_ = ipIntelligenceRepository.Load(context.TODO())
 
-	modulesService := modules.NewModuleService(configs, modulesMongoDBRepository, dependencies.Logs, metric)
-	operatorService := operators.NewOperatorService(configs, dependencies.Logs, operatorMongoDBRepository, metric)
-	listsService := lists.NewListsService(configs, logger, metric, listsClient)
-	fieldsService := fields.NewFieldsService(configs, fieldsMongoDBRepository, logger, metric)
-	conditionsService := conditions.NewConditionsService(configs, conditionsMongoDBRepository, logger, metric)
-	familiesService := families.NewFamilyService(configs, familiesMongoDBRepository, rulesMongoDBRepository, logger, metric)
-	rulesService := rules.NewRulesService(configs, rulesValidator, rulesMongoDBRepository, logger, metric)
+	auditService := audit.NewAuditService(configs, auditMongoDBRepository, logger, metric)
+	modulesService := modules.NewModuleService(configs, modulesMongoDBRepository, auditService, dependencies.Logs, metric)
+	operatorService := operators.NewOperatorService(configs, dependencies.Logs, operatorMongoDBRepository, auditService, metric)
+	listsService := lists.NewListsService(configs, logger, metric, listsClient, listsMongoDBRepository)
+	fieldsService := fields.NewFieldsService(configs, fieldsMongoDBRepository, auditService, logger, metric)
+	conditionsService := conditions.NewConditionsService(configs, conditionsMongoDBRepository, auditService, logger, metric)
+	familiesService := families.NewFamilyService(configs, familiesMongoDBRepository, rulesMongoDBRepository,
+		auditService, logger, metric)
+	ruleCatalogService := rules.NewRuleCatalogService(fieldsMongoDBRepository, operatorMongoDBRepository,
+		conditionsMongoDBRepository, logger)
+	rulesService := rules.NewRulesService(configs, rulesValidator, ruleCatalogService, rulesMongoDBRepository,
+		ruleVersionMongoDBRepository, auditService, logger, metric)
+	ruleTransferService := rules.NewRuleTransferService(configs, rulesService, ruleCatalogService, rulesMongoDBRepository,
+		familiesMongoDBRepository, familyCompaniesMongoDBRepository, auditService, mongoDB, logger, metric)
 	familyCompaniesService := familycom.NewFamilyCompaniesService(configs, familyCompaniesMongoDBRepository,
-		rulesMongoDBRepository, logger, metric)
+		rulesMongoDBRepository, auditService, logger, metric)
 	omniscoreService := omniscores.NewOmniscoreService(configs, logger, omniscoreRestClient)
-	chargeService := charges.NewChargeService(configs, rulesValidator, rulesMongoDBRepository,
-		listsService, chargesMongoDBRepository, familiesService, familyCompaniesService, chargebacksMongoDBRepository,
-		omniscoreService, merchantsScoreMongoDBRepository, dependencies.Logs, metric)
+	scoreThresholdService := scorethresholds.NewScoreThresholdService(configs, scoreThresholdMongoDBRepository, logger, metric)
+	velocityService := velocity.NewVelocityService(configs, velocityMongoDBRepository, logger, metric)
+	enrichers := []entities.Enricher{
+		chargebacks.NewChargebacksEnricher(configs, chargebacksMongoDBRepository),
+		omniscores.NewOmniscoreEnricher(configs, omniscoreService),
+		merchantsscore.NewMerchantScoreEnricher(configs, merchantsScoreMongoDBRepository),
+		velocity.NewVelocityEnricher(configs, velocityService),
+		bins.NewBinEnricher(configs, binTableRepository),
+		ipintelligence.NewIPIntelligenceEnricher(configs, ipIntelligenceRepository),
+	}
+	chargeService := charges.NewChargeService(configs, rulesValidator, rulesSnapshotRepository,
+		listsService, chargesMongoDBRepository, familiesService, familyCompaniesService, scoreThresholdService,
+		velocityService, enrichers, dependencies.Logs, metric)
+	backtestService := backtests.NewBacktestService(configs, rulesValidator, rulesService, backtestMongoDBRepository,
+		familiesService, familyCompaniesService, logger, metric)
 	chargebackService := chargebacks.NewChargebacksService(configs, chargebacksMongoDBRepository, logger, metric)
 	merchantsScoreService := merchantsscore.NewMerchantsScoreService(configs, logger, metric,
 		merchantsScoreMongoDBRepository, merchantRepositoryS3)
@@ -93,6 +141,8 @@ func Build() Dependencies {
 	dependencies.StatusHandler = status.NewStatusHandler(configs, metric)
 	dependencies.RulesHandler = rules.NewRulesHandler(configs, rulesService, logger)
 	dependencies.RulesHandler = rules.NewRulesHandler(configs, rulesService, dependencies.Logs)
+	dependencies.RuleTransferHandler = rules.NewRuleTransferHandler(ruleTransferService, logger)
+	dependencies.BacktestHandler = backtests.NewBacktestHandler(backtestService, dependencies.Logs)
 	dependencies.ChargeHandler = charges.NewChargeHandler(configs, chargeService, dependencies.Logs, metric)
 	dependencies.OperatorHandler = operators.NewOperatorHandler(dependencies.Logs, operatorService)
 	dependencies.ModulesHandler = modules.NewModuleHandler(modulesService, dependencies.Logs)
@@ -102,6 +152,12 @@ func Build() Dependencies {
 	dependencies.FamilyCompaniesHandler = familycom.NewFamilyCompaniesHandler(familyCompaniesService, logger)
 	dependencies.ChargebacksHandler = chargebacks.NewChargebackHandler(chargebackService, configs, logger, metric)
 	dependencies.MerchantsScoreHandler = merchantsscore.NewMerchantsScoreHandler(configs, logger, merchantsScoreService)
+	dependencies.ScoreThresholdHandler = scorethresholds.NewScoreThresholdHandler(scoreThresholdService, logger)
+	dependencies.ListsHandler = lists.NewListsHandler(listsService, logger)
+	dependencies.AuditHandler = audit.NewAuditHandler(auditService, logger)
+	dependencies.RulesSnapshot = rulesSnapshotRepository
+	dependencies.BinTable = binTableRepository
+	dependencies.IPIntelligence = ipIntelligenceRepository
 	dependencies.Config = configs
 
 	return dependencies
@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	customString "github.com/conekta/risk-rules/pkg/strings"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditEntityType string
+
+const (
+	AuditRules           AuditEntityType = "rules"
+	AuditFamilies        AuditEntityType = "families"
+	AuditFamilyCompanies AuditEntityType = "family_companies"
+	AuditModules         AuditEntityType = "modules"
+	AuditFields          AuditEntityType = "fields"
+	AuditOperators       AuditEntityType = "operators"
+	AuditConditions      AuditEntityType = "conditions"
+)
+
+var auditEntityTypeValues = map[AuditEntityType]bool{
+	AuditRules:           true,
+	AuditFamilies:        true,
+	AuditFamilyCompanies: true,
+	AuditModules:         true,
+	AuditFields:          true,
+	AuditOperators:       true,
+	AuditConditions:      true,
+}
+
+type AuditAction string
+
+const (
+	AuditCreated AuditAction = "created"
+	AuditUpdated AuditAction = "updated"
+	AuditDeleted AuditAction = "deleted"
+)
+
+// AuditOrigin identifies the request behind a mutation, Actor is used when the mutation does not carry an author.
+type AuditOrigin struct {
+	RequestID string
+	Origin    string
+	Actor     string
+}
+
+type AuditEntry struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Actor      string             `json:"actor" bson:"actor"`
+	EntityType AuditEntityType    `json:"entity_type" bson:"entity_type"`
+	EntityID   string             `json:"entity_id" bson:"entity_id"`
+	Action     AuditAction        `json:"action" bson:"action"`
+	Before     bson.M             `json:"before,omitempty" bson:"before,omitempty"`
+	After      bson.M             `json:"after,omitempty" bson:"after,omitempty"`
+	RequestID  string             `json:"request_id" bson:"request_id"`
+	Origin     string             `json:"origin" bson:"origin"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type AuditFilter struct {
+	EntityType string `json:"entity_type" query:"entity_type"`
+	EntityID   string `json:"entity_id" query:"entity_id"`
+	Actor      string `json:"actor" query:"actor"`
+	Action     string `json:"action" query:"action"`
+	RequestID  string `json:"request_id" query:"request_id"`
+	Origin     string `json:"origin" query:"origin"`
+	From       string `json:"from" query:"from"`
+	Until      string `json:"until" query:"until"`
+}
+
+func NewAuditEntry(entityType AuditEntityType, entityID string, action AuditAction, actor string,
+	before bson.M) AuditEntry {
+	return AuditEntry{
+		Actor:      actor,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Before:     before,
+	}
+}
+
+func (filter *AuditFilter) Validate() error {
+	if !customString.IsEmpty(filter.EntityType) && !auditEntityTypeValues[AuditEntityType(filter.EntityType)] {
+		return fmt.Errorf("entity_type [%s] is not a valid value", filter.EntityType)
+	}
+
+	from, until, err := filter.GetDateRange()
+	if err != nil {
+		return err
+	}
+
+	if from != nil && until != nil && !until.After(*from) {
+		return errors.New("until must be after from")
+	}
+
+	return nil
+}
+
+func (filter *AuditFilter) GetDateRange() (*time.Time, *time.Time, error) {
+	from, err := parseAuditDate("from", filter.From)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	until, err := parseAuditDate("until", filter.Until)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return from, until, nil
+}
+
+func parseAuditDate(name, value string) (*time.Time, error) {
+	if customString.IsEmpty(value) {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s [%s] must have the RFC3339 format", name, value)
+	}
+
+	return &date, nil
+}
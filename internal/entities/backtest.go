@@ -0,0 +1,146 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	customString "github.com/conekta/risk-rules/pkg/strings"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	EvaluationsSource          = "charge_evaluations"
+	EvaluationsOnlyRulesSource = "charge_evaluations_only_rules"
+)
+
+type BacktestRequest struct {
+	Rule            RuleRequest `json:"rule" validate:"required"`
+	From            time.Time   `json:"from" validate:"required"`
+	To              time.Time   `json:"to" validate:"required"`
+	CompanyID       string      `json:"company_id"`
+	FamilyID        string      `json:"family_id"`
+	FamilyCompanyID string      `json:"family_company_id"`
+	SampleSize      int         `json:"sample_size"`
+}
+
+func (bReq *BacktestRequest) Validate() error {
+	if !bReq.From.Before(bReq.To) {
+		return errors.New("from must be before to")
+	}
+
+	if bReq.SampleSize < 0 {
+		return fmt.Errorf("sample_size [%d] must not be negative", bReq.SampleSize)
+	}
+
+	scopes := 0
+	for _, scope := range []string{bReq.CompanyID, bReq.FamilyID, bReq.FamilyCompanyID} {
+		if !customString.IsEmpty(scope) {
+			scopes++
+		}
+	}
+	if scopes > 1 {
+		return errors.New("only one option: [family_id - company_id - family_company_id] could be set at same time")
+	}
+
+	return bReq.Rule.Validate()
+}
+
+func (bReq *BacktestRequest) GetScope() (companyID, familyID, familyCompanyID string) {
+	if !customString.IsEmpty(bReq.CompanyID) || !customString.IsEmpty(bReq.FamilyID) ||
+		!customString.IsEmpty(bReq.FamilyCompanyID) {
+		return bReq.CompanyID, bReq.FamilyID, bReq.FamilyCompanyID
+	}
+
+	return bReq.Rule.CompanyID, bReq.Rule.FamilyID, bReq.Rule.FamilyCompanyID
+}
+
+type BacktestFilter struct {
+	From               time.Time
+	To                 time.Time
+	CompanyIDs         []string
+	ExcludedCompanyIDs []string
+	CompanyMccs        []string
+	Limit              int64
+}
+
+func (filter BacktestFilter) GetFromObjectID() primitive.ObjectID {
+	return primitive.NewObjectIDFromTimestamp(filter.From)
+}
+
+func (filter BacktestFilter) GetToObjectID() primitive.ObjectID {
+	return primitive.NewObjectIDFromTimestamp(filter.To)
+}
+
+type StoredEvaluation struct {
+	EvaluationID primitive.ObjectID `json:"-" bson:"evaluation_id"`
+	Source       string             `json:"source" bson:"-"`
+	Decision     string             `json:"decision" bson:"decision"`
+	Charge       ChargeRequest      `json:"charge" bson:"charge"`
+}
+
+// LatestEvaluationsByCharge keeps the latest evaluation of every charge among the sources, in the order they were
+// evaluated and up to the limit, so a charge evaluated by both endpoints or retried is replayed once.
+func LatestEvaluationsByCharge(evaluations []StoredEvaluation, limit int64) []StoredEvaluation {
+	latest := make([]StoredEvaluation, 0, len(evaluations))
+	positions := make(map[string]int, len(evaluations))
+	for _, evaluation := range evaluations {
+		position, ok := positions[evaluation.Charge.ID]
+		if !ok || customString.IsEmpty(evaluation.Charge.ID) {
+			positions[evaluation.Charge.ID] = len(latest)
+			latest = append(latest, evaluation)
+			continue
+		}
+		if evaluation.EvaluationID.Hex() > latest[position].EvaluationID.Hex() {
+			latest[position] = evaluation
+		}
+	}
+
+	sort.SliceStable(latest, func(i, j int) bool {
+		return latest[i].EvaluationID.Hex() < latest[j].EvaluationID.Hex()
+	})
+	if limit > 0 && int64(len(latest)) > limit {
+		latest = latest[:limit]
+	}
+
+	return latest
+}
+
+type BacktestResponse struct {
+	Evaluated       int64            `json:"evaluated"`
+	Hits            int64            `json:"hits"`
+	HitRate         float64          `json:"hit_rate"`
+	Errors          int64            `json:"errors"`
+	DecisionChanges map[string]int64 `json:"decision_changes"`
+	SampleChargeIDs []string         `json:"sample_charge_ids"`
+	Sources         map[string]int64 `json:"sources"`
+	Truncated       bool             `json:"truncated"`
+}
+
+func NewBacktestResponse() BacktestResponse {
+	return BacktestResponse{
+		DecisionChanges: make(map[string]int64),
+		SampleChargeIDs: make([]string, 0),
+		Sources:         make(map[string]int64),
+	}
+}
+
+func (bResp *BacktestResponse) AddHit(evaluation StoredEvaluation, decision Decision, sampleSize int) {
+	bResp.Hits++
+	if len(bResp.SampleChargeIDs) < sampleSize {
+		bResp.SampleChargeIDs = append(bResp.SampleChargeIDs, evaluation.Charge.ID)
+	}
+
+	storedDecision := Decision(evaluation.Decision).ValidateDecision()
+	if decision == Undecided || storedDecision == decision {
+		return
+	}
+	bResp.DecisionChanges[fmt.Sprintf("%s->%s", storedDecision, decision)]++
+}
+
+func (bResp *BacktestResponse) SetHitRate() {
+	if bResp.Evaluated > 0 {
+		bResp.HitRate = float64(bResp.Hits) / float64(bResp.Evaluated)
+	}
+}
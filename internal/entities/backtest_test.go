@@ -0,0 +1,56 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLatestEvaluationsByCharge(t *testing.T) {
+	evaluatedAt := time.Date(2022, 5, 10, 0, 0, 0, 0, time.UTC)
+	newEvaluation := func(chargeID, source, decision string, minute int) entities.StoredEvaluation {
+		return entities.StoredEvaluation{
+			EvaluationID: primitive.NewObjectIDFromTimestamp(evaluatedAt.Add(time.Duration(minute) * time.Minute)),
+			Source:       source,
+			Decision:     decision,
+			Charge:       entities.ChargeRequest{ID: chargeID},
+		}
+	}
+
+	t.Run("when a charge was evaluated by both sources, then keep its latest evaluation", func(t *testing.T) {
+		evaluations := []entities.StoredEvaluation{
+			newEvaluation("1", entities.EvaluationsSource, "A", 1),
+			newEvaluation("2", entities.EvaluationsSource, "D", 3),
+			newEvaluation("1", entities.EvaluationsOnlyRulesSource, "D", 2),
+			newEvaluation("3", entities.EvaluationsOnlyRulesSource, "UN", 0),
+		}
+
+		latest := entities.LatestEvaluationsByCharge(evaluations, 0)
+
+		assert.Equal(t, []entities.StoredEvaluation{evaluations[3], evaluations[2], evaluations[1]}, latest)
+	})
+
+	t.Run("when the limit is reached, then keep the first charges evaluated", func(t *testing.T) {
+		evaluations := []entities.StoredEvaluation{
+			newEvaluation("1", entities.EvaluationsSource, "A", 2),
+			newEvaluation("2", entities.EvaluationsOnlyRulesSource, "D", 1),
+			newEvaluation("3", entities.EvaluationsOnlyRulesSource, "UN", 3),
+		}
+
+		latest := entities.LatestEvaluationsByCharge(evaluations, 2)
+
+		assert.Equal(t, []entities.StoredEvaluation{evaluations[1], evaluations[0]}, latest)
+	})
+
+	t.Run("when the charges have no id, then keep all of them", func(t *testing.T) {
+		evaluations := []entities.StoredEvaluation{
+			newEvaluation("", entities.EvaluationsSource, "A", 1),
+			newEvaluation("", entities.EvaluationsOnlyRulesSource, "D", 2),
+		}
+
+		assert.Len(t, entities.LatestEvaluationsByCharge(evaluations, 0), 2)
+	})
+}
@@ -0,0 +1,28 @@
+package entities
+
+type BatchEvaluationItem struct {
+	Index      int                 `json:"index"`
+	Evaluation *EvaluationResponse `json:"evaluation,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+type BatchEvaluationResponse struct {
+	Total  int                   `json:"total"`
+	Failed int                   `json:"failed"`
+	Data   []BatchEvaluationItem `json:"data"`
+}
+
+func NewBatchEvaluationResponse(items []BatchEvaluationItem) BatchEvaluationResponse {
+	response := BatchEvaluationResponse{
+		Total: len(items),
+		Data:  items,
+	}
+
+	for _, item := range items {
+		if item.Error != "" {
+			response.Failed++
+		}
+	}
+
+	return response
+}
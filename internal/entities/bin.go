@@ -0,0 +1,40 @@
+package entities
+
+import "strings"
+
+const (
+	BinProductPrepaid   = "prepaid"
+	BinProductCorporate = "corporate"
+)
+
+// BinInfo is a row of the BIN table, Bin is the prefix of the card number it describes.
+type BinInfo struct {
+	Bin          string `json:"bin" csv:"bin" bson:"bin"`
+	Brand        string `json:"brand" csv:"brand" bson:"brand"`
+	Issuer       string `json:"issuer" csv:"issuer" bson:"issuer"`
+	BankName     string `json:"bank_name" csv:"bank_name" bson:"bank_name"`
+	Country      string `json:"country" csv:"country" bson:"country"`
+	CardType     string `json:"card_type" csv:"card_type" bson:"card_type"`
+	ProductLevel string `json:"product_level" csv:"product_level" bson:"product_level"`
+}
+
+// Mismatches compares the payment method sent by the caller with the BIN data, a field is only compared when
+// both sides have it.
+func (info BinInfo) Mismatches(paymentMethod PaymentMethodRequest) map[string]bool {
+	return map[string]bool{
+		"brand":     isBinMismatch(paymentMethod.Brand, info.Brand),
+		"issuer":    isBinMismatch(paymentMethod.Issuer, info.Issuer),
+		"country":   isBinMismatch(paymentMethod.Country, info.Country),
+		"card_type": isBinMismatch(paymentMethod.CardType, info.CardType),
+	}
+}
+
+func isBinMismatch(value, binValue string) bool {
+	value = strings.TrimSpace(value)
+	binValue = strings.TrimSpace(binValue)
+	if value == "" || binValue == "" {
+		return false
+	}
+
+	return !strings.EqualFold(value, binValue)
+}
@@ -17,6 +17,7 @@ type ChargeRequest struct {
 	Details             DetailsRequest          `json:"details" mapstructure:"details" validate:"required"`
 	PaymentMethod       PaymentMethodRequest    `json:"payment_method" mapstructure:"payment_method" bson:"payment_method" validate:"required"`
 	Aggregation         Aggregation             `json:"aggregation" mapstructure:"aggregation"`
+	Velocity            Velocity                `json:"velocity" mapstructure:"velocity" bson:"velocity"`
 	Payer               PayerRequest            `json:"payer" mapstructure:"payer" bson:"payer"`
 	IsGraylist          bool                    `json:"is_graylist" mapstructure:"is_graylist" bson:"is_graylist"`
 	Omniscore           float64                 `json:"omniscore" mapstructure:"omniscore" bson:"omniscore"`
@@ -25,6 +26,10 @@ type ChargeRequest struct {
 	EmailProximity      EmailEvaluationResponse `json:"email_proximity" mapstructure:"email_proximity" bson:"email_proximity,omitempty"`
 	MarketSegment       string                  `json:"market_segment" mapstructure:"market_segment" bson:"market_segment"`
 	IsYellowFlag        bool                    `json:"is_yellow_flag" mapstructure:"is_yellow_flag" bson:"is_yellow_flag"`
+	Enrichments         map[string]interface{}  `json:"enrichments,omitempty" mapstructure:"-" bson:"enrichments,omitempty"`
+	Explain             bool                    `json:"-" mapstructure:"-" bson:"-"`
+	Idempotent          bool                    `json:"-" mapstructure:"-" bson:"-"`
+	ForceReevaluate     bool                    `json:"-" mapstructure:"-" bson:"-"`
 }
 
 type Component struct {
@@ -128,15 +133,23 @@ func (c *ChargeRequest) ToMap() (map[string]interface{}, error) {
 		panic(err)
 	}
 
+	for path, value := range c.Enrichments {
+		setMapPath(mapCharge, path, value)
+	}
+
 	return mapCharge, nil
 }
 
 func (c *ChargeRequest) NewListsSearch() ListsSearch {
 	return ListsSearch{
-		CompanyID: c.CompanyID,
-		Email:     c.Details.Email,
-		CardHash:  c.PaymentMethod.CardHash,
-		Phone:     c.Details.Phone,
+		CompanyID:         c.CompanyID,
+		Email:             c.Details.Email,
+		CardHash:          c.PaymentMethod.CardHash,
+		Phone:             c.Details.Phone,
+		IPAddress:         c.Details.IPAddress,
+		DeviceFingerprint: c.DeviceFingerprint,
+		BinNumber:         c.PaymentMethod.BinNumber,
+		Name:              c.Details.Name,
 	}
 }
 
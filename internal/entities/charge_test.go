@@ -45,3 +45,87 @@ func TestChargeRequest_NotHaveSecondaryDecision(t *testing.T) {
 		assert.False(t, component.HaveSecondaryDecision())
 	})
 }
+
+func TestChargeRequest_VelocityKeys(t *testing.T) {
+	t.Run("keys skip empty values and normalize the email", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		charge.Details.Email = " Mail@Hotmail.com "
+		charge.Details.Phone = ""
+
+		keys := charge.VelocityKeys()
+
+		assert.Equal(t, []entities.VelocityKey{
+			{Dimension: entities.VelocityEmail, Value: "mail@hotmail.com"},
+			{Dimension: entities.VelocityCardHash, Value: charge.PaymentMethod.CardHash},
+			{Dimension: entities.VelocityBin, Value: charge.PaymentMethod.BinNumber},
+			{Dimension: entities.VelocityDeviceFingerprint, Value: charge.DeviceFingerprint},
+			{Dimension: entities.VelocityIP, Value: charge.Details.IPAddress},
+		}, keys)
+		assert.NotEqual(t, keys[0].Hash(), entities.VelocityKey{Dimension: entities.VelocityPhone,
+			Value: "mail@hotmail.com"}.Hash())
+	})
+}
+
+func TestVelocity_OverrideWith(t *testing.T) {
+	t.Run("caller windows replace the counters and empty windows keep them", func(t *testing.T) {
+		velocity := entities.Velocity{}
+		velocity.Email.H1 = entities.AggregationEventProperties{Sum: 10, Count: 1}
+		velocity.Email.D1 = entities.AggregationEventProperties{Sum: 30, Count: 3}
+		velocity.IP.H1 = entities.AggregationEventProperties{Sum: 5, Count: 1}
+
+		velocity.OverrideWith(testdata.GetDefaultAggregation())
+
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 100, Count: 200}, velocity.Email.H1)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 30, Count: 3}, velocity.Email.D1)
+		assert.Equal(t, entities.AggregationEventProperties{Sum: 5, Count: 1}, velocity.IP.H1)
+	})
+
+	t.Run("velocity is exposed to the rules in the charge map", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		charge.Velocity.CardHash.H1.Count = 3
+
+		mapCharge, err := charge.ToMap()
+
+		assert.NoError(t, err)
+		velocity := mapCharge["velocity"].(map[string]interface{})
+		cardHash := velocity["card_hash"].(map[string]interface{})
+		h1 := cardHash["h1"].(map[string]interface{})
+		assert.Equal(t, 3, h1["count"])
+	})
+}
+
+func TestChargeRequest_SetEnrichments(t *testing.T) {
+	t.Run("charge fields are set in the struct and new fields are added to the map", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+
+		err := charge.SetEnrichments(map[string]interface{}{
+			"payer.chargebacks": int64(2),
+			"omniscore":         0.7,
+			"bin.country":       "MX",
+		})
+		mapCharge, _ := charge.ToMap()
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), charge.Payer.Chargebacks)
+		assert.Equal(t, 0.7, charge.Omniscore)
+		assert.Equal(t, map[string]interface{}{"bin.country": "MX"}, charge.Enrichments)
+		assert.Equal(t, "MX", mapCharge["bin"].(map[string]interface{})["country"])
+		assert.Equal(t, testdata.GetDefaultCharge().PaymentMethod, charge.PaymentMethod)
+	})
+
+	t.Run("nested payment method fields keep the ones that are not enriched", func(t *testing.T) {
+		charge := testdata.GetDefaultCharge()
+		charge.PaymentMethod.Issuer = ""
+
+		err := charge.SetEnrichments(map[string]interface{}{
+			"payment_method.issuer": "BBVA",
+			"bin":                   map[string]interface{}{"found": true, "mismatch": false},
+		})
+		mapCharge, _ := charge.ToMap()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "BBVA", charge.PaymentMethod.Issuer)
+		assert.Equal(t, testdata.GetDefaultCharge().PaymentMethod.CardHash, charge.PaymentMethod.CardHash)
+		assert.Equal(t, true, mapCharge["bin"].(map[string]interface{})["found"])
+	})
+}
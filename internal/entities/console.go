@@ -17,6 +17,8 @@ const (
 	YellowFlagType         ConsoleComponent = "YellowFlag"
 )
 
+const IdentityModuleField = "email_proximity"
+
 var ComponentsWithOutSecondaryDecision = []ConsoleComponent{
 	IdentityModuleType,
 	YellowFlagType,
@@ -0,0 +1,45 @@
+package entities
+
+import "fmt"
+
+const (
+	DegradationContinue DegradationPolicy = "continue"
+	DegradationFail     DegradationPolicy = "fail"
+)
+
+// DegradationPolicy tells what the evaluation does when a console component can not be evaluated: continue as if
+// nothing matched, force a decision like UN or D, or fail the request.
+type DegradationPolicy string
+
+// Degradation records a console component that could not be evaluated and the policy applied.
+type Degradation struct {
+	Component ConsoleComponent  `json:"component" bson:"component"`
+	Policy    DegradationPolicy `json:"policy" bson:"policy"`
+	Decision  Decision          `json:"decision,omitempty" bson:"decision,omitempty"`
+	Error     string            `json:"error" bson:"error"`
+}
+
+func (policy DegradationPolicy) IsValid() bool {
+	_, isDecision := policy.ForcedDecision()
+	return policy == DegradationContinue || policy == DegradationFail || isDecision
+}
+
+// ForcedDecision returns the decision the policy forces, if it forces one.
+func (policy DegradationPolicy) ForcedDecision() (Decision, bool) {
+	decision := Decision(policy)
+	return decision, hasValidRuleDecision(decision)
+}
+
+func NewDegradation(component ConsoleComponent, policy DegradationPolicy, cause error) Degradation {
+	degradation := Degradation{Component: component, Policy: policy, Error: cause.Error()}
+	if decision, ok := policy.ForcedDecision(); ok {
+		degradation.Decision = decision
+	}
+
+	return degradation
+}
+
+func (degradation Degradation) Reason() string {
+	return fmt.Sprintf("component %s degraded with policy %s: %s", degradation.Component, degradation.Policy,
+		degradation.Error)
+}
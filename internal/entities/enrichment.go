@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	EnrichmentRan     = "ran"
+	EnrichmentSkipped = "skipped"
+	EnrichmentFailed  = "failed"
+
+	EnrichmentUseDefaults = "use_defaults"
+	EnrichmentOmitFields  = "omit_fields"
+)
+
+// Enricher adds a signal to the charge before the rules are evaluated, the values are keyed by the charge map
+// path they provide, like payer.chargebacks or omniscore.
+type Enricher interface {
+	Name() string
+	Fields() []string
+	TimeoutMilliseconds() int
+	FailurePolicy() EnrichmentFailurePolicy
+	IsEnabled() bool
+	Enrich(ctx context.Context, charge ChargeRequest) (map[string]interface{}, error)
+}
+
+// EnrichmentFailurePolicy tells what the charge gets when the enricher fails, times out or is disabled.
+type EnrichmentFailurePolicy struct {
+	OnFailure string
+	Defaults  map[string]interface{}
+}
+
+type EnrichmentResult struct {
+	Name   string `json:"name" bson:"name"`
+	Status string `json:"status" bson:"status"`
+	Error  string `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+func (policy EnrichmentFailurePolicy) Fallback() map[string]interface{} {
+	if policy.OnFailure == EnrichmentUseDefaults {
+		return policy.Defaults
+	}
+
+	return nil
+}
+
+// SetEnrichments writes the enriched values in the charge, the paths of charge fields like omniscore are decoded in
+// the struct and the new ones are kept in Enrichments so ToMap exposes them to the rules.
+func (c *ChargeRequest) SetEnrichments(values map[string]interface{}) error {
+	mapCharge, err := c.ToMap()
+	if err != nil {
+		return err
+	}
+
+	extra := make(map[string]interface{})
+	fields := make(map[string]interface{})
+	for path, value := range values {
+		if !hasMapPath(mapCharge, path) {
+			extra[path] = value
+			continue
+		}
+		setMapPath(mapCharge, path, value)
+		field := strings.Split(path, ".")[0]
+		fields[field] = mapCharge[field]
+	}
+
+	if err = mapstructure.Decode(fields, c); err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		c.Enrichments = extra
+	}
+
+	return nil
+}
+
+func hasMapPath(values map[string]interface{}, path string) bool {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := values[key].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		values = nested
+	}
+
+	_, ok := values[keys[len(keys)-1]]
+	return ok
+}
+
+func setMapPath(values map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := values[key].(map[string]interface{})
+		if !ok {
+			nested = make(map[string]interface{})
+			values[key] = nested
+		}
+		values = nested
+	}
+
+	values[keys[len(keys)-1]] = value
+}
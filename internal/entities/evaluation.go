@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"time"
+
 	customString "github.com/conekta/go_common/strings"
 )
 
@@ -39,9 +41,16 @@ func (decision Decision) ValidateDecision() Decision {
 }
 
 type EvaluationResponse struct {
-	Decision string          `json:"decision"`
-	Modules  ModulesResponse `json:"modules"`
-	Charge   ChargeRequest   `json:"charge"`
+	Decision            string             `json:"decision"`
+	Modules             ModulesResponse    `json:"modules"`
+	Charge              ChargeRequest      `json:"charge"`
+	TimedOutEnrichments []string           `json:"timed_out_enrichments,omitempty" bson:"timed_out_enrichments,omitempty"`
+	Enrichments         []EnrichmentResult `json:"enrichments,omitempty" bson:"enrichments,omitempty"`
+	RiskScore           *RiskScore         `json:"risk_score,omitempty" bson:"risk_score,omitempty"`
+	Degradations        []Degradation      `json:"degradations,omitempty" bson:"degradations,omitempty"`
+	EvaluatedAt         *time.Time         `json:"evaluated_at,omitempty" bson:"evaluated_at,omitempty"`
+	Replayed            bool               `json:"replayed,omitempty" bson:"-"`
+	Trace               *EvaluationTrace   `json:"trace,omitempty" bson:"-"`
 }
 
 func NewUndecidedEvaluationResponse(charge ChargeRequest, evaluationOrder []string) EvaluationResponse {
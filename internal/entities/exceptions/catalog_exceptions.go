@@ -7,4 +7,10 @@ const (
 
 	FamilyCompaniesNameDuplicated      = "004"
 	FamilyCompaniesAssociatedWithARule = "005"
+
+	RuleFieldNotFound         = "006"
+	RuleOperatorNotFound      = "007"
+	RuleOperatorNotCompatible = "008"
+	RuleValueNotValid         = "009"
+	RuleConditionNotFound     = "010"
 )
@@ -0,0 +1,53 @@
+package exceptions
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ClauseError struct {
+	Clause   int    `json:"clause"`
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+func (clauseError ClauseError) Error() string {
+	return fmt.Sprintf("clause %d: %s", clauseError.Clause, clauseError.Message)
+}
+
+type InvalidRuleException interface {
+	Error() string
+	IsInvalidRuleException() bool
+	Clauses() []ClauseError
+}
+
+type invalidRuleException struct {
+	ErrMessage string
+	ErrClauses []ClauseError
+}
+
+func (exception *invalidRuleException) Error() string {
+	return exception.ErrMessage
+}
+
+func (exception *invalidRuleException) IsInvalidRuleException() bool {
+	return true
+}
+
+func (exception *invalidRuleException) Clauses() []ClauseError {
+	return exception.ErrClauses
+}
+
+func NewInvalidRuleException(clauses []ClauseError) InvalidRuleException {
+	messages := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		messages = append(messages, clause.Error())
+	}
+
+	return &invalidRuleException{
+		ErrMessage: fmt.Sprintf("the rule does not match the catalog: %s", strings.Join(messages, "; ")),
+		ErrClauses: clauses,
+	}
+}
@@ -0,0 +1,28 @@
+package exceptions
+
+type ServiceUnavailableException interface {
+	Error() string
+	IsServiceUnavailableError() bool
+	Causes() Causes
+}
+
+type serviceUnavailableException struct {
+	ErrMessage string
+	ErrCause   Causes
+}
+
+func (exception *serviceUnavailableException) Error() string {
+	return exception.ErrMessage
+}
+
+func (exception *serviceUnavailableException) IsServiceUnavailableError() bool {
+	return true
+}
+
+func (exception *serviceUnavailableException) Causes() Causes {
+	return exception.ErrCause
+}
+
+func NewServiceUnavailableExceptionWithCause(message string, causes Causes) ServiceUnavailableException {
+	return &serviceUnavailableException{ErrMessage: message, ErrCause: causes}
+}
@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"fmt"
+)
+
+type EvaluationTrace struct {
+	Components   []*ComponentTrace  `json:"components"`
+	ShortCircuit *ShortCircuitTrace `json:"short_circuit,omitempty"`
+}
+
+type ComponentTrace struct {
+	Component                 ConsoleComponent `json:"component"`
+	Priority                  []Decision       `json:"priority"`
+	Rules                     []RuleTrace      `json:"rules,omitempty"`
+	ListsMatched              int              `json:"lists_matched"`
+	Decision                  Decision         `json:"decision"`
+	TestDecision              Decision         `json:"test_decision"`
+	DecisionTaken             bool             `json:"decision_taken"`
+	SecondaryDecisionAssigned bool             `json:"secondary_decision_assigned"`
+	Reasons                   []string         `json:"reasons,omitempty"`
+}
+
+type RuleTrace struct {
+	RuleID   string        `json:"rule_id"`
+	Revision int64         `json:"revision"`
+	Rule     string        `json:"rule"`
+	IsTest   bool          `json:"is_test"`
+	Decision Decision      `json:"decision"`
+	Result   bool          `json:"result"`
+	Error    string        `json:"error,omitempty"`
+	Clauses  []ClauseTrace `json:"clauses"`
+}
+
+type ClauseTrace struct {
+	RuleContent
+	Expression   string        `json:"expression"`
+	Result       bool          `json:"result"`
+	FormulaValue *float64      `json:"formula_value,omitempty"`
+	FormulaSteps []FormulaStep `json:"formula_steps,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
+
+type ShortCircuitTrace struct {
+	Component ConsoleComponent `json:"component"`
+	Decision  Decision         `json:"decision"`
+	Reason    string           `json:"reason"`
+}
+
+func NewEvaluationTrace(explain bool) *EvaluationTrace {
+	if !explain {
+		return nil
+	}
+
+	return &EvaluationTrace{Components: make([]*ComponentTrace, 0)}
+}
+
+func (trace *EvaluationTrace) AddComponent(component Component) *ComponentTrace {
+	if trace == nil {
+		return nil
+	}
+
+	componentTrace := &ComponentTrace{
+		Component: component.Name,
+		Priority:  component.Priority,
+		Rules:     make([]RuleTrace, 0),
+	}
+	trace.Components = append(trace.Components, componentTrace)
+
+	return componentTrace
+}
+
+func (trace *EvaluationTrace) SetShortCircuit(component Component, decision Decision, reason string) {
+	if trace == nil {
+		return
+	}
+
+	trace.ShortCircuit = &ShortCircuitTrace{
+		Component: component.Name,
+		Decision:  decision,
+		Reason:    reason,
+	}
+}
+
+func (componentTrace *ComponentTrace) AddRule(ruleTrace RuleTrace) {
+	if componentTrace == nil {
+		return
+	}
+
+	componentTrace.Rules = append(componentTrace.Rules, ruleTrace)
+}
+
+func (componentTrace *ComponentTrace) AddReason(reason string, args ...interface{}) {
+	if componentTrace == nil {
+		return
+	}
+
+	componentTrace.Reasons = append(componentTrace.Reasons, fmt.Sprintf(reason, args...))
+}
+
+func (componentTrace *ComponentTrace) SetSecondaryDecision(decision Decision) {
+	if componentTrace == nil {
+		return
+	}
+
+	componentTrace.SecondaryDecisionAssigned = true
+	componentTrace.AddReason("shouldAssignSecondaryDecision: secondary priority %s assigned", decision)
+}
+
+func (componentTrace *ComponentTrace) SetListResult(listResult ListResponse) {
+	if componentTrace == nil {
+		return
+	}
+
+	componentTrace.ListsMatched = len(listResult.DecisionRules) + len(listResult.TestRules)
+}
+
+func (componentTrace *ComponentTrace) SetDecision(decision, testDecision Decision, decisionTaken bool) {
+	if componentTrace == nil {
+		return
+	}
+
+	componentTrace.Decision = decision
+	componentTrace.TestDecision = testDecision
+	componentTrace.DecisionTaken = decisionTaken
+}
@@ -32,10 +32,18 @@ var mathOperationValues = map[string]bool{
 type FormulaContent struct {
 	Fields        *[]string `json:"fields" bson:"fields"  mapstructure:"fields" `
 	MathOperation *string   `json:"math_operation" bson:"math_operation"  mapstructure:"math_operation" `
+	Expression    *string   `json:"expression,omitempty" bson:"expression,omitempty"  mapstructure:"expression" `
 }
 
 func ValidateFormulas(rules []RuleContent) error {
 	for _, rule := range rules {
+		if isFormulaExpressionRule(rule) {
+			if err := validateFormulaExpression(rule); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if hasValidFormulaContent(rule) {
 			return fmt.Errorf(
 				"formula should have both fields and math operation. Fields: %q, MathOperation: %q",
@@ -83,10 +91,46 @@ func ValidateFormulas(rules []RuleContent) error {
 	return nil
 }
 
+func validateFormulaExpression(rule RuleContent) error {
+	if rule.Fields != nil || rule.MathOperation != nil {
+		return errors.New("formula should have either an expression or fields and math operation")
+	}
+
+	expression, err := ParseFormulaExpression(*rule.Expression)
+	if err != nil {
+		return fmt.Errorf("formula expression %q is not valid: %v", *rule.Expression, err)
+	}
+
+	if len(expression.Fields()) == 0 {
+		return fmt.Errorf("formula expression %q should use at least one field", *rule.Expression)
+	}
+
+	return nil
+}
+
 func isFormulaRule(rule RuleContent) bool {
 	return rule.Fields != nil && rule.MathOperation != nil
 }
 
+func isFormulaExpressionRule(rule RuleContent) bool {
+	return !rulesString.IsStringPointerEmpty(rule.Expression)
+}
+
+// hasFormula tells if the field of the clause is generated from a formula.
+func hasFormula(rule RuleContent) bool {
+	return isFormulaRule(rule) || isFormulaExpressionRule(rule)
+}
+
+// FormulaExpression parses the expression of the clause, the second value is false for the clauses without one.
+func (rc *RuleContent) FormulaExpression() (FormulaExpression, bool) {
+	if !isFormulaExpressionRule(*rc) {
+		return FormulaExpression{}, false
+	}
+
+	expression, err := ParseFormulaExpression(*rc.Expression)
+	return expression, err == nil
+}
+
 func hasValidFormulaContent(rule RuleContent) bool {
 	return (rule.Fields != nil && rulesString.IsStringPointerEmpty(rule.MathOperation)) ||
 		(rule.Fields == nil && !rulesString.IsStringPointerEmpty(rule.MathOperation))
@@ -99,6 +143,11 @@ func hasValidMathOperation(operation string) bool {
 
 func GenerateRuleFieldWithFormulaFields(rules *[]RuleContent) {
 	for i, rule := range *(rules) {
+		if expression, ok := rule.FormulaExpression(); ok {
+			(*rules)[i].Field = expression.Field()
+			continue
+		}
+
 		if !isFormulaRule(rule) {
 			continue
 		}
@@ -0,0 +1,448 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	MIN   = "MIN"
+	MAX   = "MAX"
+	ABS   = "ABS"
+	MOD   = "MOD"
+	RATIO = "RATIO"
+)
+
+// FormulaField is the path of the charge info where the values of the formula expressions are set before
+// the evaluation, the engine only compares the computed value.
+const FormulaField = "formula"
+
+type formulaFunction struct {
+	minArgs int
+	maxArgs int
+}
+
+// formulaFunctions lists the arity of each function, a zero max means any number of arguments.
+var formulaFunctions = map[string]formulaFunction{
+	SUM:      {minArgs: 2},
+	MLP:      {minArgs: 2},
+	SUBTRACT: {minArgs: 2, maxArgs: 2},
+	DIV:      {minArgs: 2, maxArgs: 2},
+	MIN:      {minArgs: 2},
+	MAX:      {minArgs: 2},
+	ABS:      {minArgs: 1, maxArgs: 1},
+	MOD:      {minArgs: 2, maxArgs: 2},
+	RATIO:    {minArgs: 2, maxArgs: 2},
+}
+
+type FormulaStep struct {
+	Expression string  `json:"expression"`
+	Value      float64 `json:"value"`
+}
+
+// FormulaExpression is a parsed arithmetic expression over charge fields, like
+// `aggregation.card_hash.charge.h1.sum / amount` or `MAX(amount, 100) - ABS(shipping.amount)`.
+// Division and modulo by zero produce 0 like DIV, and RATIO(a, b) is the share a / (a + b), 0 when both are 0.
+type FormulaExpression struct {
+	root formulaNode
+}
+
+type formulaNode interface {
+	calculate(lookup func(string) float64, steps *[]FormulaStep) float64
+	fields(fields []string) []string
+	String() string
+}
+
+func ParseFormulaExpression(expression string) (FormulaExpression, error) {
+	parser := &formulaParser{tokens: tokenizeFormula(expression)}
+	root, err := parser.parseExpression()
+	if err != nil {
+		return FormulaExpression{}, err
+	}
+
+	if parser.position < len(parser.tokens) {
+		return FormulaExpression{}, fmt.Errorf("unexpected %q", parser.tokens[parser.position])
+	}
+
+	return FormulaExpression{root: root}, nil
+}
+
+func (expression FormulaExpression) String() string {
+	return expression.root.String()
+}
+
+// Fields returns the charge fields the expression reads, in order and without duplicates.
+func (expression FormulaExpression) Fields() []string {
+	fields := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, field := range expression.root.fields(nil) {
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// Key names the computed value of the expression, equal expressions written with different spacing share it.
+func (expression FormulaExpression) Key() string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(expression.String()))
+	return fmt.Sprintf("f%08x", hash.Sum32())
+}
+
+// Field is the engine field of the clause that compares the expression.
+func (expression FormulaExpression) Field() string {
+	return fmt.Sprintf("%s.%s", FormulaField, expression.Key())
+}
+
+// Calculate returns the value of the expression and every intermediate value in evaluation order.
+func (expression FormulaExpression) Calculate(lookup func(field string) float64) (float64, []FormulaStep) {
+	steps := make([]FormulaStep, 0)
+	value := expression.root.calculate(lookup, &steps)
+	return value, steps
+}
+
+type formulaNumber float64
+
+func (node formulaNumber) calculate(func(string) float64, *[]FormulaStep) float64 {
+	return float64(node)
+}
+
+func (node formulaNumber) fields(fields []string) []string {
+	return fields
+}
+
+func (node formulaNumber) String() string {
+	return strconv.FormatFloat(float64(node), 'f', -1, 64)
+}
+
+type formulaFieldNode string
+
+func (node formulaFieldNode) calculate(lookup func(string) float64, steps *[]FormulaStep) float64 {
+	value := lookup(string(node))
+	*steps = append(*steps, FormulaStep{Expression: node.String(), Value: value})
+	return value
+}
+
+func (node formulaFieldNode) fields(fields []string) []string {
+	return append(fields, string(node))
+}
+
+func (node formulaFieldNode) String() string {
+	return string(node)
+}
+
+type formulaNegation struct {
+	operand formulaNode
+}
+
+func (node formulaNegation) calculate(lookup func(string) float64, steps *[]FormulaStep) float64 {
+	value := -node.operand.calculate(lookup, steps)
+	*steps = append(*steps, FormulaStep{Expression: node.String(), Value: value})
+	return value
+}
+
+func (node formulaNegation) fields(fields []string) []string {
+	return node.operand.fields(fields)
+}
+
+func (node formulaNegation) String() string {
+	if _, ok := node.operand.(formulaBinary); ok {
+		return fmt.Sprintf("-(%s)", node.operand)
+	}
+	return fmt.Sprintf("-%s", node.operand)
+}
+
+type formulaBinary struct {
+	operator string
+	left     formulaNode
+	right    formulaNode
+}
+
+func (node formulaBinary) calculate(lookup func(string) float64, steps *[]FormulaStep) float64 {
+	left, right := node.left.calculate(lookup, steps), node.right.calculate(lookup, steps)
+
+	var value float64
+	switch node.operator {
+	case "+":
+		value = left + right
+	case "-":
+		value = left - right
+	case "*":
+		value = left * right
+	case "/":
+		value = safeDivide(left, right)
+	}
+
+	*steps = append(*steps, FormulaStep{Expression: node.String(), Value: value})
+	return value
+}
+
+func (node formulaBinary) fields(fields []string) []string {
+	return node.right.fields(node.left.fields(fields))
+}
+
+func (node formulaBinary) String() string {
+	left, right := node.left.String(), node.right.String()
+	if child, ok := node.left.(formulaBinary); ok && precedence(child.operator) < precedence(node.operator) {
+		left = fmt.Sprintf("(%s)", left)
+	}
+	if child, ok := node.right.(formulaBinary); ok && precedence(child.operator) <= precedence(node.operator) {
+		right = fmt.Sprintf("(%s)", right)
+	}
+
+	return fmt.Sprintf("%s %s %s", left, node.operator, right)
+}
+
+type formulaCall struct {
+	function string
+	args     []formulaNode
+}
+
+func (node formulaCall) calculate(lookup func(string) float64, steps *[]FormulaStep) float64 {
+	values := make([]float64, 0, len(node.args))
+	for _, arg := range node.args {
+		values = append(values, arg.calculate(lookup, steps))
+	}
+
+	value := values[0]
+	switch node.function {
+	case SUM:
+		for _, arg := range values[1:] {
+			value += arg
+		}
+	case MLP:
+		for _, arg := range values[1:] {
+			value *= arg
+		}
+	case SUBTRACT:
+		value = values[0] - values[1]
+	case DIV:
+		value = safeDivide(values[0], values[1])
+	case MIN:
+		for _, arg := range values[1:] {
+			value = math.Min(value, arg)
+		}
+	case MAX:
+		for _, arg := range values[1:] {
+			value = math.Max(value, arg)
+		}
+	case ABS:
+		value = math.Abs(values[0])
+	case MOD:
+		value = 0
+		if values[1] != 0 {
+			value = math.Mod(values[0], values[1])
+		}
+	case RATIO:
+		value = safeDivide(values[0], values[0]+values[1])
+	}
+
+	*steps = append(*steps, FormulaStep{Expression: node.String(), Value: value})
+	return value
+}
+
+func (node formulaCall) fields(fields []string) []string {
+	for _, arg := range node.args {
+		fields = arg.fields(fields)
+	}
+	return fields
+}
+
+func (node formulaCall) String() string {
+	args := make([]string, 0, len(node.args))
+	for _, arg := range node.args {
+		args = append(args, arg.String())
+	}
+	return fmt.Sprintf("%s(%s)", node.function, strings.Join(args, ", "))
+}
+
+func safeDivide(dividend, divisor float64) float64 {
+	if divisor == 0 {
+		return 0
+	}
+	return dividend / divisor
+}
+
+func precedence(operator string) int {
+	if operator == "*" || operator == "/" {
+		return 2
+	}
+	return 1
+}
+
+type formulaParser struct {
+	tokens   []string
+	position int
+}
+
+func (parser *formulaParser) peek() string {
+	if parser.position < len(parser.tokens) {
+		return parser.tokens[parser.position]
+	}
+	return ""
+}
+
+func (parser *formulaParser) next() string {
+	token := parser.peek()
+	parser.position++
+	return token
+}
+
+func (parser *formulaParser) parseExpression() (formulaNode, error) {
+	return parser.parseBinary(parser.parseTerm, "+", "-")
+}
+
+func (parser *formulaParser) parseTerm() (formulaNode, error) {
+	return parser.parseBinary(parser.parseUnary, "*", "/")
+}
+
+func (parser *formulaParser) parseBinary(operand func() (formulaNode, error), operators ...string) (formulaNode, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for containsToken(operators, parser.peek()) {
+		operator := parser.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = formulaBinary{operator: operator, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *formulaParser) parseUnary() (formulaNode, error) {
+	if parser.peek() != "-" {
+		return parser.parsePrimary()
+	}
+
+	parser.next()
+	operand, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if number, ok := operand.(formulaNumber); ok {
+		return -number, nil
+	}
+	return formulaNegation{operand: operand}, nil
+}
+
+func (parser *formulaParser) parsePrimary() (formulaNode, error) {
+	token := parser.next()
+	switch {
+	case token == "":
+		return nil, errors.New("unexpected end of the expression")
+	case token == "(":
+		node, err := parser.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if parser.next() != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return node, nil
+	case isFormulaNumber(token):
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", token)
+		}
+		return formulaNumber(value), nil
+	case parser.peek() == "(":
+		return parser.parseCall(token)
+	case isFormulaIdentifier(token):
+		return formulaFieldNode(token), nil
+	}
+
+	return nil, fmt.Errorf("unexpected %q", token)
+}
+
+func (parser *formulaParser) parseCall(name string) (formulaNode, error) {
+	function, ok := formulaFunctions[strings.ToUpper(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q", name)
+	}
+
+	parser.next()
+	call := formulaCall{function: strings.ToUpper(name)}
+	for {
+		arg, err := parser.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+
+		token := parser.next()
+		if token == ")" {
+			break
+		}
+		if token != "," {
+			return nil, fmt.Errorf("missing closing parenthesis of %s", call.function)
+		}
+	}
+
+	if len(call.args) < function.minArgs || (function.maxArgs > 0 && len(call.args) > function.maxArgs) {
+		return nil, fmt.Errorf("wrong number of arguments for %s: %d", call.function, len(call.args))
+	}
+
+	return call, nil
+}
+
+func tokenizeFormula(expression string) []string {
+	tokens := make([]string, 0)
+	for i := 0; i < len(expression); {
+		char := expression[i]
+		switch {
+		case char == ' ' || char == '\t' || char == '\n':
+			i++
+		case strings.IndexByte("+-*/(),", char) >= 0:
+			tokens = append(tokens, string(char))
+			i++
+		default:
+			start := i
+			for i < len(expression) && strings.IndexByte(" \t\n+-*/(),", expression[i]) < 0 {
+				i++
+			}
+			tokens = append(tokens, expression[start:i])
+		}
+	}
+
+	return tokens
+}
+
+func isFormulaNumber(token string) bool {
+	return token[0] >= '0' && token[0] <= '9' || token[0] == '.'
+}
+
+func isFormulaIdentifier(token string) bool {
+	for i, char := range token {
+		isLetter := char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char == '_'
+		if i == 0 && !isLetter {
+			return false
+		}
+		if !isLetter && !(char >= '0' && char <= '9') && char != '.' && char != ':' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsToken(tokens []string, token string) bool {
+	for _, candidate := range tokens {
+		if candidate == token {
+			return true
+		}
+	}
+
+	return false
+}
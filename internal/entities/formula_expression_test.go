@@ -0,0 +1,86 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormulaExpression_Calculate(t *testing.T) {
+	values := map[string]float64{"amount": 200, "shipping.amount": 50, "aggregation.card_hash.charge.h1.sum": 700}
+	lookup := func(field string) float64 { return values[field] }
+
+	tests := []struct {
+		name       string
+		expression string
+		formatted  string
+		want       float64
+	}{
+		{"division between fields", "aggregation.card_hash.charge.h1.sum / amount",
+			"aggregation.card_hash.charge.h1.sum / amount", 3.5},
+		{"precedence and literals", "amount + shipping.amount * 2", "amount + shipping.amount * 2", 300},
+		{"parentheses", "(amount + shipping.amount) * 2", "(amount + shipping.amount) * 2", 500},
+		{"nested functions", "max(amount, ABS(shipping.amount - 400)) - MIN(10, 20, 5)",
+			"MAX(amount, ABS(shipping.amount - 400)) - MIN(10, 20, 5)", 345},
+		{"modulo", "MOD(amount, 30)", "MOD(amount, 30)", 20},
+		{"ratio", "RATIO(shipping.amount, amount)", "RATIO(shipping.amount, amount)", 0.2},
+		{"division by zero", "amount / missing", "amount / missing", 0},
+		{"modulo by zero", "MOD(amount, 0)", "MOD(amount, 0)", 0},
+		{"ratio without values", "RATIO(missing, other)", "RATIO(missing, other)", 0},
+		{"negation", "-(amount - shipping.amount) + -1.5", "-(amount - shipping.amount) + -1.5", -151.5},
+		{"legacy operations", "SUBTRACT(SUM(amount, shipping.amount), DIV(amount, 4))",
+			"SUBTRACT(SUM(amount, shipping.amount), DIV(amount, 4))", 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := entities.ParseFormulaExpression(tt.expression)
+			assert.NoError(t, err)
+
+			got, _ := expression.Calculate(lookup)
+
+			assert.InDelta(t, tt.want, got, 0.000001)
+			assert.Equal(t, tt.formatted, expression.String())
+		})
+	}
+
+	t.Run("steps show every intermediate value", func(t *testing.T) {
+		expression, _ := entities.ParseFormulaExpression("aggregation.card_hash.charge.h1.sum / (amount + 50)")
+
+		value, steps := expression.Calculate(lookup)
+
+		assert.Equal(t, 2.8, value)
+		assert.Equal(t, []entities.FormulaStep{
+			{Expression: "aggregation.card_hash.charge.h1.sum", Value: 700},
+			{Expression: "amount", Value: 200},
+			{Expression: "amount + 50", Value: 250},
+			{Expression: "aggregation.card_hash.charge.h1.sum / (amount + 50)", Value: 2.8},
+		}, steps)
+		assert.Equal(t, []string{"aggregation.card_hash.charge.h1.sum", "amount"}, expression.Fields())
+	})
+}
+
+func TestParseFormulaExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"amount +", "unexpected end of the expression"},
+		{"(amount + 1", "missing closing parenthesis"},
+		{"amount 1", `unexpected "1"`},
+		{"POW(amount, 2)", `unknown function "POW"`},
+		{"ABS(amount, 2)", "wrong number of arguments for ABS: 2"},
+		{"MAX(amount)", "wrong number of arguments for MAX: 1"},
+		{"amount * $", `unexpected "$"`},
+		{"1.2.3 + amount", `invalid number "1.2.3"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := entities.ParseFormulaExpression(tt.expression)
+
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -64,6 +64,35 @@ func TestFormula_ValidateFormula(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("When formula has a valid expression, it should return no error", func(t *testing.T) {
+		rules := testdata.GetExpressionFormulaRules("aggregation.card_hash.charge.h1.sum / amount")
+		err := entities.ValidateFormulas(rules)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("When formula expression can not be parsed, it should return error", func(t *testing.T) {
+		rules := testdata.GetExpressionFormulaRules("MAX(amount, ")
+		err := entities.ValidateFormulas(rules)
+
+		assert.EqualError(t, err, `formula expression "MAX(amount, " is not valid: unexpected end of the expression`)
+	})
+
+	t.Run("When formula expression has no fields, it should return error", func(t *testing.T) {
+		rules := testdata.GetExpressionFormulaRules("3 * 4")
+		err := entities.ValidateFormulas(rules)
+
+		assert.EqualError(t, err, `formula expression "3 * 4" should use at least one field`)
+	})
+
+	t.Run("When formula has an expression and fields, it should return error", func(t *testing.T) {
+		rules := testdata.GetExpressionFormulaRules("amount * 2")
+		rules[0].Fields = &[]string{"amount", "amount"}
+		err := entities.ValidateFormulas(rules)
+
+		assert.EqualError(t, err, "formula should have either an expression or fields and math operation")
+	})
 }
 
 func TestFormula_GenerateRuleFieldWithFormulaFields(t *testing.T) {
@@ -82,4 +111,13 @@ func TestFormula_GenerateRuleFieldWithFormulaFields(t *testing.T) {
 
 		assert.Equal(t, expectedField, rules[0].Field)
 	})
+	t.Run("When formula has an expression, it should return the field of its computed value", func(t *testing.T) {
+		rules := testdata.GetExpressionFormulaRules("aggregation.card_hash.charge.h1.sum/amount")
+		spaced := testdata.GetExpressionFormulaRules(" aggregation.card_hash.charge.h1.sum  /  amount ")
+		entities.GenerateRuleFieldWithFormulaFields(&rules)
+		entities.GenerateRuleFieldWithFormulaFields(&spaced)
+
+		assert.Regexp(t, `^formula\.f[0-9a-f]{8}$`, rules[0].Field)
+		assert.Equal(t, rules[0].Field, spaced[0].Field)
+	})
 }
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IPInfo is what the IP databases know about the network of an IP, a CSV row describes a CIDR Network.
+type IPInfo struct {
+	Network   string `json:"network" csv:"network"`
+	Country   string `json:"country" csv:"country"`
+	ASN       int64  `json:"asn" csv:"asn"`
+	IsHosting bool   `json:"is_hosting" csv:"is_hosting"`
+	IsTor     bool   `json:"is_tor" csv:"is_tor"`
+}
+
+// Merge fills the values that are missing with the ones of other, the flags are set when any database sets them.
+func (info *IPInfo) Merge(other IPInfo) {
+	if info.Network == "" {
+		info.Network = other.Network
+	}
+	if info.Country == "" {
+		info.Country = other.Country
+	}
+	if info.ASN == 0 {
+		info.ASN = other.ASN
+	}
+	info.IsHosting = info.IsHosting || other.IsHosting
+	info.IsTor = info.IsTor || other.IsTor
+}
+
+// IsPrivateIP tells if the IP is not routable on internet, like private, loopback or link local addresses.
+func IsPrivateIP(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified()
+}
+
+// IsIPCountryMismatch compares the country of the IP with the country of the card, it is only a mismatch when both
+// are known.
+func IsIPCountryMismatch(ipCountry, cardCountry string) bool {
+	ipCountry = strings.TrimSpace(ipCountry)
+	cardCountry = strings.TrimSpace(cardCountry)
+	if ipCountry == "" || cardCountry == "" {
+		return false
+	}
+
+	return !strings.EqualFold(ipCountry, cardCountry)
+}
+
+// ParseNetwork reads a CIDR range like 10.0.0.0/8, a single IP is read as the network of that IP only.
+func ParseNetwork(value string) (*net.IPNet, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		return network, err
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid network %q", value)
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)}, nil
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)}, nil
+}
+
+// IPRangeKey returns the IP as the hex digits of its 16 bytes form, the keys compare like the IPs so the IP lists can
+// be queried by the bounds of their ranges.
+func IPRangeKey(ip net.IP) string {
+	return hex.EncodeToString(ip.To16())
+}
+
+// IPRangeBounds returns the keys of the first and the last IP of the network of the value.
+func IPRangeBounds(value string) (string, string, error) {
+	network, err := ParseNetwork(value)
+	if err != nil {
+		return "", "", err
+	}
+
+	first := network.IP.Mask(network.Mask)
+	last := make(net.IP, len(first))
+	for i := range first {
+		last[i] = first[i] | ^network.Mask[i]
+	}
+
+	return IPRangeKey(first), IPRangeKey(last), nil
+}
@@ -1,16 +1,25 @@
 package entities
 
 import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
 	"time"
 
+	customString "github.com/conekta/risk-rules/pkg/strings"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 const (
-	White      TypeList = "Whitelist"
-	Black      TypeList = "Blacklist"
-	Gray       TypeList = "Graylist"
-	EmailField          = "email"
+	White            TypeList = "Whitelist"
+	Black            TypeList = "Blacklist"
+	Gray             TypeList = "Graylist"
+	EmailField                = "email"
+	CardHashField             = "card_hash"
+	PhoneField                = "phone"
+	IPField                   = "ip"
+	ListsSourceLocal          = "local"
 )
 
 type List struct {
@@ -30,6 +39,9 @@ type List struct {
 	Value       string             `json:"value" bson:"value"`
 	TimeToLive  int64              `json:"time_to_live" bson:"time_to_live"`
 	Expires     *time.Time         `json:"expires" bson:"expires"`
+	MatchedKey  string             `json:"matched_key,omitempty" bson:"matched_key,omitempty"`
+	RangeStart  string             `json:"-" bson:"range_start,omitempty"`
+	RangeEnd    string             `json:"-" bson:"range_end,omitempty"`
 }
 
 func (l *List) IsEmpty() bool { return l.CreatedBy == "" }
@@ -40,11 +52,168 @@ func (l *List) IsBlacklist() bool { return l.Type == Black.String() }
 
 func (l *List) IsGraylist() bool { return l.Type == Gray.String() }
 
+func (l *List) IsIPList() bool { return l.Field == IPField }
+
+// MatchesIP tells if the IP is in the value of the list, the value can be a single IP or a CIDR range.
+func (l *List) MatchesIP(ipAddress string) bool {
+	ip := net.ParseIP(strings.TrimSpace(ipAddress))
+	if ip == nil {
+		return false
+	}
+
+	network, err := ParseNetwork(l.Value)
+	if err != nil {
+		return false
+	}
+
+	return network.Contains(ip)
+}
+
 type ListsSearch struct {
-	Email     string `json:"email"`
-	CardHash  string `json:"card_hash"`
-	Phone     string `json:"phone"`
-	CompanyID string `json:"company_id"`
+	Email             string `json:"email"`
+	CardHash          string `json:"card_hash"`
+	Phone             string `json:"phone"`
+	CompanyID         string `json:"company_id"`
+	IPAddress         string `json:"ip_address,omitempty"`
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+	BinNumber         string `json:"bin_number,omitempty"`
+	Name              string `json:"name,omitempty"`
+}
+
+// ListRequest creates or replaces a list entry, TimeToLive is in seconds and zero means the entry never expires.
+type ListRequest struct {
+	CompanyID   string `json:"company_id"`
+	IsGlobal    *bool  `json:"is_global" validate:"required"`
+	IsTest      bool   `json:"is_test"`
+	Type        string `json:"type" validate:"required"`
+	Field       string `json:"field" validate:"required"`
+	Value       string `json:"value" validate:"required"`
+	Description string `json:"description"`
+	TimeToLive  int64  `json:"time_to_live"`
+	Author      string `json:"author" validate:"required"`
+}
+
+type ListFilter struct {
+	ID        string `query:"id"`
+	CompanyID string `query:"company_id"`
+	Type      string `query:"type"`
+	Field     string `query:"field"`
+	Value     string `query:"value"`
+	IsGlobal  bool   `query:"is_global"`
+}
+
+func (request *ListRequest) Validate() error {
+	hasCompany := !customString.IsEmpty(request.CompanyID)
+	if *request.IsGlobal && hasCompany {
+		return errors.New("company_id should not be passed, the list is configured as Global")
+	}
+	if !*request.IsGlobal && !hasCompany {
+		return errors.New("company_id is required for a non global list")
+	}
+
+	list := List{Type: request.Type}
+	if !list.IsValidListType() {
+		return fmt.Errorf("invalid list type: %s", request.Type)
+	}
+	if _, ok := GetListKeyType(request.Field); !ok {
+		return fmt.Errorf("invalid list field: %s", request.Field)
+	}
+	if request.Field == IPField {
+		if _, err := ParseNetwork(request.Value); err != nil {
+			return fmt.Errorf("invalid ip or cidr range: %s", request.Value)
+		}
+	}
+	if request.Field == BinField && !isDigits(strings.TrimSpace(request.Value)) {
+		return fmt.Errorf("invalid bin: %s", request.Value)
+	}
+	if request.TimeToLive < 0 {
+		return errors.New("time_to_live can not be negative")
+	}
+
+	return nil
+}
+
+func (request *ListRequest) NewListFromPostRequest() List {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	list := request.newList(now)
+	list.CreatedAt = now
+	list.CreatedBy = request.Author
+	return list
+}
+
+func (request *ListRequest) NewListFromPutRequest() List {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	list := request.newList(now)
+	list.UpdatedAt = &now
+	list.UpdatedBy = &request.Author
+	return list
+}
+
+func (request *ListRequest) newList(now time.Time) List {
+	keyType, _ := GetListKeyType(request.Field)
+	value := keyType.Normalize(request.Value)
+	list := List{
+		CompanyID:   request.CompanyID,
+		Description: request.Description,
+		Field:       request.Field,
+		IsGlobal:    *request.IsGlobal,
+		IsTest:      request.IsTest,
+		Rule:        fmt.Sprintf("%s %s %s", request.Field, keyType.Operator(), value),
+		Type:        request.Type,
+		Value:       value,
+		TimeToLive:  request.TimeToLive,
+	}
+	list.Decision = list.GetDecision()
+	if list.IsIPList() {
+		list.RangeStart, list.RangeEnd, _ = IPRangeBounds(value)
+	}
+	if request.TimeToLive > 0 {
+		expires := now.Add(time.Duration(request.TimeToLive) * time.Second)
+		list.Expires = &expires
+	}
+
+	return list
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l *List) GetDecision() Decision {
+	switch {
+	case l.IsWhitelist():
+		return Accepted
+	case l.IsBlacklist():
+		return Declined
+	}
+
+	return Undecided
+}
+
+func (l *List) GetListFilter() ListFilter {
+	return ListFilter{
+		CompanyID: l.CompanyID,
+		Type:      l.Type,
+		Field:     l.Field,
+		Value:     l.Value,
+		IsGlobal:  l.IsGlobal,
+	}
+}
+
+func (l *List) IsTheSame(id string) bool {
+	ID, _ := primitive.ObjectIDFromHex(id)
+	return l.ID == ID
 }
 
 type TypeList string
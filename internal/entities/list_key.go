@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+const (
+	DeviceFingerprintField = "device_fingerprint"
+	BinField               = "bin"
+	PhonePrefixField       = "phone_prefix"
+	NameField              = "name"
+
+	ListMatchExact  ListMatch = "exact"
+	ListMatchPrefix ListMatch = "prefix"
+	ListMatchRange  ListMatch = "cidr"
+)
+
+type ListMatch string
+
+// ListKeyType is a value of the charge that can be listed and how the values of the lists are compared with it.
+type ListKeyType struct {
+	Match     ListMatch
+	value     func(search ListsSearch) string
+	normalize func(value string) string
+}
+
+var listKeyTypes = map[string]ListKeyType{
+	EmailField: {Match: ListMatchExact, normalize: strings.TrimSpace,
+		value: func(search ListsSearch) string { return search.Email }},
+	CardHashField: {Match: ListMatchExact, normalize: strings.TrimSpace,
+		value: func(search ListsSearch) string { return search.CardHash }},
+	PhoneField: {Match: ListMatchExact, normalize: strings.TrimSpace,
+		value: func(search ListsSearch) string { return search.Phone }},
+	DeviceFingerprintField: {Match: ListMatchExact, normalize: strings.TrimSpace,
+		value: func(search ListsSearch) string { return search.DeviceFingerprint }},
+	NameField: {Match: ListMatchExact, normalize: normalizeName,
+		value: func(search ListsSearch) string { return search.Name }},
+	BinField: {Match: ListMatchPrefix, normalize: strings.TrimSpace,
+		value: func(search ListsSearch) string { return search.BinNumber }},
+	PhonePrefixField: {Match: ListMatchPrefix, normalize: strings.TrimSpace,
+		value: func(search ListsSearch) string { return search.Phone }},
+	IPField: {Match: ListMatchRange, normalize: strings.TrimSpace,
+		value: func(search ListsSearch) string { return search.IPAddress }},
+}
+
+// ListLookup has every list value that matches a key of the charge, range keys have the range key of the IP to find
+// the ranges that contain it.
+type ListLookup struct {
+	Field  string
+	Values []string
+}
+
+func GetListKeyType(field string) (ListKeyType, bool) {
+	keyType, ok := listKeyTypes[field]
+	return keyType, ok
+}
+
+func (keyType ListKeyType) Operator() string {
+	switch keyType.Match {
+	case ListMatchPrefix:
+		return "starts_with"
+	case ListMatchRange:
+		return "in"
+	}
+
+	return "=="
+}
+
+func (keyType ListKeyType) Normalize(value string) string {
+	return keyType.normalize(value)
+}
+
+func (keyType ListKeyType) valueOf(search ListsSearch) string {
+	return keyType.normalize(keyType.value(search))
+}
+
+// Lookups returns a lookup for every key that the charge has, prefix keys look for every prefix of the value.
+func (search ListsSearch) Lookups() []ListLookup {
+	fields := make([]string, 0, len(listKeyTypes))
+	for field := range listKeyTypes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	lookups := make([]ListLookup, 0, len(fields))
+	for _, field := range fields {
+		keyType := listKeyTypes[field]
+		value := keyType.valueOf(search)
+		if value == "" {
+			continue
+		}
+
+		lookup := ListLookup{Field: field}
+		switch keyType.Match {
+		case ListMatchExact:
+			lookup.Values = []string{value}
+		case ListMatchPrefix:
+			for length := len(value); length > 0; length-- {
+				lookup.Values = append(lookup.Values, value[:length])
+			}
+		case ListMatchRange:
+			ip := net.ParseIP(strings.TrimSpace(value))
+			if ip == nil {
+				continue
+			}
+			lookup.Values = []string{IPRangeKey(ip)}
+		}
+		lookups = append(lookups, lookup)
+	}
+
+	return lookups
+}
+
+// Match tells if the value of the list matches the value of the charge for the key type of the list.
+func (l *List) Match(search ListsSearch) bool {
+	keyType, ok := listKeyTypes[l.Field]
+	if !ok {
+		return false
+	}
+
+	value := keyType.valueOf(search)
+	if value == "" {
+		return false
+	}
+
+	switch keyType.Match {
+	case ListMatchPrefix:
+		return strings.HasPrefix(value, keyType.normalize(l.Value))
+	case ListMatchRange:
+		return l.MatchesIP(value)
+	}
+
+	return value == keyType.normalize(l.Value)
+}
+
+// SetMatchedKey records the key of the charge that was found in the list.
+func (l *List) SetMatchedKey(search ListsSearch) {
+	keyType, ok := listKeyTypes[l.Field]
+	if !ok {
+		return
+	}
+
+	l.MatchedKey = fmt.Sprintf("%s:%s", l.Field, keyType.valueOf(search))
+}
+
+func normalizeName(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
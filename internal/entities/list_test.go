@@ -2,6 +2,7 @@ package entities_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/conekta/risk-rules/test/testdata"
@@ -58,3 +59,180 @@ func TestList_NewListResponse(t *testing.T) {
 		assert.True(t, listResponse.IsListResponseEmpty())
 	})
 }
+
+func TestList_MatchesIP(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		ip    string
+		want  bool
+	}{
+		{name: "ip in the CIDR range", value: "187.190.0.0/16", ip: "187.190.38.10", want: true},
+		{name: "ip out of the CIDR range", value: "187.190.0.0/16", ip: "187.191.0.1", want: false},
+		{name: "same single ip", value: "185.220.101.1", ip: "185.220.101.1", want: true},
+		{name: "ipv6 range", value: "2001:db8::/32", ip: "2001:db8::1", want: true},
+		{name: "invalid list value", value: "not-a-network", ip: "185.220.101.1", want: false},
+		{name: "invalid ip", value: "187.190.0.0/16", ip: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := entities.List{Field: entities.IPField, Value: tt.value}
+
+			assert.True(t, list.IsIPList())
+			assert.Equal(t, tt.want, list.MatchesIP(tt.ip))
+		})
+	}
+}
+
+func TestListRequest_Validate(t *testing.T) {
+	isGlobal, isNotGlobal := true, false
+	tests := []struct {
+		name    string
+		request entities.ListRequest
+		wantErr string
+	}{
+		{name: "valid company list", request: entities.ListRequest{CompanyID: "2", IsGlobal: &isNotGlobal,
+			Type: "Blacklist", Field: entities.CardHashField, Value: "hash"}},
+		{name: "valid global ip range", request: entities.ListRequest{IsGlobal: &isGlobal,
+			Type: "Graylist", Field: entities.IPField, Value: "187.190.0.0/16"}},
+		{name: "company list without company", request: entities.ListRequest{IsGlobal: &isNotGlobal,
+			Type: "Blacklist", Field: entities.EmailField, Value: "me@gmail.com"},
+			wantErr: "company_id is required for a non global list"},
+		{name: "unknown type", request: entities.ListRequest{IsGlobal: &isGlobal,
+			Type: "Redlist", Field: entities.EmailField, Value: "me@gmail.com"}, wantErr: "invalid list type: Redlist"},
+		{name: "unknown field", request: entities.ListRequest{IsGlobal: &isGlobal,
+			Type: "Blacklist", Field: "document", Value: "me"}, wantErr: "invalid list field: document"},
+		{name: "bin with letters", request: entities.ListRequest{IsGlobal: &isGlobal,
+			Type: "Blacklist", Field: entities.BinField, Value: "4111ab"}, wantErr: "invalid bin: 4111ab"},
+		{name: "negative time to live", request: entities.ListRequest{IsGlobal: &isGlobal, Type: "Blacklist",
+			Field: entities.PhoneField, Value: "+5215555555555", TimeToLive: -1},
+			wantErr: "time_to_live can not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestListRequest_NewListFromPostRequest(t *testing.T) {
+	isGlobal := false
+	request := entities.ListRequest{CompanyID: "2", IsGlobal: &isGlobal, Type: "Whitelist",
+		Field: entities.EmailField, Value: " me@gmail.com ", TimeToLive: 60, Author: "me"}
+
+	list := request.NewListFromPostRequest()
+
+	assert.Equal(t, entities.Accepted, list.Decision)
+	assert.Equal(t, "me@gmail.com", list.Value)
+	assert.Equal(t, "email == me@gmail.com", list.Rule)
+	assert.Equal(t, list.CreatedAt.Add(time.Minute), *list.Expires)
+	assert.Empty(t, list.RangeStart)
+}
+
+func TestListRequest_NewListFromPostRequestWithIPRange(t *testing.T) {
+	isGlobal := true
+	request := entities.ListRequest{IsGlobal: &isGlobal, Type: "Blacklist", Field: entities.IPField,
+		Value: "187.190.38.0/24", Author: "me"}
+
+	list := request.NewListFromPostRequest()
+
+	assert.Equal(t, "00000000000000000000ffffbbbe2600", list.RangeStart)
+	assert.Equal(t, "00000000000000000000ffffbbbe26ff", list.RangeEnd)
+}
+
+func TestList_Match(t *testing.T) {
+	search := entities.ListsSearch{
+		Email:             "me@gmail.com",
+		Phone:             "+5215555555555",
+		IPAddress:         "187.190.38.10",
+		DeviceFingerprint: "cGfNEDJZjyj7W1N7DGbFtQi5RbkxhAvn",
+		BinNumber:         "41111111",
+		Name:              "Mario  Moreno",
+	}
+	tests := []struct {
+		name string
+		list entities.List
+		want bool
+	}{
+		{name: "same email", list: entities.List{Field: entities.EmailField, Value: "me@gmail.com"}, want: true},
+		{name: "same device", list: entities.List{Field: entities.DeviceFingerprintField,
+			Value: "cGfNEDJZjyj7W1N7DGbFtQi5RbkxhAvn"}, want: true},
+		{name: "name without case and spaces", list: entities.List{Field: entities.NameField, Value: "mario moreno"},
+			want: true},
+		{name: "bin prefix", list: entities.List{Field: entities.BinField, Value: "4111"}, want: true},
+		{name: "other bin", list: entities.List{Field: entities.BinField, Value: "5111"}, want: false},
+		{name: "phone prefix", list: entities.List{Field: entities.PhonePrefixField, Value: "+52155"}, want: true},
+		{name: "ip range", list: entities.List{Field: entities.IPField, Value: "187.190.0.0/16"}, want: true},
+		{name: "empty value of the charge", list: entities.List{Field: entities.CardHashField, Value: "hash"},
+			want: false},
+		{name: "unknown field", list: entities.List{Field: "document", Value: "me@gmail.com"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.list.Match(search))
+		})
+	}
+}
+
+func TestListsSearch_Lookups(t *testing.T) {
+	search := entities.ListsSearch{
+		Email:     "me@gmail.com",
+		IPAddress: "187.190.38.10",
+		BinNumber: "411111",
+		Name:      " Mario Moreno",
+	}
+
+	lookups := search.Lookups()
+
+	assert.Equal(t, []entities.ListLookup{
+		{Field: entities.BinField, Values: []string{"411111", "41111", "4111", "411", "41", "4"}},
+		{Field: entities.EmailField, Values: []string{"me@gmail.com"}},
+		{Field: entities.IPField, Values: []string{"00000000000000000000ffffbbbe260a"}},
+		{Field: entities.NameField, Values: []string{"mario moreno"}},
+	}, lookups)
+}
+
+func TestListsSearch_LookupsWithInvalidIP(t *testing.T) {
+	search := entities.ListsSearch{Email: "me@gmail.com", IPAddress: "not an ip"}
+
+	lookups := search.Lookups()
+
+	assert.Equal(t, []entities.ListLookup{{Field: entities.EmailField, Values: []string{"me@gmail.com"}}}, lookups)
+}
+
+func TestIPRangeBounds(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantStart string
+		wantEnd   string
+	}{
+		{value: "187.190.38.0/24", wantStart: "00000000000000000000ffffbbbe2600", wantEnd: "00000000000000000000ffffbbbe26ff"},
+		{value: "187.190.38.10", wantStart: "00000000000000000000ffffbbbe260a", wantEnd: "00000000000000000000ffffbbbe260a"},
+		{value: "2001:db8::/32", wantStart: "20010db8000000000000000000000000", wantEnd: "20010db8ffffffffffffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			start, end, err := entities.IPRangeBounds(tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+
+	t.Run("an invalid value has no bounds", func(t *testing.T) {
+		_, _, err := entities.IPRangeBounds("187.190.38")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,52 @@
+package entities
+
+type RiskScore struct {
+	Score          float64             `json:"score" bson:"score"`
+	TestScore      float64             `json:"test_score" bson:"test_score"`
+	Decision       Decision            `json:"decision" bson:"decision"`
+	TestDecision   Decision            `json:"test_decision" bson:"test_decision"`
+	ThresholdScope string              `json:"threshold_scope,omitempty" bson:"threshold_scope,omitempty"`
+	DeclineScore   *float64            `json:"decline_score,omitempty" bson:"decline_score,omitempty"`
+	AcceptScore    *float64            `json:"accept_score,omitempty" bson:"accept_score,omitempty"`
+	Contributions  []ScoreContribution `json:"contributions" bson:"contributions"`
+}
+
+type ScoreContribution struct {
+	RuleID   string  `json:"rule_id" bson:"rule_id"`
+	Revision int64   `json:"revision" bson:"revision"`
+	Rule     string  `json:"rule" bson:"rule"`
+	Weight   float64 `json:"weight" bson:"weight"`
+	IsTest   bool    `json:"is_test" bson:"is_test"`
+}
+
+func NewRiskScore() *RiskScore {
+	return &RiskScore{
+		Decision:      Undecided,
+		TestDecision:  Undecided,
+		Contributions: make([]ScoreContribution, 0),
+	}
+}
+
+func (riskScore *RiskScore) AddContribution(rule Rule) {
+	weight := rule.GetWeight()
+	riskScore.Contributions = append(riskScore.Contributions, ScoreContribution{
+		RuleID:   rule.ID.Hex(),
+		Revision: rule.Revision,
+		Rule:     rule.Rule,
+		Weight:   weight,
+		IsTest:   rule.IsTest,
+	})
+
+	riskScore.TestScore += weight
+	if !rule.IsTest {
+		riskScore.Score += weight
+	}
+}
+
+func (riskScore *RiskScore) ApplyThreshold(threshold ScoreThreshold) {
+	riskScore.ThresholdScope = threshold.GetScope()
+	riskScore.DeclineScore = threshold.DeclineScore
+	riskScore.AcceptScore = threshold.AcceptScore
+	riskScore.Decision = threshold.Decide(riskScore.Score)
+	riskScore.TestDecision = threshold.Decide(riskScore.TestScore)
+}
@@ -18,7 +18,7 @@ const (
 )
 
 type RuleContent struct {
-	Field     string `json:"field" bson:"field" validate:"required_without=Fields,excluded_with=Fields"`
+	Field     string `json:"field" bson:"field" validate:"required_without_all=Fields Expression,excluded_with=Fields Expression"`
 	Operator  string `json:"operator" bson:"operator" validate:"required"`
 	Value     string `json:"value" bson:"value" validate:"required"`
 	Condition string `json:"condition" bson:"condition" validate:"required"`
@@ -74,8 +74,18 @@ type Rule struct {
 	FamilyCompanyID *string            `json:"family_company_id" bson:"family_company_id"`
 	Rule            string             `json:"rule" bson:"rule"`
 	Rules           []RuleContent      `json:"rules" bson:"rules"`
+	Group           *RuleGroup         `json:"group,omitempty" bson:"group,omitempty"`
 	Decision        Decision           `json:"decision" bson:"decision"`
 	IsYellowFlag    bool               `json:"is_yellow_flag" bson:"is_yellow_flag"`
+	IsScoreRule     bool               `json:"is_score_rule" bson:"is_score_rule"`
+	Weight          *float64           `json:"weight,omitempty" bson:"weight,omitempty"`
+	ActiveFrom      *time.Time         `json:"active_from,omitempty" bson:"active_from,omitempty"`
+	ActiveUntil     *time.Time         `json:"active_until,omitempty" bson:"active_until,omitempty"`
+	Schedule        *RuleSchedule      `json:"schedule,omitempty" bson:"schedule,omitempty"`
+	Revision        int64              `json:"revision" bson:"revision"`
+	Status          RuleStatus         `json:"status" bson:"status"`
+	Review          *RuleReview        `json:"review,omitempty" bson:"review,omitempty"`
+	Pending         *Rule              `json:"pending,omitempty" bson:"pending,omitempty"`
 }
 
 type RuleRequest struct {
@@ -87,19 +97,26 @@ type RuleRequest struct {
 	CompanyID       string        `json:"company_id"`
 	FamilyID        string        `json:"family_id"`
 	FamilyCompanyID string        `json:"family_company_id"`
-	Rules           []RuleContent `json:"rules" validate:"required,gt=0,dive,required"`
+	Rules           []RuleContent `json:"rules" validate:"required_without=Group,omitempty,gt=0,dive,required"`
+	Group           *RuleGroup    `json:"group" validate:"-"`
 	Author          string        `json:"author" validate:"required"`
 	IsYellowFlag    bool          `json:"is_yellow_flag"`
+	IsScoreRule     bool          `json:"is_score_rule"`
+	Weight          *float64      `json:"weight"`
+	ActiveFrom      *time.Time    `json:"active_from"`
+	ActiveUntil     *time.Time    `json:"active_until"`
+	Schedule        *RuleSchedule `json:"schedule"`
 }
 
 func (rReq *RuleRequest) NewRuleFromPostRequest() Rule {
 	now := time.Now().UTC()
 
-	orderRulesWithFormulas(&rReq.Rules)
-	GenerateRuleFieldWithFormulaFields(&rReq.Rules)
+	rReq.prepareRules()
 
 	return Rule{
 		ID:              primitive.NewObjectID(),
+		Revision:        FirstRuleRevision,
+		Status:          RuleStatusDraft,
 		CreatedBy:       rReq.Author,
 		CreatedAt:       now,
 		IsTest:          *rReq.IsTest,
@@ -110,16 +127,21 @@ func (rReq *RuleRequest) NewRuleFromPostRequest() Rule {
 		FamilyMccID:     customString.StringToStringPointer(rReq.FamilyID),
 		FamilyCompanyID: customString.StringToStringPointer(rReq.FamilyCompanyID),
 		Rules:           rReq.Rules,
+		Group:           rReq.Group,
 		Decision:        rReq.Decision,
 		IsYellowFlag:    rReq.IsYellowFlag,
+		IsScoreRule:     rReq.IsScoreRule,
+		Weight:          rReq.Weight,
+		ActiveFrom:      rReq.ActiveFrom,
+		ActiveUntil:     rReq.ActiveUntil,
+		Schedule:        rReq.Schedule,
 	}
 }
 
 func (rReq *RuleRequest) NewRuleFromPutRequest() Rule {
 	now := time.Now().UTC()
 
-	orderRulesWithFormulas(&rReq.Rules)
-	GenerateRuleFieldWithFormulaFields(&rReq.Rules)
+	rReq.prepareRules()
 
 	return Rule{
 		UpdatedBy:       &rReq.Author,
@@ -132,11 +154,30 @@ func (rReq *RuleRequest) NewRuleFromPutRequest() Rule {
 		FamilyCompanyID: customString.StringToStringPointer(rReq.FamilyCompanyID),
 		FamilyMccID:     &rReq.FamilyID,
 		Rules:           rReq.Rules,
+		Group:           rReq.Group,
 		Decision:        rReq.Decision,
 		IsYellowFlag:    rReq.IsYellowFlag,
+		IsScoreRule:     rReq.IsScoreRule,
+		Weight:          rReq.Weight,
+		ActiveFrom:      rReq.ActiveFrom,
+		ActiveUntil:     rReq.ActiveUntil,
+		Schedule:        rReq.Schedule,
 	}
 }
 
+// prepareRules flattens the group of the request into its rules, the clauses of a group keep their order
+// because the position of each clause changes the meaning of the tree.
+func (rReq *RuleRequest) prepareRules() {
+	if rReq.Group != nil {
+		rReq.Group.GenerateFormulaFields()
+		rReq.Rules = rReq.Group.Contents()
+		return
+	}
+
+	orderRulesWithFormulas(&rReq.Rules)
+	GenerateRuleFieldWithFormulaFields(&rReq.Rules)
+}
+
 func (rReq *RuleRequest) HasMultipleValues() bool {
 	return len(rReq.CompanyID) > 0 && len(rReq.FamilyID) > 0 ||
 		len(rReq.CompanyID) > 0 && len(rReq.FamilyCompanyID) > 0 ||
@@ -158,7 +199,7 @@ func (rReq *RuleRequest) Validate() error {
 		return err
 	}
 
-	err = ValidateFormulas(rReq.Rules)
+	err = ValidateRuleDefinition(rReq.Rules, rReq.Group)
 	if err != nil {
 		return err
 	}
@@ -168,9 +209,31 @@ func (rReq *RuleRequest) Validate() error {
 		return err
 	}
 
+	err = ValidateIsScoreRule(rReq)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateActivation(rReq)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func ValidateRuleDefinition(rules []RuleContent, group *RuleGroup) error {
+	if group == nil {
+		return ValidateFormulas(rules)
+	}
+
+	if len(rules) > 0 {
+		return errors.New("rules and group could not be passed at same time")
+	}
+
+	return group.Validate()
+}
+
 func ValidateIsYellowFlag(rReq *RuleRequest) error {
 	if rReq.IsYellowFlag && rReq.Decision != Undecided {
 		return errors.New("yellow flag rules must have the undecided decision")
@@ -178,6 +241,38 @@ func ValidateIsYellowFlag(rReq *RuleRequest) error {
 	return nil
 }
 
+func ValidateIsScoreRule(rReq *RuleRequest) error {
+	if !rReq.IsScoreRule {
+		if rReq.Weight != nil {
+			return errors.New("weight should only be passed on score rules")
+		}
+		return nil
+	}
+
+	if rReq.Weight == nil {
+		return errors.New("score rules must have a weight")
+	}
+	if rReq.Decision != Undecided {
+		return errors.New("score rules must have the undecided decision")
+	}
+	if rReq.IsYellowFlag {
+		return errors.New("score rules could not be yellow flag rules")
+	}
+	return nil
+}
+
+func ValidateActivation(rReq *RuleRequest) error {
+	if rReq.ActiveFrom != nil && rReq.ActiveUntil != nil && !rReq.ActiveUntil.After(*rReq.ActiveFrom) {
+		return errors.New("active_until must be after active_from")
+	}
+
+	if rReq.Schedule != nil {
+		return rReq.Schedule.Validate()
+	}
+
+	return nil
+}
+
 func ValidateIsGlobal(rReq *RuleRequest) error {
 	if !*rReq.IsGlobal {
 		if rReq.HasMultipleValues() {
@@ -235,13 +330,14 @@ type RuleUpdate struct {
 }
 
 type RulesResponse struct {
-	Decision                Decision `json:"decision"`
-	TestDecision            Decision `json:"-"`
-	DecisionRules           []Rule   `json:"decision_rules" bson:"decision_rules"`
-	TestRules               []Rule   `json:"test_rules" bson:"test_rules"`
-	EvaluatedGlobalRules    int64    `json:"evaluated_global_rules" bson:"evaluated_global_rules"`
-	EvaluatedNonGlobalRules int64    `json:"evaluated_non_global_rules" bson:"evaluated_non_global_rules"`
-	Errors                  []string `json:"errors"`
+	Decision                Decision   `json:"decision"`
+	TestDecision            Decision   `json:"-"`
+	DecisionRules           []Rule     `json:"decision_rules" bson:"decision_rules"`
+	TestRules               []Rule     `json:"test_rules" bson:"test_rules"`
+	EvaluatedGlobalRules    int64      `json:"evaluated_global_rules" bson:"evaluated_global_rules"`
+	EvaluatedNonGlobalRules int64      `json:"evaluated_non_global_rules" bson:"evaluated_non_global_rules"`
+	Errors                  []string   `json:"errors"`
+	RiskScore               *RiskScore `json:"risk_score,omitempty" bson:"risk_score,omitempty"`
 }
 
 type RuleFilter struct {
@@ -254,6 +350,8 @@ type RuleFilter struct {
 	FamilyCompanyID    string   `json:"family_company_id" query:"family_company_id"`
 	FamilyCompaniesIDs []string `json:"family_companies_ids" query:"family_companies_ids"`
 	Rule               string   `json:"rule" query:"rule"`
+	Active             string   `json:"active" query:"active"`
+	Status             string   `json:"status" query:"status"`
 }
 
 func NewRulesResponse() RulesResponse {
@@ -312,6 +410,30 @@ func (r *Rule) GetRuleFilter() RuleFilter {
 	}
 }
 
+func (r *Rule) GetWeight() float64 {
+	if r.Weight == nil {
+		return 0
+	}
+
+	return *r.Weight
+}
+
+func (r *Rule) IsActiveAt(moment time.Time) bool {
+	if r.ActiveFrom != nil && moment.Before(*r.ActiveFrom) {
+		return false
+	}
+
+	if r.ActiveUntil != nil && !moment.Before(*r.ActiveUntil) {
+		return false
+	}
+
+	if r.Schedule != nil {
+		return r.Schedule.IsActiveAt(moment)
+	}
+
+	return true
+}
+
 func (r *Rule) IsContained(rules []Rule) bool {
 	for _, rule := range rules {
 		if rule.Rule == r.Rule {
@@ -322,6 +444,12 @@ func (r *Rule) IsContained(rules []Rule) bool {
 	return false
 }
 
+func (r *Rule) HasSameScope(other Rule) bool {
+	return customString.StringPointerToString(r.CompanyID) == customString.StringPointerToString(other.CompanyID) &&
+		customString.StringPointerToString(r.FamilyMccID) == customString.StringPointerToString(other.FamilyMccID) &&
+		customString.StringPointerToString(r.FamilyCompanyID) == customString.StringPointerToString(other.FamilyCompanyID)
+}
+
 func (s *RuleFilter) IsEmptyCompanyID() bool {
 	return customString.IsEmpty(s.CompanyID)
 }
@@ -341,6 +469,10 @@ func (s *RuleFilter) IsIDValid() bool {
 	return true
 }
 
+func (s *RuleFilter) IsActiveValid() bool {
+	return customString.IsEmpty(s.Active) || customString.IsBoolean(s.Active)
+}
+
 func (s *RuleFilter) IsEmptyFamilyCompaniesID() bool {
 	return customString.IsEmpty(s.FamilyCompanyID)
 }
@@ -355,11 +487,18 @@ func orderRulesWithFormulas(rules *[]RuleContent) {
 }
 
 type RulesEvaluationResponse struct {
-	Decision      string               `json:"decision"`
-	RulesModules  RulesModulesResponse `json:"modules"`
-	Omniscore     float64              `json:"omniscore"`
-	MerchantScore float64              `json:"merchant_score"`
-	Charge        ChargeRequest        `json:"charge"`
+	Decision            string               `json:"decision"`
+	RulesModules        RulesModulesResponse `json:"modules"`
+	Omniscore           float64              `json:"omniscore"`
+	MerchantScore       float64              `json:"merchant_score"`
+	Charge              ChargeRequest        `json:"charge"`
+	TimedOutEnrichments []string             `json:"timed_out_enrichments,omitempty" bson:"timed_out_enrichments,omitempty"`
+	Enrichments         []EnrichmentResult   `json:"enrichments,omitempty" bson:"enrichments,omitempty"`
+	RiskScore           *RiskScore           `json:"risk_score,omitempty" bson:"risk_score,omitempty"`
+	Degradations        []Degradation        `json:"degradations,omitempty" bson:"degradations,omitempty"`
+	EvaluatedAt         *time.Time           `json:"evaluated_at,omitempty" bson:"evaluated_at,omitempty"`
+	Replayed            bool                 `json:"replayed,omitempty" bson:"-"`
+	Trace               *EvaluationTrace     `json:"trace,omitempty" bson:"-"`
 }
 
 type RulesModulesResponse struct {
@@ -369,6 +508,7 @@ type RulesModulesResponse struct {
 	GlobalRules        *RulesResponse `json:"global_rules,omitempty"`
 	IdentityModule     *RulesResponse `json:"identity_module,omitempty"`
 	YellowFlagModule   *RulesResponse `json:"yellow_flag_module,omitempty"`
+	ScoreRules         *RulesResponse `json:"score_rules,omitempty"`
 }
 
 func (rulesModulesResponse *RulesModulesResponse) SetRuleResponse(component Component, rulesResponse RulesResponse) {
@@ -386,6 +526,8 @@ func (rulesModulesResponse *RulesModulesResponse) SetRuleResponse(component Comp
 			rulesModulesResponse.IdentityModule = &rulesResponse
 		case YellowFlagType:
 			rulesModulesResponse.YellowFlagModule = &rulesResponse
+		case ScoreRulesType:
+			rulesModulesResponse.ScoreRules = &rulesResponse
 		}
 	}
 }
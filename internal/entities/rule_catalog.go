@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/conekta/risk-rules/internal/entities/exceptions"
+)
+
+// RuleCatalog holds the fields, operators and conditions a rule is allowed to use.
+type RuleCatalog struct {
+	fields     map[string]Field
+	operators  map[string][]Operator
+	conditions map[string]bool
+}
+
+func NewRuleCatalog(fields []Field, operators []Operator, conditions []Condition) RuleCatalog {
+	catalog := RuleCatalog{
+		fields:     make(map[string]Field, len(fields)),
+		operators:  make(map[string][]Operator, len(operators)),
+		conditions: make(map[string]bool, len(conditions)),
+	}
+
+	for _, field := range fields {
+		catalog.fields[field.Name] = field
+	}
+	for _, operator := range operators {
+		catalog.operators[operator.Name] = append(catalog.operators[operator.Name], operator)
+	}
+	for _, condition := range conditions {
+		catalog.conditions[strings.ToLower(condition.Name)] = true
+	}
+
+	return catalog
+}
+
+// Check returns the first problem found on every clause, an empty slice means the rule matches the catalog.
+func (catalog RuleCatalog) Check(contents []RuleContent) []exceptions.ClauseError {
+	clauseErrors := make([]exceptions.ClauseError, 0)
+	for index, content := range contents {
+		if clauseError := catalog.checkClause(index, content); clauseError != nil {
+			clauseErrors = append(clauseErrors, *clauseError)
+		}
+	}
+
+	return clauseErrors
+}
+
+func (catalog RuleCatalog) checkClause(index int, content RuleContent) *exceptions.ClauseError {
+	if !catalog.conditions[strings.ToLower(content.Condition)] {
+		return newClauseError(index, content, content.Field, exceptions.RuleConditionNotFound,
+			fmt.Sprintf("condition '%s' not found", content.Condition))
+	}
+
+	fieldName, fieldType, clauseError := catalog.findFieldType(index, content)
+	if clauseError != nil {
+		return clauseError
+	}
+
+	operators, ok := catalog.operators[content.Operator]
+	if !ok {
+		return newClauseError(index, content, fieldName, exceptions.RuleOperatorNotFound,
+			fmt.Sprintf("operator '%s' not found", content.Operator))
+	}
+
+	if !supportsType(operators, fieldType) {
+		return newClauseError(index, content, fieldName, exceptions.RuleOperatorNotCompatible,
+			fmt.Sprintf("operator '%s' can not be applied to the %s field '%s'", content.Operator, fieldType, fieldName))
+	}
+
+	if !isValueOfType(content.Value, content.IsOperatorIn(), fieldType) {
+		return newClauseError(index, content, fieldName, exceptions.RuleValueNotValid,
+			fmt.Sprintf("value '%s' is not a valid %s", content.Value, fieldType))
+	}
+
+	return nil
+}
+
+// findFieldType resolves the type the operator works on, formulas always produce a number out of number fields.
+func (catalog RuleCatalog) findFieldType(index int, content RuleContent) (string, string, *exceptions.ClauseError) {
+	if expression, ok := content.FormulaExpression(); ok {
+		return catalog.findFormulaType(index, content, expression.Fields(), "formula")
+	}
+
+	if !isFormulaRule(content) {
+		field, ok := catalog.fields[content.Field]
+		if !ok {
+			return content.Field, "", newClauseError(index, content, content.Field, exceptions.RuleFieldNotFound,
+				fmt.Sprintf("field '%s' not found", content.Field))
+		}
+
+		return field.Name, field.Type, nil
+	}
+
+	return catalog.findFormulaType(index, content, *content.Fields,
+		fmt.Sprintf("math operation '%s'", *content.MathOperation))
+}
+
+func (catalog RuleCatalog) findFormulaType(index int, content RuleContent, fields []string,
+	formula string) (string, string, *exceptions.ClauseError) {
+	for _, name := range fields {
+		field, ok := catalog.fields[name]
+		if !ok {
+			return name, "", newClauseError(index, content, name, exceptions.RuleFieldNotFound, fmt.Sprintf("field '%s' not found", name))
+		}
+
+		if field.Type != OperatorTypeNumber {
+			return name, "", newClauseError(index, content, name, exceptions.RuleOperatorNotCompatible,
+				fmt.Sprintf("%s can not be applied to the %s field '%s'", formula, field.Type, name))
+		}
+	}
+
+	return content.Field, OperatorTypeNumber, nil
+}
+
+func newClauseError(index int, content RuleContent, field, code, message string) *exceptions.ClauseError {
+	return &exceptions.ClauseError{
+		Clause:   index,
+		Field:    field,
+		Operator: content.Operator,
+		Code:     code,
+		Message:  message,
+	}
+}
+
+func supportsType(operators []Operator, fieldType string) bool {
+	for _, operator := range operators {
+		if operator.Type == fieldType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isValueOfType(value string, isList bool, fieldType string) bool {
+	values := []string{value}
+	if isList {
+		trimmed := strings.TrimSpace(value)
+		if !strings.HasPrefix(trimmed, "[") || !strings.HasSuffix(trimmed, "]") {
+			return false
+		}
+		values = strings.Split(strings.TrimSuffix(strings.TrimPrefix(trimmed, "["), "]"), ",")
+	}
+
+	for _, item := range values {
+		item = strings.Trim(strings.TrimSpace(item), `"`)
+
+		var err error
+		switch fieldType {
+		case OperatorTypeNumber:
+			_, err = strconv.ParseFloat(item, 64)
+		case OperatorTypeBoolean:
+			_, err = strconv.ParseBool(item)
+		}
+		if err != nil {
+			return false
+		}
+	}
+
+	return true
+}
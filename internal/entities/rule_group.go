@@ -0,0 +1,170 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	customString "github.com/conekta/risk-rules/pkg/strings"
+)
+
+const (
+	RuleGroupAnd = "and"
+	RuleGroupOr  = "or"
+)
+
+// RuleGroup is a tree of clauses and subgroups joined by the same condition, the engine evaluates and/or
+// from left to right so every subgroup is written between parentheses.
+type RuleGroup struct {
+	Condition string          `json:"condition" bson:"condition"`
+	Not       bool            `json:"not" bson:"not"`
+	Items     []RuleGroupItem `json:"items" bson:"items"`
+}
+
+// RuleGroupItem holds either a clause or a subgroup, the condition of the clause is taken from its group.
+type RuleGroupItem struct {
+	Clause *RuleContent `json:"clause,omitempty" bson:"clause,omitempty"`
+	Group  *RuleGroup   `json:"group,omitempty" bson:"group,omitempty"`
+}
+
+func (g *RuleGroup) Validate() error {
+	condition := strings.ToLower(g.Condition)
+	if condition != RuleGroupAnd && condition != RuleGroupOr {
+		return fmt.Errorf("group condition should be %s or %s. Requested condition: %q", RuleGroupAnd, RuleGroupOr,
+			g.Condition)
+	}
+
+	if len(g.Items) == 0 {
+		return errors.New("group should have at least one item")
+	}
+
+	for _, item := range g.Items {
+		if (item.Clause == nil) == (item.Group == nil) {
+			return errors.New("group item should have either a clause or a group")
+		}
+
+		if item.Group != nil {
+			if err := item.Group.Validate(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		clause := item.Clause
+		if (customString.IsEmpty(clause.Field) && !hasFormula(*clause)) || customString.IsEmpty(clause.Operator) ||
+			customString.IsEmpty(clause.Value) {
+			return errors.New("group clause should have field, operator and value")
+		}
+	}
+
+	return ValidateFormulas(g.Contents())
+}
+
+// Contents flattens the clauses of the tree in order, each clause keeps the condition of its group.
+func (g *RuleGroup) Contents() []RuleContent {
+	contents := make([]RuleContent, 0)
+	for _, item := range g.Items {
+		if item.Group != nil {
+			contents = append(contents, item.Group.Contents()...)
+			continue
+		}
+
+		clause := *item.Clause
+		clause.Condition = strings.ToLower(g.Condition)
+		contents = append(contents, clause)
+	}
+
+	return contents
+}
+
+// IsFlat tells if the tree means the same as its flattened clauses, with a single condition and no negated groups.
+func (g *RuleGroup) IsFlat() bool {
+	return g.isFlat(strings.ToLower(g.Condition))
+}
+
+func (g *RuleGroup) isFlat(condition string) bool {
+	if g.Not || strings.ToLower(g.Condition) != condition {
+		return false
+	}
+
+	for _, item := range g.Items {
+		if item.Group != nil && !item.Group.isFlat(condition) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (g *RuleGroup) RuleAsString() string {
+	expressions := make([]string, 0, len(g.Items))
+	for _, item := range g.Items {
+		if item.Group != nil {
+			expressions = append(expressions, item.Group.subgroupAsString())
+			continue
+		}
+
+		clause := *item.Clause
+		expressions = append(expressions, clause.RuleAsString(true))
+	}
+
+	expression := strings.Join(expressions, fmt.Sprintf(" %s ", strings.ToLower(g.Condition)))
+	if g.Not {
+		return fmt.Sprintf("not (%s)", expression)
+	}
+
+	return expression
+}
+
+func (g *RuleGroup) subgroupAsString() string {
+	if g.Not {
+		return g.RuleAsString()
+	}
+
+	return fmt.Sprintf("(%s)", g.RuleAsString())
+}
+
+// GenerateFormulaFields sets the field of the formula clauses of the tree like GenerateRuleFieldWithFormulaFields.
+func (g *RuleGroup) GenerateFormulaFields() {
+	for _, item := range g.Items {
+		if item.Group != nil {
+			item.Group.GenerateFormulaFields()
+			continue
+		}
+
+		if hasFormula(*item.Clause) {
+			contents := []RuleContent{*item.Clause}
+			GenerateRuleFieldWithFormulaFields(&contents)
+			*item.Clause = contents[0]
+		}
+	}
+}
+
+// Copy returns a deep copy of the tree, so the clauses can be changed without touching the stored rule.
+func (g *RuleGroup) Copy() *RuleGroup {
+	group := &RuleGroup{Condition: g.Condition, Not: g.Not, Items: make([]RuleGroupItem, len(g.Items))}
+	for i, item := range g.Items {
+		if item.Group != nil {
+			group.Items[i].Group = item.Group.Copy()
+			continue
+		}
+
+		clause := *item.Clause
+		group.Items[i].Clause = &clause
+	}
+
+	return group
+}
+
+func (g *RuleGroup) clearFormulaFields() {
+	for _, item := range g.Items {
+		if item.Group != nil {
+			item.Group.clearFormulaFields()
+			continue
+		}
+
+		if hasFormula(*item.Clause) {
+			item.Clause.Field = customString.Empty
+		}
+	}
+}
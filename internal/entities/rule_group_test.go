@@ -0,0 +1,129 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func getRuleGroup() entities.RuleGroup {
+	return entities.RuleGroup{
+		Condition: "OR",
+		Items: []entities.RuleGroupItem{
+			{Group: &entities.RuleGroup{Condition: "and", Items: []entities.RuleGroupItem{
+				{Clause: &entities.RuleContent{Field: "amount", Operator: ">", Value: "100"}},
+				{Clause: &entities.RuleContent{Field: "email", Operator: "==", Value: "fraud@mail.com"}},
+			}}},
+			{Group: &entities.RuleGroup{Condition: "and", Not: true, Items: []entities.RuleGroupItem{
+				{Clause: &entities.RuleContent{Field: "live_mode", Operator: "==", Value: "true"}},
+				{Clause: &entities.RuleContent{Field: "payment_method.country", Operator: "in", Value: `["MX","US"]`}},
+			}}},
+		},
+	}
+}
+
+func TestRuleGroup_RuleAsString(t *testing.T) {
+	t.Run("when the group has subgroups then write them between parentheses", func(t *testing.T) {
+		group := getRuleGroup()
+
+		assert.Equal(t, `(amount > 100 and email == "fraud@mail.com") or `+
+			`not (live_mode == true and payment_method.country in ["MX","US"])`, group.RuleAsString())
+		assert.Equal(t, "fraud@mail.com", group.Items[0].Group.Items[1].Clause.Value)
+	})
+
+	t.Run("when the root group is negated then negate the whole rule", func(t *testing.T) {
+		group := entities.RuleGroup{Condition: "and", Not: true, Items: []entities.RuleGroupItem{
+			{Clause: &entities.RuleContent{Field: "amount", Operator: ">", Value: "100"}},
+		}}
+
+		assert.Equal(t, "not (amount > 100)", group.RuleAsString())
+	})
+}
+
+func TestRuleGroup_Contents(t *testing.T) {
+	group := getRuleGroup()
+
+	contents := group.Contents()
+
+	assert.Len(t, contents, 4)
+	assert.Equal(t, "amount", contents[0].Field)
+	assert.Equal(t, "and", contents[0].Condition)
+	assert.Equal(t, "payment_method.country", contents[3].Field)
+	assert.False(t, group.IsFlat())
+}
+
+func TestRuleGroup_IsFlat(t *testing.T) {
+	group := entities.RuleGroup{Condition: "and", Items: []entities.RuleGroupItem{
+		{Clause: &entities.RuleContent{Field: "amount", Operator: ">", Value: "100"}},
+		{Group: &entities.RuleGroup{Condition: "AND", Items: []entities.RuleGroupItem{
+			{Clause: &entities.RuleContent{Field: "live_mode", Operator: "==", Value: "true"}},
+		}}},
+	}}
+
+	assert.True(t, group.IsFlat())
+}
+
+func TestRuleGroup_Validate(t *testing.T) {
+	clause := &entities.RuleContent{Field: "amount", Operator: ">", Value: "100"}
+	tests := []struct {
+		name  string
+		group entities.RuleGroup
+		err   string
+	}{
+		{
+			name:  "when the condition is not valid",
+			group: entities.RuleGroup{Condition: "xor", Items: []entities.RuleGroupItem{{Clause: clause}}},
+			err:   `group condition should be and or or. Requested condition: "xor"`,
+		},
+		{
+			name:  "when the group is empty",
+			group: entities.RuleGroup{Condition: "and"},
+			err:   "group should have at least one item",
+		},
+		{
+			name: "when an item has a clause and a group",
+			group: entities.RuleGroup{Condition: "and", Items: []entities.RuleGroupItem{
+				{Clause: clause, Group: &entities.RuleGroup{Condition: "or"}},
+			}},
+			err: "group item should have either a clause or a group",
+		},
+		{
+			name: "when a nested clause has no value",
+			group: entities.RuleGroup{Condition: "and", Items: []entities.RuleGroupItem{
+				{Group: &entities.RuleGroup{Condition: "or", Items: []entities.RuleGroupItem{
+					{Clause: &entities.RuleContent{Field: "amount", Operator: ">"}},
+				}}},
+			}},
+			err: "group clause should have field, operator and value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.group.Validate(), tt.err)
+		})
+	}
+
+	t.Run("when the group is valid", func(t *testing.T) {
+		group := getRuleGroup()
+		assert.NoError(t, group.Validate())
+	})
+}
+
+func TestRuleExport_Group(t *testing.T) {
+	fields, operation := []string{"amount", "shipping.amount"}, entities.SUM
+	group := getRuleGroup()
+	group.Items = append(group.Items, entities.RuleGroupItem{Clause: &entities.RuleContent{Field: "SUM (amount,shipping.amount)",
+		Operator: ">", Value: "300", FormulaContent: entities.FormulaContent{Fields: &fields, MathOperation: &operation}}})
+	rule := entities.Rule{IsGlobal: true, Decision: entities.Declined, Group: &group, Rules: group.Contents()}
+
+	export := entities.NewRuleExport(rule, "", "")
+	request := export.ToRuleRequest("me", "", "")
+
+	assert.Nil(t, export.Rules)
+	assert.Empty(t, export.Group.Items[2].Clause.Field)
+	assert.Equal(t, "SUM (amount,shipping.amount)", group.Items[2].Clause.Field)
+	assert.Equal(t, export.Group, request.Group)
+	assert.NoError(t, request.Validate())
+}
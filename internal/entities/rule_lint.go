@@ -0,0 +1,55 @@
+package entities
+
+import customString "github.com/conekta/risk-rules/pkg/strings"
+
+type RuleLintCode string
+
+const (
+	RuleLintContradiction RuleLintCode = "contradiction"
+	RuleLintTautology     RuleLintCode = "tautology"
+	RuleLintEquivalent    RuleLintCode = "equivalent"
+	RuleLintShadowed      RuleLintCode = "shadowed"
+)
+
+type RuleLintWarning struct {
+	Code          RuleLintCode `json:"code"`
+	Message       string       `json:"message"`
+	Clauses       []int        `json:"clauses,omitempty"`
+	RelatedRuleID string       `json:"related_rule_id,omitempty"`
+}
+
+type RuleLintResponse struct {
+	Rule     string            `json:"rule"`
+	Warnings []RuleLintWarning `json:"warnings"`
+}
+
+// ConsoleComponent is the component of the default console that evaluates the rule, empty for the rules
+// evaluated apart from the console order like score and identity module rules.
+func (r *Rule) ConsoleComponent() ConsoleComponent {
+	if r.IsScoreRule || r.IsIdentityModuleRule() {
+		return ""
+	}
+
+	switch {
+	case !customString.IsStringPointerEmpty(r.CompanyID):
+		return CompanyRulesType
+	case !customString.IsStringPointerEmpty(r.FamilyMccID):
+		return FamilyCompanyRulesType
+	case !customString.IsStringPointerEmpty(r.FamilyCompanyID):
+		return FamilyMccRulesType
+	case r.IsGlobal:
+		return GlobalRulesType
+	}
+
+	return ""
+}
+
+func (r *Rule) IsIdentityModuleRule() bool {
+	for _, content := range r.Rules {
+		if customString.Contains(content.Field, IdentityModuleField) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	customString "github.com/conekta/risk-rules/pkg/strings"
+)
+
+const scheduleTimeLayout = "15:04"
+
+var scheduleWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+type RuleSchedule struct {
+	Timezone string           `json:"timezone" bson:"timezone"`
+	Windows  []ScheduleWindow `json:"windows" bson:"windows"`
+}
+
+// ScheduleWindow is a recurring window, when StartTime is after EndTime the window crosses midnight
+// and the hours after midnight belong to the day the window started.
+type ScheduleWindow struct {
+	Days      []string `json:"days" bson:"days"`
+	StartTime string   `json:"start_time" bson:"start_time"`
+	EndTime   string   `json:"end_time" bson:"end_time"`
+}
+
+func (schedule *RuleSchedule) Validate() error {
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+		return fmt.Errorf("schedule timezone [%s] is not a valid timezone", schedule.Timezone)
+	}
+
+	if len(schedule.Windows) == 0 {
+		return errors.New("schedule must have at least one window")
+	}
+
+	for _, window := range schedule.Windows {
+		if err := window.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (schedule *RuleSchedule) IsActiveAt(moment time.Time) bool {
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return false
+	}
+
+	localMoment := moment.In(location)
+	for _, window := range schedule.Windows {
+		if window.contains(localMoment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (window ScheduleWindow) validate() error {
+	for _, day := range window.Days {
+		if _, ok := scheduleWeekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("schedule day [%s] is not a valid day", day)
+		}
+	}
+
+	if customString.IsEmpty(window.StartTime) != customString.IsEmpty(window.EndTime) {
+		return errors.New("schedule window must have both start_time and end_time or none of them")
+	}
+
+	if customString.IsEmpty(window.StartTime) {
+		return nil
+	}
+
+	start, err := time.Parse(scheduleTimeLayout, window.StartTime)
+	if err != nil {
+		return fmt.Errorf("schedule start_time [%s] must have the HH:MM format", window.StartTime)
+	}
+
+	end, err := time.Parse(scheduleTimeLayout, window.EndTime)
+	if err != nil {
+		return fmt.Errorf("schedule end_time [%s] must have the HH:MM format", window.EndTime)
+	}
+
+	if start.Equal(end) {
+		return errors.New("schedule start_time and end_time must be different")
+	}
+
+	return nil
+}
+
+func (window ScheduleWindow) contains(localMoment time.Time) bool {
+	if customString.IsEmpty(window.StartTime) {
+		return window.includesDay(localMoment.Weekday())
+	}
+
+	start, _ := time.Parse(scheduleTimeLayout, window.StartTime)
+	end, _ := time.Parse(scheduleTimeLayout, window.EndTime)
+	minute := localMoment.Hour()*60 + localMoment.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute < endMinute {
+		return minute >= startMinute && minute < endMinute && window.includesDay(localMoment.Weekday())
+	}
+
+	if minute >= startMinute {
+		return window.includesDay(localMoment.Weekday())
+	}
+
+	return minute < endMinute && window.includesDay(localMoment.AddDate(0, 0, -1).Weekday())
+}
+
+func (window ScheduleWindow) includesDay(weekday time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+
+	for _, day := range window.Days {
+		if scheduleWeekdays[strings.ToLower(day)] == weekday {
+			return true
+		}
+	}
+
+	return false
+}
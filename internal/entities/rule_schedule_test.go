@@ -0,0 +1,79 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSchedule_Validate(t *testing.T) {
+	t.Run("when timezone is invalid, then return error", func(t *testing.T) {
+		schedule := entities.RuleSchedule{Timezone: "Mars/Olympus", Windows: []entities.ScheduleWindow{{}}}
+		assert.Error(t, schedule.Validate())
+	})
+
+	t.Run("when day is invalid, then return error", func(t *testing.T) {
+		schedule := entities.RuleSchedule{Timezone: "America/Mexico_City",
+			Windows: []entities.ScheduleWindow{{Days: []string{"funday"}}}}
+		assert.Error(t, schedule.Validate())
+	})
+
+	t.Run("when time has not HH:MM format, then return error", func(t *testing.T) {
+		schedule := entities.RuleSchedule{Timezone: "America/Mexico_City",
+			Windows: []entities.ScheduleWindow{{StartTime: "9am", EndTime: "18:00"}}}
+		assert.Error(t, schedule.Validate())
+	})
+
+	t.Run("when schedule is valid, then return nil", func(t *testing.T) {
+		schedule := entities.RuleSchedule{Timezone: "America/Mexico_City",
+			Windows: []entities.ScheduleWindow{{Days: []string{"Friday"}, StartTime: "22:00", EndTime: "06:00"}}}
+		assert.Nil(t, schedule.Validate())
+	})
+}
+
+func TestRule_IsActiveAt(t *testing.T) {
+	location, _ := time.LoadLocation("America/Mexico_City")
+	fridayNight := time.Date(2022, time.November, 18, 23, 0, 0, 0, location)
+	saturdayMorning := time.Date(2022, time.November, 19, 5, 0, 0, 0, location)
+	saturdayNoon := time.Date(2022, time.November, 19, 12, 0, 0, 0, location)
+
+	t.Run("when rule has no activation, then it is always active", func(t *testing.T) {
+		rule := testdata.GetDefaultRuleWithID(false)
+		assert.True(t, rule.IsActiveAt(fridayNight))
+	})
+
+	t.Run("when moment is outside activation dates, then rule is inactive", func(t *testing.T) {
+		rule := testdata.GetDefaultRuleWithID(false)
+		from := saturdayMorning
+		until := saturdayNoon
+		rule.ActiveFrom = &from
+		rule.ActiveUntil = &until
+
+		assert.False(t, rule.IsActiveAt(fridayNight))
+		assert.True(t, rule.IsActiveAt(saturdayMorning))
+		assert.False(t, rule.IsActiveAt(saturdayNoon))
+	})
+
+	t.Run("when window crosses midnight, then it belongs to the starting day", func(t *testing.T) {
+		rule := testdata.GetDefaultRuleWithID(false)
+		rule.Schedule = &entities.RuleSchedule{Timezone: "America/Mexico_City",
+			Windows: []entities.ScheduleWindow{{Days: []string{"friday"}, StartTime: "22:00", EndTime: "06:00"}}}
+
+		assert.True(t, rule.IsActiveAt(fridayNight.UTC()))
+		assert.True(t, rule.IsActiveAt(saturdayMorning.UTC()))
+		assert.False(t, rule.IsActiveAt(saturdayNoon.UTC()))
+	})
+}
+
+func TestRuleRequest_ValidateActivation(t *testing.T) {
+	request := testdata.GetDefaultRuleRequestWithAmount()
+	from := time.Now().UTC()
+	until := from.Add(-time.Hour)
+	request.ActiveFrom = &from
+	request.ActiveUntil = &until
+
+	assert.EqualError(t, request.Validate(), "active_until must be after active_from")
+}
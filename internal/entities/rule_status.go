@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	customString "github.com/conekta/risk-rules/pkg/strings"
+)
+
+type RuleStatus string
+
+const (
+	RuleStatusDraft           RuleStatus = "draft"
+	RuleStatusPendingApproval RuleStatus = "pending_approval"
+	RuleStatusActive          RuleStatus = "active"
+	RuleStatusRetired         RuleStatus = "retired"
+)
+
+var ruleStatusValues = map[RuleStatus]bool{
+	RuleStatusDraft:           true,
+	RuleStatusPendingApproval: true,
+	RuleStatusActive:          true,
+	RuleStatusRetired:         true,
+}
+
+// ruleTransitions holds the status a rule must have before each review action and the status it gets after it.
+var ruleTransitions = map[RuleAction][2]RuleStatus{
+	RuleSubmitted: {RuleStatusDraft, RuleStatusPendingApproval},
+	RuleApproved:  {RuleStatusPendingApproval, RuleStatusActive},
+	RuleRejected:  {RuleStatusPendingApproval, RuleStatusDraft},
+	RuleRetired:   {RuleStatusActive, RuleStatusRetired},
+}
+
+type RuleReview struct {
+	SubmittedBy string     `json:"submitted_by,omitempty" bson:"submitted_by,omitempty"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty" bson:"submitted_at,omitempty"`
+	ReviewedBy  string     `json:"reviewed_by,omitempty" bson:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty" bson:"reviewed_at,omitempty"`
+	Comment     string     `json:"comment,omitempty" bson:"comment,omitempty"`
+}
+
+type RuleReviewRequest struct {
+	Author  string `json:"author" validate:"required"`
+	Comment string `json:"comment"`
+}
+
+// GetStatus treats the rules stored before the approval workflow existed as active.
+func (r *Rule) GetStatus() RuleStatus {
+	if customString.IsEmpty(string(r.Status)) {
+		return RuleStatusActive
+	}
+
+	return r.Status
+}
+
+// IsEvaluable reports whether the rule is loaded for charge evaluation, drafts are evaluated as test rules.
+func (r *Rule) IsEvaluable() bool {
+	status := r.GetStatus()
+	return status == RuleStatusActive || status == RuleStatusDraft
+}
+
+func (r *Rule) GetAuthor() string {
+	if !customString.IsStringPointerEmpty(r.UpdatedBy) {
+		return *r.UpdatedBy
+	}
+
+	return r.CreatedBy
+}
+
+// Edit returns the rule to store for the edited content: an active rule keeps deciding with its approved content and
+// holds the edit as a pending revision until it is approved, any other rule is replaced by the edit as a draft.
+func (r *Rule) Edit(edited Rule) Rule {
+	edited.Status = RuleStatusDraft
+	edited.Review = nil
+	edited.Pending = nil
+	if r.GetStatus() != RuleStatusActive {
+		return edited
+	}
+
+	active := *r
+	active.Revision = edited.Revision
+	active.Pending = &edited
+	return active
+}
+
+// Transition moves the rule through its lifecycle, the approval must come from someone other than
+// the author of the current content. The review of a rule with a pending revision reviews the pending revision, which
+// replaces the active content once approved.
+func (r *Rule) Transition(action RuleAction, review RuleReviewRequest, now time.Time) error {
+	transition, ok := ruleTransitions[action]
+	if !ok {
+		return fmt.Errorf("action [%s] is not a review action", action)
+	}
+
+	if r.Pending != nil && action != RuleRetired {
+		pending := *r.Pending
+		if err := pending.Transition(action, review, now); err != nil {
+			return fmt.Errorf("pending revision: %w", err)
+		}
+		if action == RuleApproved {
+			*r = pending
+			return nil
+		}
+		r.Pending = &pending
+		return nil
+	}
+
+	if r.GetStatus() != transition[0] {
+		return fmt.Errorf("rule must be %s to be %s, current status is %s", transition[0], action, r.GetStatus())
+	}
+
+	switch action {
+	case RuleSubmitted:
+		r.Review = &RuleReview{SubmittedBy: review.Author, SubmittedAt: &now, Comment: review.Comment}
+	case RuleApproved, RuleRejected:
+		if action == RuleApproved && strings.EqualFold(review.Author, r.GetAuthor()) {
+			return errors.New("rule must be approved by someone other than its author")
+		}
+		if r.Review == nil {
+			r.Review = &RuleReview{}
+		}
+		r.Review.ReviewedBy = review.Author
+		r.Review.ReviewedAt = &now
+		r.Review.Comment = review.Comment
+	}
+
+	r.Status = transition[1]
+	return nil
+}
+
+func (s *RuleFilter) IsStatusValid() bool {
+	return customString.IsEmpty(s.Status) || ruleStatusValues[RuleStatus(s.Status)]
+}
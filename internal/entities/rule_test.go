@@ -131,3 +131,18 @@ func TestRule_SetRuleResponse(t *testing.T) {
 			}
 		})
 }
+
+func TestNewRuleDiff(t *testing.T) {
+	before := testdata.GetDefaultRuleWithID(false)
+	after := before
+	after.Decision = entities.Accepted
+	after.Description = "updated"
+	after.Revision = before.Revision + 1
+
+	diff := entities.NewRuleDiff(before, after)
+
+	assert.Equal(t, []entities.RuleChange{
+		{Field: "decision", Before: "D", After: "A"},
+		{Field: "description", Before: "empty", After: "updated"},
+	}, diff)
+}
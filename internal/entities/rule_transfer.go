@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	customString "github.com/conekta/risk-rules/pkg/strings"
+)
+
+const (
+	TransferFormatJSON = "json"
+	TransferFormatYAML = "yaml"
+)
+
+type RuleImportStatus string
+
+const (
+	RuleImportCreate   RuleImportStatus = "create"
+	RuleImportUpdate   RuleImportStatus = "update"
+	RuleImportConflict RuleImportStatus = "conflict"
+)
+
+// RuleExport is the portable form of a rule, families and family companies are referenced by name
+// because their ids differ between environments.
+type RuleExport struct {
+	ID            string        `json:"id"`
+	Module        string        `json:"module" validate:"required"`
+	Description   string        `json:"description" validate:"required"`
+	Decision      Decision      `json:"decision" validate:"required"`
+	IsTest        *bool         `json:"is_test" validate:"required"`
+	IsGlobal      *bool         `json:"is_global" validate:"required"`
+	CompanyID     string        `json:"company_id,omitempty"`
+	Family        string        `json:"family,omitempty"`
+	FamilyCompany string        `json:"family_company,omitempty"`
+	Rules         []RuleContent `json:"rules,omitempty" validate:"required_without=Group,omitempty,gt=0,dive,required"`
+	Group         *RuleGroup    `json:"group,omitempty" validate:"-"`
+	IsYellowFlag  bool          `json:"is_yellow_flag"`
+	IsScoreRule   bool          `json:"is_score_rule"`
+	Weight        *float64      `json:"weight,omitempty"`
+	ActiveFrom    *time.Time    `json:"active_from,omitempty"`
+	ActiveUntil   *time.Time    `json:"active_until,omitempty"`
+	Schedule      *RuleSchedule `json:"schedule,omitempty"`
+}
+
+type RuleImportItem struct {
+	Index  int              `json:"index"`
+	ID     string           `json:"id"`
+	Rule   string           `json:"rule"`
+	Status RuleImportStatus `json:"status"`
+	Reason string           `json:"reason,omitempty"`
+}
+
+type RuleImportReport struct {
+	DryRun    bool             `json:"dry_run"`
+	Applied   bool             `json:"applied"`
+	Creates   []RuleImportItem `json:"creates"`
+	Updates   []RuleImportItem `json:"updates"`
+	Conflicts []RuleImportItem `json:"conflicts"`
+}
+
+func NewRuleExport(rule Rule, familyName, familyCompanyName string) RuleExport {
+	var contents []RuleContent
+	var group *RuleGroup
+	if rule.Group != nil {
+		group = rule.Group.Copy()
+		group.clearFormulaFields()
+	} else {
+		contents = make([]RuleContent, len(rule.Rules))
+		copy(contents, rule.Rules)
+		for i := range contents {
+			if hasFormula(contents[i]) {
+				contents[i].Field = customString.Empty
+			}
+		}
+	}
+
+	isTest, isGlobal := rule.IsTest, rule.IsGlobal
+	return RuleExport{
+		ID:            rule.ID.Hex(),
+		Module:        rule.Module,
+		Description:   rule.Description,
+		Decision:      rule.Decision,
+		IsTest:        &isTest,
+		IsGlobal:      &isGlobal,
+		CompanyID:     customString.StringPointerToString(rule.CompanyID),
+		Family:        familyName,
+		FamilyCompany: familyCompanyName,
+		Rules:         contents,
+		Group:         group,
+		IsYellowFlag:  rule.IsYellowFlag,
+		IsScoreRule:   rule.IsScoreRule,
+		Weight:        rule.Weight,
+		ActiveFrom:    rule.ActiveFrom,
+		ActiveUntil:   rule.ActiveUntil,
+		Schedule:      rule.Schedule,
+	}
+}
+
+// ToRuleRequest builds the request of the exported rule with the ids the family names have in this environment.
+func (export RuleExport) ToRuleRequest(author, familyID, familyCompanyID string) RuleRequest {
+	var contents []RuleContent
+	var group *RuleGroup
+	if export.Group != nil {
+		group = export.Group.Copy()
+	} else {
+		contents = make([]RuleContent, len(export.Rules))
+		copy(contents, export.Rules)
+	}
+
+	return RuleRequest{
+		Decision:        export.Decision,
+		IsTest:          export.IsTest,
+		Module:          export.Module,
+		IsGlobal:        export.IsGlobal,
+		Description:     export.Description,
+		CompanyID:       export.CompanyID,
+		FamilyID:        familyID,
+		FamilyCompanyID: familyCompanyID,
+		Rules:           contents,
+		Group:           group,
+		Author:          author,
+		IsYellowFlag:    export.IsYellowFlag,
+		IsScoreRule:     export.IsScoreRule,
+		Weight:          export.Weight,
+		ActiveFrom:      export.ActiveFrom,
+		ActiveUntil:     export.ActiveUntil,
+		Schedule:        export.Schedule,
+	}
+}
+
+func NewRuleImportReport(dryRun bool) RuleImportReport {
+	return RuleImportReport{
+		DryRun:    dryRun,
+		Creates:   make([]RuleImportItem, 0),
+		Updates:   make([]RuleImportItem, 0),
+		Conflicts: make([]RuleImportItem, 0),
+	}
+}
+
+func (report *RuleImportReport) Add(item RuleImportItem) {
+	switch item.Status {
+	case RuleImportCreate:
+		report.Creates = append(report.Creates, item)
+	case RuleImportUpdate:
+		report.Updates = append(report.Updates, item)
+	default:
+		report.Conflicts = append(report.Conflicts, item)
+	}
+}
+
+func (report *RuleImportReport) HasConflicts() bool {
+	return len(report.Conflicts) > 0
+}
+
+func ValidateTransferFormat(format string) error {
+	switch strings.ToLower(format) {
+	case customString.Empty, TransferFormatJSON, TransferFormatYAML:
+		return nil
+	}
+
+	return fmt.Errorf("format [%s] is not a valid value", format)
+}
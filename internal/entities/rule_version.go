@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const FirstRuleRevision int64 = 1
+
+type RuleAction string
+
+const (
+	RuleCreated    RuleAction = "created"
+	RuleUpdated    RuleAction = "updated"
+	RuleDeleted    RuleAction = "deleted"
+	RuleRolledBack RuleAction = "rolled_back"
+	RuleSubmitted  RuleAction = "submitted"
+	RuleApproved   RuleAction = "approved"
+	RuleRejected   RuleAction = "rejected"
+	RuleRetired    RuleAction = "retired"
+)
+
+var ruleDiffIgnoredFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"created_by": true,
+	"updated_at": true,
+	"updated_by": true,
+	"revision":   true,
+}
+
+type RuleVersion struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	RuleID         primitive.ObjectID `json:"rule_id" bson:"rule_id"`
+	Revision       int64              `json:"revision" bson:"revision"`
+	Action         RuleAction         `json:"action" bson:"action"`
+	Author         string             `json:"author" bson:"author"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	Snapshot       Rule               `json:"snapshot" bson:"snapshot"`
+	Diff           []RuleChange       `json:"diff" bson:"diff"`
+	RolledBackFrom *int64             `json:"rolled_back_from,omitempty" bson:"rolled_back_from,omitempty"`
+}
+
+type RuleChange struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+type RuleRollbackRequest struct {
+	Author string `json:"author" validate:"required"`
+}
+
+func NewRuleVersion(action RuleAction, author string, previous *Rule, current Rule) RuleVersion {
+	version := RuleVersion{
+		RuleID:    current.ID,
+		Revision:  current.Revision,
+		Action:    action,
+		Author:    author,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+		Snapshot:  current,
+		Diff:      make([]RuleChange, 0),
+	}
+
+	if previous != nil {
+		version.Diff = NewRuleDiff(*previous, current)
+	}
+
+	return version
+}
+
+func NewRuleDiff(before, after Rule) []RuleChange {
+	beforeFields := ruleToMap(before)
+	afterFields := ruleToMap(after)
+
+	fields := make([]string, 0, len(afterFields))
+	for field := range beforeFields {
+		fields = append(fields, field)
+	}
+	for field := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]RuleChange, 0)
+	for _, field := range fields {
+		if ruleDiffIgnoredFields[field] || reflect.DeepEqual(beforeFields[field], afterFields[field]) {
+			continue
+		}
+		changes = append(changes, RuleChange{Field: field, Before: beforeFields[field], After: afterFields[field]})
+	}
+
+	return changes
+}
+
+func ruleToMap(rule Rule) map[string]interface{} {
+	fields := make(map[string]interface{})
+	content, err := json.Marshal(rule)
+	if err != nil {
+		return fields
+	}
+
+	_ = json.Unmarshal(content, &fields)
+	return fields
+}
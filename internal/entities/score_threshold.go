@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	customString "github.com/conekta/risk-rules/pkg/strings"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ScoreThreshold struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	CompanyID    *string            `json:"company_id" bson:"company_id"`
+	FamilyID     *string            `json:"family_id" bson:"family_id"`
+	IsGlobal     bool               `json:"is_global" bson:"is_global"`
+	DeclineScore *float64           `json:"decline_score" bson:"decline_score"`
+	AcceptScore  *float64           `json:"accept_score" bson:"accept_score"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	CreatedBy    string             `json:"created_by" bson:"created_by"`
+	UpdatedAt    *time.Time         `json:"updated_at" bson:"updated_at"`
+	UpdatedBy    *string            `json:"updated_by" bson:"updated_by"`
+}
+
+type ScoreThresholdRequest struct {
+	CompanyID    string   `json:"company_id"`
+	FamilyID     string   `json:"family_id"`
+	IsGlobal     *bool    `json:"is_global" validate:"required"`
+	DeclineScore *float64 `json:"decline_score"`
+	AcceptScore  *float64 `json:"accept_score"`
+	Author       string   `json:"author" validate:"required"`
+}
+
+type ScoreThresholdFilter struct {
+	ID        string `query:"id"`
+	CompanyID string `query:"company_id"`
+	FamilyID  string `query:"family_id"`
+	IsGlobal  bool   `query:"is_global"`
+}
+
+func (request *ScoreThresholdRequest) Validate() error {
+	hasCompany := !customString.IsEmpty(request.CompanyID)
+	hasFamily := !customString.IsEmpty(request.FamilyID)
+
+	if *request.IsGlobal && (hasCompany || hasFamily) {
+		return errors.New("company_id and family_id should not be passed, the threshold is configured as Global")
+	}
+	if !*request.IsGlobal && hasCompany == hasFamily {
+		return errors.New("non global threshold, one option: [company_id - family_id] have to be passed")
+	}
+
+	if request.DeclineScore == nil && request.AcceptScore == nil {
+		return errors.New("at least one of decline_score or accept_score have to be passed")
+	}
+	if request.DeclineScore != nil && request.AcceptScore != nil && *request.AcceptScore >= *request.DeclineScore {
+		return errors.New("accept_score must be lower than decline_score")
+	}
+
+	return nil
+}
+
+func (request *ScoreThresholdRequest) NewScoreThresholdFromPostRequest() ScoreThreshold {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	return ScoreThreshold{
+		CompanyID:    customString.StringToStringPointer(request.CompanyID),
+		FamilyID:     customString.StringToStringPointer(request.FamilyID),
+		IsGlobal:     *request.IsGlobal,
+		DeclineScore: request.DeclineScore,
+		AcceptScore:  request.AcceptScore,
+		CreatedAt:    now,
+		CreatedBy:    request.Author,
+	}
+}
+
+func (request *ScoreThresholdRequest) NewScoreThresholdFromPutRequest() ScoreThreshold {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	return ScoreThreshold{
+		CompanyID:    customString.StringToStringPointer(request.CompanyID),
+		FamilyID:     customString.StringToStringPointer(request.FamilyID),
+		IsGlobal:     *request.IsGlobal,
+		DeclineScore: request.DeclineScore,
+		AcceptScore:  request.AcceptScore,
+		UpdatedAt:    &now,
+		UpdatedBy:    &request.Author,
+	}
+}
+
+func (threshold *ScoreThreshold) GetScoreThresholdFilter() ScoreThresholdFilter {
+	filter := ScoreThresholdFilter{IsGlobal: threshold.IsGlobal}
+	if !customString.IsStringPointerEmpty(threshold.CompanyID) {
+		filter.CompanyID = *threshold.CompanyID
+	}
+	if !customString.IsStringPointerEmpty(threshold.FamilyID) {
+		filter.FamilyID = *threshold.FamilyID
+	}
+
+	return filter
+}
+
+func (threshold *ScoreThreshold) IsEmpty() bool {
+	return threshold.DeclineScore == nil && threshold.AcceptScore == nil
+}
+
+func (threshold *ScoreThreshold) IsTheSame(id string) bool {
+	ID, _ := primitive.ObjectIDFromHex(id)
+	return threshold.ID == ID
+}
+
+func (threshold *ScoreThreshold) GetScope() string {
+	switch {
+	case !customString.IsStringPointerEmpty(threshold.CompanyID):
+		return "company"
+	case !customString.IsStringPointerEmpty(threshold.FamilyID):
+		return "family"
+	case threshold.IsGlobal:
+		return "global"
+	}
+
+	return customString.Empty
+}
+
+func (threshold *ScoreThreshold) Decide(score float64) Decision {
+	if threshold.DeclineScore != nil && score >= *threshold.DeclineScore {
+		return Declined
+	}
+	if threshold.AcceptScore != nil && score <= *threshold.AcceptScore {
+		return Accepted
+	}
+
+	return Undecided
+}
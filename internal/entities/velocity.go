@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	VelocityEmail             = "email"
+	VelocityCardHash          = "card_hash"
+	VelocityBin               = "bin"
+	VelocityDeviceFingerprint = "device_fingerprint"
+	VelocityIP                = "ip"
+	VelocityPhone             = "phone"
+)
+
+// AggregationWindows are the durations of the windows of AggregationEvent, in the same order as Windows.
+var AggregationWindows = []time.Duration{
+	time.Minute, 5 * time.Minute, time.Hour, 2 * time.Hour, 3 * time.Hour, 6 * time.Hour, 9 * time.Hour,
+	12 * time.Hour, 24 * time.Hour, 2 * 24 * time.Hour, 3 * 24 * time.Hour, 4 * 24 * time.Hour,
+	7 * 24 * time.Hour, 15 * 24 * time.Hour, 30 * 24 * time.Hour, 60 * 24 * time.Hour, 120 * 24 * time.Hour,
+}
+
+// Velocity holds the counters kept by the service for the charge, rules read them like velocity.card_hash.h1.count.
+type Velocity struct {
+	Email             AggregationEvent `json:"email" mapstructure:"email" bson:"email"`
+	CardHash          AggregationEvent `json:"card_hash" mapstructure:"card_hash" bson:"card_hash"`
+	Bin               AggregationEvent `json:"bin" mapstructure:"bin" bson:"bin"`
+	DeviceFingerprint AggregationEvent `json:"device_fingerprint" mapstructure:"device_fingerprint" bson:"device_fingerprint"`
+	IP                AggregationEvent `json:"ip" mapstructure:"ip" bson:"ip"`
+	Phone             AggregationEvent `json:"phone" mapstructure:"phone" bson:"phone"`
+}
+
+type VelocityKey struct {
+	Dimension string
+	Value     string
+}
+
+// VelocityBucket counts the charges of a key that started in [Start, Start + resolution).
+type VelocityBucket struct {
+	Key        string    `bson:"key"`
+	Dimension  string    `bson:"dimension"`
+	Resolution string    `bson:"resolution"`
+	Start      time.Time `bson:"start"`
+	Count      int       `bson:"count"`
+	Sum        float64   `bson:"sum"`
+	Expires    time.Time `bson:"expires"`
+}
+
+// Hash identifies the key in the counters without storing emails, phones or IPs.
+func (key VelocityKey) Hash() string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s:%s", key.Dimension, key.Value)))
+	return hex.EncodeToString(hash[:])
+}
+
+// VelocityKeys returns the keys the charge is counted by, the empty ones are skipped.
+func (c *ChargeRequest) VelocityKeys() []VelocityKey {
+	values := []VelocityKey{
+		{Dimension: VelocityEmail, Value: strings.ToLower(c.Details.Email)},
+		{Dimension: VelocityCardHash, Value: c.PaymentMethod.CardHash},
+		{Dimension: VelocityBin, Value: c.PaymentMethod.BinNumber},
+		{Dimension: VelocityDeviceFingerprint, Value: c.DeviceFingerprint},
+		{Dimension: VelocityIP, Value: c.Details.IPAddress},
+		{Dimension: VelocityPhone, Value: c.Details.Phone},
+	}
+
+	keys := make([]VelocityKey, 0, len(values))
+	for _, key := range values {
+		key.Value = strings.TrimSpace(key.Value)
+		if key.Value != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func (v *Velocity) Dimension(dimension string) *AggregationEvent {
+	switch dimension {
+	case VelocityEmail:
+		return &v.Email
+	case VelocityCardHash:
+		return &v.CardHash
+	case VelocityBin:
+		return &v.Bin
+	case VelocityDeviceFingerprint:
+		return &v.DeviceFingerprint
+	case VelocityIP:
+		return &v.IP
+	case VelocityPhone:
+		return &v.Phone
+	}
+
+	return nil
+}
+
+// OverrideWith takes the windows sent by the caller in the aggregation, the payer is counted by email.
+func (v *Velocity) OverrideWith(aggregation Aggregation) {
+	v.Email.OverrideWith(aggregation.PayerAggregation.Charge)
+	v.CardHash.OverrideWith(aggregation.CardHash.Charge)
+	v.Bin.OverrideWith(aggregation.BinNumber.Charge)
+}
+
+func (e *AggregationEvent) Windows() []*AggregationEventProperties {
+	return []*AggregationEventProperties{
+		&e.M1, &e.M5, &e.H1, &e.H2, &e.H3, &e.H6, &e.H9, &e.H12, &e.D1, &e.D2, &e.D3, &e.D4, &e.D7, &e.D15,
+		&e.D30, &e.D60, &e.D120,
+	}
+}
+
+// OverrideWith replaces the windows that are not empty in the given event.
+func (e *AggregationEvent) OverrideWith(event AggregationEvent) {
+	windows := e.Windows()
+	for i, window := range event.Windows() {
+		if window.Count != 0 || window.Sum != 0 {
+			*windows[i] = *window
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+type BreakerState string
+
+// circuitBreaker opens after a number of consecutive failures and lets a single probe through once the open time is
+// over, the probe closes it again or keeps it open for another period.
+type circuitBreaker struct {
+	mutex            sync.Mutex
+	state            BreakerState
+	failures         int
+	failureThreshold int
+	openDuration     time.Duration
+	openedAt         time.Time
+	probing          bool
+	now              func() time.Time
+}
+
+func newCircuitBreaker(failureThreshold int, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		state:            BreakerClosed,
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		now:              time.Now,
+	}
+}
+
+// allow tells if a call can be made, the state of the breaker and if asking changed it.
+func (breaker *circuitBreaker) allow() (bool, BreakerState, bool) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	changed := false
+	if breaker.state == BreakerOpen && breaker.now().Sub(breaker.openedAt) >= breaker.openDuration {
+		breaker.state = BreakerHalfOpen
+		breaker.probing = false
+		changed = true
+	}
+
+	switch breaker.state {
+	case BreakerOpen:
+		return false, breaker.state, changed
+	case BreakerHalfOpen:
+		if breaker.probing {
+			return false, breaker.state, changed
+		}
+		breaker.probing = true
+	}
+
+	return true, breaker.state, changed
+}
+
+// record saves the result of a call and returns the state of the breaker and if it changed.
+func (breaker *circuitBreaker) record(success bool) (BreakerState, bool) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	previous := breaker.state
+	if success {
+		breaker.failures = 0
+		breaker.state = BreakerClosed
+	} else {
+		breaker.failures++
+		if breaker.state == BreakerHalfOpen ||
+			(breaker.failureThreshold > 0 && breaker.failures >= breaker.failureThreshold) {
+			breaker.state = BreakerOpen
+			breaker.openedAt = breaker.now()
+		}
+	}
+	breaker.probing = false
+
+	return breaker.state, previous != breaker.state
+}
+
+// release frees the probe of a call that ended without a result for the breaker.
+func (breaker *circuitBreaker) release() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.probing = false
+}
@@ -0,0 +1,255 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/conekta/go_common/datadog"
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/pkg/text"
+	"github.com/go-resty/resty/v2"
+)
+
+const clientName = "httpclient.%s"
+
+var (
+	ErrCircuitOpen  = errors.New("circuit breaker is open")
+	ErrBulkheadFull = errors.New("too many concurrent requests")
+)
+
+var breakerStateValues = map[BreakerState]float64{
+	BreakerClosed:   0,
+	BreakerHalfOpen: 1,
+	BreakerOpen:     2,
+}
+
+// Call is a request to a host, Send sets up and sends the request of every attempt.
+type Call struct {
+	Host       string
+	Idempotent bool
+	Timeout    time.Duration
+	Send       func(request *resty.Request) (*resty.Response, error)
+}
+
+type Client interface {
+	Execute(ctx context.Context, call Call) (*resty.Response, error)
+}
+
+type hostGuard struct {
+	breaker  *circuitBreaker
+	bulkhead chan struct{}
+}
+
+type client struct {
+	config  config.Config
+	resty   *resty.Client
+	log     logs.Logger
+	datadog datadog.Metricer
+	mutex   sync.Mutex
+	hosts   map[string]*hostGuard
+}
+
+// NewClient builds the client shared by every outbound service, its connections are pooled by host.
+func NewClient(cfg config.Config, logger logs.Logger, metric datadog.Metricer) Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = cfg.HTTPClient.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = time.Duration(cfg.HTTPClient.IdleConnTimeoutSeconds) * time.Second
+
+	return &client{
+		config:  cfg,
+		resty:   resty.NewWithClient(&http.Client{Transport: transport}),
+		log:     logger,
+		datadog: metric,
+		hosts:   make(map[string]*hostGuard),
+	}
+}
+
+// Execute sends the call through the bulkhead and the circuit breaker of its host, idempotent calls are retried with
+// exponential backoff and full jitter while the host fails or throttles.
+func (c *client) Execute(ctx context.Context, call Call) (*resty.Response, error) {
+	host := hostName(call.Host)
+	guard := c.guard(host)
+
+	attempts := 1
+	if call.Idempotent && c.config.HTTPClient.MaxRetries > 0 {
+		attempts += c.config.HTTPClient.MaxRetries
+	}
+
+	var resp *resty.Response
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 && !c.wait(ctx, attempt) {
+			break
+		}
+
+		resp, err = c.send(ctx, host, guard, call)
+		if errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrBulkheadFull) || !isRetryable(resp, err) {
+			break
+		}
+	}
+
+	return resp, err
+}
+
+func (c *client) send(ctx context.Context, host string, guard *hostGuard, call Call) (*resty.Response, error) {
+	if guard.bulkhead != nil {
+		select {
+		case guard.bulkhead <- struct{}{}:
+			defer func() { <-guard.bulkhead }()
+		default:
+			c.sendErrorMetric(ctx, host, "bulkhead_full")
+			return nil, ErrBulkheadFull
+		}
+	}
+
+	allowed, state, changed := guard.breaker.allow()
+	if changed {
+		c.sendBreakerMetric(ctx, host, state)
+	}
+	if !allowed {
+		c.sendErrorMetric(ctx, host, "circuit_open")
+		return nil, ErrCircuitOpen
+	}
+
+	attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+	if call.Timeout > 0 {
+		attemptCtx, cancel = context.WithTimeout(ctx, call.Timeout)
+	}
+	defer cancel()
+
+	start := time.Now()
+	resp, err := call.Send(c.resty.R().SetContext(attemptCtx))
+	c.sendLatencyMetric(ctx, host, resp, time.Since(start))
+
+	// a call the caller gave up on says nothing about the host
+	if ctx.Err() != nil {
+		guard.breaker.release()
+		return resp, err
+	}
+
+	failed := isFailure(resp, err)
+	state, changed = guard.breaker.record(!failed)
+	if changed {
+		c.sendBreakerMetric(ctx, host, state)
+	}
+	if failed {
+		c.sendErrorMetric(ctx, host, failureReason(err))
+	}
+
+	return resp, err
+}
+
+func (c *client) guard(host string) *hostGuard {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	guard, ok := c.hosts[host]
+	if !ok {
+		guard = &hostGuard{breaker: newCircuitBreaker(c.config.HTTPClient.BreakerFailureThreshold,
+			time.Duration(c.config.HTTPClient.BreakerOpenMilliseconds)*time.Millisecond)}
+		if c.config.HTTPClient.MaxConcurrentRequestsByHost > 0 {
+			guard.bulkhead = make(chan struct{}, c.config.HTTPClient.MaxConcurrentRequestsByHost)
+		}
+		c.hosts[host] = guard
+	}
+
+	return guard
+}
+
+// wait sleeps a random time up to the exponential backoff of the attempt, it is false when the context is done first.
+func (c *client) wait(ctx context.Context, attempt int) bool {
+	backoff := time.Duration(c.config.HTTPClient.RetryBaseMilliseconds) * time.Millisecond << (attempt - 1)
+	maxBackoff := time.Duration(c.config.HTTPClient.RetryMaxMilliseconds) * time.Millisecond
+	if maxBackoff > 0 && (backoff > maxBackoff || backoff <= 0) {
+		backoff = maxBackoff
+	}
+
+	var delay time.Duration
+	if backoff > 0 {
+		delay = time.Duration(rand.Int63n(int64(backoff) + 1))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (c *client) sendLatencyMetric(ctx context.Context, host string, resp *resty.Response, latency time.Duration) {
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode()
+	}
+
+	c.sendAsync(ctx, func(tags []string) error {
+		return c.datadog.Histogram(ctx, text.HTTPClientLatencyMetricName, float64(latency.Milliseconds()),
+			append(tags, fmt.Sprintf(text.MetricTagStatusCode, statusCode)), 1)
+	}, host)
+}
+
+func (c *client) sendErrorMetric(ctx context.Context, host, reason string) {
+	c.sendAsync(ctx, func(tags []string) error {
+		return c.datadog.Incr(ctx, text.HTTPClientErrorMetricName,
+			append(tags, fmt.Sprintf(text.MetricTagErrorReason, reason)), 1)
+	}, host)
+}
+
+func (c *client) sendBreakerMetric(ctx context.Context, host string, state BreakerState) {
+	c.log.Info(ctx, fmt.Sprintf("circuit breaker of %s is %s", host, state), text.LogTagMethod,
+		fmt.Sprintf(clientName, "Execute"))
+	c.sendAsync(ctx, func(tags []string) error {
+		return c.datadog.Gauge(ctx, text.HTTPClientBreakerMetricName, breakerStateValues[state],
+			append(tags, fmt.Sprintf(text.MetricTagBreakerState, state)), 1)
+	}, host)
+}
+
+func (c *client) sendAsync(ctx context.Context, send func(tags []string) error, host string) {
+	go func() {
+		tags := []string{fmt.Sprintf(text.MetricTagHost, host), fmt.Sprintf(text.MetricTagScope, c.config.Env)}
+		if err := send(tags); err != nil {
+			c.log.Error(ctx, err.Error(), text.LogTagMethod, fmt.Sprintf(clientName, "Execute"))
+		}
+	}()
+}
+
+func isFailure(resp *resty.Response, err error) bool {
+	return err != nil || resp == nil || resp.StatusCode() >= http.StatusInternalServerError
+}
+
+func isRetryable(resp *resty.Response, err error) bool {
+	return isFailure(resp, err) || resp.StatusCode() == http.StatusTooManyRequests
+}
+
+func failureReason(err error) string {
+	switch {
+	case err == nil:
+		return "server_error"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+
+	return "transport"
+}
+
+func hostName(host string) string {
+	parsed, err := url.Parse(host)
+	if err != nil || parsed.Host == "" {
+		return host
+	}
+
+	return parsed.Host
+}
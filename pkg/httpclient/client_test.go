@@ -0,0 +1,218 @@
+package httpclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/pkg/httpclient"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Execute(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("idempotent calls are retried until the host answers", func(t *testing.T) {
+		var calls int32
+		server := newServer(func(w http.ResponseWriter) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+		defer server.Close()
+		client := httpclient.NewClient(newConfig(), logger, new(datadog.MetricsDogMock))
+
+		resp, err := client.Execute(context.TODO(), getCall(server.URL, true))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("non idempotent calls and client errors are not retried", func(t *testing.T) {
+		var calls int32
+		server := newServer(func(w http.ResponseWriter) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		defer server.Close()
+		badRequestServer := newServer(func(w http.ResponseWriter) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		})
+		defer badRequestServer.Close()
+		client := httpclient.NewClient(newConfig(), logger, new(datadog.MetricsDogMock))
+
+		resp, err := client.Execute(context.TODO(), getCall(server.URL, false))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode())
+
+		resp, err = client.Execute(context.TODO(), getCall(badRequestServer.URL, true))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("slow hosts time out on every attempt", func(t *testing.T) {
+		server := newServer(func(w http.ResponseWriter) {
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		})
+		defer server.Close()
+		cfg := newConfig()
+		cfg.HTTPClient.MaxRetries = 0
+		client := httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock))
+		call := getCall(server.URL, true)
+		call.Timeout = 20 * time.Millisecond
+
+		_, err := client.Execute(context.TODO(), call)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	logger, _ := logs.New()
+
+	t.Run("the breaker opens after consecutive failures and a probe closes it", func(t *testing.T) {
+		var healthy, calls int32
+		server := newServer(func(w http.ResponseWriter) {
+			atomic.AddInt32(&calls, 1)
+			if atomic.LoadInt32(&healthy) == 0 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+		defer server.Close()
+		cfg := newConfig()
+		cfg.HTTPClient.MaxRetries = 0
+		cfg.HTTPClient.BreakerFailureThreshold = 2
+		cfg.HTTPClient.BreakerOpenMilliseconds = 50
+		client := httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock))
+
+		_, _ = client.Execute(context.TODO(), getCall(server.URL, true))
+		_, _ = client.Execute(context.TODO(), getCall(server.URL, true))
+		_, err := client.Execute(context.TODO(), getCall(server.URL, true))
+		assert.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+		atomic.StoreInt32(&healthy, 1)
+		time.Sleep(60 * time.Millisecond)
+		resp, err := client.Execute(context.TODO(), getCall(server.URL, true))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+
+		_, err = client.Execute(context.TODO(), getCall(server.URL, true))
+		assert.NoError(t, err)
+	})
+
+	t.Run("the calls the caller cancels do not open the breaker", func(t *testing.T) {
+		server := newServer(func(w http.ResponseWriter) {
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		})
+		defer server.Close()
+		cfg := newConfig()
+		cfg.HTTPClient.MaxRetries = 0
+		cfg.HTTPClient.BreakerFailureThreshold = 1
+		client := httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock))
+
+		for i := 0; i < 3; i++ {
+			ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+			_, err := client.Execute(ctx, getCall(server.URL, true))
+			cancel()
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+
+		resp, err := client.Execute(context.TODO(), getCall(server.URL, true))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+	})
+
+	t.Run("a failed probe opens the breaker again", func(t *testing.T) {
+		server := newServer(func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+		defer server.Close()
+		cfg := newConfig()
+		cfg.HTTPClient.MaxRetries = 0
+		cfg.HTTPClient.BreakerFailureThreshold = 1
+		cfg.HTTPClient.BreakerOpenMilliseconds = 50
+		client := httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock))
+
+		_, _ = client.Execute(context.TODO(), getCall(server.URL, true))
+		time.Sleep(60 * time.Millisecond)
+		_, err := client.Execute(context.TODO(), getCall(server.URL, true))
+		assert.NoError(t, err)
+
+		_, err = client.Execute(context.TODO(), getCall(server.URL, true))
+		assert.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+	})
+}
+
+func TestClient_Bulkhead(t *testing.T) {
+	logger, _ := logs.New()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	server := newServer(func(w http.ResponseWriter) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+	cfg := newConfig()
+	cfg.HTTPClient.MaxConcurrentRequestsByHost = 1
+	client := httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock))
+
+	done := make(chan error)
+	go func() {
+		_, err := client.Execute(context.TODO(), getCall(server.URL, true))
+		done <- err
+	}()
+	<-started
+
+	_, err := client.Execute(context.TODO(), getCall(server.URL, true))
+	close(release)
+
+	assert.ErrorIs(t, err, httpclient.ErrBulkheadFull)
+	assert.NoError(t, <-done)
+}
+
+func newConfig() config.Config {
+	cfg := config.Config{}
+	cfg.HTTPClient.MaxIdleConnsPerHost = 10
+	cfg.HTTPClient.MaxRetries = 2
+	cfg.HTTPClient.RetryBaseMilliseconds = 1
+	cfg.HTTPClient.RetryMaxMilliseconds = 5
+	cfg.HTTPClient.BreakerFailureThreshold = 10
+	cfg.HTTPClient.BreakerOpenMilliseconds = 1000
+	cfg.HTTPClient.MaxConcurrentRequestsByHost = 10
+	return cfg
+}
+
+func newServer(handle func(w http.ResponseWriter)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		handle(w)
+	}))
+}
+
+func getCall(host string, idempotent bool) httpclient.Call {
+	return httpclient.Call{
+		Host:       host,
+		Idempotent: idempotent,
+		Timeout:    time.Second,
+		Send: func(request *resty.Request) (*resty.Response, error) {
+			return request.Get(host + "/lists")
+		},
+	}
+}
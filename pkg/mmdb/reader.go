@@ -0,0 +1,350 @@
+package mmdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+)
+
+const (
+	dataSectionSeparatorSize = 16
+
+	// maxDataDepth is the deepest nesting of maps, arrays and pointers of a value, like the one of libmaxminddb.
+	maxDataDepth = 512
+	// maxDecodedValues bounds the values decoded for a single record, shared pointers can make a small file expand
+	// exponentially.
+	maxDecodedValues = 1 << 16
+
+	typeExtended = 0
+	typePointer  = 1
+	typeString   = 2
+	typeDouble   = 3
+	typeBytes    = 4
+	typeUint16   = 5
+	typeUint32   = 6
+	typeMap      = 7
+	typeInt32    = 8
+	typeUint64   = 9
+	typeUint128  = 10
+	typeArray    = 11
+	typeBool     = 14
+	typeFloat    = 15
+)
+
+var (
+	metadataStartMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+	ErrInvalidDatabase = errors.New("invalid MaxMind database")
+)
+
+// Reader looks up IP addresses in a MaxMind DB file, the whole file is kept in memory.
+type Reader struct {
+	tree       []byte
+	data       decoder
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	ipv4Start  uint
+}
+
+type decoder struct {
+	buffer []byte
+}
+
+// decoding keeps the values left to decode for a single record.
+type decoding struct {
+	decoder
+	remaining int
+}
+
+func FromBytes(buffer []byte) (*Reader, error) {
+	metadataStart := bytes.LastIndex(buffer, metadataStartMarker)
+	if metadataStart == -1 {
+		return nil, fmt.Errorf("%w: metadata not found", ErrInvalidDatabase)
+	}
+
+	metadataValue, _, err := decoder{buffer: buffer[metadataStart+len(metadataStartMarker):]}.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	metadata, ok := metadataValue.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+
+	reader := &Reader{
+		nodeCount:  toUint(metadata["node_count"]),
+		recordSize: toUint(metadata["record_size"]),
+		ipVersion:  toUint(metadata["ip_version"]),
+	}
+	if reader.recordSize != 24 && reader.recordSize != 28 && reader.recordSize != 32 {
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, reader.recordSize)
+	}
+
+	if reader.nodeCount > uint(metadataStart) {
+		return nil, fmt.Errorf("%w: search tree is bigger than the file", ErrInvalidDatabase)
+	}
+	treeSize := reader.nodeCount * reader.recordSize / 4
+	if treeSize+dataSectionSeparatorSize > uint(metadataStart) {
+		return nil, fmt.Errorf("%w: search tree is bigger than the file", ErrInvalidDatabase)
+	}
+	reader.tree = buffer[:treeSize]
+	reader.data = decoder{buffer: buffer[treeSize+dataSectionSeparatorSize : metadataStart]}
+
+	if reader.ipVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < reader.nodeCount; i++ {
+			node = reader.readNode(node, 0)
+		}
+		reader.ipv4Start = node
+	}
+
+	return reader, nil
+}
+
+// Lookup returns the record of the network that contains the IP, found is false when the IP is not in the database.
+func (r *Reader) Lookup(ip net.IP) (record map[string]interface{}, found bool, err error) {
+	node := uint(0)
+	address := ip.To4()
+	if address != nil {
+		node = r.ipv4Start
+	} else {
+		if r.ipVersion == 4 {
+			return nil, false, nil
+		}
+		address = ip.To16()
+	}
+	if address == nil {
+		return nil, false, fmt.Errorf("invalid IP address %q", ip.String())
+	}
+
+	for i := 0; i < len(address)*8 && node < r.nodeCount; i++ {
+		bit := uint(address[i>>3]>>(7-uint(i&7))) & 1
+		node = r.readNode(node, bit)
+	}
+
+	if node == r.nodeCount {
+		return nil, false, nil
+	}
+	if node < r.nodeCount {
+		return nil, false, fmt.Errorf("%w: search tree is deeper than the IP", ErrInvalidDatabase)
+	}
+
+	value, _, err := r.data.decode(node - r.nodeCount - dataSectionSeparatorSize)
+	if err != nil {
+		return nil, false, err
+	}
+	record, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("%w: record is not a map", ErrInvalidDatabase)
+	}
+
+	return record, true, nil
+}
+
+func (r *Reader) readNode(node, bit uint) uint {
+	base := node * r.recordSize / 4
+	switch r.recordSize {
+	case 24:
+		offset := base + bit*3
+		return uint(r.tree[offset])<<16 | uint(r.tree[offset+1])<<8 | uint(r.tree[offset+2])
+	case 28:
+		if bit == 0 {
+			return uint(r.tree[base+3]&0xF0)<<20 | uint(r.tree[base])<<16 | uint(r.tree[base+1])<<8 |
+				uint(r.tree[base+2])
+		}
+		return uint(r.tree[base+3]&0x0F)<<24 | uint(r.tree[base+4])<<16 | uint(r.tree[base+5])<<8 |
+			uint(r.tree[base+6])
+	default:
+		return uint(binary.BigEndian.Uint32(r.tree[base+bit*4:]))
+	}
+}
+
+func (d decoder) decode(offset uint) (interface{}, uint, error) {
+	return (&decoding{decoder: d, remaining: maxDecodedValues}).decode(offset, 0)
+}
+
+func (d *decoding) decode(offset uint, depth int) (interface{}, uint, error) {
+	if offset >= uint(len(d.buffer)) {
+		return nil, 0, fmt.Errorf("%w: offset %d is out of the data section", ErrInvalidDatabase, offset)
+	}
+	if depth > maxDataDepth {
+		return nil, 0, fmt.Errorf("%w: value is nested deeper than %d", ErrInvalidDatabase, maxDataDepth)
+	}
+	if d.remaining--; d.remaining < 0 {
+		return nil, 0, fmt.Errorf("%w: record has more than %d values", ErrInvalidDatabase, maxDecodedValues)
+	}
+
+	control := d.buffer[offset]
+	offset++
+	dataType := uint(control >> 5)
+	if dataType == typePointer {
+		pointer, next, err := d.pointer(control, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		if pointer < uint(len(d.buffer)) && d.buffer[pointer]>>5 == typePointer {
+			return nil, 0, fmt.Errorf("%w: pointer %d points to another pointer", ErrInvalidDatabase, pointer)
+		}
+		value, _, err := d.decode(pointer, depth+1)
+		return value, next, err
+	}
+
+	if dataType == typeExtended {
+		if offset >= uint(len(d.buffer)) {
+			return nil, 0, fmt.Errorf("%w: extended type is out of the data section", ErrInvalidDatabase)
+		}
+		dataType = 7 + uint(d.buffer[offset])
+		offset++
+	}
+
+	size, offset, err := d.size(control, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch dataType {
+	case typeMap:
+		return d.decodeMap(size, offset, depth)
+	case typeArray:
+		return d.decodeArray(size, offset, depth)
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(d.buffer)) {
+		return nil, 0, fmt.Errorf("%w: value is out of the data section", ErrInvalidDatabase)
+	}
+	content := d.buffer[offset : offset+size]
+	next := offset + size
+
+	switch dataType {
+	case typeString:
+		return string(content), next, nil
+	case typeBytes:
+		return append([]byte(nil), content...), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: double of %d bytes", ErrInvalidDatabase, size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(content)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: float of %d bytes", ErrInvalidDatabase, size)
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(content)), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		return uint64(uintFromBytes(content)), next, nil
+	case typeInt32:
+		return int32(uint32(uintFromBytes(content))), next, nil
+	case typeUint128:
+		return new(big.Int).SetBytes(content), next, nil
+	}
+
+	return nil, 0, fmt.Errorf("%w: unknown data type %d", ErrInvalidDatabase, dataType)
+}
+
+// decodeMap checks the size before allocating, every entry takes at least a byte for its key and one for its value.
+func (d *decoding) decodeMap(size, offset uint, depth int) (interface{}, uint, error) {
+	if size > (uint(len(d.buffer))-offset)/2 {
+		return nil, 0, fmt.Errorf("%w: map of %d entries is out of the data section", ErrInvalidDatabase, size)
+	}
+
+	values := make(map[string]interface{}, size)
+	for i := uint(0); i < size; i++ {
+		key, next, err := d.decode(offset, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, 0, fmt.Errorf("%w: map key is not a string", ErrInvalidDatabase)
+		}
+
+		values[name], offset, err = d.decode(next, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return values, offset, nil
+}
+
+// decodeArray checks the size before allocating, every value takes at least a byte.
+func (d *decoding) decodeArray(size, offset uint, depth int) (interface{}, uint, error) {
+	if size > uint(len(d.buffer))-offset {
+		return nil, 0, fmt.Errorf("%w: array of %d values is out of the data section", ErrInvalidDatabase, size)
+	}
+
+	values := make([]interface{}, size)
+	for i := range values {
+		var err error
+		values[i], offset, err = d.decode(offset, depth+1)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return values, offset, nil
+}
+
+func (d decoder) size(control byte, offset uint) (uint, uint, error) {
+	size := uint(control & 0x1f)
+	if size < 29 {
+		return size, offset, nil
+	}
+
+	length := size - 28
+	if offset+length > uint(len(d.buffer)) {
+		return 0, 0, fmt.Errorf("%w: size is out of the data section", ErrInvalidDatabase)
+	}
+	value := uintFromBytes(d.buffer[offset : offset+length])
+	switch size {
+	case 29:
+		value += 29
+	case 30:
+		value += 285
+	default:
+		value += 65821
+	}
+
+	return value, offset + length, nil
+}
+
+func (d decoder) pointer(control byte, offset uint) (uint, uint, error) {
+	length := uint((control>>3)&0x3) + 1
+	if offset+length > uint(len(d.buffer)) {
+		return 0, 0, fmt.Errorf("%w: pointer is out of the data section", ErrInvalidDatabase)
+	}
+
+	value := uintFromBytes(d.buffer[offset : offset+length])
+	prefix := uint(control & 0x7)
+	switch length {
+	case 1:
+		value |= prefix << 8
+	case 2:
+		value = (value | prefix<<16) + 2048
+	case 3:
+		value = (value | prefix<<24) + 526336
+	}
+
+	return value, offset + length, nil
+}
+
+func uintFromBytes(content []byte) uint {
+	value := uint(0)
+	for _, b := range content {
+		value = value<<8 | uint(b)
+	}
+
+	return value
+}
+
+func toUint(value interface{}) uint {
+	number, _ := value.(uint64)
+	return uint(number)
+}
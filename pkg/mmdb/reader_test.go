@@ -0,0 +1,266 @@
+package mmdb_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/conekta/risk-rules/pkg/mmdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReader_Lookup(t *testing.T) {
+	writer := newTestWriter()
+	mexico := writer.add(encodeMap(map[string][]byte{
+		"country":                  encodeMap(map[string][]byte{"iso_code": encodeString("MX")}),
+		"autonomous_system_number": encodeUint32(8151),
+		"is_tor_exit_node":         encodeBool(true),
+		"score":                    encodeDouble(0.25),
+		"ranges":                   encodeArray(encodeString("a"), encodeString("b")),
+	}))
+	shared := writer.add(encodeString("US"))
+	unitedStates := writer.add(encodeMap(map[string][]byte{
+		"country": encodeMap(map[string][]byte{"iso_code": encodePointer(shared)}),
+	}))
+	writer.insert(net.ParseIP("187.190.0.0"), 96+16, mexico)
+	writer.insert(net.ParseIP("2001:db8::"), 32, unitedStates)
+	reader, err := mmdb.FromBytes(writer.bytes())
+	assert.NoError(t, err)
+
+	t.Run("ipv4 addresses are found in the ipv4 subtree", func(t *testing.T) {
+		record, found, err := reader.Lookup(net.ParseIP("187.190.38.1"))
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, map[string]interface{}{
+			"country":                  map[string]interface{}{"iso_code": "MX"},
+			"autonomous_system_number": uint64(8151),
+			"is_tor_exit_node":         true,
+			"score":                    0.25,
+			"ranges":                   []interface{}{"a", "b"},
+		}, record)
+	})
+
+	t.Run("pointers are followed", func(t *testing.T) {
+		record, found, err := reader.Lookup(net.ParseIP("2001:db8::1"))
+
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, map[string]interface{}{"iso_code": "US"}, record["country"])
+	})
+
+	t.Run("addresses out of the networks are not found", func(t *testing.T) {
+		for _, ip := range []string{"187.191.0.1", "10.0.0.1", "2001:db9::1"} {
+			record, found, err := reader.Lookup(net.ParseIP(ip))
+
+			assert.NoError(t, err)
+			assert.False(t, found, ip)
+			assert.Nil(t, record)
+		}
+	})
+}
+
+func TestReader_LookupMalformed(t *testing.T) {
+	lookup := func(t *testing.T, build func(writer *testWriter) int) error {
+		writer := newTestWriter()
+		writer.insert(net.ParseIP("10.0.0.0"), 96+8, build(writer))
+		reader, err := mmdb.FromBytes(writer.bytes())
+		assert.NoError(t, err)
+
+		_, _, err = reader.Lookup(net.ParseIP("10.0.0.1"))
+		return err
+	}
+
+	t.Run("a record that points to itself is rejected", func(t *testing.T) {
+		err := lookup(t, func(writer *testWriter) int {
+			return writer.add(encodeMap(map[string][]byte{"self": encodePointer(0)}))
+		})
+
+		assert.True(t, errors.Is(err, mmdb.ErrInvalidDatabase))
+	})
+
+	t.Run("a pointer to another pointer is rejected", func(t *testing.T) {
+		err := lookup(t, func(writer *testWriter) int {
+			value := writer.add(encodeString("MX"))
+			pointer := writer.add(encodePointer(value))
+			return writer.add(encodeMap(map[string][]byte{"country": encodePointer(pointer)}))
+		})
+
+		assert.True(t, errors.Is(err, mmdb.ErrInvalidDatabase))
+	})
+
+	t.Run("pointers shared until the record expands exponentially are rejected", func(t *testing.T) {
+		err := lookup(t, func(writer *testWriter) int {
+			level := writer.add(encodeString("MX"))
+			for i := 0; i < 40; i++ {
+				level = writer.add(encodeMap(map[string][]byte{
+					"left":  encodePointer(level),
+					"right": encodePointer(level),
+				}))
+			}
+			return level
+		})
+
+		assert.True(t, errors.Is(err, mmdb.ErrInvalidDatabase))
+	})
+
+	t.Run("a map bigger than the data section is rejected before allocating it", func(t *testing.T) {
+		err := lookup(t, func(writer *testWriter) int {
+			return writer.add([]byte{7<<5 | 31, 0xFF, 0xFF, 0xFF})
+		})
+
+		assert.True(t, errors.Is(err, mmdb.ErrInvalidDatabase))
+	})
+
+	t.Run("an array bigger than the data section is rejected before allocating it", func(t *testing.T) {
+		err := lookup(t, func(writer *testWriter) int {
+			return writer.add([]byte{31, 11 - 7, 0xFF, 0xFF, 0xFF})
+		})
+
+		assert.True(t, errors.Is(err, mmdb.ErrInvalidDatabase))
+	})
+}
+
+func TestFromBytes(t *testing.T) {
+	t.Run("files without metadata are rejected", func(t *testing.T) {
+		_, err := mmdb.FromBytes([]byte("id,country\n1,MX\n"))
+
+		assert.True(t, errors.Is(err, mmdb.ErrInvalidDatabase))
+	})
+
+	t.Run("files with more nodes than bytes are rejected", func(t *testing.T) {
+		buffer := append([]byte("\xAB\xCD\xEFMaxMind.com"), encodeMap(map[string][]byte{
+			"node_count":  encodeUint32(math.MaxUint32),
+			"record_size": encodeUint16(32),
+			"ip_version":  encodeUint16(6),
+		})...)
+
+		_, err := mmdb.FromBytes(buffer)
+
+		assert.True(t, errors.Is(err, mmdb.ErrInvalidDatabase))
+	})
+}
+
+type testRecord struct {
+	node   int
+	data   int
+	isData bool
+}
+
+type testWriter struct {
+	nodes [][2]*testRecord
+	data  []byte
+}
+
+func newTestWriter() *testWriter {
+	return &testWriter{nodes: [][2]*testRecord{{}}}
+}
+
+func (w *testWriter) add(value []byte) int {
+	offset := len(w.data)
+	w.data = append(w.data, value...)
+	return offset
+}
+
+func (w *testWriter) insert(ip net.IP, prefix int, data int) {
+	address := ip.To16()
+	if ipv4 := ip.To4(); ipv4 != nil {
+		address = append(make([]byte, 12), ipv4...)
+	}
+	node := 0
+	for i := 0; i < prefix; i++ {
+		bit := (address[i/8] >> (7 - uint(i%8))) & 1
+		if i == prefix-1 {
+			w.nodes[node][bit] = &testRecord{data: data, isData: true}
+			return
+		}
+		if w.nodes[node][bit] == nil {
+			w.nodes = append(w.nodes, [2]*testRecord{})
+			w.nodes[node][bit] = &testRecord{node: len(w.nodes) - 1}
+		}
+		node = w.nodes[node][bit].node
+	}
+}
+
+func (w *testWriter) bytes() []byte {
+	nodeCount := len(w.nodes)
+	buffer := make([]byte, 0, nodeCount*6)
+	for _, node := range w.nodes {
+		for _, record := range node {
+			value := nodeCount
+			if record != nil && record.isData {
+				value = nodeCount + 16 + record.data
+			} else if record != nil {
+				value = record.node
+			}
+			buffer = append(buffer, byte(value>>16), byte(value>>8), byte(value))
+		}
+	}
+
+	buffer = append(buffer, make([]byte, 16)...)
+	buffer = append(buffer, w.data...)
+	buffer = append(buffer, []byte("\xAB\xCD\xEFMaxMind.com")...)
+	return append(buffer, encodeMap(map[string][]byte{
+		"node_count":    encodeUint32(uint32(nodeCount)),
+		"record_size":   encodeUint16(24),
+		"ip_version":    encodeUint16(6),
+		"database_type": encodeString("Test"),
+	})...)
+}
+
+func encodeString(value string) []byte {
+	return append([]byte{2<<5 | byte(len(value))}, value...)
+}
+
+func encodeUint16(value uint16) []byte {
+	return []byte{5<<5 | 2, byte(value >> 8), byte(value)}
+}
+
+func encodeUint32(value uint32) []byte {
+	buffer := []byte{6<<5 | 4, 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(buffer[1:], value)
+	return buffer
+}
+
+func encodeDouble(value float64) []byte {
+	buffer := []byte{3<<5 | 8, 0, 0, 0, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint64(buffer[1:], math.Float64bits(value))
+	return buffer
+}
+
+func encodeBool(value bool) []byte {
+	if value {
+		return []byte{1, 14 - 7}
+	}
+	return []byte{0, 14 - 7}
+}
+
+func encodePointer(offset int) []byte {
+	return []byte{1<<5 | byte(offset>>8)&0x7, byte(offset)}
+}
+
+func encodeArray(values ...[]byte) []byte {
+	buffer := []byte{byte(len(values)), 11 - 7}
+	for _, value := range values {
+		buffer = append(buffer, value...)
+	}
+	return buffer
+}
+
+func encodeMap(values map[string][]byte) []byte {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buffer := []byte{7<<5 | byte(len(values))}
+	for _, key := range keys {
+		buffer = append(buffer, encodeString(key)...)
+		buffer = append(buffer, values[key]...)
+	}
+	return buffer
+}
@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -19,6 +20,11 @@ import (
 
 const NoResultsOnFind = "mongo: no documents in result"
 const timeOut = 10 * time.Second
+const shardedClusterMessage = "isdbgrid"
+
+// ErrTransactionsNotSupported is returned by a standalone server, only replica sets and sharded clusters run
+// transactions.
+var ErrTransactionsNotSupported = errors.New("mongodb transactions need a replica set or a sharded cluster")
 
 type MongoDBier interface {
 	Collection(name string) *mongo.Collection
@@ -26,6 +32,7 @@ type MongoDBier interface {
 	PrepareData(ctx context.Context, collection string, documents ...interface{}) []primitive.ObjectID
 	ClearCollection(ctx context.Context, collection string)
 	PrepareCollectionWithTTL(ctx context.Context, collection string)
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type MongoDB struct {
@@ -85,6 +92,37 @@ func (d *MongoDB) PrepareCollectionWithTTL(ctx context.Context, collection strin
 	d.Collection(collection).Indexes().List(ctx)
 }
 
+// WithTransaction runs the function in a transaction, the operations that receive its context are committed together
+// or not at all.
+func (d *MongoDB) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	supported, err := d.supportsTransactions(ctx)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return ErrTransactionsNotSupported
+	}
+
+	return d.client.UseSession(ctx, func(session mongo.SessionContext) error {
+		_, err := session.WithTransaction(session, func(transaction mongo.SessionContext) (interface{}, error) {
+			return nil, fn(transaction)
+		})
+		return err
+	})
+}
+
+func (d *MongoDB) supportsTransactions(ctx context.Context) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := d.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+
+	return hello.SetName != "" || hello.Msg == shardedClusterMessage, nil
+}
+
 func (d *MongoDB) getExpirationIndex() mongo.IndexModel {
 	return mongo.IndexModel{
 		Keys:    bson.D{{Key: "expires", Value: 1}},
@@ -11,6 +11,7 @@ import (
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/httpclient"
 	"github.com/conekta/risk-rules/pkg/text"
 	"github.com/go-resty/resty/v2"
 )
@@ -27,12 +28,14 @@ type OmniscoreClient interface {
 
 type omniscoreClient struct {
 	config config.Config
+	client httpclient.Client
 	logs   logs.Logger
 }
 
-func NewOmniscoreClient(cfg config.Config, logger logs.Logger) OmniscoreClient {
+func NewOmniscoreClient(cfg config.Config, client httpclient.Client, logger logs.Logger) OmniscoreClient {
 	return &omniscoreClient{
 		config: cfg,
+		client: client,
 		logs:   logger,
 	}
 }
@@ -54,14 +57,8 @@ func (o *omniscoreClient) GetScore(ctx context.Context, charge entities.ChargeRe
 	scoreEndpointURL := fmt.Sprintf("%s%s", o.config.Omniscore.Host, GetScore)
 	var score *float64
 
-	timeout := time.Duration(o.config.Omniscore.TimeoutMilliseconds) * time.Millisecond
-	req := resty.New().SetTimeout(timeout).R().
-		SetContext(ctx).
-		SetBody(charge).
-		SetHeader("X-Application-ID", o.config.ProjectName).
-		SetResult(&score)
-
-	err = tracer.Inject(span.Context(), tracer.HTTPHeadersCarrier(req.Header))
+	headers := http.Header{}
+	err = tracer.Inject(span.Context(), tracer.HTTPHeadersCarrier(headers))
 	if err != nil {
 		o.logs.Error(ctx, err.Error(), "charge_id", charge.ID,
 			"company_id", charge.CompanyID)
@@ -70,7 +67,18 @@ func (o *omniscoreClient) GetScore(ctx context.Context, charge entities.ChargeRe
 		return DefaultScore, err
 	}
 
-	resp, err = req.Post(scoreEndpointURL)
+	resp, err = o.client.Execute(ctx, httpclient.Call{
+		Host:    o.config.Omniscore.Host,
+		Timeout: time.Duration(o.config.Omniscore.TimeoutMilliseconds) * time.Millisecond,
+		Send: func(request *resty.Request) (*resty.Response, error) {
+			return request.
+				SetBody(charge).
+				SetHeader("X-Application-ID", o.config.ProjectName).
+				SetHeaderMultiValues(headers).
+				SetResult(&score).
+				Post(scoreEndpointURL)
+		},
+	})
 	if err != nil {
 		o.logs.Error(ctx, err.Error(), "charge_id", charge.ID,
 			"company_id", charge.CompanyID, text.LogTagMethod, "GetScore")
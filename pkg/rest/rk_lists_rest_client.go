@@ -10,6 +10,7 @@ import (
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/httpclient"
 	"github.com/conekta/risk-rules/pkg/text"
 	"github.com/go-resty/resty/v2"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -26,12 +27,14 @@ type RkListsClient interface {
 
 type rkListsRestClient struct {
 	config config.Config
+	client httpclient.Client
 	logs   logs.Logger
 }
 
-func NewRkListsRestClient(cfg config.Config, logger logs.Logger) RkListsClient {
+func NewRkListsRestClient(cfg config.Config, client httpclient.Client, logger logs.Logger) RkListsClient {
 	return &rkListsRestClient{
 		config: cfg,
+		client: client,
 		logs:   logger,
 	}
 }
@@ -46,28 +49,17 @@ func (r *rkListsRestClient) ListsSearch(ctx context.Context, listsSearch entitie
 	}
 
 	span, _ := tracer.StartSpanFromContext(ctx, "rklists")
-	span.SetTag("http.host", config.Configs.InternalService.Host)
+	span.SetTag("http.host", r.config.InternalService.Host)
 	span.SetTag("http.url", urlLists)
 	span.SetTag("listsSearch", string(jsonListsSearch))
 	defer span.Finish()
 
-	host := fmt.Sprintf(urlLists, config.Configs.InternalService.Host)
+	host := fmt.Sprintf(urlLists, r.config.InternalService.Host)
 
 	lists := make([]entities.List, 0)
 
-	timeout := time.Duration(r.config.InternalService.TimeoutMilliseconds) * time.Millisecond
-	req := resty.New().SetTimeout(timeout).R().
-		SetContext(ctx).
-		SetQueryParams(map[string]string{
-			"email":      listsSearch.Email,
-			"card_hash":  listsSearch.CardHash,
-			"phone":      listsSearch.Phone,
-			"company_id": listsSearch.CompanyID,
-		}).
-		SetHeader("X-Application-ID", r.config.ProjectName).
-		SetResult(&lists)
-
-	err = tracer.Inject(span.Context(), tracer.HTTPHeadersCarrier(req.Header))
+	headers := http.Header{}
+	err = tracer.Inject(span.Context(), tracer.HTTPHeadersCarrier(headers))
 	if err != nil {
 		r.logs.Error(ctx, err.Error(), "listsSearch", string(jsonListsSearch), text.LogTagMethod, "ListsSearch")
 		span.SetTag(HTTPStatusCode, http.StatusInternalServerError)
@@ -75,7 +67,19 @@ func (r *rkListsRestClient) ListsSearch(ctx context.Context, listsSearch entitie
 		return nil, err
 	}
 
-	resp, err = req.Get(host)
+	resp, err = r.client.Execute(ctx, httpclient.Call{
+		Host:       r.config.InternalService.Host,
+		Idempotent: true,
+		Timeout:    time.Duration(r.config.InternalService.TimeoutMilliseconds) * time.Millisecond,
+		Send: func(request *resty.Request) (*resty.Response, error) {
+			return request.
+				SetQueryParams(getListsSearchParams(listsSearch)).
+				SetHeader("X-Application-ID", r.config.ProjectName).
+				SetHeaderMultiValues(headers).
+				SetResult(&lists).
+				Get(host)
+		},
+	})
 	if err != nil {
 		r.logs.Error(ctx, err.Error(), "listsSearch", string(jsonListsSearch), text.LogTagMethod, "ListsSearch")
 		return nil, err
@@ -91,3 +95,27 @@ func (r *rkListsRestClient) ListsSearch(ctx context.Context, listsSearch entitie
 	span.SetTag(HTTPStatusCode, resp.StatusCode())
 	return lists, nil
 }
+
+// getListsSearchParams sends every key of the search, the keys added after email, card hash and phone only when the
+// charge has them.
+func getListsSearchParams(listsSearch entities.ListsSearch) map[string]string {
+	params := map[string]string{
+		"email":      listsSearch.Email,
+		"card_hash":  listsSearch.CardHash,
+		"phone":      listsSearch.Phone,
+		"company_id": listsSearch.CompanyID,
+	}
+	optionalParams := map[string]string{
+		"ip_address":         listsSearch.IPAddress,
+		"device_fingerprint": listsSearch.DeviceFingerprint,
+		"bin_number":         listsSearch.BinNumber,
+		"name":               listsSearch.Name,
+	}
+	for key, value := range optionalParams {
+		if value != "" {
+			params[key] = value
+		}
+	}
+
+	return params
+}
@@ -23,6 +23,29 @@ const (
 	SaveChargebackMetricName     = "risk-rules.save_chargeback"
 	UpdateChargebackMetricName   = "risk-rules.update_chargeback"
 	SaveMerchantsScoreMetricName = "risk-rules.save_merchants_score"
+	RuleEvaluatorCacheMetricName = "risk-rules.rule_evaluator_cache"
+	RulesSnapshotSyncMetricName  = "risk-rules.rules_snapshot_sync"
+	RulesSnapshotWatchMetricName = "risk-rules.rules_snapshot_watch"
+	EnrichmentTimeoutMetricName  = "risk-rules.enrichment_timeout"
+	BacktestRuleMetricName       = "risk-rules.backtest_rule"
+	RollbackRuleMetricName       = "risk-rules.rollback_rule"
+	ReviewRuleMetricName         = "risk-rules.review_rule"
+	SaveAuditMetricName          = "risk-rules.save_audit"
+	ExportRulesMetricName        = "risk-rules.export_rules"
+	ImportRulesMetricName        = "risk-rules.import_rules"
+	LintRuleMetricName           = "risk-rules.lint_rule"
+	SaveThresholdMetricName      = "risk-rules.save_score_threshold"
+	UpdateThresholdMetricName    = "risk-rules.update_score_threshold"
+	DeleteThresholdMetricName    = "risk-rules.delete_score_threshold"
+	GetVelocityMetricName        = "risk-rules.get_velocity"
+	TrackVelocityMetricName      = "risk-rules.track_velocity"
+	BinTableSyncMetricName       = "risk-rules.bin_table_sync"
+	IPIntelligenceSyncMetricName = "risk-rules.ip_intelligence_sync"
+	HTTPClientLatencyMetricName  = "risk-rules.http_client.latency"
+	HTTPClientErrorMetricName    = "risk-rules.http_client.error"
+	HTTPClientBreakerMetricName  = "risk-rules.http_client.breaker_state"
+	DegradedEvaluationMetricName = "risk-rules.degraded_evaluation"
+	IdempotencyMetricName        = "risk-rules.idempotent_evaluation"
 
 	MetricTagSuccess                 = "success:%t"
 	MetricTagScope                   = "scope:%s"
@@ -35,6 +58,19 @@ const (
 	MetricCountry                    = "country:%s"
 	MetricIssuer                     = "issuer:%s"
 	MetricStatus                     = "status:%s"
+	MetricTagCacheResult             = "cache_result:%s"
+	MetricTagEnrichment              = "enrichment:%s"
+	MetricTagEntityType              = "entity_type:%s"
+	MetricTagDryRun                  = "dry_run:%t"
+	MetricTagHasWarnings             = "has_warnings:%t"
+	MetricTagHost                    = "host:%s"
+	MetricTagStatusCode              = "status_code:%d"
+	MetricTagErrorReason             = "reason:%s"
+	MetricTagBreakerState            = "breaker_state:%s"
+	MetricTagComponent               = "component:%s"
+	MetricTagDegradationPolicy       = "degradation_policy:%s"
+	MetricTagIdempotencyResult       = "idempotency_result:%s"
+	MetricTagAlreadyTracked          = "already_tracked:%t"
 
 	LogTagMethod    = "Method"
 	CompanyID       = "company_id"
@@ -53,4 +89,8 @@ const (
 	PayerID         = "payer_id"
 	Email           = "email"
 	MerchantScore   = "merchant_score"
+	ThresholdID     = "threshold_id"
+	RuleID          = "rule_id"
+	AuditEntityType = "entity_type"
+	AuditEntityID   = "entity_id"
 )
@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/audit"
+	"github.com/conekta/risk-rules/internal/apps/modules"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository_FindEntity(t *testing.T) {
+	t.Run("on entity found then return the stored document", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		mongoDB := mongodb.NewMongoDB(cfg)
+		module := testdata.GetDefaultModule()
+		repository := audit.NewAuditMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := modules.NewModulesMongoRepository(cfg, mongoDB, logger).Save(ctx, &module)
+		assert.NoError(t, err)
+		defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.Modules, module.ID)
+
+		document, err := repository.FindEntity(ctx, entities.AuditModules, module.ID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, module.Name, document["name"])
+	})
+
+	t.Run("on entity not found then return nil", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		mongoDB := mongodb.NewMongoDB(cfg)
+		repository := audit.NewAuditMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		document, err := repository.FindEntity(ctx, entities.AuditModules, "60f6f32ba0f965ae8ae2c87e")
+
+		assert.NoError(t, err)
+		assert.Nil(t, document)
+	})
+}
+
+func TestAuditRepository_FindPaged(t *testing.T) {
+	t.Run("on entries filtered by entity and date range", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		mongoDB := mongodb.NewMongoDB(cfg)
+		repository := audit.NewAuditMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		created := entities.NewAuditEntry(entities.AuditModules, "60f6f32ba0f965ae8ae2c87e", entities.AuditCreated,
+			"santiago.ceron@conekta.com", nil)
+		created.CreatedAt = now.Add(-time.Hour)
+		updated := entities.NewAuditEntry(entities.AuditModules, "60f6f32ba0f965ae8ae2c87e", entities.AuditUpdated,
+			"santiago.ceron@conekta.com", nil)
+		updated.CreatedAt = now
+
+		assert.NoError(t, repository.Add(ctx, &created))
+		assert.NoError(t, repository.Add(ctx, &updated))
+		defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.AuditLogs, created.ID, updated.ID)
+
+		filter := entities.AuditFilter{
+			EntityType: string(entities.AuditModules),
+			EntityID:   "60f6f32ba0f965ae8ae2c87e",
+			From:       now.Add(-time.Minute).Format(time.RFC3339),
+		}
+		response, err := repository.FindPaged(ctx, filter, entities.NewDefaultPagination())
+
+		assert.NoError(t, err)
+		entries := response.Data.([]entities.AuditEntry)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, updated.ID, entries[0].ID)
+	})
+}
@@ -180,6 +180,42 @@ func TestChargeRepository_GetOnlyRules(t *testing.T) {
 	})
 }
 
+func TestChargeRepository_FindEvaluation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration tests in short mode.")
+	}
+
+	logger, _ := logs.New()
+	cfg := config.NewConfig()
+	mongoDB := mongodb.NewMongoDB(cfg)
+
+	t.Run("when the charge was evaluated inside the window then return the latest evaluation", func(t *testing.T) {
+		repository := charges.NewChargeMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		older := testdata.GetEvaluationResponseAcceptedByWhiteListSuccessful()
+		older.Charge.ID = primitive.NewObjectID().Hex()
+		olderEvaluatedAt := time.Now().UTC().Add(-time.Hour)
+		older.EvaluatedAt = &olderEvaluatedAt
+		latest := older
+		latest.Decision = "D"
+		latestEvaluatedAt := time.Now().UTC()
+		latest.EvaluatedAt = &latestEvaluatedAt
+		assert.NoError(t, repository.Save(ctx, older))
+		assert.NoError(t, repository.Save(ctx, latest))
+		defer mongoDB.Collection(cfg.MongoDB.Collections.ChargeEvaluations).
+			DeleteMany(ctx, bson.D{primitive.E{Key: "charge._id", Value: older.Charge.ID}})
+
+		found, err := repository.FindEvaluation(ctx, older.Charge.ID, olderEvaluatedAt.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, "D", found.Decision)
+
+		_, err = repository.FindEvaluation(ctx, older.Charge.ID, latestEvaluatedAt.Add(time.Minute))
+		assert.EqualError(t, err, fmt.Sprintf("error, charge_evaluation %s not found", older.Charge.ID))
+	})
+}
+
 func get(ctx context.Context, mongoDB mongodb.MongoDBier, cfg config.Config, id string) primitive.ObjectID {
 	var result EvaluationDelete
 	moduleCollection := mongoDB.Collection(cfg.MongoDB.Collections.ChargeEvaluations)
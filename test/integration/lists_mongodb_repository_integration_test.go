@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/lists"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListsRepository_SearchByCharge(t *testing.T) {
+	t.Run("on lists of the company, global and expired then return the live ones of the charge", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		mongoDB := mongodb.NewMongoDB(cfg)
+		repository := lists.NewListsMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		mongoDB.PrepareCollectionWithTTL(ctx, cfg.MongoDB.Collections.Lists)
+		defer mongoDB.ClearCollection(ctx, cfg.MongoDB.Collections.Lists)
+
+		companyList := testdata.GetDefaultBlackList(false)
+		globalList := testdata.GetDefaultWhiteList(false)
+		globalList.CompanyID = ""
+		globalList.IsGlobal = true
+		otherCompanyList := testdata.GetDefaultBlackList(false)
+		otherCompanyList.CompanyID = "3"
+		expiredList := testdata.GetDefaultGrayList(false)
+		ipList := testdata.GetDefaultIPBlackList("187.190.38.0/24")
+		rangeList := testdata.GetDefaultIPBlackList("187.190.0.0/16")
+		rangeList.RangeStart, rangeList.RangeEnd, _ = entities.IPRangeBounds(rangeList.Value)
+		otherRangeList := testdata.GetDefaultIPBlackList("10.0.0.0/8")
+		otherRangeList.RangeStart, otherRangeList.RangeEnd, _ = entities.IPRangeBounds(otherRangeList.Value)
+		binList := testdata.GetDefaultIPBlackList("4111")
+		binList.Field = entities.BinField
+		for _, list := range []entities.List{companyList, globalList, otherCompanyList, expiredList, ipList, rangeList,
+			otherRangeList, binList} {
+			list := list
+			assert.NoError(t, repository.Add(ctx, &list))
+		}
+
+		search := testdata.GetDefaultRequestLists()
+		search.IPAddress = "187.190.38.10"
+		search.BinNumber = "41111111"
+		foundLists, err := repository.SearchByCharge(ctx, search)
+
+		assert.NoError(t, err)
+		assert.Len(t, foundLists, 5)
+	})
+
+	t.Run("on a search without keys then return no lists", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		repository := lists.NewListsMongoDBRepository(cfg, mongodb.NewMongoDB(cfg), logger)
+
+		foundLists, err := repository.SearchByCharge(context.TODO(), entities.ListsSearch{CompanyID: "2"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, foundLists)
+	})
+}
+
+func TestListsRepository_UpdateAndDelete(t *testing.T) {
+	t.Run("on an added list then update and delete it", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		mongoDB := mongodb.NewMongoDB(cfg)
+		repository := lists.NewListsMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		mongoDB.PrepareCollectionWithTTL(ctx, cfg.MongoDB.Collections.Lists)
+		defer mongoDB.ClearCollection(ctx, cfg.MongoDB.Collections.Lists)
+
+		request := testdata.GetListRequest()
+		list := request.NewListFromPostRequest()
+		assert.NoError(t, repository.Add(ctx, &list))
+
+		request.Value = "other@gmail.com"
+		assert.NoError(t, repository.Update(ctx, list.ID.Hex(), request.NewListFromPutRequest()))
+		foundLists, err := repository.Search(ctx, entities.ListFilter{ID: list.ID.Hex()})
+		assert.NoError(t, err)
+		assert.Equal(t, "other@gmail.com", foundLists[0].Value)
+
+		assert.NoError(t, repository.Delete(ctx, list.ID.Hex()))
+		assert.Error(t, repository.Delete(ctx, list.ID.Hex()))
+	})
+}
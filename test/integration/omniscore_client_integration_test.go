@@ -8,7 +8,9 @@ import (
 
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/pkg/httpclient"
 	"github.com/conekta/risk-rules/pkg/rest"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
 	"github.com/conekta/risk-rules/test/testdata"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,7 +24,7 @@ func TestOmniscoreRest_GetScore(t *testing.T) {
 
 		charge := testdata.GetDefaultCharge()
 		charge.ID = "615324eb5bc1dea9ce66068c"
-		restClient := rest.NewOmniscoreClient(cfg, logger)
+		restClient := rest.NewOmniscoreClient(cfg, httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock)), logger)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
@@ -40,7 +42,7 @@ func TestOmniscoreRest_GetScore(t *testing.T) {
 
 		charge := testdata.GetDefaultCharge()
 		charge.ID = "615324eb5bc1dea9ce66068a"
-		restClient := rest.NewOmniscoreClient(cfg, logger)
+		restClient := rest.NewOmniscoreClient(cfg, httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock)), logger)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
@@ -58,7 +60,7 @@ func TestOmniscoreRest_GetScore(t *testing.T) {
 
 		charge := testdata.GetDefaultCharge()
 		charge.ID = "615324eb5bc1dea9ce66068b"
-		restClient := rest.NewOmniscoreClient(cfg, logger)
+		restClient := rest.NewOmniscoreClient(cfg, httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock)), logger)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
@@ -78,7 +80,7 @@ func TestOmniscoreRest_GetScore(t *testing.T) {
 		cfg.Omniscore.IsEnabled = false
 
 		charge := testdata.GetDefaultCharge()
-		restClient := rest.NewOmniscoreClient(cfg, logger)
+		restClient := rest.NewOmniscoreClient(cfg, httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock)), logger)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
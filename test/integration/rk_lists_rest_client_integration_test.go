@@ -2,13 +2,18 @@ package integration
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/conekta/go_common/logs"
 	"github.com/conekta/risk-rules/internal/config"
 	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/httpclient"
 	"github.com/conekta/risk-rules/pkg/rest"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
 	"github.com/conekta/risk-rules/test/testdata"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +26,7 @@ func TestRkListsRest_ListsSearch(t *testing.T) {
 		cfg := config.NewConfig()
 
 		listsSearch := testdata.GetDefaultRequestLists()
-		restClient := rest.NewRkListsRestClient(cfg, logger)
+		restClient := rest.NewRkListsRestClient(cfg, httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock)), logger)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
@@ -30,4 +35,34 @@ func TestRkListsRest_ListsSearch(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, []entities.List{expected}, foundLists)
 	})
+
+	t.Run("when the search has the new keys they are sent to rk lists", func(t *testing.T) {
+		var query url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("[]"))
+		}))
+		defer server.Close()
+
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		cfg.InternalService.Host = server.URL
+		listsSearch := testdata.GetDefaultRequestLists()
+		listsSearch.IPAddress = "187.190.38.10"
+		listsSearch.DeviceFingerprint = "fp_1"
+		listsSearch.BinNumber = "411111"
+		listsSearch.Name = "mario moreno"
+		restClient := rest.NewRkListsRestClient(cfg, httpclient.NewClient(cfg, logger, new(datadog.MetricsDogMock)), logger)
+
+		foundLists, err := restClient.ListsSearch(context.Background(), listsSearch)
+
+		assert.NoError(t, err)
+		assert.Empty(t, foundLists)
+		assert.Equal(t, listsSearch.Email, query.Get("email"))
+		assert.Equal(t, "187.190.38.10", query.Get("ip_address"))
+		assert.Equal(t, "fp_1", query.Get("device_fingerprint"))
+		assert.Equal(t, "411111", query.Get("bin_number"))
+		assert.Equal(t, "mario moreno", query.Get("name"))
+	})
 }
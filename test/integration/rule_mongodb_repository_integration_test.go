@@ -123,6 +123,24 @@ func TestRuleRepository_GetRulesByFilters(t *testing.T) {
 
 		defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.Rules, ruleCreated.ID)
 	})
+
+	t.Run("when rule is pending approval, then it is not loaded", func(t *testing.T) {
+		rule := testdata.GetDefaultRuleWithID(true)
+		rule.Status = entities.RuleStatusPendingApproval
+		repository := rules.NewRuleMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		ruleCreated, _ := repository.AddRule(ctx, rule)
+		response, err := repository.GetRulesByFilters(ctx, rule.GetRuleFilter(), entities.CompanyRulesType)
+
+		assert.Nil(t, err)
+		for _, ruleFound := range response {
+			assert.NotEqual(t, ruleCreated.ID, ruleFound.ID)
+		}
+
+		defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.Rules, ruleCreated.ID)
+	})
 }
 
 func TestRuleRepository_GlobalRuleType(t *testing.T) {
@@ -152,6 +170,42 @@ func TestRuleRepository_GlobalRuleType(t *testing.T) {
 	})
 }
 
+func TestRuleRepository_FindLintCandidates(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping integration tests in short mode.")
+	}
+	logger, _ := logs.New()
+	cfg := config.NewConfig()
+	mongoDB := mongodb.NewMongoDB(cfg)
+
+	t.Run("when a global rule is linted then the company rules with its decision are candidates", func(t *testing.T) {
+		companyRule := testdata.GetDefaultRule(false)
+		otherCompanyRule := testdata.GetDefaultRule(false)
+		otherCompanyRule.Decision = entities.Accepted
+		globalRule := testdata.GetDefaultRuleEmailBlockedGlobal(false)
+		globalRule.Decision = companyRule.Decision
+		repository := rules.NewRuleMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		companyCreated, _ := repository.AddRule(ctx, companyRule)
+		otherCreated, _ := repository.AddRule(ctx, otherCompanyRule)
+		defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.Rules, companyCreated.ID, otherCreated.ID)
+
+		candidates, err := repository.FindLintCandidates(ctx, globalRule,
+			[]entities.ConsoleComponent{entities.CompanyRulesType})
+
+		assert.NoError(t, err)
+		ids := make([]primitive.ObjectID, 0, len(candidates))
+		for _, candidate := range candidates {
+			ids = append(ids, candidate.ID)
+		}
+		assert.Contains(t, ids, companyCreated.ID)
+		assert.NotContains(t, ids, otherCreated.ID)
+	})
+}
+
 func TestRuleRepository_Find_Rules_Paged(t *testing.T) {
 
 	if testing.Short() {
@@ -230,6 +284,28 @@ func TestRuleRepository_Find_Rules_Paged(t *testing.T) {
 
 		defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.Rules, ruleCreated.ID)
 	})
+
+	t.Run("when filtering by active state, then expired rules are only returned as inactive", func(t *testing.T) {
+		rule := testdata.GetDefaultRuleWithID(true)
+		expiredAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+		rule.ActiveUntil = &expiredAt
+		repository := rules.NewRuleMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		ruleCreated, _ := repository.AddRule(ctx, rule)
+		activeResponse, err := repository.FindRulesPaged(ctx,
+			entities.RuleFilter{ID: rule.ID.Hex(), Active: "true"}, entities.Pagination{})
+		assert.Nil(t, err)
+		assert.Empty(t, activeResponse.Data.([]entities.Rule))
+
+		inactiveResponse, err := repository.FindRulesPaged(ctx,
+			entities.RuleFilter{ID: rule.ID.Hex(), Active: "false"}, entities.Pagination{})
+		assert.Nil(t, err)
+		assert.Equal(t, ruleCreated.ID, inactiveResponse.Data.([]entities.Rule)[0].ID)
+
+		defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.Rules, ruleCreated.ID)
+	})
 }
 
 func TestRuleRepository_DeleteRule(t *testing.T) {
@@ -0,0 +1,143 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/rules"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/conekta/risk-rules/test/mocks/datadog"
+	"github.com/conekta/risk-rules/test/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSnapshotRepository_GetRulesByFilters(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration tests in short mode.")
+	}
+	logger, _ := logs.New()
+	cfg := config.NewConfig()
+	cfg.RulesSnapshot.IsEnabled = true
+	mongoDB := mongodb.NewMongoDB(cfg)
+
+	mongoRepository := rules.NewRuleMongoDBRepository(cfg, mongoDB, logger)
+	snapshotRepository := rules.NewRuleSnapshotRepository(cfg, mongoDB, mongoRepository, logger, new(datadog.MetricsDogMock))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	companyRule, _ := mongoRepository.AddRule(ctx, testdata.GetDefaultRule(true))
+	identityRule, _ := mongoRepository.AddRule(ctx, testdata.GetDefaultRuleEmailProximity(true))
+	yellowFlagRule, _ := mongoRepository.AddRule(ctx, testdata.GetDefaultRuleYellowFlag(true))
+	defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.Rules, companyRule.ID, identityRule.ID, yellowFlagRule.ID)
+
+	assert.NoError(t, snapshotRepository.Load(ctx))
+
+	tests := []struct {
+		name      string
+		rule      entities.Rule
+		component entities.ConsoleComponent
+	}{
+		{name: "company rules", rule: companyRule, component: entities.CompanyRulesType},
+		{name: "identity module rules", rule: identityRule, component: entities.IdentityModuleType},
+		{name: "yellow flag rules", rule: yellowFlagRule, component: entities.YellowFlagType},
+	}
+
+	for _, tt := range tests {
+		t.Run("when the snapshot finds the same "+tt.name+" as mongo", func(t *testing.T) {
+			expected, err := mongoRepository.GetRulesByFilters(ctx, tt.rule.GetRuleFilter(), tt.component)
+			assert.NoError(t, err)
+
+			rulesFound, err := snapshotRepository.GetRulesByFilters(ctx, tt.rule.GetRuleFilter(), tt.component)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, getRuleIDs(expected), getRuleIDs(rulesFound))
+		})
+	}
+}
+
+func TestRuleSnapshotRepository_Watch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration tests in short mode.")
+	}
+	logger, _ := logs.New()
+	cfg := config.NewConfig()
+	cfg.RulesSnapshot.IsEnabled = true
+	cfg.RulesSnapshot.ResyncSeconds = 1
+	mongoDB := mongodb.NewMongoDB(cfg)
+
+	mongoRepository := rules.NewRuleMongoDBRepository(cfg, mongoDB, logger)
+	snapshotRepository := rules.NewRuleSnapshotRepository(cfg, mongoDB, mongoRepository, logger, new(datadog.MetricsDogMock))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+	go snapshotRepository.Watch(watchCtx)
+
+	companyRule, _ := mongoRepository.AddRule(ctx, testdata.GetDefaultRule(true))
+	defer mongoDB.CleanCollectionByIds(ctx, cfg.MongoDB.Collections.Rules, companyRule.ID)
+
+	t.Run("when a rule is added while watching it is found with or without change streams", func(t *testing.T) {
+		assert.Eventually(t, func() bool {
+			rulesFound, err := snapshotRepository.GetRulesByFilters(ctx, companyRule.GetRuleFilter(),
+				entities.CompanyRulesType)
+			return err == nil && containsRuleID(rulesFound, companyRule.ID.Hex())
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("when a rule is updated while watching its new content is found", func(t *testing.T) {
+		updated := companyRule
+		updated.Description = "updated while watching"
+		assert.NoError(t, mongoRepository.UpdateRule(ctx, companyRule.ID.Hex(), updated))
+
+		assert.Eventually(t, func() bool {
+			rulesFound, err := snapshotRepository.GetRulesByFilters(ctx, companyRule.GetRuleFilter(),
+				entities.CompanyRulesType)
+			return err == nil && findRuleByID(rulesFound, companyRule.ID.Hex()).Description == updated.Description
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("when a rule is removed while watching it is not found", func(t *testing.T) {
+		assert.NoError(t, mongoRepository.RemoveRule(ctx, companyRule.ID.Hex()))
+
+		assert.Eventually(t, func() bool {
+			rulesFound, err := snapshotRepository.GetRulesByFilters(ctx, companyRule.GetRuleFilter(),
+				entities.CompanyRulesType)
+			return err == nil && !containsRuleID(rulesFound, companyRule.ID.Hex())
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
+
+func findRuleByID(rulesFound []entities.Rule, id string) entities.Rule {
+	for _, rule := range rulesFound {
+		if rule.ID.Hex() == id {
+			return rule
+		}
+	}
+
+	return entities.Rule{}
+}
+
+func getRuleIDs(rulesFound []entities.Rule) []string {
+	ids := make([]string, 0, len(rulesFound))
+	for _, rule := range rulesFound {
+		ids = append(ids, rule.ID.Hex())
+	}
+
+	return ids
+}
+
+func containsRuleID(rulesFound []entities.Rule, id string) bool {
+	for _, ruleID := range getRuleIDs(rulesFound) {
+		if ruleID == id {
+			return true
+		}
+	}
+
+	return false
+}
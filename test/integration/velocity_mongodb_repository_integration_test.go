@@ -0,0 +1,89 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conekta/go_common/logs"
+	"github.com/conekta/risk-rules/internal/apps/velocity"
+	"github.com/conekta/risk-rules/internal/config"
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/conekta/risk-rules/pkg/mongodb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVelocityRepository_IncrementAndFindBuckets(t *testing.T) {
+	t.Run("on two charges of the same key then every resolution bucket counts both", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		mongoDB := mongodb.NewMongoDB(cfg)
+		repository := velocity.NewVelocityMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		mongoDB.ClearCollection(ctx, cfg.MongoDB.Collections.Velocity)
+		defer mongoDB.ClearCollection(ctx, cfg.MongoDB.Collections.Velocity)
+
+		keys := []entities.VelocityKey{{Dimension: entities.VelocityCardHash, Value: "card-hash-1"}}
+		now := time.Now().UTC()
+
+		assert.NoError(t, repository.Increment(ctx, keys, 100, now))
+		assert.NoError(t, repository.Increment(ctx, keys, 50, now))
+		buckets, err := repository.FindBuckets(ctx, keys, now)
+
+		assert.NoError(t, err)
+		assert.Len(t, buckets, 4)
+		for _, bucket := range buckets {
+			assert.Equal(t, entities.VelocityCardHash, bucket.Dimension)
+			assert.Equal(t, 2, bucket.Count)
+			assert.Equal(t, float64(150), bucket.Sum)
+			assert.True(t, bucket.Expires.After(now))
+		}
+	})
+
+	t.Run("on other keys then return no buckets", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		mongoDB := mongodb.NewMongoDB(cfg)
+		repository := velocity.NewVelocityMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		keys := []entities.VelocityKey{{Dimension: entities.VelocityIP, Value: "10.0.0.1"}}
+		buckets, err := repository.FindBuckets(ctx, keys, time.Now().UTC())
+
+		assert.NoError(t, err)
+		assert.Empty(t, buckets)
+	})
+}
+
+func TestVelocityRepository_MarkTracked(t *testing.T) {
+	t.Run("on the same charge twice then only the first mark is new", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("skipping integration tests in short mode.")
+		}
+		logger, _ := logs.New()
+		cfg := config.NewConfig()
+		mongoDB := mongodb.NewMongoDB(cfg)
+		repository := velocity.NewVelocityMongoDBRepository(cfg, mongoDB, logger)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		mongoDB.ClearCollection(ctx, cfg.MongoDB.Collections.Velocity)
+		defer mongoDB.ClearCollection(ctx, cfg.MongoDB.Collections.Velocity)
+
+		now := time.Now().UTC()
+		first, err := repository.MarkTracked(ctx, "charge-1", now)
+		assert.NoError(t, err)
+		second, err := repository.MarkTracked(ctx, "charge-1", now)
+		assert.NoError(t, err)
+
+		assert.True(t, first)
+		assert.False(t, second)
+	})
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type AuditRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AuditRepositoryMock) Add(ctx context.Context, entry *entities.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *AuditRepositoryMock) FindPaged(ctx context.Context, filter entities.AuditFilter,
+	pagination entities.Pagination) (entities.PagedResponse, error) {
+	args := m.Called(ctx, filter, pagination)
+	return args.Get(0).(entities.PagedResponse), args.Error(1)
+}
+
+func (m *AuditRepositoryMock) FindEntity(ctx context.Context, entityType entities.AuditEntityType,
+	entityID string) (bson.M, error) {
+	args := m.Called(ctx, entityType, entityID)
+	document, _ := args.Get(0).(bson.M)
+	return document, args.Error(1)
+}
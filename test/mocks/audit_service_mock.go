@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type AuditServiceMock struct {
+	mock.Mock
+}
+
+// NewAuditServiceMock accepts any audit call, for the tests that do not check the audit trail.
+func NewAuditServiceMock() *AuditServiceMock {
+	auditService := new(AuditServiceMock)
+	auditService.On("Snapshot", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	auditService.On("Record", mock.Anything, mock.Anything).Return().Maybe()
+	return auditService
+}
+
+func (m *AuditServiceMock) Snapshot(ctx context.Context, entityType entities.AuditEntityType,
+	entityID string) bson.M {
+	args := m.Called(ctx, entityType, entityID)
+	snapshot, _ := args.Get(0).(bson.M)
+	return snapshot
+}
+
+func (m *AuditServiceMock) Record(ctx context.Context, entry entities.AuditEntry) {
+	m.Called(ctx, entry)
+}
+
+func (m *AuditServiceMock) Search(ctx context.Context, filter entities.AuditFilter,
+	pagination entities.Pagination) (entities.PagedResponse, error) {
+	args := m.Called(ctx, filter, pagination)
+	return args.Get(0).(entities.PagedResponse), args.Error(1)
+}
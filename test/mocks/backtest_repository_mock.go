@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type BacktestRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *BacktestRepositoryMock) GetEvaluations(ctx context.Context,
+	filter entities.BacktestFilter) ([]entities.StoredEvaluation, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]entities.StoredEvaluation), args.Error(1)
+}
@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type BacktestServiceMock struct {
+	mock.Mock
+}
+
+func (m *BacktestServiceMock) Backtest(ctx context.Context,
+	request entities.BacktestRequest) (entities.BacktestResponse, error) {
+	args := m.Called(ctx, request)
+	return args.Get(0).(entities.BacktestResponse), args.Error(1)
+}
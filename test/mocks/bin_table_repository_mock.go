@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type BinTableRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *BinTableRepositoryMock) FindByBin(ctx context.Context, binNumber string) (entities.BinInfo, bool) {
+	args := m.Called(ctx, binNumber)
+	return args.Get(0).(entities.BinInfo), args.Bool(1)
+}
+
+func (m *BinTableRepositoryMock) Load(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *BinTableRepositoryMock) Watch(ctx context.Context) {
+	m.Called(ctx)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/conekta/risk-rules/internal/entities"
 	"github.com/stretchr/testify/mock"
@@ -31,3 +32,15 @@ func (m *ChargeEvaluationRepositoryMock) GetOnlyRules(ctx context.Context, id st
 	args := m.Called(ctx, id)
 	return args.Get(0).(entities.RulesEvaluationResponse), args.Error(1)
 }
+
+func (m *ChargeEvaluationRepositoryMock) FindEvaluation(ctx context.Context, id string,
+	since time.Time) (entities.EvaluationResponse, error) {
+	args := m.Called(ctx, id, since)
+	return args.Get(0).(entities.EvaluationResponse), args.Error(1)
+}
+
+func (m *ChargeEvaluationRepositoryMock) FindEvaluationOnlyRules(ctx context.Context, id string,
+	since time.Time) (entities.RulesEvaluationResponse, error) {
+	args := m.Called(ctx, id, since)
+	return args.Get(0).(entities.RulesEvaluationResponse), args.Error(1)
+}
@@ -23,6 +23,12 @@ func (m *ChargeServiceMock) EvaluateChargeOnlyRules(ctx context.Context,
 	return args.Get(0).(entities.RulesEvaluationResponse), args.Error(1)
 }
 
+func (m *ChargeServiceMock) EvaluateChargesBatch(ctx context.Context,
+	charges []entities.ChargeRequest) []entities.BatchEvaluationItem {
+	args := m.Called(ctx, charges)
+	return args.Get(0).([]entities.BatchEvaluationItem)
+}
+
 func (m *ChargeServiceMock) Get(ctx context.Context, id string) (entities.EvaluationResponse, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(entities.EvaluationResponse), args.Error(1)
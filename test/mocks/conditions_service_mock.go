@@ -32,7 +32,7 @@ func (m *ConditionServiceMock) Update(ctx context.Context, id string, condition
 	return args.Error(0)
 }
 
-func (m *ConditionServiceMock) Delete(ctx context.Context, id string) error {
-	args := m.Mock.Called(ctx, id)
+func (m *ConditionServiceMock) Delete(ctx context.Context, id string, author string) error {
+	args := m.Mock.Called(ctx, id, author)
 	return args.Error(0)
 }
@@ -38,7 +38,7 @@ func (m *FamilyCompaniesServiceMock) GetFamiliesCompaniesFromFilter(ctx context.
 	return args.Get(0).([]entities.FamilyCompanies), args.Error(1)
 }
 
-func (m *FamilyCompaniesServiceMock) Delete(ctx context.Context, id string) error {
-	args := m.Mock.Called(ctx, id)
+func (m *FamilyCompaniesServiceMock) Delete(ctx context.Context, id string, author string) error {
+	args := m.Mock.Called(ctx, id, author)
 	return args.Error(0)
 }
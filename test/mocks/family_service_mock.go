@@ -41,7 +41,7 @@ func (m *FamilyServiceMock) Get(ctx context.Context, pagination entities.Paginat
 	return args.Get(0), args.Error(1)
 }
 
-func (m *FamilyServiceMock) Delete(ctx context.Context, id string) error {
-	args := m.Mock.Called(ctx, id)
+func (m *FamilyServiceMock) Delete(ctx context.Context, id string, author string) error {
+	args := m.Mock.Called(ctx, id, author)
 	return args.Error(0)
 }
@@ -26,8 +26,8 @@ func (m *FieldsServiceMock) Update(ctx context.Context, id string, field entitie
 	return args.Error(0)
 }
 
-func (m *FieldsServiceMock) Delete(ctx context.Context, id string) error {
-	args := m.Mock.Called(ctx, id)
+func (m *FieldsServiceMock) Delete(ctx context.Context, id string, author string) error {
+	args := m.Mock.Called(ctx, id, author)
 	return args.Error(0)
 }
 
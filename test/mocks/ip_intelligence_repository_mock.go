@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"net"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type IPIntelligenceRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *IPIntelligenceRepositoryMock) FindByIP(ctx context.Context, ip net.IP) (entities.IPInfo, bool, error) {
+	args := m.Called(ctx, ip)
+	return args.Get(0).(entities.IPInfo), args.Bool(1), args.Error(2)
+}
+
+func (m *IPIntelligenceRepositoryMock) Load(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *IPIntelligenceRepositoryMock) Watch(ctx context.Context) {
+	m.Called(ctx)
+}
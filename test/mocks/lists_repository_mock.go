@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type ListsRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ListsRepositoryMock) Add(ctx context.Context, list *entities.List) error {
+	args := m.Called(ctx, list)
+	return args.Error(0)
+}
+
+func (m *ListsRepositoryMock) Update(ctx context.Context, id string, list entities.List) error {
+	args := m.Called(ctx, id, list)
+	return args.Error(0)
+}
+
+func (m *ListsRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ListsRepositoryMock) Search(ctx context.Context, filter entities.ListFilter) ([]entities.List, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]entities.List), args.Error(1)
+}
+
+func (m *ListsRepositoryMock) SearchByCharge(ctx context.Context,
+	listsSearch entities.ListsSearch) ([]entities.List, error) {
+	args := m.Called(ctx, listsSearch)
+	return args.Get(0).([]entities.List), args.Error(1)
+}
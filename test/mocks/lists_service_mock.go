@@ -20,3 +20,23 @@ func (m *ListsServiceMock) GetLists(ctx context.Context, listsSearch entities.Li
 	args := m.Called(ctx, listsSearch)
 	return args.Get(0).([]entities.List), args.Error(1)
 }
+
+func (m *ListsServiceMock) Create(ctx context.Context, list entities.List) (entities.List, error) {
+	args := m.Called(ctx, list)
+	return args.Get(0).(entities.List), args.Error(1)
+}
+
+func (m *ListsServiceMock) Update(ctx context.Context, id string, list entities.List) error {
+	args := m.Called(ctx, id, list)
+	return args.Error(0)
+}
+
+func (m *ListsServiceMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ListsServiceMock) Get(ctx context.Context, filter entities.ListFilter) ([]entities.List, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]entities.List), args.Error(1)
+}
@@ -27,7 +27,7 @@ func (m *ModuleServiceMock) Update(ctx context.Context, id string, module entiti
 	return args.Error(0)
 }
 
-func (m *ModuleServiceMock) Delete(ctx context.Context, id string) error {
-	args := m.Mock.Called(ctx, id)
+func (m *ModuleServiceMock) Delete(ctx context.Context, id string, author string) error {
+	args := m.Mock.Called(ctx, id, author)
 	return args.Error(0)
 }
@@ -27,7 +27,7 @@ func (m *OperatorServiceMock) Update(ctx context.Context, id string, operator en
 	return args.Error(0)
 }
 
-func (m *OperatorServiceMock) Delete(ctx context.Context, id string) error {
-	args := m.Mock.Called(ctx, id)
+func (m *OperatorServiceMock) Delete(ctx context.Context, id string, author string) error {
+	args := m.Mock.Called(ctx, id, author)
 	return args.Error(0)
 }
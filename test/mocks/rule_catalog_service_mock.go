@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type RuleCatalogServiceMock struct {
+	mock.Mock
+}
+
+// NewRuleCatalogServiceMock accepts every rule, for the tests that do not check the catalog.
+func NewRuleCatalogServiceMock() *RuleCatalogServiceMock {
+	catalogService := new(RuleCatalogServiceMock)
+	catalogService.On("Validate", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return catalogService
+}
+
+func (m *RuleCatalogServiceMock) Load(ctx context.Context) (entities.RuleCatalog, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(entities.RuleCatalog), args.Error(1)
+}
+
+func (m *RuleCatalogServiceMock) Validate(ctx context.Context, contents []entities.RuleContent) error {
+	args := m.Called(ctx, contents)
+	return args.Error(0)
+}
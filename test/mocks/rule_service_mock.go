@@ -16,6 +16,11 @@ func (m *RuleServiceMock) BuildRule(ruleContent []entities.RuleContent) string {
 	return args.Get(0).(string)
 }
 
+func (m *RuleServiceMock) BuildExpression(rule entities.Rule) string {
+	args := m.Mock.Called(rule)
+	return args.Get(0).(string)
+}
+
 func (m *RuleServiceMock) AddRule(ctx context.Context, rule entities.Rule) (entities.Rule, error) {
 	args := m.Mock.Called(ctx, rule)
 	return args.Get(0).(entities.Rule), args.Error(1)
@@ -26,13 +31,73 @@ func (m *RuleServiceMock) UpdateRule(ctx context.Context, ruleID string, ruleReq
 	return args.Error(0)
 }
 
-func (m *RuleServiceMock) RemoveRule(ctx context.Context, id string) error {
-	args := m.Mock.Called(ctx, id)
+func (m *RuleServiceMock) RemoveRule(ctx context.Context, id string, author string) error {
+	args := m.Mock.Called(ctx, id, author)
 	return args.Error(0)
 }
 
+func (m *RuleServiceMock) GetVersions(ctx context.Context, ruleID string) ([]entities.RuleVersion, error) {
+	args := m.Mock.Called(ctx, ruleID)
+	return args.Get(0).([]entities.RuleVersion), args.Error(1)
+}
+
+func (m *RuleServiceMock) GetVersion(ctx context.Context, ruleID string,
+	revision int64) (entities.RuleVersion, error) {
+	args := m.Mock.Called(ctx, ruleID, revision)
+	return args.Get(0).(entities.RuleVersion), args.Error(1)
+}
+
+func (m *RuleServiceMock) RollbackRule(ctx context.Context, ruleID string, revision int64,
+	author string) (entities.Rule, error) {
+	args := m.Mock.Called(ctx, ruleID, revision, author)
+	return args.Get(0).(entities.Rule), args.Error(1)
+}
+
+func (m *RuleServiceMock) SubmitRule(ctx context.Context, ruleID string,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	args := m.Mock.Called(ctx, ruleID, review)
+	return args.Get(0).(entities.Rule), args.Error(1)
+}
+
+func (m *RuleServiceMock) ApproveRule(ctx context.Context, ruleID string,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	args := m.Mock.Called(ctx, ruleID, review)
+	return args.Get(0).(entities.Rule), args.Error(1)
+}
+
+func (m *RuleServiceMock) RejectRule(ctx context.Context, ruleID string,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	args := m.Mock.Called(ctx, ruleID, review)
+	return args.Get(0).(entities.Rule), args.Error(1)
+}
+
+func (m *RuleServiceMock) RetireRule(ctx context.Context, ruleID string,
+	review entities.RuleReviewRequest) (entities.Rule, error) {
+	args := m.Mock.Called(ctx, ruleID, review)
+	return args.Get(0).(entities.Rule), args.Error(1)
+}
+
 func (m *RuleServiceMock) ListRules(ctx context.Context, ruleFilter entities.RuleFilter,
 	pagination entities.Pagination) (entities.PagedResponse, error) {
 	args := m.Mock.Called(ctx, ruleFilter, pagination)
 	return args.Get(0).(entities.PagedResponse), args.Error(1)
 }
+
+func (m *RuleServiceMock) LintRule(ctx context.Context, rule entities.Rule) (entities.RuleLintResponse, error) {
+	args := m.Mock.Called(ctx, rule)
+	return args.Get(0).(entities.RuleLintResponse), args.Error(1)
+}
+
+func (m *RuleServiceMock) ValidateSyntax(ctx context.Context, rule entities.Rule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *RuleServiceMock) AddVersion(ctx context.Context, version entities.RuleVersion) error {
+	args := m.Called(ctx, version)
+	return args.Error(0)
+}
+
+func (m *RuleServiceMock) InvalidateRule(ruleID string) {
+	m.Called(ruleID)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type RuleTransferServiceMock struct {
+	mock.Mock
+}
+
+func (m *RuleTransferServiceMock) Export(ctx context.Context, filter entities.RuleFilter) ([]entities.RuleExport, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]entities.RuleExport), args.Error(1)
+}
+
+func (m *RuleTransferServiceMock) Import(ctx context.Context, exports []entities.RuleExport, author string,
+	dryRun bool) (entities.RuleImportReport, error) {
+	args := m.Called(ctx, exports, author, dryRun)
+	return args.Get(0).(entities.RuleImportReport), args.Error(1)
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type RuleVersionRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RuleVersionRepositoryMock) Add(ctx context.Context, version entities.RuleVersion) error {
+	args := m.Called(ctx, version)
+	return args.Error(0)
+}
+
+func (m *RuleVersionRepositoryMock) Remove(ctx context.Context, version entities.RuleVersion) error {
+	args := m.Called(ctx, version)
+	return args.Error(0)
+}
+
+func (m *RuleVersionRepositoryMock) FindByRuleID(ctx context.Context,
+	ruleID string) ([]entities.RuleVersion, error) {
+	args := m.Called(ctx, ruleID)
+	return args.Get(0).([]entities.RuleVersion), args.Error(1)
+}
+
+func (m *RuleVersionRepositoryMock) FindByRevision(ctx context.Context, ruleID string,
+	revision int64) (entities.RuleVersion, error) {
+	args := m.Called(ctx, ruleID, revision)
+	return args.Get(0).(entities.RuleVersion), args.Error(1)
+}
@@ -17,6 +17,12 @@ func (m *RulesRepositoryMock) GetRulesByFilters(ctx context.Context, filter enti
 	return args.Get(0).([]entities.Rule), args.Error(1)
 }
 
+func (m *RulesRepositoryMock) FindLintCandidates(ctx context.Context, rule entities.Rule,
+	components []entities.ConsoleComponent) ([]entities.Rule, error) {
+	args := m.Called(ctx, rule, components)
+	return args.Get(0).([]entities.Rule), args.Error(1)
+}
+
 func (m *RulesRepositoryMock) FindRulesPaged(ctx context.Context, filter entities.RuleFilter,
 	pagination entities.Pagination) (entities.PagedResponse, error) {
 	args := m.Called(ctx, filter, pagination)
@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type S3ReaderMock struct {
+	mock.Mock
+}
+
+func (m *S3ReaderMock) ReadS3File(ctx context.Context, bucketName string, fileName string) ([]byte, error) {
+	args := m.Called(ctx, bucketName, fileName)
+	return args.Get(0).([]byte), args.Error(1)
+}
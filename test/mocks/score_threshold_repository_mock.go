@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type ScoreThresholdRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ScoreThresholdRepositoryMock) Add(ctx context.Context, threshold *entities.ScoreThreshold) error {
+	args := m.Called(ctx, threshold)
+	return args.Error(0)
+}
+
+func (m *ScoreThresholdRepositoryMock) Update(ctx context.Context, id string,
+	threshold entities.ScoreThreshold) error {
+	args := m.Called(ctx, id, threshold)
+	return args.Error(0)
+}
+
+func (m *ScoreThresholdRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ScoreThresholdRepositoryMock) Search(ctx context.Context,
+	filter entities.ScoreThresholdFilter) ([]entities.ScoreThreshold, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]entities.ScoreThreshold), args.Error(1)
+}
+
+func (m *ScoreThresholdRepositoryMock) SearchByScope(ctx context.Context,
+	companyID, familyID string) ([]entities.ScoreThreshold, error) {
+	args := m.Called(ctx, companyID, familyID)
+	return args.Get(0).([]entities.ScoreThreshold), args.Error(1)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type ScoreThresholdServiceMock struct {
+	mock.Mock
+}
+
+func (m *ScoreThresholdServiceMock) Create(ctx context.Context,
+	threshold entities.ScoreThreshold) (entities.ScoreThreshold, error) {
+	args := m.Called(ctx, threshold)
+	return args.Get(0).(entities.ScoreThreshold), args.Error(1)
+}
+
+func (m *ScoreThresholdServiceMock) Update(ctx context.Context, id string,
+	threshold entities.ScoreThreshold) error {
+	args := m.Called(ctx, id, threshold)
+	return args.Error(0)
+}
+
+func (m *ScoreThresholdServiceMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ScoreThresholdServiceMock) Get(ctx context.Context,
+	filter entities.ScoreThresholdFilter) ([]entities.ScoreThreshold, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]entities.ScoreThreshold), args.Error(1)
+}
+
+func (m *ScoreThresholdServiceMock) GetThreshold(ctx context.Context,
+	companyID, familyID string) (entities.ScoreThreshold, error) {
+	args := m.Called(ctx, companyID, familyID)
+	return args.Get(0).(entities.ScoreThreshold), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TransactorMock struct {
+	mock.Mock
+}
+
+func (m *TransactorMock) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+
+	return fn(ctx)
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type VelocityRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *VelocityRepositoryMock) MarkTracked(ctx context.Context, chargeID string, at time.Time) (bool, error) {
+	args := m.Called(ctx, chargeID, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *VelocityRepositoryMock) Increment(ctx context.Context, keys []entities.VelocityKey, amount float64,
+	at time.Time) error {
+	args := m.Called(ctx, keys, amount, at)
+	return args.Error(0)
+}
+
+func (m *VelocityRepositoryMock) FindBuckets(ctx context.Context, keys []entities.VelocityKey,
+	now time.Time) ([]entities.VelocityBucket, error) {
+	args := m.Called(ctx, keys, now)
+	return args.Get(0).([]entities.VelocityBucket), args.Error(1)
+}
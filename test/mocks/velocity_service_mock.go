@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type VelocityServiceMock struct {
+	mock.Mock
+}
+
+func (m *VelocityServiceMock) GetVelocity(ctx context.Context, charge entities.ChargeRequest) (entities.Velocity, error) {
+	args := m.Called(ctx, charge)
+	return args.Get(0).(entities.Velocity), args.Error(1)
+}
+
+func (m *VelocityServiceMock) Track(ctx context.Context, charge entities.ChargeRequest) error {
+	args := m.Called(ctx, charge)
+	return args.Error(0)
+}
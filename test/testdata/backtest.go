@@ -0,0 +1,40 @@
+package testdata
+
+import (
+	"time"
+
+	"github.com/conekta/risk-rules/internal/entities"
+)
+
+func GetDefaultBacktestRequest() entities.BacktestRequest {
+	to := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	rule := GetDefaultRuleNotGlobalWithCompanyID()
+	rule.Rules = []entities.RuleContent{{Field: "amount", Operator: ">", Value: "5000", Condition: "and"}}
+
+	return entities.BacktestRequest{
+		Rule:       rule,
+		From:       to.AddDate(0, 0, -7),
+		To:         to,
+		SampleSize: 1,
+	}
+}
+
+func GetStoredEvaluations() []entities.StoredEvaluation {
+	undecidedCharge := GetDefaultCharge()
+	declinedCharge := GetDefaultCharge()
+	declinedCharge.ID = "615324eb5bc1dea9ce660690"
+	declinedCharge.Amount = 6000
+	acceptedCharge := GetDefaultCharge()
+	acceptedCharge.ID = "615324eb5bc1dea9ce660691"
+	acceptedCharge.Amount = 9000
+	undecidedHighCharge := GetDefaultCharge()
+	undecidedHighCharge.ID = "615324eb5bc1dea9ce660692"
+	undecidedHighCharge.Amount = 7000
+
+	return []entities.StoredEvaluation{
+		{Source: entities.EvaluationsSource, Decision: entities.Undecided.String(), Charge: undecidedCharge},
+		{Source: entities.EvaluationsSource, Decision: entities.Declined.String(), Charge: declinedCharge},
+		{Source: entities.EvaluationsSource, Decision: entities.Accepted.String(), Charge: acceptedCharge},
+		{Source: entities.EvaluationsOnlyRulesSource, Decision: entities.Undecided.String(), Charge: undecidedHighCharge},
+	}
+}
@@ -0,0 +1,23 @@
+package testdata
+
+import "github.com/conekta/risk-rules/internal/entities"
+
+func GetRawBinTable() []byte {
+	return []byte("bin,brand,issuer,bank_name,country,card_type,product_level\n" +
+		"411111,visa,BBVA,BBVA Mexico,MX,credit,classic\n" +
+		"41111122,visa,Banorte,Banco Mercantil del Norte,MX,debit,prepaid\n" +
+		"invalid,visa,Unknown,Unknown,US,credit,classic\n" +
+		"520000,mastercard,Santander,Banco Santander Mexico,MX,credit,corporate\n")
+}
+
+func GetDefaultBinInfo() entities.BinInfo {
+	return entities.BinInfo{
+		Bin:          "411111",
+		Brand:        "visa",
+		Issuer:       "BBVA",
+		BankName:     "BBVA Mexico",
+		Country:      "MX",
+		CardType:     "credit",
+		ProductLevel: "classic",
+	}
+}
@@ -41,7 +41,8 @@ func GetEvaluationResponseSuccessful() entities.EvaluationResponse {
 			},
 			Rules: entities.RulesResponse{},
 		},
-		Charge: GetDefaultCharge(),
+		Charge:      GetDefaultCharge(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -57,6 +58,7 @@ func GetRulesEvaluationResponseUndecidedCauseConsoleIsEmptyOnlyRules() entities.
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeConsoleIsEmptyOnlyRules(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -80,6 +82,7 @@ func GetRulesEvaluationResponseAcceptedCauseConsoleCompanyRules() entities.Rules
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeConsoleCompanyRules(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -103,6 +106,7 @@ func GetRulesEvaluationResponseAcceptedCauseConsoleFamilyRules() entities.RulesE
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeConsoleFamilyRules(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -126,6 +130,7 @@ func GetRulesEvaluationResponseAcceptedCauseConsoleFamilyMccRules() entities.Rul
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeConsoleFamilyMccRules(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -149,6 +154,7 @@ func GetRulesEvaluationResponseDeclinedCauseConsoleGlobalRules() entities.RulesE
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeConsoleGlobalRules(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -172,6 +178,7 @@ func GetRulesEvaluationResponseDeclinedCauseConsoleRules() entities.RulesEvaluat
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeConsoleRules(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 func GetRulesEvaluationResponseDeclinedEmailProximityRules() entities.RulesEvaluationResponse {
@@ -195,6 +202,7 @@ func GetRulesEvaluationResponseDeclinedEmailProximityRules() entities.RulesEvalu
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeWithEmailProximity(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -215,6 +223,7 @@ func GetRulesEvaluationResponseUndecidedYellowFlag() entities.RulesEvaluationRes
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeYellowFlag(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -244,6 +253,7 @@ func GetRulesEvaluationResponseDeclinedYellowFlag() entities.RulesEvaluationResp
 		Omniscore:     -1,
 		MerchantScore: -1,
 		Charge:        GetChargeYellowFlagAndGlobal(),
+		Enrichments:   GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -285,7 +295,8 @@ func GetEvaluationResponseSuccessfulFamily() entities.EvaluationResponse {
 				EvaluatedNonGlobalRules: 1,
 				Errors:                  []string{}},
 		},
-		Charge: GetDefaultChargeFamily(),
+		Charge:      GetDefaultChargeFamily(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -327,7 +338,8 @@ func GetEvaluationResponseSuccessfulFamilyMcc() entities.EvaluationResponse {
 				EvaluatedNonGlobalRules: 1,
 				Errors:                  []string{}},
 		},
-		Charge: GetDefaultChargeFamilyMcc(),
+		Charge:      GetDefaultChargeFamilyMcc(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -362,7 +374,8 @@ func GetEvaluationResponseAcceptedByWhiteListSuccessful() entities.EvaluationRes
 			},
 			Rules: entities.RulesResponse{},
 		},
-		Charge: GetDefaultCharge(),
+		Charge:      GetDefaultCharge(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -397,7 +410,8 @@ func GetEvaluationResponseAcceptedByWhiteListAndBlackListPresentSuccessful() ent
 			},
 			Rules: entities.RulesResponse{},
 		},
-		Charge: GetDefaultCharge(),
+		Charge:      GetDefaultCharge(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -432,7 +446,8 @@ func GetEvaluationResponseAcceptedByBlackListSuccessful() entities.EvaluationRes
 			},
 			Rules: entities.RulesResponse{},
 		},
-		Charge: GetDefaultChargeBlacklist(),
+		Charge:      GetDefaultChargeBlacklist(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -474,7 +489,8 @@ func GetEvaluationResponseDeclinedByRulesSuccessful() entities.EvaluationRespons
 				EvaluatedNonGlobalRules: 1,
 				Errors:                  []string{}},
 		},
-		Charge: GetChargeWithDeviceFingerprintBlocked(),
+		Charge:      GetChargeWithDeviceFingerprintBlocked(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -516,7 +532,8 @@ func GetEvaluationResponseDeclinedByGlobalRuleSuccessful() entities.EvaluationRe
 				EvaluatedNonGlobalRules: 0,
 				Errors:                  []string{}},
 		},
-		Charge: GetChargeWithEmailBlockedGlobal(),
+		Charge:      GetChargeWithEmailBlockedGlobal(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -549,7 +566,8 @@ func GetEvaluationResponseSuccessfulWithoutAggregation() entities.EvaluationResp
 				EvaluatedNonGlobalRules: 1,
 				Errors:                  []string{}},
 		},
-		Charge: GetDefaultChargeWithoutAggregation(),
+		Charge:      GetDefaultChargeWithoutAggregation(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -584,7 +602,8 @@ func GetEvaluationResponseUndecidedInGraylistWithoutRuleApplied() entities.Evalu
 			},
 			Rules: entities.RulesResponse{},
 		},
-		Charge: GetDefaultChargeInGraylist(),
+		Charge:      GetDefaultChargeInGraylist(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -626,7 +645,8 @@ func GetEvaluationResponseDeclinedInGraylistWithRuleApplied() entities.Evaluatio
 				EvaluatedNonGlobalRules: 0,
 				Errors:                  []string{}},
 		},
-		Charge: GetDefaultChargeInGraylistAndRule(),
+		Charge:      GetDefaultChargeInGraylistAndRule(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -668,7 +688,8 @@ func GetEvaluationResponseDeclinedExistChargebackWithRuleApplied() entities.Eval
 				EvaluatedNonGlobalRules: 0,
 				Errors:                  []string{}},
 		},
-		Charge: GetDefaultChargeWithChargebacks(),
+		Charge:      GetDefaultChargeWithChargebacks(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -710,7 +731,8 @@ func GetEvaluationResponseDeclinedWithOmniscoreRuleApplied() entities.Evaluation
 				EvaluatedNonGlobalRules: 0,
 				Errors:                  []string{}},
 		},
-		Charge: GetChargeForOmniscoreRule(),
+		Charge:      GetChargeForOmniscoreRule(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -745,7 +767,8 @@ func GetEvaluationResponseUndecidedWithoutOmniscoreRuleApplied() entities.Evalua
 			},
 			Rules: entities.RulesResponse{},
 		},
-		Charge: GetChargeForOmniscoreRuleNotApplied(),
+		Charge:      GetChargeForOmniscoreRuleNotApplied(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -780,7 +803,8 @@ func GetEvaluationResponseUndecidedWithoutListTypeIncorrect() entities.Evaluatio
 			},
 			Rules: entities.RulesResponse{},
 		},
-		Charge: GetChargeRequestForBlacklist(),
+		Charge:      GetChargeRequestForBlacklist(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -822,7 +846,8 @@ func GetEvaluationResponseSuccessfulMerchantScoreApproved() entities.EvaluationR
 				EvaluatedNonGlobalRules: 1,
 				Errors:                  []string{}},
 		},
-		Charge: GetDefaultCharge(),
+		Charge:      GetDefaultCharge(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -864,7 +889,8 @@ func GetEvaluationResponseSuccessfulMerchantScoreDeclined() entities.EvaluationR
 				EvaluatedNonGlobalRules: 1,
 				Errors:                  []string{}},
 		},
-		Charge: GetDefaultCharge(),
+		Charge:      GetDefaultCharge(),
+		Enrichments: GetDefaultEnrichmentResults(),
 	}
 }
 
@@ -906,6 +932,15 @@ func GetEvaluationResponseSuccessfulMarketSegmentApproved() entities.EvaluationR
 				EvaluatedNonGlobalRules: 1,
 				Errors:                  []string{}},
 		},
-		Charge: GetDefaultCharge(),
+		Charge:      GetDefaultCharge(),
+		Enrichments: GetDefaultEnrichmentResults(),
+	}
+}
+
+func GetDefaultEnrichmentResults() []entities.EnrichmentResult {
+	return []entities.EnrichmentResult{
+		{Name: "chargebacks", Status: entities.EnrichmentRan},
+		{Name: "omniscore", Status: entities.EnrichmentRan},
+		{Name: "merchant_score", Status: entities.EnrichmentSkipped},
 	}
 }
@@ -171,3 +171,16 @@ func GetDefaultFormulaRulesWithProcessedField() []entities.RuleContent {
 		},
 	}
 }
+
+func GetExpressionFormulaRules(expression string) []entities.RuleContent {
+	return []entities.RuleContent{
+		{
+			Operator:  ">",
+			Value:     "3",
+			Condition: "and",
+			FormulaContent: entities.FormulaContent{
+				Expression: strings.StringToStringPointer(expression),
+			},
+		},
+	}
+}
@@ -0,0 +1,14 @@
+package testdata
+
+func GetRawIPCountries() []byte {
+	return []byte("network,country,asn\n" +
+		"187.190.0.0/16,MX,8151\n" +
+		"187.190.38.0/24,MX,\n" +
+		"2001:db8::/32,US,64500\n")
+}
+
+func GetRawIPThreats() []byte {
+	return []byte("network,is_hosting,is_tor\n" +
+		"187.190.38.0/24,true,false\n" +
+		"185.220.101.1,false,true\n")
+}
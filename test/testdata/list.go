@@ -68,3 +68,30 @@ func GetDefaultGrayList(isTest bool) entities.List {
 		Expires:     &expires,
 	}
 }
+
+func GetListRequest() entities.ListRequest {
+	isGlobal := false
+	return entities.ListRequest{
+		CompanyID:   "2",
+		IsGlobal:    &isGlobal,
+		Type:        entities.Black.String(),
+		Field:       entities.EmailField,
+		Value:       "me@gmail.com",
+		Description: "chargeback fraud",
+		TimeToLive:  3600,
+		Author:      "riesgo@conekta.com",
+	}
+}
+
+func GetDefaultIPBlackList(value string) entities.List {
+	return entities.List{
+		CompanyID: "2",
+		Decision:  entities.Declined,
+		CreatedAt: time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC),
+		CreatedBy: "me",
+		Value:     value,
+		Field:     entities.IPField,
+		Rule:      "ip == " + value,
+		Type:      entities.Black.String(),
+	}
+}
@@ -17,7 +17,7 @@ var (
 )
 
 func GetDefaultRule(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := "2"
 
@@ -42,7 +42,7 @@ func GetDefaultRule(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleWithID(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := "2"
 
@@ -68,7 +68,7 @@ func GetDefaultRuleWithID(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleWithApprovedDecision(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := GetDefaultCharge().CompanyID
 
@@ -94,7 +94,7 @@ func GetDefaultRuleWithApprovedDecision(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleWithFamilyMccID(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := "2"
 	familyID := "61e4dd6da5997ad4d9e76945"
@@ -119,7 +119,7 @@ func GetDefaultRuleWithFamilyMccID(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleWithFamilyCompanyID(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	familyCompanyID := "61e991ad1214eac062ada43d"
 
@@ -143,7 +143,7 @@ func GetDefaultRuleWithFamilyCompanyID(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleFingerprintBlocked(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := "2"
 
@@ -166,7 +166,7 @@ func GetDefaultRuleFingerprintBlocked(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleEmailBlockedGlobal(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyID := GetChargeWithEmailBlocked().CompanyID
 
@@ -189,7 +189,7 @@ func GetDefaultRuleEmailBlockedGlobal(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleEmailGlobalUndefined(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyID := GetChargeWithEmailBlocked().CompanyID
 
@@ -212,7 +212,7 @@ func GetDefaultRuleEmailGlobalUndefined(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleEmailProximity(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyID := GetChargeWithEmailBlocked().CompanyID
 
@@ -235,7 +235,7 @@ func GetDefaultRuleEmailProximity(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleYellowFlag(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyID := GetChargeYellowFlag().CompanyID
 
@@ -496,7 +496,7 @@ func GetRuleWithRuleEmpty(isATest bool) entities.Rule {
 }
 func GetDefaultRuleIn(isATest bool) entities.Rule {
 	companyId := "2"
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 
 	rule := entities.Rule{
@@ -517,7 +517,7 @@ func GetDefaultRuleIn(isATest bool) entities.Rule {
 }
 func GetDefaultRuleInNumber(isATest bool) entities.Rule {
 	companyId := "2"
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 
 	rule := entities.Rule{
@@ -652,7 +652,7 @@ func GetDefaultRuleFilter() (entities.RuleFilter, error) {
 }
 
 func GetDefaultRuleEmailBlockedGlobalForGraylist(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 
 	rule := entities.Rule{
@@ -681,7 +681,7 @@ func GetDefaultRuleWithFormula(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleEmailWithChargebacks(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyID := "7683457364"
 
@@ -704,7 +704,7 @@ func GetDefaultRuleEmailWithChargebacks(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleWithOmniscore(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyID := "7683457364"
 
@@ -727,7 +727,7 @@ func GetDefaultRuleWithOmniscore(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleMerchantScoreApproved(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := "7683457364"
 
@@ -752,7 +752,7 @@ func GetDefaultRuleMerchantScoreApproved(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleMerchantScoreDeclined(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := "7683457364"
 
@@ -777,7 +777,7 @@ func GetDefaultRuleMerchantScoreDeclined(isATest bool) entities.Rule {
 }
 
 func GetDefaultRuleCompanyRuleAccepted(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := "7683457364"
 
@@ -834,7 +834,7 @@ func GetDefaultRuleRequestWithYellowFlagIncorrectDecision() entities.RuleRequest
 }
 
 func GetDefaultRuleMarketSegmentApproved(isATest bool) entities.Rule {
-	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil)
+	ruleService := rules.NewRulesService(config.NewConfig(), nil, nil, nil, nil, nil, nil, nil)
 	now := time.Date(2021, 07, 24, 12, 30, 00, 00, time.UTC).Truncate(time.Millisecond)
 	companyId := "7683457364"
 
@@ -856,3 +856,20 @@ func GetDefaultRuleMarketSegmentApproved(isATest bool) entities.Rule {
 	rule.Rule = ruleService.BuildRule(rule.Rules)
 	return rule
 }
+
+func GetDefaultRuleRequestWithGroup() entities.RuleRequest {
+	rule := GetDefaultRuleRequestWithAmount()
+	rule.Rules = nil
+	rule.Group = &entities.RuleGroup{
+		Condition: "or",
+		Items: []entities.RuleGroupItem{
+			{Clause: &entities.RuleContent{Field: "amount", Operator: "<", Value: "8"}},
+			{Group: &entities.RuleGroup{Condition: "and", Items: []entities.RuleGroupItem{
+				{Clause: &entities.RuleContent{Field: "amount", Operator: ">", Value: "1000"}},
+				{Clause: &entities.RuleContent{Field: "live_mode", Operator: "==", Value: "false"}},
+			}}},
+		},
+	}
+
+	return rule
+}
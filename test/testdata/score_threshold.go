@@ -0,0 +1,48 @@
+package testdata
+
+import (
+	"time"
+
+	"github.com/conekta/risk-rules/internal/entities"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func GetScoreThresholdRequest() entities.ScoreThresholdRequest {
+	isGlobal := false
+	declineScore := 80.0
+	acceptScore := 20.0
+	return entities.ScoreThresholdRequest{
+		CompanyID:    "6262cb610211a64464781a5f",
+		IsGlobal:     &isGlobal,
+		DeclineScore: &declineScore,
+		AcceptScore:  &acceptScore,
+		Author:       "carlos.maldonado@conekta.com",
+	}
+}
+
+func GetDefaultScoreThreshold() entities.ScoreThreshold {
+	id, _ := primitive.ObjectIDFromHex("6353f4a1b0e2d3c4f5a6b7c8")
+	companyID := "6262cb610211a64464781a5f"
+	declineScore := 80.0
+	acceptScore := 20.0
+	return entities.ScoreThreshold{
+		ID:           id,
+		CompanyID:    &companyID,
+		DeclineScore: &declineScore,
+		AcceptScore:  &acceptScore,
+		CreatedAt:    time.Now().UTC().Truncate(time.Millisecond),
+		CreatedBy:    "carlos.maldonado@conekta.com",
+	}
+}
+
+func GetGlobalScoreThreshold() entities.ScoreThreshold {
+	id, _ := primitive.ObjectIDFromHex("6353f4a1b0e2d3c4f5a6b7c9")
+	declineScore := 50.0
+	return entities.ScoreThreshold{
+		ID:           id,
+		IsGlobal:     true,
+		DeclineScore: &declineScore,
+		CreatedAt:    time.Now().UTC().Truncate(time.Millisecond),
+		CreatedBy:    "carlos.maldonado@conekta.com",
+	}
+}